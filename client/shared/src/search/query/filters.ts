@@ -19,6 +19,7 @@ export enum FilterType {
     count = 'count',
     file = 'file',
     fork = 'fork',
+    join = 'join',
     lang = 'lang',
     message = 'message',
     patterntype = 'patterntype',
@@ -244,6 +245,12 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         description: 'Include results from forked repositories.',
         singular: true,
     },
+    [FilterType.join]: {
+        description: 'Join the results of parenthesized subqueries combined with AND on the same repository or file.',
+        discreteValues: () => ['repo', 'file'].map(value => ({ label: value })),
+        default: 'repo',
+        singular: true,
+    },
     [FilterType.lang]: {
         alias: 'l',
         discreteValues: value => languageCompletion(value).map(toCompletionItem),
@@ -301,7 +308,7 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
     },
     [FilterType.type]: {
         description: 'Limit results to the specified type.',
-        discreteValues: () => ['diff', 'commit', 'symbol', 'repo', 'path', 'file'].map(value => ({ label: value })),
+        discreteValues: () => ['diff', 'commit', 'symbol', 'reference', 'repo', 'path', 'file'].map(value => ({ label: value })),
     },
     [FilterType.visibility]: {
         discreteValues: () => ['any', 'private', 'public'].map(value => ({ label: value })),
@@ -47,7 +47,8 @@ describe('selectorCompletion', () => {
             symbol.struct,
             symbol.event,
             symbol.operator,
-            symbol.type-parameter
+            symbol.type-parameter,
+            symbol.references
         `)
     })
 
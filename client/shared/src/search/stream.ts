@@ -168,6 +168,9 @@ export interface Progress {
 
     // The URL of the trace for this query, if it exists.
     trace?: string
+
+    // The query after expanding query fragments, if it references any.
+    expandedQuery?: string
 }
 
 export interface Skipped {
@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 )
 
 // Services is a bag of HTTP handlers and factory functions that are registered by the
@@ -35,6 +36,9 @@ type Services struct {
 	OrgRepositoryResolver     graphqlbackend.OrgRepositoryResolver
 	NotebooksResolver         graphqlbackend.NotebooksResolver
 	ComputeResolver           graphqlbackend.ComputeResolver
+
+	// CodeIntelSearchClient is used by search jobs to query precise code intelligence.
+	CodeIntelSearchClient precise.Client
 }
 
 // NewCodeIntelUploadHandler creates a new handler for the LSIF upload endpoint. The
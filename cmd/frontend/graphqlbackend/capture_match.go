@@ -0,0 +1,62 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// CaptureMatchResolver is a resolver for the GraphQL type `CaptureMatch`
+type CaptureMatchResolver struct {
+	result.CaptureMatch
+
+	RepoResolver *RepositoryResolver
+	db           database.DB
+}
+
+func (cm *CaptureMatchResolver) File() *GitTreeEntryResolver {
+	// Like FileMatchResolver, omits other commit fields to avoid needing to
+	// fetch them.
+	commit := NewGitCommitResolver(cm.db, cm.RepoResolver, cm.CommitID, nil)
+	commit.inputRev = cm.InputRev
+	return NewGitTreeEntryResolver(cm.db, commit, CreateFileInfo(cm.Path, false))
+}
+
+func (cm *CaptureMatchResolver) Repository() *RepositoryResolver {
+	return cm.RepoResolver
+}
+
+func (cm *CaptureMatchResolver) RevSpec() *gitRevSpec {
+	if cm.InputRev == nil || *cm.InputRev == "" {
+		return nil // default branch
+	}
+	return &gitRevSpec{
+		expr: &gitRevSpecExpr{expr: *cm.InputRev, repo: cm.Repository()},
+	}
+}
+
+func (cm *CaptureMatchResolver) Captures() []captureResolver {
+	r := make([]captureResolver, 0, len(cm.CaptureMatch.Captures))
+	for _, c := range cm.CaptureMatch.Captures {
+		r = append(r, captureResolver{c})
+	}
+	return r
+}
+
+func (cm *CaptureMatchResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (cm *CaptureMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (cm *CaptureMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (cm *CaptureMatchResolver) ToCaptureMatch() (*CaptureMatchResolver, bool) { return cm, true }
+
+type captureResolver struct {
+	result.Capture
+}
+
+func (c captureResolver) Value() string {
+	return c.Capture.Value
+}
+
+func (c captureResolver) Count() int32 {
+	return int32(c.Capture.Count)
+}
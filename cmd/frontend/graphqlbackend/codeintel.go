@@ -37,6 +37,7 @@ type AutoindexingServiceResolver interface {
 	LSIFIndexesByRepo(ctx context.Context, args *LSIFRepositoryIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	QueueAutoIndexJobsForRepo(ctx context.Context, args *QueueAutoIndexJobsForRepoArgs) ([]LSIFIndexResolver, error)
 	UpdateRepositoryIndexConfiguration(ctx context.Context, args *UpdateRepositoryIndexConfigurationArgs) (*EmptyResponse, error)
+	UpdateRepositoryInferenceScript(ctx context.Context, args *UpdateRepositoryInferenceScriptArgs) (*EmptyResponse, error)
 }
 
 type ExecutorResolver interface {
@@ -53,6 +54,7 @@ type UploadsServiceResolver interface {
 	LSIFUploads(ctx context.Context, args *LSIFUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
+	CodeIntelSemanticDiff(ctx context.Context, args *CodeIntelSemanticDiffArgs) ([]CodeIntelSemanticDiffResolver, error)
 }
 type PoliciesServiceResolver interface {
 	CodeIntelligenceConfigurationPolicies(ctx context.Context, args *CodeIntelligenceConfigurationPoliciesArgs) (CodeIntelligenceConfigurationPolicyConnectionResolver, error)
@@ -60,6 +62,7 @@ type PoliciesServiceResolver interface {
 	CreateCodeIntelligenceConfigurationPolicy(ctx context.Context, args *CreateCodeIntelligenceConfigurationPolicyArgs) (CodeIntelligenceConfigurationPolicyResolver, error)
 	DeleteCodeIntelligenceConfigurationPolicy(ctx context.Context, args *DeleteCodeIntelligenceConfigurationPolicyArgs) (*EmptyResponse, error)
 	PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *PreviewGitObjectFilterArgs) ([]GitObjectFilterPreviewResolver, error)
+	PreviewCodeIntelligenceConfigurationPolicyImpact(ctx context.Context, args *PreviewCodeIntelligenceConfigurationPolicyImpactArgs) (CodeIntelligenceRetentionPolicyImpactConnectionResolver, error)
 	PreviewRepositoryFilter(ctx context.Context, args *PreviewRepositoryFilterArgs) (RepositoryFilterPreviewResolver, error)
 	UpdateCodeIntelligenceConfigurationPolicy(ctx context.Context, args *UpdateCodeIntelligenceConfigurationPolicyArgs) (*EmptyResponse, error)
 }
@@ -106,6 +109,49 @@ type LSIFUploadResolver interface {
 	RetentionPolicyOverview(ctx context.Context, args *LSIFUploadRetentionPolicyMatchesArgs) (CodeIntelligenceRetentionPolicyMatchesConnectionResolver, error)
 	DocumentPaths(ctx context.Context, args *LSIFUploadDocumentPathsQueryArgs) (LSIFUploadDocumentPathsConnectionResolver, error)
 	AuditLogs(ctx context.Context) (*[]LSIFUploadsAuditLogsResolver, error)
+	SemanticDiff(ctx context.Context, args *LSIFUploadSemanticDiffArgs) (CodeIntelSemanticDiffResolver, error)
+}
+
+type LSIFUploadSemanticDiffArgs struct {
+	Base graphql.ID
+	graphqlutil.ConnectionArgs
+	After *string
+}
+
+type CodeIntelSemanticDiffArgs struct {
+	RepositoryID graphql.ID
+	BaseCommit   api.CommitID
+	HeadCommit   api.CommitID
+	graphqlutil.ConnectionArgs
+	After *string
+}
+
+type CodeIntelSemanticDiffResolver interface {
+	Base(ctx context.Context) (LSIFUploadResolver, error)
+	Head(ctx context.Context) (LSIFUploadResolver, error)
+	AddedDefinitions() []CodeIntelSemanticDiffSymbolResolver
+	RemovedDefinitions() []CodeIntelSemanticDiffSymbolResolver
+	ChangedDefinitions() []CodeIntelSemanticDiffChangeResolver
+	BrokenReferences() []CodeIntelSemanticDiffReferenceResolver
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type CodeIntelSemanticDiffSymbolResolver interface {
+	Scheme() string
+	Identifier() string
+	Location(ctx context.Context) (LocationResolver, error)
+	Hover() *Markdown
+}
+
+type CodeIntelSemanticDiffChangeResolver interface {
+	Base() CodeIntelSemanticDiffSymbolResolver
+	Head() CodeIntelSemanticDiffSymbolResolver
+}
+
+type CodeIntelSemanticDiffReferenceResolver interface {
+	Scheme() string
+	Identifier() string
+	Location(ctx context.Context) (LocationResolver, error)
 }
 
 type LSIFUploadConnectionResolver interface {
@@ -226,7 +272,10 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFCallHierarchyArgs) (CallHierarchyCallConnectionResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFCallHierarchyArgs) (CallHierarchyCallConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
+	Documents(ctx context.Context) ([]CodeIntelDocumentResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -255,6 +304,13 @@ type LSIFPagedQueryPositionArgs struct {
 	Filter *string
 }
 
+type LSIFCallHierarchyArgs struct {
+	Line      int32
+	Character int32
+	graphqlutil.ConnectionArgs
+	After *string
+}
+
 type LSIFDiagnosticsArgs struct {
 	graphqlutil.ConnectionArgs
 }
@@ -276,11 +332,50 @@ type LocationConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type CallHierarchyCallConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CallHierarchyCallResolver, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CallHierarchyCallResolver interface {
+	Item() CallHierarchyItemResolver
+	CallSites(ctx context.Context) ([]LocationResolver, error)
+}
+
+type CallHierarchyItemResolver interface {
+	Name() string
+	Location(ctx context.Context) (LocationResolver, error)
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
 }
 
+type CodeIntelDocumentResolver interface {
+	Upload(ctx context.Context) (LSIFUploadResolver, error)
+	Occurrences() []CodeIntelOccurrenceResolver
+	Hovers() []CodeIntelHoverResolver
+}
+
+type CodeIntelOccurrenceResolver interface {
+	Range() RangeResolver
+	SymbolRoles() []string
+	Monikers() []CodeIntelMonikerResolver
+	HoverID() *string
+}
+
+type CodeIntelMonikerResolver interface {
+	Kind() string
+	Scheme() string
+	Identifier() string
+}
+
+type CodeIntelHoverResolver interface {
+	ID() string
+	Markdown() Markdown
+}
+
 type DiagnosticConnectionResolver interface {
 	Nodes(ctx context.Context) ([]DiagnosticResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -355,6 +450,7 @@ type LSIFIndexesWithRepositoryNamespaceResolver interface {
 type IndexConfigurationResolver interface {
 	Configuration(ctx context.Context) (*string, error)
 	InferredConfiguration(ctx context.Context) (*string, error)
+	InferenceScript(ctx context.Context) (*string, error)
 }
 
 type UpdateRepositoryIndexConfigurationArgs struct {
@@ -362,12 +458,40 @@ type UpdateRepositoryIndexConfigurationArgs struct {
 	Configuration string
 }
 
+type UpdateRepositoryInferenceScriptArgs struct {
+	Repository graphql.ID
+	Script     string
+}
+
 type PreviewRepositoryFilterArgs struct {
 	graphqlutil.ConnectionArgs
 	Patterns []string
 	After    *string
 }
 
+type PreviewCodeIntelligenceConfigurationPolicyImpactArgs struct {
+	graphqlutil.ConnectionArgs
+	ID         *graphql.ID
+	Repository *graphql.ID
+	CodeIntelConfigurationPolicy
+	After *string
+}
+
+type CodeIntelligenceRetentionPolicyImpactConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CodeIntelligenceRetentionPolicyImpactResolver, error)
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type CodeIntelligenceRetentionPolicyImpactResolver interface {
+	Repository() *RepositoryResolver
+	ExpiredUploads(ctx context.Context) ([]LSIFUploadResolver, error)
+	ProtectedUploads(ctx context.Context) ([]LSIFUploadResolver, error)
+	ReclaimedBytes() BigInt
+	RetainedBytes() BigInt
+	UnsizedUploads() int32
+}
+
 type RepositoryFilterPreviewResolver interface {
 	Nodes() []*RepositoryResolver
 	TotalCount() int32
@@ -429,6 +553,31 @@ type GitTreeEntryCodeIntelInfoArgs struct {
 type GitTreeCodeIntelSupportResolver interface {
 	SearchBasedSupport(context.Context) (*[]GitTreeSearchBasedCoverage, error)
 	PreciseSupport(context.Context) (*[]GitTreePreciseCoverage, error)
+	PreciseCoverage(context.Context, *GitTreePreciseCoverageArgs) (CodeIntelCoverageResolver, error)
+}
+
+type GitTreePreciseCoverageArgs struct {
+	First int32
+}
+
+type CodeIntelCoverageResolver interface {
+	Path() string
+	TotalFiles() int32
+	CoveredFiles() int32
+	Indexers() []CodeIntelIndexerCoverageResolver
+	Languages() []CodeIntelLanguageCoverageResolver
+	Subdirectories() []CodeIntelCoverageResolver
+}
+
+type CodeIntelIndexerCoverageResolver interface {
+	Indexer() CodeIntelIndexerResolver
+	CoveredFiles() int32
+}
+
+type CodeIntelLanguageCoverageResolver interface {
+	Language() string
+	TotalFiles() int32
+	CoveredFiles() int32
 }
 
 type GitTreeSearchBasedCoverage interface {
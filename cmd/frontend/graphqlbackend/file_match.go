@@ -81,6 +81,7 @@ func (fm *FileMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (fm *FileMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (fm *FileMatchResolver) ToCaptureMatch() (*CaptureMatchResolver, bool) { return nil, false }
 
 type lineMatchResolver struct {
 	*result.LineMatch
@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 	sgtrace "github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -362,8 +363,10 @@ func NewSchema(
 	orgRepositoryResolver OrgRepositoryResolver,
 	notebooks NotebooksResolver,
 	compute ComputeResolver,
+	codeIntelSearchClient precise.Client,
 ) (*graphql.Schema, error) {
 	resolver := newSchemaResolver(db)
+	resolver.codeIntelSearchClient = codeIntelSearchClient
 	schemas := []string{mainSchema}
 
 	if batchChanges != nil {
@@ -476,6 +479,10 @@ type schemaResolver struct {
 	repoupdaterClient *repoupdater.Client
 	nodeByIDFns       map[string]NodeByIDFunc
 
+	// codeIntelSearchClient is used by searches to query precise code intelligence.
+	// It is nil if precise code intelligence is not available.
+	codeIntelSearchClient precise.Client
+
 	// SubResolvers are assigned using the Schema constructor.
 
 	BatchChangesResolver
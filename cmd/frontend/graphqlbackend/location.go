@@ -13,11 +13,13 @@ type LocationResolver interface {
 	Range() *rangeResolver
 	URL(ctx context.Context) (string, error)
 	CanonicalURL() string
+	Confidence() *float64
 }
 
 type locationResolver struct {
-	resource *GitTreeEntryResolver
-	lspRange *lsp.Range
+	resource   *GitTreeEntryResolver
+	lspRange   *lsp.Range
+	confidence *float64
 }
 
 var _ LocationResolver = &locationResolver{}
@@ -29,8 +31,20 @@ func NewLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range) Lo
 	}
 }
 
+// NewApproximateLocationResolver returns a location resolver for a range that was approximated with
+// the given confidence.
+func NewApproximateLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range, confidence float64) LocationResolver {
+	return &locationResolver{
+		resource:   resource,
+		lspRange:   lspRange,
+		confidence: &confidence,
+	}
+}
+
 func (r *locationResolver) Resource() *GitTreeEntryResolver { return r.resource }
 
+func (r *locationResolver) Confidence() *float64 { return r.confidence }
+
 func (r *locationResolver) Range() *rangeResolver {
 	if r.lspRange == nil {
 		return nil
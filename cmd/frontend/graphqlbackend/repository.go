@@ -14,6 +14,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/externallink"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -299,6 +300,7 @@ func (r *RepositoryResolver) ToFileMatch() (*FileMatchResolver, bool)   { return
 func (r *RepositoryResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return nil, false
 }
+func (r *RepositoryResolver) ToCaptureMatch() (*CaptureMatchResolver, bool) { return nil, false }
 
 func (r *RepositoryResolver) Type(ctx context.Context) (*types.Repo, error) {
 	return r.repo(ctx)
@@ -363,6 +365,35 @@ func (r *RepositoryResolver) IndexConfiguration(ctx context.Context) (IndexConfi
 	return EnterpriseResolvers.codeIntelResolver.IndexConfiguration(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) CodeIntelSemanticDiff(ctx context.Context, args *struct {
+	Base, Head string
+	graphqlutil.ConnectionArgs
+	After *string
+}) ([]CodeIntelSemanticDiffResolver, error) {
+	repo, err := r.repo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := backend.NewRepos(r.logger, r.db)
+	baseCommit, err := repos.ResolveRev(ctx, repo, args.Base)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := repos.ResolveRev(ctx, repo, args.Head)
+	if err != nil {
+		return nil, err
+	}
+
+	return EnterpriseResolvers.codeIntelResolver.CodeIntelSemanticDiff(ctx, &CodeIntelSemanticDiffArgs{
+		RepositoryID:   r.ID(),
+		BaseCommit:     baseCommit,
+		HeadCommit:     headCommit,
+		ConnectionArgs: args.ConnectionArgs,
+		After:          args.After,
+	})
+}
+
 func (r *RepositoryResolver) CodeIntelligenceCommitGraph(ctx context.Context) (CodeIntelligenceCommitGraphResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.CommitGraph(ctx, r.ID())
 }
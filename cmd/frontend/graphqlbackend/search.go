@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -27,13 +28,14 @@ type SearchImplementer interface {
 }
 
 // NewBatchSearchImplementer returns a SearchImplementer that provides search results and suggestions.
-func NewBatchSearchImplementer(ctx context.Context, logger log.Logger, db database.DB, args *SearchArgs) (_ SearchImplementer, err error) {
+// The given code intelligence client may be nil if precise code intelligence is not available.
+func NewBatchSearchImplementer(ctx context.Context, logger log.Logger, db database.DB, codeIntel precise.Client, args *SearchArgs) (_ SearchImplementer, err error) {
 	settings, err := DecodedViewerFinalSettings(ctx, db)
 	if err != nil {
 		return nil, err
 	}
 
-	cli := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	cli := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), codeIntel)
 	inputs, err := cli.Plan(
 		ctx,
 		args.Version,
@@ -59,7 +61,7 @@ func NewBatchSearchImplementer(ctx context.Context, logger log.Logger, db databa
 }
 
 func (r *schemaResolver) Search(ctx context.Context, args *SearchArgs) (SearchImplementer, error) {
-	return NewBatchSearchImplementer(ctx, r.logger, r.db, args)
+	return NewBatchSearchImplementer(ctx, r.logger, r.db, r.codeIntelSearchClient, args)
 }
 
 // searchResolver is a resolver for the GraphQL type `Search`
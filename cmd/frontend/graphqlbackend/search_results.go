@@ -165,6 +165,12 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.CaptureMatch:
+			resolvers = append(resolvers, &CaptureMatchResolver{
+				db:           db,
+				CaptureMatch: *v,
+				RepoResolver: getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -667,4 +673,5 @@ type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToCaptureMatch() (*CaptureMatchResolver, bool)
 }
@@ -341,7 +341,7 @@ func BenchmarkSearchResults(b *testing.B) {
 			b.Fatal(err)
 		}
 		resolver := &searchResolver{
-			client: client.NewSearchClient(logtest.Scoped(b), db, z, nil),
+			client: client.NewSearchClient(logtest.Scoped(b), db, z, nil, nil),
 			db:     db,
 			SearchInputs: &search.Inputs{
 				Plan:         plan,
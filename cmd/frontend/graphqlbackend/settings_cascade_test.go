@@ -146,6 +146,27 @@ func TestMergeSettings(t *testing.T) {
 				"test3": {"merged", 4},
 			},
 		},
+	}, {
+		name: "deep merge search.queryFragments",
+		left: &schema.Settings{
+			SearchQueryFragments: map[string]string{
+				"nogen":  "-file:vendor/",
+				"notest": "-file:_test.go$",
+			},
+		},
+		right: &schema.Settings{
+			SearchQueryFragments: map[string]string{
+				"nogen": "-file:generated/",
+				"acme":  "repo:^github\\.com/acme/",
+			},
+		},
+		expected: &schema.Settings{
+			SearchQueryFragments: map[string]string{
+				"nogen":  "-file:generated/",
+				"notest": "-file:_test.go$",
+				"acme":   "repo:^github\\.com/acme/",
+			},
+		},
 	}, {
 		name: "deep merge insightsDashboards",
 		left: &schema.Settings{
@@ -26,7 +26,7 @@ func init() {
 }
 
 func TestRouter(t *testing.T) {
-	InitRouter(database.NewMockDB(), nil)
+	InitRouter(database.NewMockDB(), nil, nil)
 	router := Router()
 	tests := []struct {
 		path      string
@@ -211,7 +211,7 @@ func TestRouter(t *testing.T) {
 }
 
 func TestRouter_RootPath(t *testing.T) {
-	InitRouter(database.NewMockDB(), nil)
+	InitRouter(database.NewMockDB(), nil, nil)
 	router := Router()
 
 	tests := []struct {
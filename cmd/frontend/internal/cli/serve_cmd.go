@@ -33,6 +33,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/bg"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli/loghandlers"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	"github.com/sourcegraph/sourcegraph/internal/adminanalytics"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -214,7 +215,7 @@ func Main(enterpriseSetupHook func(db database.DB, c conftypes.UnifiedWatchable)
 	if err != nil {
 		return errors.Wrap(err, "Failed to create sub-repo client")
 	}
-	ui.InitRouter(db, enterprise.CodeIntelResolver)
+	ui.InitRouter(db, enterprise.CodeIntelResolver, enterprise.CodeIntelSearchClient)
 
 	if len(os.Args) >= 2 {
 		switch os.Args[1] {
@@ -288,6 +289,7 @@ func Main(enterpriseSetupHook func(db database.DB, c conftypes.UnifiedWatchable)
 		enterprise.OrgRepositoryResolver,
 		enterprise.NotebooksResolver,
 		enterprise.ComputeResolver,
+		enterprise.CodeIntelSearchClient,
 	)
 	if err != nil {
 		return err
@@ -313,6 +315,17 @@ func Main(enterpriseSetupHook func(db database.DB, c conftypes.UnifiedWatchable)
 		routines = append(routines, internalAPI)
 	}
 
+	observationContext := &observation.Context{
+		Logger:     logger,
+		Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+	exportRoutines, err := search.NewExportRoutines(context.Background(), logger, db, enterprise.CodeIntelSearchClient, observationContext)
+	if err != nil {
+		return err
+	}
+	routines = append(routines, exportRoutines...)
+
 	oce.GlobalExporter = oce.NewDataExporter(db, logger)
 
 	if printLogo {
@@ -344,6 +357,7 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 			BitbucketCloudWebhook:     enterprise.BitbucketCloudWebhook,
 			NewCodeIntelUploadHandler: enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:   enterprise.NewComputeStreamHandler,
+			CodeIntelSearchClient:     enterprise.CodeIntelSearchClient,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
@@ -375,6 +389,7 @@ func makeInternalAPI(schema *graphql.Schema, db database.DB, enterprise enterpri
 		db,
 		enterprise.NewCodeIntelUploadHandler,
 		enterprise.NewComputeStreamHandler,
+		enterprise.CodeIntelSearchClient,
 		rateLimiter,
 		healthCheckHandler,
 	)
@@ -11,8 +11,11 @@ const (
 	LSIFUpload = "lsif.upload"
 	GraphQL    = "graphql"
 
-	SearchStream  = "search.stream"
-	ComputeStream = "compute.stream"
+	SearchStream         = "search.stream"
+	SearchExport         = "search.export"
+	SearchExportStatus   = "search.export.status"
+	SearchExportDownload = "search.export.download"
+	ComputeStream        = "compute.stream"
 
 	SrcCli             = "src-cli"
 	SrcCliVersionCache = "src-cli.version-cache"
@@ -57,6 +60,9 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/bitbucket-cloud-webhooks").Methods("POST").Name(BitbucketCloudWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/export").Methods("POST").Name(SearchExport)
+	base.Path("/search/export/{id:[0-9]+}").Methods("GET").Name(SearchExportStatus)
+	base.Path("/search/export/{id:[0-9]+}/download").Methods("GET").Name(SearchExportDownload)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
@@ -1,57 +1,191 @@
 package search
 
 import (
+	"net/http"
+
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 )
 
-func newEventWriter(inner *streamhttp.Writer) *eventWriter {
-	return &eventWriter{inner: inner}
+// eventWriter writes the events of a frontend stream in one of the encodings
+// supported by the stream API.
+type eventWriter interface {
+	// Match buffers match until the next call to FlushMatches.
+	Match(match streamhttp.EventMatch) error
+	FlushMatches() error
+
+	Progress(current api.Progress) error
+	Filters(fs []*streaming.Filter) error
+	Alert(alert *search.Alert) error
+	Error(err error) error
+	Done() error
+}
+
+func newEventWriter(inner *streamhttp.Writer) *sseEventWriter {
+	e := &sseEventWriter{inner: inner}
+	// Store marshalled matches and flush periodically or when we go over
+	// 32kb. 32kb chosen to be smaller than bufio.MaxTokenSize. Note: we can
+	// still write more than that.
+	e.matchesBuf = streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
+		return e.MatchesJSON(data)
+	})
+	return e
 }
 
-// eventWriter is a type that wraps a streamhttp.Writer with typed
+// sseEventWriter is a type that wraps a streamhttp.Writer with typed
 // methods for each of the supported evens in a frontend stream.
-type eventWriter struct {
-	inner *streamhttp.Writer
+type sseEventWriter struct {
+	inner      *streamhttp.Writer
+	matchesBuf *streamhttp.JSONArrayBuf
 }
 
-func (e *eventWriter) Done() error {
+func (e *sseEventWriter) Done() error {
 	return e.inner.Event("done", map[string]any{})
 }
 
-func (e *eventWriter) Progress(current api.Progress) error {
+func (e *sseEventWriter) Progress(current api.Progress) error {
 	return e.inner.Event("progress", current)
 }
 
-func (e *eventWriter) MatchesJSON(data []byte) error {
+func (e *sseEventWriter) Match(match streamhttp.EventMatch) error {
+	return e.matchesBuf.Append(match)
+}
+
+func (e *sseEventWriter) FlushMatches() error {
+	return e.matchesBuf.Flush()
+}
+
+func (e *sseEventWriter) MatchesJSON(data []byte) error {
 	return e.inner.EventBytes("matches", data)
 }
 
-func (e *eventWriter) Filters(fs []*streaming.Filter) error {
+func (e *sseEventWriter) Filters(fs []*streaming.Filter) error {
 	if len(fs) > 0 {
-		buf := make([]streamhttp.EventFilter, 0, len(fs))
-		for _, f := range fs {
-			buf = append(buf, streamhttp.EventFilter{
-				Value:    f.Value,
-				Label:    f.Label,
-				Count:    f.Count,
-				LimitHit: f.IsLimitHit,
-				Kind:     f.Kind,
-			})
-		}
-
-		return e.inner.Event("filters", buf)
+		return e.inner.Event("filters", toEventFilters(fs))
 	}
 	return nil
 }
 
-func (e *eventWriter) Error(err error) error {
+func (e *sseEventWriter) Error(err error) error {
 	return e.inner.Event("error", streamhttp.EventError{Message: err.Error()})
 }
 
-func (e *eventWriter) Alert(alert *search.Alert) error {
+func (e *sseEventWriter) Alert(alert *search.Alert) error {
+	return e.inner.Event("alert", toEventAlert(alert))
+}
+
+func newNDJSONEventWriter(inner *streamhttp.NDJSONEncoder) *ndjsonEventWriter {
+	return &ndjsonEventWriter{inner: inner}
+}
+
+// ndjsonEventWriter writes a frontend stream as newline delimited JSON, with
+// one line per match or other event.
+type ndjsonEventWriter struct {
+	inner *streamhttp.NDJSONEncoder
+}
+
+func (e *ndjsonEventWriter) Done() error {
+	return e.inner.Event("done", nil)
+}
+
+func (e *ndjsonEventWriter) Progress(current api.Progress) error {
+	return e.inner.Event("progress", current)
+}
+
+func (e *ndjsonEventWriter) Match(match streamhttp.EventMatch) error {
+	return e.inner.Match(match)
+}
+
+func (e *ndjsonEventWriter) FlushMatches() error {
+	return e.inner.Flush()
+}
+
+func (e *ndjsonEventWriter) Filters(fs []*streaming.Filter) error {
+	if len(fs) > 0 {
+		return e.inner.Event("filters", toEventFilters(fs))
+	}
+	return nil
+}
+
+func (e *ndjsonEventWriter) Error(err error) error {
+	return e.inner.Event("error", streamhttp.EventError{Message: err.Error()})
+}
+
+func (e *ndjsonEventWriter) Alert(alert *search.Alert) error {
+	return e.inner.Event("alert", toEventAlert(alert))
+}
+
+const (
+	// csvAlertTrailer and csvErrorTrailer are the HTTP trailers in which a
+	// CSV stream reports alerts and errors, since they cannot be encoded in
+	// the CSV.
+	csvAlertTrailer = "X-Sourcegraph-Search-Alert"
+	csvErrorTrailer = "X-Sourcegraph-Search-Error"
+)
+
+func newCSVEventWriter(w http.ResponseWriter, inner *streamhttp.CSVEncoder) (*csvEventWriter, error) {
+	w.Header().Add("Trailer", csvAlertTrailer)
+	w.Header().Add("Trailer", csvErrorTrailer)
+	if err := inner.Header(); err != nil {
+		return nil, err
+	}
+	return &csvEventWriter{w: w, inner: inner}, nil
+}
+
+// csvEventWriter writes the matches of a frontend stream as CSV. Progress and
+// filters are dropped, alerts and errors are reported in HTTP trailers.
+type csvEventWriter struct {
+	w     http.ResponseWriter
+	inner *streamhttp.CSVEncoder
+}
+
+func (e *csvEventWriter) Done() error {
+	return e.FlushMatches()
+}
+
+func (e *csvEventWriter) Progress(api.Progress) error {
+	return nil
+}
+
+func (e *csvEventWriter) Match(match streamhttp.EventMatch) error {
+	return e.inner.Match(match)
+}
+
+func (e *csvEventWriter) FlushMatches() error {
+	return e.inner.Flush()
+}
+
+func (e *csvEventWriter) Filters([]*streaming.Filter) error {
+	return nil
+}
+
+func (e *csvEventWriter) Error(err error) error {
+	e.w.Header().Set(csvErrorTrailer, err.Error())
+	return nil
+}
+
+func (e *csvEventWriter) Alert(alert *search.Alert) error {
+	e.w.Header().Set(csvAlertTrailer, alert.Title)
+	return nil
+}
+
+func toEventFilters(fs []*streaming.Filter) []streamhttp.EventFilter {
+	buf := make([]streamhttp.EventFilter, 0, len(fs))
+	for _, f := range fs {
+		buf = append(buf, streamhttp.EventFilter{
+			Value:    f.Value,
+			Label:    f.Label,
+			Count:    f.Count,
+			LimitHit: f.IsLimitHit,
+			Kind:     f.Kind,
+		})
+	}
+	return buf
+}
+
+func toEventAlert(alert *search.Alert) streamhttp.EventAlert {
 	var pqs []streamhttp.ProposedQuery
 	for _, pq := range alert.ProposedQueries {
 		pqs = append(pqs, streamhttp.ProposedQuery{
@@ -59,10 +193,10 @@ func (e *eventWriter) Alert(alert *search.Alert) error {
 			Query:       pq.QueryString(),
 		})
 	}
-	return e.inner.Event("alert", streamhttp.EventAlert{
+	return streamhttp.EventAlert{
 		Title:           alert.Title,
 		Description:     alert.Description,
 		Kind:            alert.Kind,
 		ProposedQueries: pqs,
-	})
+	}
 }
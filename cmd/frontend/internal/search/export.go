@@ -0,0 +1,244 @@
+package search
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExportHandler serves the search export API. An export runs a search in the
+// background, see exportHandler, and stores all of its results in the upload
+// store to be downloaded once the search is done. Unlike the stream API, an
+// export is not bound to a single request, so it is suited for queries with
+// many results, like count:all searches across all repositories.
+type ExportHandler struct {
+	logger       log.Logger
+	db           database.DB
+	store        *exportStore
+	searchClient client.SearchClient
+}
+
+func NewExportHandler(db database.DB, codeIntel precise.Client) *ExportHandler {
+	logger := log.Scoped("searchExportHandler", "")
+	return &ExportHandler{
+		logger:       logger,
+		db:           db,
+		store:        newExportStore(db),
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), codeIntel),
+	}
+}
+
+// exportStatus is the JSON representation of a search export.
+type exportStatus struct {
+	ID             int        `json:"id"`
+	Query          string     `json:"query"`
+	Format         string     `json:"format"`
+	State          string     `json:"state"`
+	FailureMessage *string    `json:"failureMessage,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+}
+
+func toExportStatus(export *searchExport) exportStatus {
+	return exportStatus{
+		ID:             export.ID,
+		Query:          export.Query,
+		Format:         export.Format,
+		State:          export.State,
+		FailureMessage: export.FailureMessage,
+		CreatedAt:      export.CreatedAt,
+		FinishedAt:     export.FinishedAt,
+	}
+}
+
+// ServeCreate creates an export of the search in the request parameters. It
+// accepts the same parameters as the stream API, and the format of the
+// export as "format", which is either "csv" or "ndjson".
+func (h *ExportHandler) ServeCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "search exports require an authenticated user", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	args, err := parseURLQuery(r.Form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format := r.Form.Get("format")
+	switch format {
+	case "":
+		format = formatCSV
+	case formatCSV, formatNDJSON:
+	default:
+		http.Error(w, fmt.Sprintf("unsupported export format %q. Supported formats are: %s, %s", format, formatCSV, formatNDJSON), http.StatusBadRequest)
+		return
+	}
+
+	// Validate the query now rather than failing the export later.
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := h.searchClient.Plan(ctx, args.Version, strPtr(args.PatternType), args.Query, search.Streaming, settings, envvar.SourcegraphDotComMode()); err != nil {
+		var queryErr *client.QueryError
+		if errors.As(err, &queryErr) {
+			http.Error(w, queryErr.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	export := &searchExport{
+		UserID:      a.UID,
+		Query:       args.Query,
+		PatternType: args.PatternType,
+		Version:     args.Version,
+		Format:      format,
+	}
+	if err := h.store.Create(ctx, export); err != nil {
+		h.logger.Error("failed to create search export", log.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	export, err = h.store.Get(ctx, export.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeExportStatus(w, http.StatusAccepted, export)
+}
+
+// ServeStatus writes the status of the export in the "id" route variable.
+func (h *ExportHandler) ServeStatus(w http.ResponseWriter, r *http.Request) {
+	export, ok := h.getExport(w, r)
+	if !ok {
+		return
+	}
+	writeExportStatus(w, http.StatusOK, export)
+}
+
+// ServeDownload writes the results of the export in the "id" route variable,
+// which must have completed.
+func (h *ExportHandler) ServeDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	export, ok := h.getExport(w, r)
+	if !ok {
+		return
+	}
+	if export.State != "completed" {
+		http.Error(w, fmt.Sprintf("search export %d is %s, results can only be downloaded once it is completed", export.ID, export.State), http.StatusConflict)
+		return
+	}
+
+	contentType, ext := streamhttp.NDJSONContentType, "ndjson"
+	if export.Format == formatCSV {
+		contentType, ext = streamhttp.CSVContentType, "csv"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=search-export-%d.%s", export.ID, ext))
+
+	// Chunks only contain the rows of matches, the header is only written
+	// once for the whole export.
+	if export.Format == formatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(streamhttp.CSVHeader); err != nil {
+			return
+		}
+		cw.Flush()
+	}
+
+	if err := h.writeChunks(ctx, w, export.ID); err != nil {
+		// We may have written part of the results already, so we can only
+		// log the error.
+		h.logger.Error("failed to write search export", log.Int("id", export.ID), log.Error(err))
+	}
+}
+
+// writeChunks writes the objects of the chunks of the export to w.
+func (h *ExportHandler) writeChunks(ctx context.Context, w io.Writer, exportID int) error {
+	uploadStore, err := getExportUploadStore()
+	if err != nil {
+		return err
+	}
+	keys, err := h.store.ChunkKeys(ctx, exportID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		rc, err := uploadStore.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getExport returns the export in the "id" route variable if it belongs to
+// the current user. Otherwise it writes an error to w and returns false.
+func (h *ExportHandler) getExport(w http.ResponseWriter, r *http.Request) (*searchExport, bool) {
+	a := actor.FromContext(r.Context())
+	if !a.IsAuthenticated() {
+		http.Error(w, "search exports require an authenticated user", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid search export ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	export, err := h.store.Get(r.Context(), id)
+	// Exports of other users are reported as not found to not reveal that
+	// they exist.
+	if errors.Is(err, errExportNotFound) || err == nil && export.UserID != a.UID {
+		http.Error(w, errExportNotFound.Error(), http.StatusNotFound)
+		return nil, false
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+	return export, true
+}
+
+func writeExportStatus(w http.ResponseWriter, code int, export *searchExport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(toExportStatus(export))
+}
@@ -0,0 +1,255 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	workerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// searchExport is a search that runs in the background to export all of its
+// results. The results are stored as chunks, see exportStore.
+type searchExport struct {
+	// Search export fields
+	UserID      int32
+	Query       string
+	PatternType string
+	Version     string
+	Format      string
+	CreatedAt   time.Time
+
+	// Standard dbworker fields
+	ID             int
+	State          string
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+	ExecutionLogs  []workerutil.ExecutionLogEntry
+}
+
+func (e *searchExport) RecordID() int {
+	return e.ID
+}
+
+var searchExportColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_exports.user_id"),
+	sqlf.Sprintf("search_exports.query"),
+	sqlf.Sprintf("search_exports.pattern_type"),
+	sqlf.Sprintf("search_exports.version"),
+	sqlf.Sprintf("search_exports.format"),
+	sqlf.Sprintf("search_exports.created_at"),
+	sqlf.Sprintf("search_exports.id"),
+	sqlf.Sprintf("search_exports.state"),
+	sqlf.Sprintf("search_exports.failure_message"),
+	sqlf.Sprintf("search_exports.started_at"),
+	sqlf.Sprintf("search_exports.finished_at"),
+	sqlf.Sprintf("search_exports.process_after"),
+	sqlf.Sprintf("search_exports.num_resets"),
+	sqlf.Sprintf("search_exports.num_failures"),
+	sqlf.Sprintf("search_exports.execution_logs"),
+}
+
+func scanSearchExport(sc dbutil.Scanner) (*searchExport, error) {
+	var e searchExport
+	return &e, sc.Scan(
+		// Search export fields
+		&e.UserID,
+		&e.Query,
+		&e.PatternType,
+		&e.Version,
+		&e.Format,
+		&e.CreatedAt,
+
+		// Standard dbworker fields
+		&e.ID,
+		&e.State,
+		&e.FailureMessage,
+		&e.StartedAt,
+		&e.FinishedAt,
+		&e.ProcessAfter,
+		&e.NumResets,
+		&e.NumFailures,
+		pq.Array(&e.ExecutionLogs),
+	)
+}
+
+var errExportNotFound = errors.New("search export not found")
+
+// exportStore stores search exports and their results.
+//
+// The results of an export are stored in chunks, each holding the encoded
+// matches of a batch of repositories in an object of the upload store. A
+// chunk is only recorded once its object is uploaded, and only holds the
+// repositories which were searched completely, so when an export is retried
+// after a failure or a restart it only searches the repositories which are
+// not in a chunk yet.
+type exportStore struct {
+	*basestore.Store
+}
+
+func newExportStore(db database.DB) *exportStore {
+	return &exportStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+// newExportWorkerStore returns the dbworker store of the search_exports
+// table.
+func newExportWorkerStore(db database.DB) workerstore.Store {
+	return workerstore.New(db.Handle(), workerstore.Options{
+		Name:              "search_export_worker_store",
+		TableName:         "search_exports",
+		Scan:              workerstore.BuildWorkerScan(scanSearchExport),
+		OrderByExpression: sqlf.Sprintf("search_exports.queued_at, search_exports.id"),
+		ColumnExpressions: searchExportColumns,
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      5,
+		MaxNumRetries:     3,
+	})
+}
+
+// Create queues export and sets its ID.
+func (s *exportStore) Create(ctx context.Context, export *searchExport) error {
+	id, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		createSearchExportFmtStr,
+		export.UserID,
+		export.Query,
+		export.PatternType,
+		export.Version,
+		export.Format,
+	)))
+	if err != nil {
+		return err
+	}
+	export.ID = id
+	return nil
+}
+
+const createSearchExportFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:Create
+INSERT INTO search_exports (user_id, query, pattern_type, version, format)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING id
+`
+
+// Get returns the export with the given ID, or errExportNotFound.
+func (s *exportStore) Get(ctx context.Context, id int) (*searchExport, error) {
+	export, ok, err := basestore.NewFirstScanner(scanSearchExport)(s.Query(ctx, sqlf.Sprintf(
+		getSearchExportFmtStr,
+		sqlf.Join(searchExportColumns, ", "),
+		id,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errExportNotFound
+	}
+	return export, nil
+}
+
+const getSearchExportFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:Get
+SELECT %s FROM search_exports WHERE id = %s
+`
+
+// exportProgress is the progress of an export, as stored by its chunks.
+type exportProgress struct {
+	// Repos are the repositories which have been searched.
+	Repos map[api.RepoID]struct{}
+	// NumMatches is the number of matches exported so far.
+	NumMatches int
+	// NumChunks is the number of chunks of the export.
+	NumChunks int
+}
+
+// Progress returns the progress of the export.
+func (s *exportStore) Progress(ctx context.Context, exportID int) (progress exportProgress, err error) {
+	ids, err := basestore.ScanInt32s(s.Query(ctx, sqlf.Sprintf(exportedReposFmtStr, exportID)))
+	if err != nil {
+		return exportProgress{}, err
+	}
+
+	progress.Repos = make(map[api.RepoID]struct{}, len(ids))
+	for _, id := range ids {
+		progress.Repos[api.RepoID(id)] = struct{}{}
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(exportedChunksFmtStr, exportID))
+	if err != nil {
+		return exportProgress{}, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		if err := rows.Scan(&progress.NumChunks, &progress.NumMatches); err != nil {
+			return exportProgress{}, err
+		}
+	}
+	return progress, nil
+}
+
+const exportedReposFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:Progress
+SELECT unnest(repo_ids) FROM search_export_chunks WHERE export_id = %s
+`
+
+const exportedChunksFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:Progress
+SELECT COUNT(*), COALESCE(SUM(num_matches), 0) FROM search_export_chunks WHERE export_id = %s
+`
+
+// AddChunk records the object at key, which holds numMatches matches, as the
+// results of the given repositories.
+func (s *exportStore) AddChunk(ctx context.Context, exportID int, key string, repoIDs []api.RepoID, numMatches int) error {
+	ids := make([]int32, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		ids = append(ids, int32(id))
+	}
+	return s.Exec(ctx, sqlf.Sprintf(addChunkFmtStr, exportID, pq.Array(ids), numMatches, key))
+}
+
+const addChunkFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:AddChunk
+INSERT INTO search_export_chunks (export_id, repo_ids, num_matches, object_key)
+VALUES (%s, %s, %s, %s)
+`
+
+// ChunkKeys returns the object keys of the chunks of the export, in the order
+// they were added.
+func (s *exportStore) ChunkKeys(ctx context.Context, exportID int) ([]string, error) {
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(chunkKeysFmtStr, exportID)))
+}
+
+const chunkKeysFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:ChunkKeys
+SELECT object_key FROM search_export_chunks WHERE export_id = %s ORDER BY id
+`
+
+// DeleteOld deletes finished exports, and their chunks, which finished before
+// the given time. It returns the object keys of the deleted chunks.
+func (s *exportStore) DeleteOld(ctx context.Context, before time.Time) ([]string, error) {
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(deleteOldFmtStr, before)))
+}
+
+// The chunks are deleted by the cascade, but the select still sees them since
+// it runs on the snapshot from before the delete.
+const deleteOldFmtStr = `
+-- source: cmd/frontend/internal/search/export_store.go:DeleteOld
+WITH deleted AS (
+	DELETE FROM search_exports
+	WHERE state IN ('completed', 'failed') AND finished_at < %s
+	RETURNING id
+)
+SELECT c.object_key FROM search_export_chunks c JOIN deleted d ON d.id = c.export_id
+`
@@ -0,0 +1,416 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// exportBatchSize is the number of repositories searched at a time by an
+	// export. The results of every batch are stored as a chunk, so this is
+	// also the most work that is repeated when an export is resumed.
+	exportBatchSize = 100
+
+	// exportChunkKeyPrefix is the prefix of the keys of the objects which
+	// hold the chunks of exports in the upload store.
+	exportChunkKeyPrefix = "search-exports"
+
+	// exportRetention is how long the results of a finished export are kept.
+	exportRetention = 7 * 24 * time.Hour
+)
+
+// NewExportRoutines returns the background routines which run search exports
+// created with ExportHandler.
+func NewExportRoutines(ctx context.Context, logger log.Logger, db database.DB, codeIntel precise.Client, observationContext *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	logger = logger.Scoped("searchExports", "background search exports")
+	uploadStore, err := getExportUploadStore()
+	if err != nil {
+		return nil, err
+	}
+	store := newExportStore(db)
+	workerStore := newExportWorkerStore(db)
+	handler := &exportHandler{
+		db:           db,
+		store:        store,
+		uploadStore:  uploadStore,
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), codeIntel),
+	}
+
+	worker := dbworker.NewWorker(ctx, workerStore, handler, workerutil.WorkerOptions{
+		Name:              "search_export_worker",
+		NumHandlers:       2,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationContext, "search_export_worker_processor"),
+	})
+	resetter := dbworker.NewResetter(workerStore, dbworker.ResetterOptions{
+		Name:     "search_export_resetter",
+		Interval: time.Minute,
+		Metrics:  *dbworker.NewMetrics(observationContext, "search_export_worker"),
+	})
+	cleaner := goroutine.NewPeriodicGoroutine(ctx, time.Hour, goroutine.NewHandlerWithErrorMessage(
+		"search_export_cleaner",
+		func(ctx context.Context) error {
+			keys, err := store.DeleteOld(ctx, time.Now().Add(-exportRetention))
+			if err != nil {
+				return err
+			}
+			// Objects which fail to be deleted here are still removed by
+			// the expiration of the upload store.
+			var errs error
+			for _, key := range keys {
+				errs = errors.Append(errs, uploadStore.Delete(ctx, key))
+			}
+			return errs
+		},
+	))
+
+	return []goroutine.BackgroundRoutine{worker, resetter, cleaner}, nil
+}
+
+// exportHandler runs search exports. It resolves the repositories of the
+// search and searches them in batches, streaming the results of each batch
+// to the upload store as a chunk. If an export is retried it skips the
+// repositories which are in a chunk already.
+type exportHandler struct {
+	db           database.DB
+	store        *exportStore
+	uploadStore  uploadstore.Store
+	searchClient client.SearchClient
+}
+
+var _ workerutil.Handler = &exportHandler{}
+
+func (h *exportHandler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) error {
+	export, ok := record.(*searchExport)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	// SECURITY: run the search as the user who created the export, so that
+	// it only exports results the user has access to.
+	ctx = actor.WithActor(ctx, actor.FromUser(export.UserID))
+	ctx = featureflag.WithFlags(ctx, h.db.FeatureFlags())
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		return errors.Wrap(err, "query settings")
+	}
+
+	inputs, err := h.searchClient.Plan(ctx, export.Version, strPtr(export.PatternType), export.Query, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	progress, err := h.store.Progress(ctx, export.ID)
+	if err != nil {
+		return errors.Wrap(err, "get export progress")
+	}
+
+	repos, err := h.resolveRepos(ctx, inputs)
+	if err != nil {
+		return errors.Wrap(err, "resolve repositories")
+	}
+
+	remaining := make([]types.MinimalRepo, 0, len(repos))
+	for _, repo := range repos {
+		if _, ok := progress.Repos[repo.ID]; !ok {
+			remaining = append(remaining, repo)
+		}
+	}
+
+	// The count: of the query limits the matches of the whole export rather
+	// than those of every batch.
+	limit := 0
+	if count := inputs.Query.Count(); count != nil {
+		limit = *count
+	}
+	numMatches := progress.NumMatches
+	numChunks := progress.NumChunks
+
+	logger.Info("exporting search results",
+		log.Int("export", export.ID),
+		log.Int("repos", len(repos)),
+		log.Int("remaining", len(remaining)),
+		log.Int("limit", limit),
+		log.Int("matches", numMatches))
+
+	var timedOut []api.RepoID
+	for len(remaining) > 0 && (limit == 0 || numMatches < limit) {
+		batch := remaining
+		if len(batch) > exportBatchSize {
+			batch = batch[:exportBatchSize]
+		}
+		remaining = remaining[len(batch):]
+
+		batchLimit := 0
+		if limit != 0 {
+			batchLimit = limit - numMatches
+		}
+		// The key only depends on the number of recorded chunks, so that
+		// the object of a chunk which failed to be recorded is replaced
+		// when the export is retried.
+		key := exportChunkKey(export.ID, numChunks)
+		batchMatches, batchTimedOut, err := h.uploadBatch(ctx, key, inputs, export.Format, batch, batchLimit)
+		if err != nil {
+			return errors.Wrap(err, "search repositories")
+		}
+		timedOut = append(timedOut, batchTimedOut...)
+
+		// Repositories which timed out are left out of the chunk, so that
+		// only they are searched again when the export is retried.
+		repoIDs := make([]api.RepoID, 0, len(batch))
+		for _, repo := range batch {
+			if !containsRepoID(batchTimedOut, repo.ID) {
+				repoIDs = append(repoIDs, repo.ID)
+			}
+		}
+		if len(repoIDs) == 0 {
+			if err := h.uploadStore.Delete(ctx, key); err != nil {
+				return errors.Wrap(err, "delete results")
+			}
+			continue
+		}
+		if err := h.store.AddChunk(ctx, export.ID, key, repoIDs, batchMatches); err != nil {
+			return errors.Wrap(err, "store results")
+		}
+		numMatches += batchMatches
+		numChunks++
+	}
+
+	if len(timedOut) > 0 && (limit == 0 || numMatches < limit) {
+		return errors.Newf("timed out searching %d repositories", len(timedOut))
+	}
+	return nil
+}
+
+// exportChunkKey returns the key of the object holding the chunk with the
+// given index of an export.
+func exportChunkKey(exportID, index int) string {
+	return fmt.Sprintf("%s/%d/%d", exportChunkKeyPrefix, exportID, index)
+}
+
+func containsRepoID(ids []api.RepoID, id api.RepoID) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRepos returns the repositories searched by inputs, ordered by ID.
+func (h *exportHandler) resolveRepos(ctx context.Context, inputs *search.Inputs) ([]types.MinimalRepo, error) {
+	plan := inputs.Plan
+	if inputs.Join != nil {
+		plan = inputs.Join.Plan()
+	}
+
+	clients := h.searchClient.JobClients()
+	resolver := searchrepos.NewResolver(clients.Logger, clients.DB, clients.SearcherURLs, clients.Zoekt)
+
+	seen := make(map[api.RepoID]struct{})
+	var repos []types.MinimalRepo
+	for _, b := range plan {
+		err := resolver.Paginate(ctx, jobutil.ToRepoOptions(b, inputs.UserSettings), func(page *searchrepos.Resolved) error {
+			for _, repoRevs := range page.RepoRevs {
+				if _, ok := seen[repoRevs.Repo.ID]; ok {
+					continue
+				}
+				seen[repoRevs.Repo.ID] = struct{}{}
+				repos = append(repos, repoRevs.Repo)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, searchrepos.ErrNoResolvedRepos) {
+			return nil, err
+		}
+	}
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].ID < repos[j].ID })
+	return repos, nil
+}
+
+// exportEncoder encodes the matches of an export.
+type exportEncoder interface {
+	Match(match streamhttp.EventMatch) error
+	Flush() error
+}
+
+func newExportEncoder(w io.Writer, format string) exportEncoder {
+	if format == formatCSV {
+		return streamhttp.NewCSVEncoder(w)
+	}
+	return streamhttp.NewNDJSONEncoder(w)
+}
+
+// uploadBatch runs the search of inputs on repos, see searchBatch, and
+// uploads its matches to the object at key as they arrive. If the search
+// fails the upload is aborted.
+func (h *exportHandler) uploadBatch(ctx context.Context, key string, inputs *search.Inputs, format string, repos []types.MinimalRepo, limit int) (int, []api.RepoID, error) {
+	pr, pw := io.Pipe()
+	uploadErr := make(chan error, 1)
+	go func() {
+		_, err := h.uploadStore.Upload(ctx, key, pr)
+		// Unblock the search if the upload stopped reading early.
+		pr.CloseWithError(err)
+		uploadErr <- err
+	}()
+
+	numMatches, timedOut, err := h.searchBatch(ctx, inputs, format, repos, limit, pw)
+	pw.CloseWithError(err)
+	if uploadErr := <-uploadErr; err == nil {
+		err = uploadErr
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return numMatches, timedOut, nil
+}
+
+// searchBatch runs the search of inputs on repos and writes its matches to w
+// encoded in format. It returns the number of matches along with the
+// repositories which timed out, which were not searched. If limit is not 0,
+// at most limit matches are written.
+func (h *exportHandler) searchBatch(ctx context.Context, inputs *search.Inputs, format string, repos []types.MinimalRepo, limit int, w io.Writer) (int, []api.RepoID, error) {
+	batchInputs := *inputs
+	batchInputs.Plan = restrictToRepos(inputs.Plan, repos, limit)
+	batchInputs.Query = batchInputs.Plan.ToQ()
+	if inputs.Join != nil {
+		join := *inputs.Join
+		join.Operands = make([]query.Plan, 0, len(inputs.Join.Operands))
+		for _, operand := range inputs.Join.Operands {
+			join.Operands = append(join.Operands, restrictToRepos(operand, repos, 0))
+		}
+		batchInputs.Join = &join
+	}
+
+	var (
+		encoder = newExportEncoder(w, format)
+
+		mu         sync.Mutex
+		stats      streaming.Stats
+		numMatches int
+		encodeErr  error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		repoMetadata, err := getEventRepoMetadata(ctx, h.db, event)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		stats.Update(&event.Stats)
+		if err != nil {
+			encodeErr = errors.Append(encodeErr, err)
+			return
+		}
+
+		for _, match := range event.Results {
+			if limit != 0 && numMatches >= limit {
+				return
+			}
+
+			// Like the stream API, skip matches which we cannot map to a
+			// repo the actor has access to.
+			repo := match.RepoName()
+			if md, ok := repoMetadata[repo.ID]; !ok || md.Name != repo.Name {
+				continue
+			}
+			if err := encoder.Match(fromMatch(match, repoMetadata, false)); err != nil {
+				encodeErr = errors.Append(encodeErr, err)
+				return
+			}
+			numMatches++
+		}
+	})
+
+	_, err := h.searchClient.Execute(ctx, stream, &batchInputs)
+	if err != nil {
+		return 0, nil, err
+	}
+	if encodeErr != nil {
+		return 0, nil, encodeErr
+	}
+	if err := encoder.Flush(); err != nil {
+		return 0, nil, err
+	}
+
+	var timedOut []api.RepoID
+	stats.Status.Filter(search.RepoStatusTimedout, func(id api.RepoID) {
+		timedOut = append(timedOut, id)
+	})
+	return numMatches, timedOut, nil
+}
+
+// restrictToRepos returns plan with every query limited to repos and at
+// most limit results, or all of their results if limit is 0. The count: of
+// the queries is replaced, since the export applies it to all of its
+// batches rather than to each one.
+//
+// The repositories were resolved with the queries of plan, so forks and
+// archived repositories are included explicitly rather than by default,
+// since the default depends on the repo: filters of the query.
+func restrictToRepos(plan query.Plan, repos []types.MinimalRepo, limit int) query.Plan {
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, regexp.QuoteMeta(string(repo.Name)))
+	}
+	repoFilter := fmt.Sprintf("^(?:%s)$", strings.Join(names, "|"))
+
+	count := "99999999"
+	if limit != 0 {
+		count = strconv.Itoa(limit)
+	}
+
+	restricted := make(query.Plan, 0, len(plan))
+	for _, b := range plan {
+		params := make(query.Parameters, 0, len(b.Parameters)+4)
+		for _, param := range b.Parameters {
+			if param.Field != query.FieldCount {
+				params = append(params, param)
+			}
+		}
+		params = append(params, query.Parameter{Field: query.FieldRepo, Value: repoFilter})
+		if b.Fork() == nil {
+			params = append(params, query.Parameter{Field: query.FieldFork, Value: string(query.Yes)})
+		}
+		if b.Archived() == nil {
+			params = append(params, query.Parameter{Field: query.FieldArchived, Value: string(query.Yes)})
+		}
+		params = append(params, query.Parameter{Field: query.FieldCount, Value: count})
+		restricted = append(restricted, query.Basic{Parameters: params, Pattern: b.Pattern})
+	}
+	return restricted
+}
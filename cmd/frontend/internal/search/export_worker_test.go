@@ -0,0 +1,190 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRestrictToRepos(t *testing.T) {
+	repos := []types.MinimalRepo{
+		{ID: 1, Name: "github.com/foo/bar"},
+		{ID: 2, Name: "github.com/foo/bar.js"},
+	}
+
+	cases := []struct {
+		query string
+		limit int
+		want  []string
+	}{{
+		query: "foo",
+		want: []string{
+			`repo:^(?:github\.com/foo/bar|github\.com/foo/bar\.js)$ fork:yes archived:yes count:99999999 foo`,
+		},
+	}, {
+		query: "repo:bar@dev fork:no count:10 foo",
+		want: []string{
+			`repo:bar@dev fork:no repo:^(?:github\.com/foo/bar|github\.com/foo/bar\.js)$ archived:yes count:99999999 foo`,
+		},
+	}, {
+		query: "repo:bar@dev fork:no count:10 foo",
+		limit: 3,
+		want: []string{
+			`repo:bar@dev fork:no repo:^(?:github\.com/foo/bar|github\.com/foo/bar\.js)$ archived:yes count:3 foo`,
+		},
+	}, {
+		query: "(repo:bar or repo:baz) foo",
+		want: []string{
+			`repo:bar repo:^(?:github\.com/foo/bar|github\.com/foo/bar\.js)$ fork:yes archived:yes count:99999999 foo`,
+			`repo:baz repo:^(?:github\.com/foo/bar|github\.com/foo/bar\.js)$ fork:yes archived:yes count:99999999 foo`,
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitLiteral(tc.query))
+			require.NoError(t, err)
+
+			var got []string
+			for _, b := range restrictToRepos(plan, repos, tc.limit) {
+				got = append(got, query.StringHuman(b.ToParseTree()))
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSearchBatchLimit(t *testing.T) {
+	repos := []types.MinimalRepo{{ID: 1, Name: "github.com/foo/bar"}}
+
+	mock := client.NewMockSearchClient()
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		for _, path := range []string{"a", "b", "c"} {
+			s.Send(streaming.SearchEvent{
+				Results: result.Matches{&result.FileMatch{
+					File: result.File{Repo: types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"}, Path: path},
+				}},
+			})
+		}
+		return nil, nil
+	})
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &types.SearchedRepo{ID: id, Name: "github.com/foo/bar"})
+		}
+		return out, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	h := &exportHandler{db: db, searchClient: mock}
+
+	plan, err := query.Pipeline(query.InitLiteral("count:10 foo"))
+	require.NoError(t, err)
+	inputs := &search.Inputs{Plan: plan, Query: plan.ToQ()}
+
+	for _, tc := range []struct {
+		limit       int
+		wantMatches int
+		wantCount   int
+	}{
+		{limit: 0, wantMatches: 3, wantCount: 99999999},
+		{limit: 2, wantMatches: 2, wantCount: 2},
+	} {
+		var buf bytes.Buffer
+		numMatches, timedOut, err := h.searchBatch(context.Background(), inputs, formatNDJSON, repos, tc.limit, &buf)
+		require.NoError(t, err)
+		require.Empty(t, timedOut)
+		require.Equal(t, tc.wantMatches, numMatches)
+		require.Equal(t, tc.wantMatches, bytes.Count(buf.Bytes(), []byte("\n")))
+
+		history := mock.ExecuteFunc.History()
+		batchInputs := history[len(history)-1].Arg2
+		require.Equal(t, tc.wantCount, *batchInputs.Query.Count())
+	}
+}
+
+func TestUploadBatch(t *testing.T) {
+	repos := []types.MinimalRepo{
+		{ID: 1, Name: "github.com/foo/bar"},
+		{ID: 2, Name: "github.com/foo/baz"},
+	}
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &types.SearchedRepo{ID: id, Name: "github.com/foo/bar"})
+		}
+		return out, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	plan, err := query.Pipeline(query.InitLiteral("foo"))
+	require.NoError(t, err)
+	inputs := &search.Inputs{Plan: plan, Query: plan.ToQ()}
+
+	t.Run("streams matches and reports timed out repositories", func(t *testing.T) {
+		searchClient := client.NewMockSearchClient()
+		searchClient.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+			var status search.RepoStatusMap
+			status.Update(2, search.RepoStatusTimedout)
+			s.Send(streaming.SearchEvent{
+				Results: result.Matches{&result.FileMatch{
+					File: result.File{Repo: repos[0], Path: "a"},
+				}},
+				Stats: streaming.Stats{Status: status},
+			})
+			return nil, nil
+		})
+
+		var uploaded bytes.Buffer
+		uploadStore := uploadstoremocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			return io.Copy(&uploaded, r)
+		})
+
+		h := &exportHandler{db: db, searchClient: searchClient, uploadStore: uploadStore}
+		numMatches, timedOut, err := h.uploadBatch(context.Background(), "search-exports/1/0", inputs, formatNDJSON, repos, 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, numMatches)
+		require.Equal(t, []api.RepoID{2}, timedOut)
+		require.Equal(t, 1, bytes.Count(uploaded.Bytes(), []byte("\n")))
+		require.Equal(t, "search-exports/1/0", uploadStore.UploadFunc.History()[0].Arg1)
+	})
+
+	t.Run("aborts the upload if the search fails", func(t *testing.T) {
+		searchErr := errors.New("search failed")
+		searchClient := client.NewMockSearchClient()
+		searchClient.ExecuteFunc.SetDefaultReturn(nil, searchErr)
+
+		uploadStore := uploadstoremocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			return io.Copy(io.Discard, r)
+		})
+
+		h := &exportHandler{db: db, searchClient: searchClient, uploadStore: uploadStore}
+		_, _, err := h.uploadBatch(context.Background(), "search-exports/1/0", inputs, formatNDJSON, repos, 0)
+		require.ErrorIs(t, err, searchErr)
+		require.Len(t, uploadStore.UploadFunc.History(), 1)
+		require.ErrorIs(t, uploadStore.UploadFunc.History()[0].Result1, searchErr)
+	})
+}
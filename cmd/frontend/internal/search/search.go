@@ -6,6 +6,7 @@ package search
 import (
 	"context"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
@@ -38,13 +40,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// StreamHandler is an http handler which streams back search results.
-func StreamHandler(db database.DB) http.Handler {
+// StreamHandler is an http handler which streams back search results. The given
+// code intelligence client may be nil if precise code intelligence is not available.
+func StreamHandler(db database.DB, codeIntel precise.Client) http.Handler {
 	logger := log.Scoped("searchStreamHandler", "")
 	return &streamHandler{
 		logger:              logger,
 		db:                  db,
-		searchClient:        client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+		searchClient:        client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), codeIntel),
 		flushTickerInternal: 100 * time.Millisecond,
 		pingTickerInterval:  5 * time.Second,
 	}
@@ -63,16 +66,19 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer tr.Finish()
 	r = r.WithContext(ctx)
 
-	streamWriter, err := streamhttp.NewWriter(w)
+	format, err := streamFormat(r)
 	if err != nil {
 		tr.SetError(err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	// Log events to trace
-	streamWriter.StatHook = eventStreamOTHook(tr.LogFields)
 
-	eventWriter := newEventWriter(streamWriter)
+	eventWriter, err := newStreamEventWriter(w, format, tr)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	defer eventWriter.Done()
 
 	err = h.serveHTTP(r, tr, eventWriter)
@@ -84,7 +90,7 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter *eventWriter) (err error) {
+func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter eventWriter) (err error) {
 	ctx := r.Context()
 	start := time.Now()
 
@@ -124,11 +130,12 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 	}
 
 	progress := &streamclient.ProgressAggregator{
-		Start:        start,
-		Limit:        limit,
-		Trace:        trace.URL(trace.ID(ctx), conf.DefaultClient()),
-		DisplayLimit: displayLimit,
-		RepoNamer:    streamclient.RepoNamer(ctx, h.db),
+		Start:         start,
+		Limit:         limit,
+		Trace:         trace.URL(trace.ID(ctx), conf.DefaultClient()),
+		DisplayLimit:  displayLimit,
+		RepoNamer:     streamclient.RepoNamer(ctx, h.db),
+		ExpandedQuery: inputs.ExpandedQuery,
 	}
 
 	var wgLogLatency sync.WaitGroup
@@ -199,6 +206,71 @@ func logSearch(ctx context.Context, logger log.Logger, alert *search.Alert, err
 	}
 }
 
+const (
+	// formatEventStream is the default format of the stream API, a Server
+	// Sent Event stream.
+	formatEventStream = "event-stream"
+	// formatNDJSON is newline delimited JSON, see streamhttp.NDJSONEncoder.
+	formatNDJSON = "ndjson"
+	// formatCSV is a CSV of the matches, see streamhttp.CSVEncoder.
+	formatCSV = "csv"
+)
+
+// streamFormat returns the format requested with the "format" URL parameter
+// or, if that is not set, with the Accept header.
+func streamFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case formatEventStream, formatNDJSON, formatCSV:
+		return format, nil
+	case "":
+	default:
+		return "", errors.Errorf("unsupported format %q. Supported formats are: %s, %s, %s", format, formatEventStream, formatNDJSON, formatCSV)
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(accept)
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/event-stream":
+			return formatEventStream, nil
+		case streamhttp.NDJSONContentType:
+			return formatNDJSON, nil
+		case streamhttp.CSVContentType:
+			return formatCSV, nil
+		}
+	}
+	return formatEventStream, nil
+}
+
+// newStreamEventWriter returns an eventWriter streaming events to w in
+// format.
+func newStreamEventWriter(w http.ResponseWriter, format string, tr *trace.Trace) (eventWriter, error) {
+	switch format {
+	case formatNDJSON:
+		encoder, err := streamhttp.NewNDJSONWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return newNDJSONEventWriter(encoder), nil
+	case formatCSV:
+		encoder, err := streamhttp.NewCSVWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return newCSVEventWriter(w, encoder)
+	default:
+		streamWriter, err := streamhttp.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		// Log events to trace
+		streamWriter.StatHook = eventStreamOTHook(tr.LogFields)
+		return newEventWriter(streamWriter), nil
+	}
+}
+
 type args struct {
 	Query              string
 	Version            string
@@ -294,6 +366,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.CaptureMatch:
+		return fromCaptureMatch(v, repoCache)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -329,6 +403,36 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 	return pathEvent
 }
 
+func fromCaptureMatch(cm *result.CaptureMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCaptureMatch {
+	captures := make([]streamhttp.Capture, 0, len(cm.Captures))
+	for _, c := range cm.Captures {
+		captures = append(captures, streamhttp.Capture{
+			Value: c.Value,
+			Count: c.Count,
+		})
+	}
+
+	captureEvent := &streamhttp.EventCaptureMatch{
+		Type:         streamhttp.CaptureMatchType,
+		Path:         cm.Path,
+		Repository:   string(cm.Repo.Name),
+		RepositoryID: int32(cm.Repo.ID),
+		Commit:       string(cm.CommitID),
+		Captures:     captures,
+	}
+
+	if r, ok := repoCache[cm.Repo.ID]; ok {
+		captureEvent.RepoStars = r.Stars
+		captureEvent.RepoLastFetched = r.LastFetched
+	}
+
+	if cm.InputRev != nil {
+		captureEvent.Branches = []string{*cm.InputRev}
+	}
+
+	return captureEvent
+}
+
 func fromChunkMatches(cms result.ChunkMatches) []streamhttp.ChunkMatch {
 	res := make([]streamhttp.ChunkMatch, 0, len(cms))
 	for _, cm := range cms {
@@ -580,7 +684,7 @@ func newEventHandler(
 	ctx context.Context,
 	logger log.Logger,
 	db database.DB,
-	eventWriter *eventWriter,
+	eventWriter eventWriter,
 	progress *streamclient.ProgressAggregator,
 	flushInterval time.Duration,
 	progressInterval time.Duration,
@@ -588,19 +692,11 @@ func newEventHandler(
 	enableChunkMatches bool,
 	logLatency func(),
 ) *eventHandler {
-	// Store marshalled matches and flush periodically or when we go over
-	// 32kb. 32kb chosen to be smaller than bufio.MaxTokenSize. Note: we can
-	// still write more than that.
-	matchesBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
-		return eventWriter.MatchesJSON(data)
-	})
-
 	eh := &eventHandler{
 		ctx:                ctx,
 		logger:             logger,
 		db:                 db,
 		eventWriter:        eventWriter,
-		matchesBuf:         matchesBuf,
 		filters:            &streaming.SearchFilters{},
 		flushInterval:      flushInterval,
 		progress:           progress,
@@ -637,11 +733,10 @@ type eventHandler struct {
 	// Everything below this line is protected by the mutex
 	mu sync.Mutex
 
-	eventWriter *eventWriter
+	eventWriter eventWriter
 
-	matchesBuf *streamhttp.JSONArrayBuf
-	filters    *streaming.SearchFilters
-	progress   *streamclient.ProgressAggregator
+	filters  *streaming.SearchFilters
+	progress *streamclient.ProgressAggregator
 
 	// These timers will be non-nil unless Done() was called
 	flushTimer    *time.Timer
@@ -677,14 +772,14 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 		}
 
 		eventMatch := fromMatch(match, repoMetadata, h.enableChunkMatches)
-		h.matchesBuf.Append(eventMatch)
+		h.eventWriter.Match(eventMatch)
 	}
 
 	// Instantly send results if we have not sent any yet.
 	if h.first && len(event.Results) > 0 {
 		h.first = false
 		h.eventWriter.Filters(h.filters.Compute())
-		h.matchesBuf.Flush()
+		h.eventWriter.FlushMatches()
 		h.logLatency()
 	}
 }
@@ -702,7 +797,7 @@ func (h *eventHandler) Done() {
 
 	// Flush the final state
 	h.eventWriter.Filters(h.filters.Compute())
-	h.matchesBuf.Flush()
+	h.eventWriter.FlushMatches()
 	h.eventWriter.Progress(h.progress.Final())
 }
 
@@ -726,7 +821,7 @@ func (h *eventHandler) flushTick() {
 	// a nil flushTimer indicates that Done() was called
 	if h.flushTimer != nil {
 		h.eventWriter.Filters(h.filters.Compute())
-		h.matchesBuf.Flush()
+		h.eventWriter.FlushMatches()
 		if h.progress.Dirty {
 			h.eventWriter.Progress(h.progress.Current())
 		}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -128,6 +129,119 @@ func TestServeStream_chunkMatches(t *testing.T) {
 	require.Len(t, chunkMatches[0].Ranges, 1)
 }
 
+func TestServeStream_formats(t *testing.T) {
+	graphqlbackend.MockDecodedViewerFinalSettings = &schema.Settings{}
+	t.Cleanup(func() { graphqlbackend.MockDecodedViewerFinalSettings = nil })
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&search.Inputs{Query: query.Q{query.Parameter{Field: "count", Value: "1000"}}}, nil)
+	mock.ExecuteFunc.SetDefaultHook(func(_ context.Context, s streaming.Sender, _ *search.Inputs) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{&result.FileMatch{
+				File: result.File{
+					Repo: types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"},
+					Path: "testpath",
+				},
+				ChunkMatches: result.ChunkMatches{{
+					Content:      "line1",
+					ContentStart: result.Location{Line: 4},
+					Ranges: result.Ranges{{
+						Start: result.Location{Offset: 0, Line: 4, Column: 0},
+						End:   result.Location{Offset: 1, Line: 4, Column: 1},
+					}},
+				}},
+			}},
+		})
+		return nil, nil
+	})
+
+	mockRepos := database.NewMockRepoStore()
+	mockRepos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api2.RepoID) ([]*types.SearchedRepo, error) {
+		out := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, &types.SearchedRepo{ID: id, Name: "github.com/foo/bar"})
+		}
+		return out, nil
+	})
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(mockRepos)
+
+	ts := httptest.NewServer(&streamHandler{
+		logger:              logtest.Scoped(t),
+		db:                  db,
+		flushTickerInternal: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	})
+	defer ts.Close()
+
+	get := func(t *testing.T, query, accept string) (*http.Response, string) {
+		req, err := http.NewRequest("GET", ts.URL+query, nil)
+		require.NoError(t, err)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res, string(b)
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		res, body := get(t, "?q=test&format=csv", "")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/csv", res.Header.Get("Content-Type"))
+		require.Equal(t, "repository,path,line,preview,commit\ngithub.com/foo/bar,testpath,5,line1,\n", body)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		res, body := get(t, "?q=test", "application/x-ndjson")
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(body), "\n")
+		require.Contains(t, lines[0], `"type":"content"`)
+		require.Contains(t, lines[0], `"path":"testpath"`)
+		require.Equal(t, `{"type":"done"}`, lines[len(lines)-1])
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		res, _ := get(t, "?q=test&format=xml", "")
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestStreamFormat(t *testing.T) {
+	cases := []struct {
+		url    string
+		accept string
+		want   string
+	}{
+		{url: "/search/stream?q=a", want: formatEventStream},
+		{url: "/search/stream?q=a", accept: "text/event-stream", want: formatEventStream},
+		{url: "/search/stream?q=a", accept: "application/x-ndjson", want: formatNDJSON},
+		{url: "/search/stream?q=a", accept: "text/csv; charset=utf-8", want: formatCSV},
+		{url: "/search/stream?q=a", accept: "application/json, text/csv", want: formatCSV},
+		{url: "/search/stream?q=a", accept: "*/*", want: formatEventStream},
+		{url: "/search/stream?q=a&format=ndjson", accept: "text/csv", want: formatNDJSON},
+	}
+	for _, tc := range cases {
+		r := httptest.NewRequest("GET", tc.url, nil)
+		if tc.accept != "" {
+			r.Header.Set("Accept", tc.accept)
+		}
+		got, err := streamFormat(r)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got, "url=%s accept=%s", tc.url, tc.accept)
+	}
+
+	_, err := streamFormat(httptest.NewRequest("GET", "/search/stream?q=a&format=xml", nil))
+	require.Error(t, err)
+}
+
 func TestDisplayLimit(t *testing.T) {
 	cases := []struct {
 		queryString         string
@@ -511,6 +511,8 @@ func getVCSSyncer(
 		}
 		cli := crates.NewClient(urn, httpcli.ExternalDoer)
 		return server.NewRustPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeMercurial:
+		return &server.MercurialRepoSyncer{}, nil
 	}
 	return &server.GitRepoSyncer{}, nil
 }
@@ -154,6 +154,14 @@ var (
 		Name: "src_gitserver_non_existing_repos_removed",
 		Help: "number of non existing repos removed during cleanup",
 	})
+	partialClonesTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_partial_clones",
+		Help: "The number of repos on disk that are partial clones",
+	})
+	promisorPacksSizeTotalBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_promisor_pack_bytes",
+		Help: "Size (in bytes) of the packfiles of partial clones, including objects fetched on demand",
+	})
 )
 
 const reposStatsName = "repos-stats.json"
@@ -208,6 +216,13 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 		wrongShardReposSizeTotalBytes.Set(float64(wrongShardRepoSize))
 	}()
 
+	var partialCloneCount int64
+	var promisorPackSize int64
+	defer func() {
+		partialClonesTotal.Set(float64(partialCloneCount))
+		promisorPacksSizeTotalBytes.Set(float64(promisorPackSize))
+	}()
+
 	var wrongShardReposDeleted int64
 	defer func() {
 		// We want to set the gauge only when wrong shard clean-up is enabled
@@ -222,10 +237,22 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 		name := s.name(dir)
 		repoToSize[name] = size
 
+		// Partial clones grow as objects are fetched on demand, so we track
+		// the size of their promisor packfiles separately.
+		if isPartialClone(dir) {
+			partialCloneCount++
+			promisorPackSize += promisorPackBytes(dir)
+		}
+
 		// Record the number and disk usage used of repos that should
 		// not belong on this instance and remove up to SRC_WRONG_SHARD_DELETE_LIMIT in a single Janitor run.
-		addr, err := s.addrForRepo(bCtx, name, gitServerAddrs)
-		if !s.hostnameMatch(addr) {
+		// Repos replicated to this instance are not on the wrong shard.
+		addrs, err := s.addrsForRepo(bCtx, name, gitServerAddrs)
+		if err != nil {
+			return false, err
+		}
+		if !s.hostnameMatchAny(addrs) {
+			addr := addrs[0]
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
 
@@ -347,10 +374,11 @@ func (s *Server) cleanupRepos(gitServerAddrs gitserver.GitServerAddresses) {
 			}
 		}
 
-		// We believe converting a Perforce depot to a Git repository is generally a
-		// very expensive operation, therefore we do not try to re-clone/redo the
-		// conversion only because it is old or slow to do "git gc".
-		if repoType == "perforce" && reason != maybeCorrupt {
+		// We believe converting a Perforce depot or a Mercurial repository to a Git
+		// repository is generally a very expensive operation, therefore we do not
+		// try to re-clone/redo the conversion only because it is old or slow to do
+		// "git gc".
+		if (repoType == "perforce" || repoType == "mercurial") && reason != maybeCorrupt {
 			reason = ""
 		}
 
@@ -1250,13 +1278,17 @@ func pruneIfNeeded(dir GitDir, limit int) (err error) {
 
 func needsMaintenance(dir GitDir) (bool, string, error) {
 	// Bitmaps store reachability information about the set of objects in a
-	// packfile which speeds up clone and fetch operations.
-	hasBm, err := hasBitmap(dir)
-	if err != nil {
-		return false, "", err
-	}
-	if !hasBm {
-		return true, "bitmap", nil
+	// packfile which speeds up clone and fetch operations. Git does not write
+	// bitmaps for the promisor packfiles of partial clones, so we would repack
+	// them on every run if we required one.
+	if !isPartialClone(dir) {
+		hasBm, err := hasBitmap(dir)
+		if err != nil {
+			return false, "", err
+		}
+		if !hasBm {
+			return true, "bitmap", nil
+		}
 	}
 
 	// The commit-graph file is a supplemental data structure that accelerates
@@ -1339,7 +1371,9 @@ func hasCommitGraph(dir GitDir) (bool, error) {
 }
 
 // tooManyPackfiles counts the packfiles in objects/pack. Packfiles with an
-// accompanying .keep file are ignored.
+// accompanying .keep file are ignored. The promisor packfiles of partial
+// clones are counted, because every on-demand fetch of missing objects adds
+// one.
 func tooManyPackfiles(dir GitDir, limit int) (bool, error) {
 	packs, err := filepath.Glob(dir.Path("objects", "pack", "*.pack"))
 	if err != nil {
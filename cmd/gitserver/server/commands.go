@@ -3,12 +3,14 @@ package server
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/internal/accesslog"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func handleGetObject(getObject gitdomain.GetObjectFunc) func(w http.ResponseWriter, r *http.Request) {
@@ -43,3 +45,69 @@ func handleGetObject(getObject gitdomain.GetObjectFunc) func(w http.ResponseWrit
 		}
 	}
 }
+
+func handleFileHistory(fileHistory gitdomain.FileHistoryFunc) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req protocol.FileHistoryRequest
+		logger := log.Scoped("handleFileHistory", "handles file history")
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "decoding body", http.StatusBadRequest)
+			logger.Error("decoding body", log.Error(err))
+			return
+		}
+		if req.Path == "" {
+			http.Error(w, "path must not be empty", http.StatusBadRequest)
+			return
+		}
+		if strings.HasPrefix(req.Commit, "-") {
+			http.Error(w, "invalid commit", http.StatusBadRequest)
+			return
+		}
+		if req.N <= 0 || req.Skip < 0 {
+			http.Error(w, "N must be positive and Skip must not be negative", http.StatusBadRequest)
+			return
+		}
+
+		// Log which actor is accessing the repo.
+		accesslog.Record(r.Context(), string(req.Repo), map[string]string{
+			"commit": req.Commit,
+			"path":   req.Path,
+		})
+
+		entries, hasNextPage, err := fileHistory(r.Context(), req.Repo, gitdomain.FileHistoryOptions{
+			Commit: req.Commit,
+			Path:   req.Path,
+			N:      req.N,
+			Skip:   req.Skip,
+		})
+		var resp protocol.FileHistoryResponse
+		var repoNotExistErr *gitdomain.RepoNotExistError
+		var revisionNotFoundErr *gitdomain.RevisionNotFoundError
+		switch {
+		case errors.As(err, &repoNotExistErr):
+			// Another replica may have a clone of the repository.
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
+				CloneInProgress: repoNotExistErr.CloneInProgress,
+				CloneProgress:   repoNotExistErr.CloneProgress,
+			})
+			return
+		case errors.As(err, &revisionNotFoundErr):
+			// The revision is missing from every replica, so this must not be reported with a
+			// status code that makes the client fail over to another replica.
+			resp.UnknownRevision = revisionNotFoundErr.Spec
+		case err != nil:
+			http.Error(w, "getting file history", http.StatusInternalServerError)
+			logger.Error("getting file history", log.Error(err))
+			return
+		default:
+			resp.Entries = entries
+			resp.HasNextPage = hasNextPage
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			logger.Error("sending response", log.Error(err))
+		}
+	}
+}
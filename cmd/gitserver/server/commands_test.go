@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHandleFileHistory(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		"no limit": {
+			body:       `{"Repo":"r","Path":"f.txt"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		"repo not cloned": {
+			body:       `{"Repo":"r","Path":"f.txt","N":10}`,
+			err:        &gitdomain.RepoNotExistError{Repo: "r", CloneInProgress: true},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `"cloneInProgress":true`,
+		},
+		"unknown revision": {
+			body:       `{"Repo":"r","Commit":"missing","Path":"f.txt","N":10}`,
+			err:        &gitdomain.RevisionNotFoundError{Repo: "r", Spec: "missing"},
+			wantStatus: http.StatusOK,
+			wantBody:   `"UnknownRevision":"missing"`,
+		},
+		"other error": {
+			body:       `{"Repo":"r","Path":"f.txt","N":10}`,
+			err:        errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			handler := handleFileHistory(func(ctx context.Context, repo api.RepoName, opts gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+				return nil, false, test.err
+			})
+
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest("POST", "/commands/file-history", strings.NewReader(test.body)))
+
+			if w.Code != test.wantStatus {
+				t.Errorf("unexpected status code. want=%d have=%d", test.wantStatus, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), test.wantBody) {
+				t.Errorf("unexpected body. want to contain %q, have %q", test.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,799 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// commitGraphFile is the name of the file within a GIT_DIR that stores the
+// commit graph index of the repository.
+const commitGraphFile = "sg_commitgraph"
+
+// commitGraphVersion is bumped whenever the encoding of commitGraph changes.
+// Indexes written with another version are discarded and rebuilt.
+const commitGraphVersion = 1
+
+// commitGraph is an index of the commits of a repository that allows answering
+// ancestry, merge-base and date range queries without running git.
+//
+// Commits are identified by their position in the index, and every commit is
+// positioned after all of its parents. The index is only ever extended: commits
+// that are no longer reachable from a ref (e.g. after a force push) remain in
+// the index until the repository is recloned.
+type commitGraph struct {
+	Version int
+
+	IDs     []api.CommitID
+	Parents [][]int32
+
+	// Generations holds the generation number of each commit: one for root
+	// commits, otherwise one more than the largest generation of its parents.
+	// A commit can only be an ancestor of commits with a larger generation.
+	Generations []int32
+
+	// CommitTimes holds the committer date of each commit in Unix seconds.
+	CommitTimes []int64
+
+	// indexes maps commit IDs to their position in the index.
+	indexes map[api.CommitID]int32
+
+	// maxAncestorTimes holds the latest committer date among each commit and its
+	// ancestors. It allows pruning of date range queries, as committer dates are
+	// not monotonic along the history.
+	maxAncestorTimes []int64
+}
+
+// index populates the fields of the graph that are not persisted.
+func (g *commitGraph) index() {
+	g.indexes = make(map[api.CommitID]int32, len(g.IDs))
+	g.maxAncestorTimes = make([]int64, 0, len(g.IDs))
+	for i, id := range g.IDs {
+		g.indexes[id] = int32(i)
+		g.maxAncestorTimes = append(g.maxAncestorTimes, g.maxAncestorTime(int32(i)))
+	}
+}
+
+func (g *commitGraph) maxAncestorTime(i int32) int64 {
+	t := g.CommitTimes[i]
+	for _, p := range g.Parents[i] {
+		if g.maxAncestorTimes[p] > t {
+			t = g.maxAncestorTimes[p]
+		}
+	}
+	return t
+}
+
+// lookup returns the position of the given commit in the index.
+func (g *commitGraph) lookup(id api.CommitID) (int32, bool) {
+	i, ok := g.indexes[id]
+	return i, ok
+}
+
+// heads returns the commits of the index that are not the parent of another commit.
+func (g *commitGraph) heads() []api.CommitID {
+	hasChildren := make([]bool, len(g.IDs))
+	for _, parents := range g.Parents {
+		for _, p := range parents {
+			hasChildren[p] = true
+		}
+	}
+
+	var heads []api.CommitID
+	for i, id := range g.IDs {
+		if !hasChildren[i] {
+			heads = append(heads, id)
+		}
+	}
+	return heads
+}
+
+// isAncestor returns true if the commit a is an ancestor of (or equal to) the commit b.
+func (g *commitGraph) isAncestor(a, b int32) bool {
+	if a == b {
+		return true
+	}
+
+	visited := map[int32]struct{}{b: {}}
+	stack := []int32{b}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, p := range g.Parents[i] {
+			if p == a {
+				return true
+			}
+			// Commits with a generation no larger than a's cannot have a as ancestor
+			if _, ok := visited[p]; ok || g.Generations[p] <= g.Generations[a] {
+				continue
+			}
+			visited[p] = struct{}{}
+			stack = append(stack, p)
+		}
+	}
+
+	return false
+}
+
+// mergeBase returns a best common ancestor of the commits a and b, i.e. a common
+// ancestor that is not an ancestor of another common ancestor. The second return
+// value is false if a and b have no common ancestor.
+func (g *commitGraph) mergeBase(a, b int32) (int32, bool) {
+	if a == b {
+		return a, true
+	}
+
+	const (
+		reachableFromA = 1 << iota
+		reachableFromB
+		reachableFromBoth = reachableFromA | reachableFromB
+	)
+
+	// Commits are visited by decreasing generation, so each commit is visited after
+	// all of its descendants that were reached. The first commit reachable from both
+	// a and b is therefore not an ancestor of another common ancestor.
+	flags := map[int32]int{a: reachableFromA, b: reachableFromB}
+	queue := &commitGraphQueue{graph: g, commits: []int32{a, b}}
+	heap.Init(queue)
+
+	for queue.Len() > 0 {
+		i := heap.Pop(queue).(int32)
+		if flags[i] == reachableFromBoth {
+			return i, true
+		}
+
+		for _, p := range g.Parents[i] {
+			f, queued := flags[p]
+			if f|flags[i] == f {
+				continue
+			}
+			flags[p] = f | flags[i]
+			if !queued {
+				heap.Push(queue, p)
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// commitsBetween returns at most limit of the commits reachable from the given commit
+// whose committer date is within [after, before), ordered from the most recent to the
+// oldest. A zero bound is ignored.
+func (g *commitGraph) commitsBetween(tip int32, after, before time.Time, limit int) []int32 {
+	inRange := func(t int64) bool {
+		return (after.IsZero() || t >= after.Unix()) && (before.IsZero() || t < before.Unix())
+	}
+	// Ancestors of a commit that is older than after along with all of its ancestors
+	// can be skipped entirely
+	pruned := func(i int32) bool {
+		return !after.IsZero() && g.maxAncestorTimes[i] < after.Unix()
+	}
+
+	commits := []int32{}
+	if pruned(tip) {
+		return commits
+	}
+
+	// A commit is emitted once no queued commit can reach a more recent commit, so
+	// commits are emitted in order and the walk stops as soon as limit are found
+	visited := map[int32]struct{}{tip: {}}
+	queue := &commitTimeQueue{graph: g, items: []commitTimeQueueItem{{commit: tip}}}
+	for queue.Len() > 0 && len(commits) < limit {
+		item := heap.Pop(queue).(commitTimeQueueItem)
+		if item.emit {
+			commits = append(commits, item.commit)
+			continue
+		}
+
+		if inRange(g.CommitTimes[item.commit]) {
+			heap.Push(queue, commitTimeQueueItem{commit: item.commit, emit: true})
+		}
+		for _, p := range g.Parents[item.commit] {
+			if _, ok := visited[p]; ok || pruned(p) {
+				continue
+			}
+			visited[p] = struct{}{}
+			heap.Push(queue, commitTimeQueueItem{commit: p})
+		}
+	}
+
+	return commits
+}
+
+// commitTimeQueue is a max-heap of commits ordered by committer date. Commits that
+// are yet to be expanded are ordered by the latest committer date among them and
+// their ancestors, and come before emitted commits with the same date so that
+// commits with the same date are emitted by decreasing generation.
+type commitTimeQueue struct {
+	graph *commitGraph
+	items []commitTimeQueueItem
+}
+
+type commitTimeQueueItem struct {
+	commit int32
+	emit   bool
+}
+
+func (q *commitTimeQueue) time(item commitTimeQueueItem) int64 {
+	if item.emit {
+		return q.graph.CommitTimes[item.commit]
+	}
+	return q.graph.maxAncestorTimes[item.commit]
+}
+
+func (q *commitTimeQueue) Len() int { return len(q.items) }
+func (q *commitTimeQueue) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if ta, tb := q.time(a), q.time(b); ta != tb {
+		return ta > tb
+	}
+	if a.emit != b.emit {
+		return !a.emit
+	}
+	if ga, gb := q.graph.Generations[a.commit], q.graph.Generations[b.commit]; ga != gb {
+		return ga > gb
+	}
+	return a.commit > b.commit
+}
+func (q *commitTimeQueue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *commitTimeQueue) Push(x any)    { q.items = append(q.items, x.(commitTimeQueueItem)) }
+func (q *commitTimeQueue) Pop() any {
+	item := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return item
+}
+
+// commitGraphQueue is a max-heap of commits ordered by generation.
+type commitGraphQueue struct {
+	graph   *commitGraph
+	commits []int32
+}
+
+func (q *commitGraphQueue) Len() int { return len(q.commits) }
+func (q *commitGraphQueue) Less(i, j int) bool {
+	gi, gj := q.graph.Generations[q.commits[i]], q.graph.Generations[q.commits[j]]
+	if gi != gj {
+		return gi > gj
+	}
+	return q.commits[i] > q.commits[j]
+}
+func (q *commitGraphQueue) Swap(i, j int) { q.commits[i], q.commits[j] = q.commits[j], q.commits[i] }
+func (q *commitGraphQueue) Push(x any)    { q.commits = append(q.commits, x.(int32)) }
+func (q *commitGraphQueue) Pop() any {
+	i := q.commits[len(q.commits)-1]
+	q.commits = q.commits[:len(q.commits)-1]
+	return i
+}
+
+// readCommitGraph reads the commit graph index of the repository at dir. An
+// empty graph is returned if the index does not exist or cannot be decoded.
+func readCommitGraph(dir GitDir) (*commitGraph, error) {
+	f, err := os.Open(dir.Path(commitGraphFile))
+	if os.IsNotExist(err) {
+		return newCommitGraph(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var g commitGraph
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&g); err != nil || g.Version != commitGraphVersion {
+		// The index will be rebuilt on the next update
+		return newCommitGraph(), nil
+	}
+	g.index()
+
+	return &g, nil
+}
+
+func newCommitGraph() *commitGraph {
+	g := &commitGraph{Version: commitGraphVersion}
+	g.index()
+	return g
+}
+
+// writeCommitGraph atomically replaces the commit graph index of the repository at dir.
+func writeCommitGraph(dir GitDir, g *commitGraph) error {
+	f, err := os.CreateTemp(string(dir), commitGraphFile)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	if err := gob.NewEncoder(w).Encode(g); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return fileutil.RenameAndSync(f.Name(), dir.Path(commitGraphFile))
+}
+
+// extendCommitGraph returns a copy of the given graph that also contains the commits
+// of the repository at dir that were created since the graph was last extended. The
+// given graph is returned as-is if there are no new commits.
+func extendCommitGraph(ctx context.Context, dir GitDir, g *commitGraph) (*commitGraph, error) {
+	// Every commit of the graph is an ancestor of one of its heads, so excluding the
+	// heads lists exactly the commits that are missing from the graph
+	heads := g.heads()
+	out, err := listCommitsExcluding(ctx, dir, heads)
+	if err != nil && len(heads) > 0 {
+		// A head may have been garbage collected after a force push, in which
+		// case we rebuild the graph from scratch
+		g = newCommitGraph()
+		out, err = listCommitsExcluding(ctx, dir, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return g, nil
+	}
+
+	extended := &commitGraph{
+		Version:          commitGraphVersion,
+		IDs:              g.IDs,
+		Parents:          g.Parents,
+		Generations:      g.Generations,
+		CommitTimes:      g.CommitTimes,
+		indexes:          make(map[api.CommitID]int32, len(g.indexes)),
+		maxAncestorTimes: g.maxAncestorTimes,
+	}
+	for id, i := range g.indexes {
+		extended.indexes[id] = i
+	}
+
+	for _, line := range bytes.Split(bytes.TrimSpace(out), []byte("\n")) {
+		// <committer timestamp> <commit> <parents>...
+		fields := strings.Fields(string(line))
+		if len(fields) < 2 {
+			return nil, errors.Errorf("invalid rev-list output line: %q", line)
+		}
+		commitTime, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rev-list output line: %q", line)
+		}
+		id := api.CommitID(fields[1])
+		if _, ok := extended.indexes[id]; ok {
+			continue
+		}
+
+		var generation int32
+		parents := make([]int32, 0, len(fields)-2)
+		for _, parentID := range fields[2:] {
+			// Parents may be missing from shallow clones
+			p, ok := extended.indexes[api.CommitID(parentID)]
+			if !ok {
+				continue
+			}
+			parents = append(parents, p)
+			if extended.Generations[p] > generation {
+				generation = extended.Generations[p]
+			}
+		}
+
+		i := int32(len(extended.IDs))
+		extended.IDs = append(extended.IDs, id)
+		extended.Parents = append(extended.Parents, parents)
+		extended.Generations = append(extended.Generations, generation+1)
+		extended.CommitTimes = append(extended.CommitTimes, commitTime)
+		extended.indexes[id] = i
+		extended.maxAncestorTimes = append(extended.maxAncestorTimes, extended.maxAncestorTime(i))
+	}
+
+	return extended, nil
+}
+
+// buildCommitGraph writes the commit graph index of the repository at dir from scratch.
+func buildCommitGraph(ctx context.Context, dir GitDir) error {
+	g, err := extendCommitGraph(ctx, dir, newCommitGraph())
+	if err != nil {
+		return err
+	}
+	return writeCommitGraph(dir, g)
+}
+
+// listCommitsExcluding lists the commits reachable from any ref of the repository
+// at dir but not from the given commits, such that parents come before children.
+func listCommitsExcluding(ctx context.Context, dir GitDir, exclude []api.CommitID) ([]byte, error) {
+	var stdin bytes.Buffer
+	for _, id := range exclude {
+		stdin.WriteString("^" + string(id) + "\n")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-list", "--reverse", "--topo-order", "--parents", "--timestamp", "--all", "--stdin")
+	dir.Set(cmd)
+	cmd.Stdin = &stdin
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, wrapCmdError(cmd, err)
+	}
+	return out, nil
+}
+
+// commitGraphCacheMaxBytes is the estimated memory used by the commit graphs kept
+// in memory, above which the least recently used graphs are evicted.
+const commitGraphCacheMaxBytes = 256 << 20
+
+// commitGraphEntryBytes is the estimated memory used by a cache entry apart from its
+// graph. It also bounds the number of entries of repositories without a graph.
+const commitGraphEntryBytes = 1 << 10
+
+// commitGraphCommitBytes is the estimated memory used per commit of a graph: its ID,
+// parents (assuming a single parent), generation, dates and entry in the indexes map.
+const commitGraphCommitBytes = (16 + 40) + (24 + 4) + 4 + 8 + 8 + 64
+
+// estimatedBytes returns an estimate of the memory used by the graph.
+func (g *commitGraph) estimatedBytes() int64 {
+	return int64(len(g.IDs)) * commitGraphCommitBytes
+}
+
+// commitGraphCache holds the most recently used commit graphs, and serializes
+// updates of the commit graph of a repository. The zero value is ready to use.
+type commitGraphCache struct {
+	// maxBytes overrides commitGraphCacheMaxBytes if positive.
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[GitDir]*list.Element // values are *cachedCommitGraph
+	recency list.List                // most recently used first
+	bytes   int64
+}
+
+// cachedCommitGraph is the cache entry of a repository. The lock serializing updates
+// of the repository's commit graph lives in the entry, so it is dropped along with
+// it. If an entry is evicted during an update, a concurrent update may start with a
+// new entry; this only duplicates work, as the index file is replaced atomically.
+type cachedCommitGraph struct {
+	dir   GitDir
+	bytes int64 // guarded by the cache's lock
+
+	updateMu sync.Mutex
+
+	mu    sync.Mutex
+	graph *commitGraph
+	stamp commitGraphStamp // the stamp of the index file graph was read from
+}
+
+// commitGraphStamp identifies a version of the commit graph index file.
+type commitGraphStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statCommitGraph(dir GitDir) commitGraphStamp {
+	fi, err := os.Stat(dir.Path(commitGraphFile))
+	if err != nil {
+		return commitGraphStamp{}
+	}
+	return commitGraphStamp{modTime: fi.ModTime(), size: fi.Size()}
+}
+
+// entry returns the cache entry of the repository at dir, adding an empty one if
+// the repository is not cached.
+func (c *commitGraphCache) entry(dir GitDir) *cachedCommitGraph {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[dir]; ok {
+		c.recency.MoveToFront(el)
+		return el.Value.(*cachedCommitGraph)
+	}
+
+	if c.entries == nil {
+		c.entries = map[GitDir]*list.Element{}
+	}
+	e := &cachedCommitGraph{dir: dir, bytes: commitGraphEntryBytes}
+	c.entries[dir] = c.recency.PushFront(e)
+	c.bytes += e.bytes
+	c.evict()
+	return e
+}
+
+// resize records the graph now held by the given entry, evicting the least recently
+// used entries if the cache grew too large.
+func (c *commitGraphCache) resize(e *cachedCommitGraph, g *commitGraph) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The entry may have been evicted in the meantime
+	if el, ok := c.entries[e.dir]; !ok || el.Value != e {
+		return
+	}
+	bytes := commitGraphEntryBytes + g.estimatedBytes()
+	c.bytes += bytes - e.bytes
+	e.bytes = bytes
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache is within its
+// budget. The most recently used entry is kept even if it exceeds the budget alone.
+func (c *commitGraphCache) evict() {
+	maxBytes := c.maxBytes
+	if maxBytes <= 0 {
+		maxBytes = commitGraphCacheMaxBytes
+	}
+
+	for c.bytes > maxBytes && c.recency.Len() > 1 {
+		e := c.recency.Remove(c.recency.Back()).(*cachedCommitGraph)
+		delete(c.entries, e.dir)
+		c.bytes -= e.bytes
+	}
+}
+
+// get returns the commit graph of the repository at dir, reading it from disk if
+// it changed since it was last read.
+func (c *commitGraphCache) get(dir GitDir) (*commitGraph, error) {
+	e := c.entry(dir)
+	g, err := e.get(dir)
+	if err != nil {
+		return nil, err
+	}
+	c.resize(e, g)
+	return g, nil
+}
+
+func (e *cachedCommitGraph) get(dir GitDir) (*commitGraph, error) {
+	stamp := statCommitGraph(dir)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.graph != nil && e.stamp == stamp {
+		return e.graph, nil
+	}
+
+	g, err := readCommitGraph(dir)
+	if err != nil {
+		return nil, err
+	}
+	e.graph, e.stamp = g, stamp
+
+	return g, nil
+}
+
+// update adds the commits of the repository at dir that are missing from its commit
+// graph index, and returns the updated graph.
+func (c *commitGraphCache) update(ctx context.Context, dir GitDir) (*commitGraph, error) {
+	e := c.entry(dir)
+	e.updateMu.Lock()
+	defer e.updateMu.Unlock()
+
+	g, err := e.get(dir)
+	if err != nil {
+		return nil, err
+	}
+	c.resize(e, g)
+
+	extended, err := extendCommitGraph(ctx, dir, g)
+	if err != nil {
+		return nil, err
+	}
+	if extended == g {
+		return g, nil
+	}
+
+	if err := writeCommitGraph(dir, extended); err != nil {
+		return nil, errors.Wrap(err, "writing commit graph")
+	}
+
+	e.mu.Lock()
+	e.graph, e.stamp = extended, statCommitGraph(dir)
+	e.mu.Unlock()
+	c.resize(e, extended)
+
+	return extended, nil
+}
+
+func (s *Server) handleCommitGraphIsAncestor(w http.ResponseWriter, r *http.Request) {
+	var req protocol.CommitGraphIsAncestorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := s.dir(req.Repo)
+	if !s.commitGraphRepoCloned(w, dir) {
+		return
+	}
+
+	var resp protocol.CommitGraphIsAncestorResponse
+	g, commits, unknownRevision, err := s.commitGraphWithRevisions(r.Context(), dir, req.Ancestor, req.Descendant)
+	if err != nil {
+		s.writeCommitGraphError(w, req.Repo, err)
+		return
+	}
+	if unknownRevision != "" {
+		resp.UnknownRevision = unknownRevision
+	} else {
+		resp.IsAncestor = g.isAncestor(commits[0], commits[1])
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleCommitGraphMergeBase(w http.ResponseWriter, r *http.Request) {
+	var req protocol.CommitGraphMergeBaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dir := s.dir(req.Repo)
+	if !s.commitGraphRepoCloned(w, dir) {
+		return
+	}
+
+	var resp protocol.CommitGraphMergeBaseResponse
+	g, commits, unknownRevision, err := s.commitGraphWithRevisions(r.Context(), dir, req.A, req.B)
+	if err != nil {
+		s.writeCommitGraphError(w, req.Repo, err)
+		return
+	}
+	if unknownRevision != "" {
+		resp.UnknownRevision = unknownRevision
+	} else if mergeBase, ok := g.mergeBase(commits[0], commits[1]); ok {
+		resp.MergeBase = g.IDs[mergeBase]
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleCommitGraphCommitsBetween(w http.ResponseWriter, r *http.Request) {
+	var req protocol.CommitGraphCommitsBetweenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Limit <= 0 {
+		http.Error(w, "limit must be positive", http.StatusBadRequest)
+		return
+	}
+
+	dir := s.dir(req.Repo)
+	if !s.commitGraphRepoCloned(w, dir) {
+		return
+	}
+
+	resp := protocol.CommitGraphCommitsBetweenResponse{Commits: []gitdomain.CommitGraphCommit{}}
+	g, commits, unknownRevision, err := s.commitGraphWithRevisions(r.Context(), dir, req.Revision)
+	if err != nil {
+		s.writeCommitGraphError(w, req.Repo, err)
+		return
+	}
+	if unknownRevision != "" {
+		resp.UnknownRevision = unknownRevision
+	} else {
+		for _, i := range g.commitsBetween(commits[0], req.After, req.Before, req.Limit) {
+			resp.Commits = append(resp.Commits, gitdomain.CommitGraphCommit{
+				ID:            g.IDs[i],
+				Generation:    int(g.Generations[i]),
+				CommitterDate: time.Unix(g.CommitTimes[i], 0).UTC(),
+			})
+		}
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// commitGraphRepoCloned returns true if the repository at dir is cloned. Otherwise,
+// it writes a not found response.
+func (s *Server) commitGraphRepoCloned(w http.ResponseWriter, dir GitDir) bool {
+	if repoCloned(dir) {
+		return true
+	}
+
+	cloneProgress, cloneInProgress := s.locker.Status(dir)
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
+		CloneInProgress: cloneInProgress,
+		CloneProgress:   cloneProgress,
+	})
+	return false
+}
+
+func (s *Server) writeCommitGraphError(w http.ResponseWriter, repo api.RepoName, err error) {
+	s.Logger.Error("querying commit graph", log.String("repo", string(repo)), log.Error(err))
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// commitGraphWithRevisions returns the commit graph of the repository at dir along
+// with the position of the commit of each of the given revisions within it. The
+// graph is brought up to date if it does not contain one of the commits yet. If a
+// revision does not resolve to a commit of the repository, it is returned instead.
+func (s *Server) commitGraphWithRevisions(ctx context.Context, dir GitDir, revs ...string) (*commitGraph, []int32, string, error) {
+	ids := make([]api.CommitID, 0, len(revs))
+	for _, rev := range revs {
+		id, err := resolveCommitGraphRevision(ctx, dir, rev)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if id == "" {
+			return nil, nil, rev, nil
+		}
+		ids = append(ids, id)
+	}
+
+	g, err := s.commitGraphs.get(dir)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	commits := make([]int32, 0, len(ids))
+	for i, id := range ids {
+		commit, ok := g.lookup(id)
+		if !ok {
+			// The commit was created after the last update of the index
+			if g, err = s.commitGraphs.update(ctx, dir); err != nil {
+				return nil, nil, "", err
+			}
+			if commit, ok = g.lookup(id); !ok {
+				return nil, nil, revs[i], nil
+			}
+		}
+		commits = append(commits, commit)
+	}
+
+	return g, commits, "", nil
+}
+
+// resolveCommitGraphRevision resolves the given revision of the repository at dir to
+// a commit. An empty commit ID is returned if the revision does not exist.
+func resolveCommitGraphRevision(ctx context.Context, dir GitDir, rev string) (api.CommitID, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if strings.HasPrefix(rev, "-") {
+		return "", nil
+	}
+	if isAbsoluteRevision(rev) {
+		return api.CommitID(rev), nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		var e *exec.ExitError
+		if errors.As(err, &e) && ctx.Err() == nil {
+			return "", nil
+		}
+		return "", wrapCmdError(cmd, err)
+	}
+
+	return api.CommitID(bytes.TrimSpace(out)), nil
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestCommitGraph(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	dir := GitDir(filepath.Join(root, ".git"))
+
+	commit := func(message string, unixTime int64) api.CommitID {
+		date := time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
+		runCmd(t, root, "sh", "-c", fmt.Sprintf("GIT_COMMITTER_DATE=%s git commit --allow-empty -m %s", date, message))
+		return api.CommitID(runCmd(t, root, "git", "rev-parse", "HEAD")[:40])
+	}
+
+	// A - B ---- M - D
+	//   \       /
+	//     ---- C
+	runCmd(t, root, "git", "init")
+	runCmd(t, root, "git", "checkout", "-b", "main")
+	a := commit("a", 1000)
+	b := commit("b", 2000)
+	runCmd(t, root, "git", "checkout", "-b", "feature", string(a))
+	c := commit("c", 1500)
+	runCmd(t, root, "git", "checkout", "main")
+	runCmd(t, root, "sh", "-c", fmt.Sprintf("GIT_COMMITTER_DATE=%s git merge --no-ff -m m feature", time.Unix(3000, 0).UTC().Format(time.RFC3339)))
+	m := api.CommitID(runCmd(t, root, "git", "rev-parse", "HEAD")[:40])
+	d := commit("d", 4000)
+
+	g, err := extendCommitGraph(ctx, dir, newCommitGraph())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	index := func(g *commitGraph, id api.CommitID) int32 {
+		t.Helper()
+		i, ok := g.lookup(id)
+		if !ok {
+			t.Fatalf("commit %s is missing from the graph", id)
+		}
+		return i
+	}
+	ids := func(g *commitGraph, commits []int32) []api.CommitID {
+		ids := make([]api.CommitID, 0, len(commits))
+		for _, i := range commits {
+			ids = append(ids, g.IDs[i])
+		}
+		return ids
+	}
+
+	generations := map[api.CommitID]int32{a: 1, b: 2, c: 2, m: 3, d: 4}
+	for id, generation := range generations {
+		if have := g.Generations[index(g, id)]; have != generation {
+			t.Errorf("unexpected generation for %s. want=%d have=%d", id, generation, have)
+		}
+	}
+
+	t.Run("isAncestor", func(t *testing.T) {
+		for _, test := range []struct {
+			a, b api.CommitID
+			want bool
+		}{
+			{a, d, true},
+			{c, d, true},
+			{d, d, true},
+			{c, b, false},
+			{b, c, false},
+			{d, a, false},
+		} {
+			if have := g.isAncestor(index(g, test.a), index(g, test.b)); have != test.want {
+				t.Errorf("unexpected isAncestor(%s, %s). want=%v have=%v", test.a, test.b, test.want, have)
+			}
+		}
+	})
+
+	t.Run("mergeBase", func(t *testing.T) {
+		for _, test := range []struct {
+			a, b, want api.CommitID
+		}{
+			{b, c, a},
+			{c, d, c},
+			{d, b, b},
+			{m, m, m},
+		} {
+			mergeBase, ok := g.mergeBase(index(g, test.a), index(g, test.b))
+			if !ok {
+				t.Fatalf("expected a merge base for %s and %s", test.a, test.b)
+			}
+			if have := g.IDs[mergeBase]; have != test.want {
+				t.Errorf("unexpected mergeBase(%s, %s). want=%s have=%s", test.a, test.b, test.want, have)
+			}
+		}
+	})
+
+	t.Run("commitsBetween", func(t *testing.T) {
+		for _, test := range []struct {
+			tip           api.CommitID
+			after, before int64
+			limit         int
+			want          []api.CommitID
+		}{
+			{d, 0, 0, 10, []api.CommitID{d, m, b, c, a}},
+			{d, 0, 0, 2, []api.CommitID{d, m}},
+			{d, 1500, 3000, 10, []api.CommitID{b, c}},
+			{d, 1500, 3000, 1, []api.CommitID{b}},
+			{d, 3500, 0, 10, []api.CommitID{d}},
+			{b, 0, 1500, 10, []api.CommitID{a}},
+			{b, 2500, 0, 10, []api.CommitID{}},
+		} {
+			var after, before time.Time
+			if test.after != 0 {
+				after = time.Unix(test.after, 0)
+			}
+			if test.before != 0 {
+				before = time.Unix(test.before, 0)
+			}
+
+			if diff := cmp.Diff(test.want, ids(g, g.commitsBetween(index(g, test.tip), after, before, test.limit))); diff != "" {
+				t.Errorf("unexpected commits (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("extend", func(t *testing.T) {
+		if err := writeCommitGraph(dir, g); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		e := commit("e", 5000)
+
+		read, err := readCommitGraph(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(g.IDs, read.IDs); diff != "" {
+			t.Errorf("unexpected commits read (-want +got):\n%s", diff)
+		}
+
+		extended, err := extendCommitGraph(ctx, dir, read)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(append(g.IDs, e), extended.IDs); diff != "" {
+			t.Errorf("unexpected commits (-want +got):\n%s", diff)
+		}
+		if have := extended.Generations[index(extended, e)]; have != 5 {
+			t.Errorf("unexpected generation. want=%d have=%d", 5, have)
+		}
+		if !extended.isAncestor(index(extended, c), index(extended, e)) {
+			t.Errorf("expected %s to be an ancestor of %s", c, e)
+		}
+
+		unchanged, err := extendCommitGraph(ctx, dir, extended)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if unchanged != extended {
+			t.Errorf("expected graph without new commits to be unchanged")
+		}
+	})
+
+	t.Run("commitsBetween with skewed dates", func(t *testing.T) {
+		// Committer dates are not monotonic, e.g. f is older than its parent e
+		f := commit("f", 500)
+		skewed, err := extendCommitGraph(ctx, dir, newCommitGraph())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		e := skewed.IDs[skewed.Parents[index(skewed, f)][0]]
+
+		for _, test := range []struct {
+			limit int
+			want  []api.CommitID
+		}{
+			{2, []api.CommitID{e, d}},
+			{10, []api.CommitID{e, d, m, b, c, a, f}},
+		} {
+			if diff := cmp.Diff(test.want, ids(skewed, skewed.commitsBetween(index(skewed, f), time.Time{}, time.Time{}, test.limit))); diff != "" {
+				t.Errorf("unexpected commits (-want +got):\n%s", diff)
+			}
+		}
+	})
+}
+
+func TestCommitGraphCache(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	dir := GitDir(filepath.Join(root, ".git"))
+
+	runCmd(t, root, "git", "init")
+	runCmd(t, root, "git", "commit", "--allow-empty", "-m", "a")
+
+	var cache commitGraphCache
+	g, err := cache.get(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(g.IDs) != 0 {
+		t.Fatalf("expected an empty graph before the first update, have %d commits", len(g.IDs))
+	}
+
+	if g, err = cache.update(ctx, dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(g.IDs) != 1 {
+		t.Fatalf("unexpected number of commits. want=%d have=%d", 1, len(g.IDs))
+	}
+
+	cached, err := cache.get(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cached != g {
+		t.Errorf("expected the updated graph to be cached")
+	}
+
+	// Another process may replace the index, e.g. when the repository is recloned
+	runCmd(t, root, "git", "commit", "--allow-empty", "-m", "b")
+	if err := buildCommitGraph(ctx, dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if g, err = cache.get(dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(g.IDs) != 2 {
+		t.Errorf("unexpected number of commits. want=%d have=%d", 2, len(g.IDs))
+	}
+}
+
+func TestCommitGraphCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	cache := commitGraphCache{maxBytes: 4 * commitGraphEntryBytes}
+	for i := 0; i < 8; i++ {
+		dir := GitDir(filepath.Join(root, strconv.Itoa(i), ".git"))
+		if _, err := cache.update(ctx, dir); err == nil {
+			t.Fatalf("expected an error updating the commit graph of a missing repository")
+		}
+	}
+
+	// Entries, along with the locks serializing their updates, are evicted
+	if n := cache.recency.Len(); n != 4 {
+		t.Errorf("unexpected number of cached entries. want=%d have=%d", 4, n)
+	}
+
+	repo := filepath.Join(root, "repo")
+	runCmd(t, root, "git", "init", repo)
+	runCmd(t, repo, "git", "commit", "--allow-empty", "-m", "a")
+	dir := GitDir(filepath.Join(repo, ".git"))
+	if _, err := cache.update(ctx, dir); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Entries are evicted by the estimated size of their graph
+	if n := cache.recency.Len(); n != 3 {
+		t.Errorf("unexpected number of cached entries. want=%d have=%d", 3, n)
+	}
+	if _, ok := cache.entries[dir]; !ok {
+		t.Errorf("expected the most recently used entry to be cached")
+	}
+	if want := 3*commitGraphEntryBytes + commitGraphCommitBytes; cache.bytes != int64(want) {
+		t.Errorf("unexpected cache size. want=%d have=%d", want, cache.bytes)
+	}
+}
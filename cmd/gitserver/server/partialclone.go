@@ -0,0 +1,264 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// partialCloneRemote is the name of the promisor remote of partial clones.
+// Git lazily fetches missing objects from it. Only the promisor settings of
+// the remote are stored in the repository config; its URL is passed to git
+// through the environment of each command (see partialCloneEnv), because we
+// don't store authenticated remote URLs on disk.
+const partialCloneRemote = "sg-promisor"
+
+var partialCloneFilters = conf.Cached(func() map[string]string {
+	exp := conf.ExperimentalFeatures()
+	return buildPartialCloneMappings(exp.GitServerPartialClones)
+})
+
+func buildPartialCloneMappings(c []*schema.PartialCloneMapping) map[string]string {
+	pcm := map[string]string{}
+	for _, mapping := range c {
+		pcm[mapping.DomainPath] = mapping.Filter
+	}
+	return pcm
+}
+
+// partialCloneFilter returns the object filter to clone remoteURL with, or
+// the empty string if it should be cloned in full.
+func partialCloneFilter(remoteURL *vcs.URL) string {
+	pcm := partialCloneFilters()
+	if len(pcm) == 0 {
+		return ""
+	}
+	return pcm[path.Join(remoteURL.Host, remoteURL.Path)]
+}
+
+// configurePartialClone turns the empty repository in dir into a partial
+// clone that omits the objects matched by filter when fetching from
+// partialCloneRemote.
+func configurePartialClone(dir GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		// Extensions are only honored by repository format version 1.
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", partialCloneRemote},
+		{"remote." + partialCloneRemote + ".promisor", "true"},
+		{"remote." + partialCloneRemote + ".partialCloneFilter", filter},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repoPartialCloneFilter returns the object filter the repository in dir was
+// cloned with, or the empty string if it is a full clone.
+func repoPartialCloneFilter(dir GitDir) (string, error) {
+	remote, err := gitConfigGet(dir, "extensions.partialClone")
+	if err != nil || remote != partialCloneRemote {
+		return "", err
+	}
+	return gitConfigGet(dir, "remote."+partialCloneRemote+".partialCloneFilter")
+}
+
+// isPartialClone reports whether the repository in dir has objects fetched
+// from a promisor remote. Unlike repoPartialCloneFilter it does not run git,
+// so it is cheap enough to call for every command we run in a repository.
+func isPartialClone(dir GitDir) bool {
+	promisors, err := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return err == nil && len(promisors) > 0
+}
+
+// partialCloneEnv returns the environment for git commands in a partial
+// clone of remoteURL. It allows git to lazily fetch missing objects from the
+// remote without prompting for credentials.
+func partialCloneEnv(remoteURL *vcs.URL) []string {
+	return append(os.Environ(), lazyFetchVars(remoteURL)...)
+}
+
+// lazyFetchVars returns the environment variables that partialCloneEnv adds
+// to the environment of gitserver.
+func lazyFetchVars(remoteURL *vcs.URL) []string {
+	return append(remoteGitEnv(tlsExternal()),
+		"GIT_CONFIG_COUNT=2",
+		"GIT_CONFIG_KEY_0=remote."+partialCloneRemote+".url",
+		"GIT_CONFIG_VALUE_0="+remoteURL.String(),
+		// Unset credential helper because the command is non-interactive.
+		"GIT_CONFIG_KEY_1=credential.helper",
+		"GIT_CONFIG_VALUE_1=",
+	)
+}
+
+// prefetchMissingBlobs fetches the blobs below pathspecs in treeish that are
+// missing from the partial clone in dir in a single batch. Git otherwise
+// fetches missing blobs one at a time when it needs them, which is very slow
+// for commands that read many files such as git archive.
+func prefetchMissingBlobs(ctx context.Context, dir GitDir, remoteURL *vcs.URL, treeish string, pathspecs []string) error {
+	// The objects are listed from the tree of treeish: for a commit, rev-list
+	// would only list them if the commit changed pathspecs.
+	args := append([]string{"rev-list", "--objects", "--no-walk", "--missing=print", treeish + "^{tree}", "--"}, pathspecs...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "listing missing objects")
+	}
+
+	var missing bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		if line := sc.Text(); strings.HasPrefix(line, "?") {
+			missing.WriteString(line[1:])
+			missing.WriteByte('\n')
+		}
+	}
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	// These are the arguments git uses for lazy fetches.
+	cmd = exec.CommandContext(ctx, "git", "fetch",
+		"--no-tags", "--no-write-fetch-head", "--recurse-submodules=no",
+		"--filter=blob:none", "--stdin", partialCloneRemote)
+	dir.Set(cmd)
+	cmd.Env = partialCloneEnv(remoteURL)
+	cmd.Stdin = &missing
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "fetching missing objects failed with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// promisorPackBytes returns the total size in bytes of the packfiles in dir
+// that contain objects fetched from a promisor remote.
+func promisorPackBytes(dir GitDir) int64 {
+	promisors, err := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	if err != nil {
+		return 0
+	}
+	var size int64
+	for _, p := range promisors {
+		if fi, err := os.Stat(strings.TrimSuffix(p, ".promisor") + ".pack"); err == nil {
+			size += fi.Size()
+		}
+	}
+	return size
+}
+
+// setGitDir updates cmd so that it will run in dir, the directory of repo,
+// like GitDir.Set. If repo is a partial clone, it also allows git to lazily
+// fetch missing objects. Every git command that reads the objects of a
+// repository should be set up with it rather than GitDir.Set.
+func (s *Server) setGitDir(ctx context.Context, repo api.RepoName, dir GitDir, cmd *exec.Cmd) {
+	if isPartialClone(dir) {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, s.lazyFetchVars(ctx, repo)...)
+	}
+	dir.Set(cmd)
+}
+
+// gitEnv returns the environment for git commands in dir, the directory of
+// repo, that are not set up with setGitDir. It is nil, meaning the
+// environment of gitserver, unless repo is a partial clone.
+func (s *Server) gitEnv(ctx context.Context, repo api.RepoName, dir GitDir) []string {
+	if !isPartialClone(dir) {
+		return nil
+	}
+	return append(os.Environ(), s.lazyFetchVars(ctx, repo)...)
+}
+
+// lazyFetchVars returns the environment variables that allow git to lazily
+// fetch missing objects in the partial clone of repo. It returns nil if the
+// remote URL of repo is unknown, in which case git can still read the objects
+// that are present.
+func (s *Server) lazyFetchVars(ctx context.Context, repo api.RepoName) []string {
+	remoteURL, err := s.partialCloneRemoteURLs.get(actor.WithInternalActor(ctx), repo, s.getRemoteURL)
+	if err != nil {
+		s.Logger.Warn("failed to get remote URL of partial clone", log.String("repo", string(repo)), log.Error(err))
+		return nil
+	}
+	return lazyFetchVars(remoteURL)
+}
+
+// prefetchMissingBlobs is a best-effort wrapper around prefetchMissingBlobs.
+// Blobs it fails to fetch are fetched by git when they are needed.
+func (s *Server) prefetchMissingBlobs(ctx context.Context, repo api.RepoName, dir GitDir, treeish string, pathspecs []string) {
+	remoteURL, err := s.partialCloneRemoteURLs.get(actor.WithInternalActor(ctx), repo, s.getRemoteURL)
+	if err == nil {
+		err = prefetchMissingBlobs(ctx, dir, remoteURL, treeish, pathspecs)
+	}
+	if err != nil {
+		s.Logger.Warn("failed to prefetch missing blobs of partial clone", log.String("repo", string(repo)), log.String("treeish", treeish), log.Error(err))
+	}
+}
+
+const (
+	// partialCloneRemoteURLCacheSize is the number of remote URLs of partial
+	// clones kept in memory.
+	partialCloneRemoteURLCacheSize = 1024
+
+	// partialCloneRemoteURLTTL is how long the remote URL of a partial clone
+	// is cached. It bounds how long git uses outdated credentials to fetch
+	// missing objects after they changed.
+	partialCloneRemoteURLTTL = 5 * time.Minute
+)
+
+// remoteURLCache caches the remote URLs of partial clones, so that git
+// commands in them don't each look up the remote URL from the frontend. The
+// zero value is ready to use.
+type remoteURLCache struct {
+	mu   sync.Mutex
+	urls *lru.Cache // api.RepoName -> cachedRemoteURL
+}
+
+type cachedRemoteURL struct {
+	url     *vcs.URL
+	expires time.Time
+}
+
+// get returns the remote URL of repo, calling lookup if it is not cached or
+// has expired.
+func (c *remoteURLCache) get(ctx context.Context, repo api.RepoName, lookup func(context.Context, api.RepoName) (*vcs.URL, error)) (*vcs.URL, error) {
+	c.mu.Lock()
+	if c.urls == nil {
+		// lru.New only fails for non-positive sizes
+		c.urls, _ = lru.New(partialCloneRemoteURLCacheSize)
+	}
+	urls := c.urls
+	c.mu.Unlock()
+
+	if v, ok := urls.Get(repo); ok {
+		if cached := v.(cachedRemoteURL); time.Now().Before(cached.expires) {
+			return cached.url, nil
+		}
+	}
+
+	remoteURL, err := lookup(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	urls.Add(repo, cachedRemoteURL{url: remoteURL, expires: time.Now().Add(partialCloneRemoteURLTTL)})
+	return remoteURL, nil
+}
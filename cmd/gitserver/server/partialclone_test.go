@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/adapters"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPartialCloneFilter(t *testing.T) {
+	oldPartialCloneFilters := partialCloneFilters
+	t.Cleanup(func() { partialCloneFilters = oldPartialCloneFilters })
+
+	partialCloneFilters = func() map[string]string {
+		return buildPartialCloneMappings([]*schema.PartialCloneMapping{
+			{DomainPath: "github.com/foo/blobless", Filter: "blob:none"},
+			{DomainPath: "github.com/foo/limited", Filter: "blob:limit=1m"},
+		})
+	}
+
+	for url, want := range map[string]string{
+		"https://8cd1419f4d5c1e0527f2893c9422f1a2a435116d@github.com/foo/blobless": "blob:none",
+		"git@github.com:foo/limited":      "blob:limit=1m",
+		"https://github.com/foo/full":     "",
+		"https://gitlab.com/foo/blobless": "",
+	} {
+		remoteURL, _ := vcs.ParseURL(url)
+		if have := partialCloneFilter(remoteURL); have != want {
+			t.Errorf("unexpected filter for %q. want=%q have=%q", url, want, have)
+		}
+	}
+}
+
+func TestPartialClone(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	reposDir := filepath.Join(root, "repos")
+	repo := api.RepoName("dst")
+	dir := GitDir(filepath.Join(reposDir, string(repo), ".git"))
+
+	runCmd(t, root, "git", "init", src)
+	runCmd(t, src, "git", "config", "uploadpack.allowFilter", "true")
+	runCmd(t, src, "git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	runCmd(t, src, "sh", "-c", "echo a > a && echo b > b && git add a b && git commit -m one")
+	runCmd(t, src, "sh", "-c", "echo z > z && git add z && git commit -m two")
+
+	remoteURL, err := vcs.ParseURL("file://" + src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPartialCloneFilters := partialCloneFilters
+	t.Cleanup(func() { partialCloneFilters = oldPartialCloneFilters })
+	partialCloneFilters = func() map[string]string {
+		return map[string]string{remoteURL.Path: "blob:none"}
+	}
+
+	missingBlobs := func(treeish string) []string {
+		t.Helper()
+		var missing []string
+		for _, line := range strings.Split(runCmd(t, dir.Path(), "git", "rev-list", "--objects", "--missing=print", treeish), "\n") {
+			if strings.HasPrefix(line, "?") {
+				missing = append(missing, line[1:])
+			}
+		}
+		return missing
+	}
+
+	syncer := &GitRepoSyncer{}
+	cmd, err := syncer.CloneCommand(ctx, remoteURL, dir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runWith(ctx, cmd, true, nil); err != nil {
+		t.Fatalf("clone failed: %s (output follows)\n\n%s", err, out)
+	}
+
+	if !isPartialClone(dir) {
+		t.Fatal("expected a partial clone")
+	}
+	if filter, err := repoPartialCloneFilter(dir); err != nil || filter != "blob:none" {
+		t.Fatalf("unexpected filter %q (err=%v)", filter, err)
+	}
+	if missing := missingBlobs("HEAD"); len(missing) != 3 {
+		t.Fatalf("expected all blobs to be missing, have %v", missing)
+	}
+
+	s := &Server{
+		Logger:           logtest.Scoped(t),
+		ReposDir:         reposDir,
+		GetRemoteURLFunc: staticGetRemoteURL(remoteURL.String()),
+	}
+
+	t.Run("lazy fetch", func(t *testing.T) {
+		cmd := exec.Command("git", "cat-file", "-p", "HEAD:a")
+		dir.Set(cmd)
+		cmd.Env = partialCloneEnv(remoteURL)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("reading a missing blob failed: %s (output follows)\n\n%s", err, out)
+		}
+		if string(out) != "a\n" {
+			t.Errorf("unexpected content %q", out)
+		}
+	})
+
+	t.Run("prefetch", func(t *testing.T) {
+		// b was not changed by the HEAD commit.
+		if err := prefetchMissingBlobs(ctx, dir, remoteURL, "HEAD", []string{"b"}); err != nil {
+			t.Fatal(err)
+		}
+		if missing := missingBlobs("HEAD"); len(missing) != 1 {
+			t.Errorf("expected only the blob of z to be missing, have %v", missing)
+		}
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		runCmd(t, src, "sh", "-c", "echo c > c && git add c && git commit -m two")
+		if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+			t.Fatal(err)
+		}
+		if missing := missingBlobs("HEAD"); len(missing) != 2 {
+			t.Errorf("expected the blobs of z and the new file to be missing, have %v", missing)
+		}
+	})
+
+	t.Run("maintenance", func(t *testing.T) {
+		if err := sgMaintenance(logtest.Scoped(t), dir); err != nil {
+			t.Fatal(err)
+		}
+		needed, reason, err := needsMaintenance(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if needed {
+			t.Errorf("partial clone needs maintenance after sg maintenance: %s", reason)
+		}
+		if promisorPackBytes(dir) == 0 {
+			t.Error("expected the objects of the partial clone to be in promisor packfiles")
+		}
+	})
+
+	t.Run("file history", func(t *testing.T) {
+		// Following a modified rename needs the content of both files.
+		runCmd(t, src, "sh", "-c", "seq 1 20 > d && git add d && git commit -m three")
+		runCmd(t, src, "sh", "-c", "git mv d e && echo 21 >> e && git commit -am four")
+		if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		git := &adapters.Git{
+			ReposDir: reposDir,
+			Env: func(ctx context.Context, repo api.RepoName) []string {
+				return s.gitEnv(ctx, repo, s.dir(repo))
+			},
+		}
+		entries, _, err := git.FileHistory(ctx, repo, gitdomain.FileHistoryOptions{Path: "e", N: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, entry := range entries {
+			paths = append(paths, entry.Path)
+		}
+		if diff := cmp.Diff([]string{"e", "d"}, paths); diff != "" {
+			t.Errorf("unexpected file history (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("create commit from patch", func(t *testing.T) {
+		// The blob of c was not fetched yet.
+		base := strings.TrimSpace(runCmd(t, dir.Path(), "git", "rev-parse", "HEAD"))
+		status, resp := s.createCommitFromPatch(ctx, protocol.CreateCommitFromPatchRequest{
+			Repo:       repo,
+			BaseCommit: api.CommitID(base),
+			Patch:      "diff --git a/c b/c\n--- a/c\n+++ b/c\n@@ -1 +1 @@\n-c\n+c2\n",
+			TargetRef:  "refs/heads/patched",
+		})
+		if status != http.StatusOK {
+			t.Fatalf("unexpected status %d: %+v", status, resp.Error)
+		}
+		if content := runCmd(t, dir.Path(), "git", "show", "refs/heads/patched:c"); content != "c2\n" {
+			t.Errorf("unexpected content %q", content)
+		}
+	})
+}
+
+func TestRemoteURLCache(t *testing.T) {
+	ctx := context.Background()
+
+	var lookups int
+	lookup := func(_ context.Context, repo api.RepoName) (*vcs.URL, error) {
+		lookups++
+		return vcs.ParseURL("https://example.com/" + string(repo))
+	}
+
+	var c remoteURLCache
+	for i := 0; i < 3; i++ {
+		remoteURL, err := c.get(ctx, "foo", lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := remoteURL.String(), "https://example.com/foo"; have != want {
+			t.Fatalf("unexpected remote URL. want=%q have=%q", want, have)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected the remote URL to be looked up once, got %d lookups", lookups)
+	}
+
+	// Expired URLs are looked up again.
+	c.urls.Add(api.RepoName("foo"), cachedRemoteURL{expires: time.Now().Add(-time.Second)})
+	if _, err := c.get(ctx, "foo", lookup); err != nil {
+		t.Fatal(err)
+	}
+	if lookups != 2 {
+		t.Errorf("expected the expired remote URL to be looked up again, got %d lookups", lookups)
+	}
+}
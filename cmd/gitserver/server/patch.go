@@ -159,9 +159,28 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		return http.StatusInternalServerError, resp
 	}
 
+	// The tmp repo reads the objects of the repo through alternates. If the repo is a partial
+	// clone, the tmp repo has to be one too, so that git can lazily fetch the blobs the patch
+	// applies to.
+	baseEnv := os.Environ()
+	if dir := GitDir(repoGitDir); isPartialClone(dir) {
+		filter, err := repoPartialCloneFilter(dir)
+		if err == nil {
+			err = configurePartialClone(GitDir(filepath.Join(tmpRepoDir, ".git")), filter)
+		}
+		if err != nil {
+			resp.SetError(repo, "", "", errors.Wrap(err, "gitserver: configure tmp repo as partial clone"))
+			return http.StatusInternalServerError, resp
+		}
+		baseEnv = append(baseEnv, s.lazyFetchVars(ctx, req.Repo)...)
+	}
+	tmpRepoEnv := func(vars ...string) []string {
+		return append(append([]string{}, baseEnv...), vars...)
+	}
+
 	cmd = exec.CommandContext(ctx, "git", "reset", "-q", string(req.BaseCommit))
 	cmd.Dir = tmpRepoDir
-	cmd.Env = append(os.Environ(), tmpGitPathEnv, altObjectsEnv)
+	cmd.Env = tmpRepoEnv(tmpGitPathEnv, altObjectsEnv)
 
 	if out, err := run(cmd, "basing staging on base rev"); err != nil {
 		s.Logger.Error("Failed to base the temporary repo on the base revision.",
@@ -175,7 +194,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	applyArgs := append([]string{"apply", "--cached"}, req.GitApplyArgs...)
 	cmd = exec.CommandContext(ctx, "git", applyArgs...)
 	cmd.Dir = tmpRepoDir
-	cmd.Env = append(os.Environ(), tmpGitPathEnv, altObjectsEnv)
+	cmd.Env = tmpRepoEnv(tmpGitPathEnv, altObjectsEnv)
 	cmd.Stdin = strings.NewReader(req.Patch)
 
 	if out, err := run(cmd, "applying patch"); err != nil {
@@ -206,7 +225,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 
 	cmd = exec.CommandContext(ctx, "git", "commit", "-m", message)
 	cmd.Dir = tmpRepoDir
-	cmd.Env = append(os.Environ(), []string{
+	cmd.Env = tmpRepoEnv(
 		tmpGitPathEnv,
 		altObjectsEnv,
 		fmt.Sprintf("GIT_COMMITTER_NAME=%s", committerName),
@@ -215,7 +234,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		fmt.Sprintf("GIT_AUTHOR_EMAIL=%s", authorEmail),
 		fmt.Sprintf("GIT_COMMITTER_DATE=%v", req.CommitInfo.Date),
 		fmt.Sprintf("GIT_AUTHOR_DATE=%v", req.CommitInfo.Date),
-	}...)
+	)
 
 	if out, err := run(cmd, "committing patch"); err != nil {
 		s.Logger.Error("Failed to commit patch.", log.String("ref", ref), log.String("output", string(out)))
@@ -224,7 +243,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 
 	cmd = exec.CommandContext(ctx, "git", "rev-parse", "HEAD")
 	cmd.Dir = tmpRepoDir
-	cmd.Env = append(os.Environ(), tmpGitPathEnv, altObjectsEnv)
+	cmd.Env = tmpRepoEnv(tmpGitPathEnv, altObjectsEnv)
 
 	// We don't use 'run' here as we only want stdout
 	out, err := cmd.Output()
@@ -3,16 +3,32 @@ package server
 import (
 	"context"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
-	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
 // HACK(keegancsmith) workaround to experiment with cloning less in a large
 // monorepo. https://github.com/sourcegraph/customer/issues/19
 var refspecOverrides = strings.Fields(env.Get("SRC_GITSERVER_REFSPECS", "", "EXPERIMENTAL: override refspec we fetch. Space separated."))
 
+// refspecOverridesPullRequests opts into fetching the head refs of pull
+// requests and merge requests in addition to the overridden refspecs, so that
+// in-flight changes can be searched with rev:pr/*.
+var refspecOverridesPullRequests, _ = strconv.ParseBool(env.Get("SRC_GITSERVER_REFSPECS_PULL_REQUESTS", "false", "EXPERIMENTAL: also fetch pull request and merge request head refs when SRC_GITSERVER_REFSPECS is set."))
+
+// pullRequestHeadRefspecs fetch only the head of each pull request or merge
+// request, skipping the merge refs code hosts create alongside them.
+var pullRequestHeadRefspecs = []string{
+	// GitHub pull requests
+	"+refs/pull/*/head:refs/pull/*/head",
+	// GitLab merge requests
+	"+refs/merge-requests/*/head:refs/merge-requests/*/head",
+	// Bitbucket Server pull requests
+	"+refs/pull-requests/*/from:refs/pull-requests/*/from",
+}
+
 // HACK(keegancsmith) workaround to experiment with cloning less in a large
 // monorepo. https://github.com/sourcegraph/customer/issues/19
 func useRefspecOverrides() bool {
@@ -21,6 +37,10 @@ func useRefspecOverrides() bool {
 
 // HACK(keegancsmith) workaround to experiment with cloning less in a large
 // monorepo. https://github.com/sourcegraph/customer/issues/19
-func refspecOverridesFetchCmd(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
-	return exec.CommandContext(ctx, "git", append([]string{"fetch", "--no-auto-gc", "--progress", "--prune", remoteURL.String()}, refspecOverrides...)...)
+func refspecOverridesFetchCmd(ctx context.Context, remote string) *exec.Cmd {
+	args := append([]string{"fetch", "--no-auto-gc", "--progress", "--prune", remote}, refspecOverrides...)
+	if refspecOverridesPullRequests {
+		args = append(args, pullRequestHeadRefspecs...)
+	}
+	return exec.CommandContext(ctx, "git", args...)
 }
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestRefspecOverridesFetchCmd(t *testing.T) {
+	oldRefspecOverrides, oldPullRequests := refspecOverrides, refspecOverridesPullRequests
+	t.Cleanup(func() {
+		refspecOverrides, refspecOverridesPullRequests = oldRefspecOverrides, oldPullRequests
+	})
+
+	remoteURL, _ := vcs.ParseURL("https://github.com/foo/bar")
+	refspecOverrides = []string{"+refs/heads/main:refs/heads/main"}
+
+	tests := []struct {
+		pullRequests bool
+		expectedArgs []string
+	}{
+		{
+			pullRequests: false,
+			expectedArgs: []string{
+				"git", "fetch", "--no-auto-gc", "--progress", "--prune", "https://github.com/foo/bar",
+				"+refs/heads/main:refs/heads/main",
+			},
+		},
+		{
+			pullRequests: true,
+			expectedArgs: []string{
+				"git", "fetch", "--no-auto-gc", "--progress", "--prune", "https://github.com/foo/bar",
+				"+refs/heads/main:refs/heads/main",
+				"+refs/pull/*/head:refs/pull/*/head",
+				"+refs/merge-requests/*/head:refs/merge-requests/*/head",
+				"+refs/pull-requests/*/from:refs/pull-requests/*/from",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		refspecOverridesPullRequests = test.pullRequests
+
+		cmd := refspecOverridesFetchCmd(context.Background(), remoteURL.String())
+		if diff := cmp.Diff(test.expectedArgs, cmd.Args); diff != "" {
+			t.Errorf("unexpected args with pull requests=%v (-want +got):\n%s", test.pullRequests, diff)
+		}
+	}
+}
@@ -335,6 +335,13 @@ type Server struct {
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
 
+	// commitGraphs caches the commit graph indexes of recently used repos.
+	commitGraphs commitGraphCache
+
+	// partialCloneRemoteURLs caches the remote URLs of partial clones, which
+	// git needs to lazily fetch missing objects.
+	partialCloneRemoteURLs remoteURLCache
+
 	// GlobalBatchLogSemaphore is a semaphore shared between all requests to ensure that a
 	// maximum number of Git subprocesses are active for all /batch-log requests combined.
 	GlobalBatchLogSemaphore *semaphore.Weighted
@@ -464,6 +471,9 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/delete", trace.WithRouteName("delete", s.handleRepoDelete))
 	mux.HandleFunc("/repo-update", trace.WithRouteName("repo-update", s.handleRepoUpdate))
 	mux.HandleFunc("/create-commit-from-patch", trace.WithRouteName("create-commit-from-patch", s.handleCreateCommitFromPatch))
+	mux.HandleFunc("/commit-graph/is-ancestor", trace.WithRouteName("commit-graph-is-ancestor", s.handleCommitGraphIsAncestor))
+	mux.HandleFunc("/commit-graph/merge-base", trace.WithRouteName("commit-graph-merge-base", s.handleCommitGraphMergeBase))
+	mux.HandleFunc("/commit-graph/commits-between", trace.WithRouteName("commit-graph-commits-between", s.handleCommitGraphCommitsBetween))
 	mux.HandleFunc("/ping", trace.WithRouteName("ping", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -480,6 +490,9 @@ func (s *Server) Handler() http.Handler {
 
 	gitAdapter := &adapters.Git{
 		ReposDir: s.ReposDir,
+		Env: func(ctx context.Context, repo api.RepoName) []string {
+			return s.gitEnv(ctx, repo, s.dir(repo))
+		},
 	}
 	getObjectService := gitdomain.GetObjectService{
 		RevParse:      gitAdapter.RevParse,
@@ -501,6 +514,27 @@ func (s *Server) Handler() http.Handler {
 			handleGetObject(getObjectFunc),
 		)))
 
+	fileHistoryFunc := gitdomain.FileHistoryFunc(func(ctx context.Context, repo api.RepoName, opts gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+		span, ctx := ot.StartSpanFromContext(ctx, "Git: FileHistory")
+		span.SetTag("commit", opts.Commit)
+		span.SetTag("path", opts.Path)
+		defer span.Finish()
+
+		dir := s.dir(repo)
+		if !repoCloned(dir) {
+			cloneProgress, cloneInProgress := s.locker.Status(dir)
+			return nil, false, &gitdomain.RepoNotExistError{Repo: repo, CloneInProgress: cloneInProgress, CloneProgress: cloneProgress}
+		}
+		return gitAdapter.FileHistory(ctx, repo, opts)
+	})
+
+	mux.HandleFunc("/commands/file-history", trace.WithRouteName("commands/file-history",
+		accesslog.HTTPMiddleware(
+			s.Logger.Scoped("commands/file-history.accesslog", "commands/file-history endpoint access log"),
+			conf.DefaultClient(),
+			handleFileHistory(fileHistoryFunc),
+		)))
+
 	return mux
 }
 
@@ -553,6 +587,10 @@ func (s *Server) addrForRepo(ctx context.Context, repoName api.RepoName, gitServ
 	return gitserver.AddrForRepo(ctx, filepath.Base(os.Args[0]), s.DB, repoName, gitServerAddrs)
 }
 
+func (s *Server) addrsForRepo(ctx context.Context, repoName api.RepoName, gitServerAddrs gitserver.GitServerAddresses) ([]string, error) {
+	return gitserver.AddrsForRepo(ctx, filepath.Base(os.Args[0]), s.DB, repoName, gitServerAddrs)
+}
+
 func currentGitserverAddresses() gitserver.GitServerAddresses {
 	cfg := conf.Get()
 	gitServerAddrs := gitserver.GitServerAddresses{
@@ -560,6 +598,7 @@ func currentGitserverAddresses() gitserver.GitServerAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		gitServerAddrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		gitServerAddrs.ReplicationFactor = cfg.ExperimentalFeatures.GitServerReplicationFactor
 	}
 
 	return gitServerAddrs
@@ -649,6 +688,16 @@ func (s *Server) hostnameMatch(addr string) bool {
 	return next == '.' || next == ':'
 }
 
+// hostnameMatchAny checks whether the hostname matches any of the given addresses.
+func (s *Server) hostnameMatchAny(addrs []string) bool {
+	for _, addr := range addrs {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	repoSyncStateCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "src_repo_sync_state_counter",
@@ -1068,6 +1117,12 @@ func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
 	req.Args = append(req.Args, treeish, "--")
 	req.Args = append(req.Args, pathspecs...)
 
+	// git archive fetches the blobs missing from a partial clone one at a
+	// time, so we fetch them in a single batch first.
+	if dir := s.dir(protocol.NormalizeRepo(req.Repo)); isPartialClone(dir) {
+		s.prefetchMissingBlobs(r.Context(), req.Repo, dir, treeish, pathspecs)
+	}
+
 	s.exec(w, r, req)
 }
 
@@ -1203,6 +1258,8 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 		}
 	}
 
+	env := s.gitEnv(ctx, args.Repo, dir)
+
 	g, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -1222,6 +1279,7 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			Logger:               s.Logger,
 			RepoName:             args.Repo,
 			RepoDir:              dir.Path(),
+			Env:                  env,
 			Revisions:            args.Revisions,
 			Query:                mt,
 			IncludeDiff:          args.IncludeDiff,
@@ -1675,7 +1733,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 
 	cmdStart = time.Now()
 	cmd := exec.CommandContext(ctx, "git", req.Args...)
-	dir.Set(cmd)
+	s.setGitDir(ctx, req.Repo, dir, cmd)
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
 
@@ -2147,6 +2205,11 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		return errors.Wrapf(err, "failed to update last changed time")
 	}
 
+	// Build the commit graph index. Queries will build it on demand if this fails.
+	if err := buildCommitGraph(ctx, tmp); err != nil {
+		s.Logger.Warn("Failed to build commit graph", log.String("repo", string(repo)), log.Error(err))
+	}
+
 	// Set gitattributes
 	if err := setGitAttributes(tmp); err != nil {
 		return err
@@ -2505,6 +2568,11 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		s.Logger.Warn("Failed to update last changed time", log.String("repo", string(repo)), log.Error(err))
 	}
 
+	// Add the fetched commits to the commit graph index.
+	if _, err := s.commitGraphs.update(ctx, dir); err != nil {
+		s.Logger.Warn("Failed to update commit graph", log.String("repo", string(repo)), log.Error(err))
+	}
+
 	// Successfully updated, best-effort updating of db fetch state based on
 	// disk state.
 	if err := s.setLastFetched(ctx, repo); err != nil {
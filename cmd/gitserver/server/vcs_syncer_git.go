@@ -56,14 +56,30 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(err, "clone setup failed")
 	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	// Custom fetch commands are responsible for what they fetch, so we only
+	// set up a partial clone if we run the fetch ourselves.
+	var filter string
+	if customFetchCmd(ctx, remoteURL) == nil {
+		filter = partialCloneFilter(remoteURL)
+	}
+	if filter != "" {
+		if err := configurePartialClone(GitDir(tmpPath), filter); err != nil {
+			return nil, errors.Wrapf(err, "partial clone setup failed")
+		}
+	}
+
+	cmd, _ = s.fetchCommand(ctx, remoteURL, filter != "")
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, revspec string) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	filter, err := repoPartialCloneFilter(dir)
+	if err != nil {
+		return err
+	}
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL, filter != "")
 	dir.Set(cmd)
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
@@ -76,19 +92,25 @@ func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.UR
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+// fetchCommand returns the command to fetch from remoteURL. Partial clones
+// fetch from partialCloneRemote so that git applies their object filter.
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, partialClone bool) (cmd *exec.Cmd, configRemoteOpts bool) {
 	configRemoteOpts = true
+	remote := remoteURL.String()
+	if partialClone {
+		remote = partialCloneRemote
+	}
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
 	} else if useRefspecOverrides() {
-		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
+		cmd = refspecOverridesFetchCmd(ctx, remote)
 	} else {
 		cmd = exec.CommandContext(ctx, "git", "fetch",
 			// We already have janitor jobs that run git gc. We disable git gc here to avoid
 			// a possible corruption of repositories by competing gc processes.
 			"--no-auto-gc",
-			"--progress", "--prune", remoteURL.String(),
+			"--progress", "--prune", remote,
 			// Normal git refs
 			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
 			// GitHub pull requests
@@ -102,5 +124,8 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 			// Possibly deprecated refs for sourcegraph zap experiment?
 			"+refs/sourcegraph/*:refs/sourcegraph/*")
 	}
+	if partialClone && configRemoteOpts {
+		cmd.Env = partialCloneEnv(remoteURL)
+	}
 	return cmd, configRemoteOpts
 }
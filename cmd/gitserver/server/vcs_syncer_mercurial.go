@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// mercurialMirrorDir is the directory within the Git directory of a converted
+// Mercurial repository that contains a mirror of the Mercurial repository.
+const mercurialMirrorDir = "sg_hg"
+
+var hgFastExport = env.Get("SRC_HG_FAST_EXPORT", "/usr/local/lib/fast-export/hg-fast-export.sh", "the path of the hg-fast-export.sh script used to convert Mercurial repositories")
+
+// MercurialRepoSyncer is a syncer for Mercurial repositories. It pulls the
+// Mercurial repository into a mirror that is stored alongside the converted
+// Git repository and converts new changesets to Git commits with
+// hg-fast-export.
+type MercurialRepoSyncer struct{}
+
+func (s *MercurialRepoSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *MercurialRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	args := append([]string{"identify"}, hgRemoteArgs(tlsExternal())...)
+	cmd := hgCommand(ctx, append(args, remoteURL.String())...)
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand converts the Mercurial repository to a Git repository in
+// tmpPath. The returned command is a no-op to satisfy the VCSSyncer interface,
+// because the conversion can't be done by a single command.
+func (s *MercurialRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	if err := s.Fetch(ctx, remoteURL, GitDir(tmpPath), ""); err != nil {
+		return nil, errors.Wrap(err, "failed to convert repository")
+	}
+
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch pulls new changesets of the Mercurial repository and converts them to
+// Git commits.
+//
+// hg-fast-export stores which changesets it converted to which commits in the
+// Git directory, so only new changesets are converted and the SHAs of
+// existing commits never change. The conversion is deterministic, so a
+// re-clone also results in the same SHAs.
+func (s *MercurialRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	if _, err := os.Stat(dir.Path(mercurialMirrorDir, ".hg")); os.IsNotExist(err) {
+		// We don't use hg clone, because it stores the remote URL, which may
+		// contain credentials, in the config of the mirror.
+		cmd := hgCommand(ctx, "init", mercurialMirrorDir)
+		cmd.Dir = string(dir)
+		if output, err := runWith(ctx, cmd, false, nil); err != nil {
+			return errors.Wrapf(err, "failed to create mirror with output %q", string(output))
+		}
+	}
+
+	args := append([]string{"--repository", mercurialMirrorDir, "pull"}, hgRemoteArgs(tlsExternal())...)
+	cmd := hgCommand(ctx, append(args, remoteURL.String())...)
+	cmd.Dir = string(dir)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to pull with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+
+	// The path of the mirror is relative to the Git directory, because the
+	// Git directory is moved after cloning and hg-fast-export remembers it.
+	//
+	// --force makes hg-fast-export convert branches with multiple heads
+	// instead of failing. The default branch is converted to master.
+	cmd = exec.CommandContext(ctx, hgFastExport, "-r", mercurialMirrorDir, "--force", "--quiet", "--hg-hash")
+	cmd.Env = append(os.Environ(), "HGPLAIN=1")
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to convert with output %q", string(output))
+	}
+
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote
+// of a Mercurial repository.
+func (s *MercurialRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// hgCommand returns an hg command that never prompts for input.
+func hgCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "hg", append([]string{"--noninteractive"}, args...)...)
+	// HGPLAIN disables user configuration that changes the behavior or output
+	// of hg, such as aliases.
+	cmd.Env = append(os.Environ(), "HGPLAIN=1")
+	return cmd
+}
+
+// hgRemoteArgs returns the options for hg commands that talk to a remote.
+// They must follow the name of the command.
+func hgRemoteArgs(tlsConf *tlsConfig) []string {
+	// Suppress asking to add SSH host key to known_hosts (which will hang
+	// because the command is non-interactive), and set a timeout to avoid
+	// indefinite hangs if the server is unreachable.
+	args := []string{"--config", "ui.ssh=ssh -o BatchMode=yes -o ConnectTimeout=30"}
+
+	if tlsConf.SSLNoVerify {
+		args = append(args, "--insecure")
+	}
+	if tlsConf.SSLCAInfo != "" {
+		args = append(args, "--config", "web.cacerts="+tlsConf.SSLCAInfo)
+	}
+	return args
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestHgRemoteArgs(t *testing.T) {
+	ssh := []string{"--config", "ui.ssh=ssh -o BatchMode=yes -o ConnectTimeout=30"}
+
+	for _, test := range []struct {
+		name    string
+		tlsConf tlsConfig
+		want    []string
+	}{
+		{
+			name: "default",
+			want: ssh,
+		},
+		{
+			name:    "no verify",
+			tlsConf: tlsConfig{SSLNoVerify: true},
+			want:    append(ssh, "--insecure"),
+		},
+		{
+			name:    "ca info",
+			tlsConf: tlsConfig{SSLCAInfo: "/tmp/ca.pem"},
+			want:    append(ssh, "--config", "web.cacerts=/tmp/ca.pem"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, hgRemoteArgs(&test.tlsConf)); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMercurialRepoSyncer(t *testing.T) {
+	if _, err := exec.LookPath("hg"); err != nil {
+		t.Skip("hg is not installed")
+	}
+	if _, err := exec.LookPath(hgFastExport); err != nil {
+		t.Skip("hg-fast-export is not installed")
+	}
+
+	ctx := context.Background()
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+
+	hg := func(args ...string) {
+		t.Helper()
+		runCmd(t, root, "hg", append([]string{"--repository", src, "--config", "ui.username=a <a@a.com>"}, args...)...)
+	}
+	runCmd(t, root, "hg", "init", src)
+	runCmd(t, src, "sh", "-c", "echo a > a")
+	hg("commit", "--addremove", "--date", "1000 0", "-m", "one")
+
+	remoteURL, err := vcs.ParseURL("file://" + src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syncer := &MercurialRepoSyncer{}
+	clone := func(name string) GitDir {
+		t.Helper()
+		dir := GitDir(filepath.Join(root, name))
+		if _, err := syncer.CloneCommand(ctx, remoteURL, dir.Path()); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+	log := func(dir GitDir) []string {
+		t.Helper()
+		return strings.Fields(runCmd(t, dir.Path(), "git", "log", "--format=%H", "master"))
+	}
+
+	dir := clone("dst")
+	first := log(dir)
+	if len(first) != 1 {
+		t.Fatalf("expected 1 commit, have %v", first)
+	}
+
+	runCmd(t, src, "sh", "-c", "echo b > b")
+	hg("commit", "--addremove", "--date", "2000 0", "-m", "two")
+	if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+		t.Fatal(err)
+	}
+	second := log(dir)
+	if len(second) != 2 || second[1] != first[0] {
+		t.Fatalf("expected the new commit on top of %s, have %v", first[0], second)
+	}
+
+	// A conversion from scratch results in the same commits.
+	if diff := cmp.Diff(second, log(clone("reclone"))); diff != "" {
+		t.Errorf("unexpected commits after re-clone (-want +got):\n%s", diff)
+	}
+}
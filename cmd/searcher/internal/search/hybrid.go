@@ -8,6 +8,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/zoekt"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	zoektutil "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -263,9 +265,42 @@ func zoektCompile(p *protocol.PatternInfo) (zoektquery.Q, error) {
 		}})
 	}
 
+	for _, lang := range p.IncludeLangs {
+		q, err := zoektLang(lang, p.PathPatternsAreCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, q)
+	}
+
+	for _, lang := range p.ExcludeLangs {
+		q, err := zoektLang(lang, p.PathPatternsAreCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, &zoektquery.Not{Child: q})
+	}
+
 	return zoektquery.Simplify(zoektquery.NewAnd(parts...)), nil
 }
 
+// zoektLang returns a query matching the files in lang, like langMatcher.
+func zoektLang(lang string, caseSensitive bool) (zoektquery.Q, error) {
+	re, err := syntax.Parse(query.LangToFileRegexp(lang), syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	name, _ := enry.GetLanguageByAlias(lang)
+	return zoektquery.NewOr(
+		&zoektquery.Regexp{
+			Regexp:        re,
+			FileName:      true,
+			CaseSensitive: caseSensitive,
+		},
+		&zoektquery.Language{Language: name},
+	), nil
+}
+
 func zoektIgnorePaths(paths []string) zoektquery.Q {
 	if len(paths) == 0 {
 		return &zoektquery.Const{Value: true}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// langMatcher matches files against the lang: filters of a request. A file
+// is in a language if its path has one of the language's file extensions, or
+// if go-enry detects the language from the file's name and content. The
+// latter finds files without a known extension, like scripts with a shebang,
+// and is the same detection Zoekt uses at index time.
+//
+// The frontend only sends the languages to match when the
+// search-content-based-lang-detection feature flag is enabled and the query
+// has lang: filters, so without both no file content is read for detection.
+type langMatcher struct {
+	include []langPattern
+	exclude []langPattern
+}
+
+type langPattern struct {
+	// name is the canonical go-enry name of the language.
+	name string
+	// path matches the file extensions and file names of the language.
+	path pathmatch.PathMatcher
+}
+
+// compileLangMatcher returns a langMatcher for the languages of p, or nil if
+// p has no languages to match.
+func compileLangMatcher(p *protocol.PatternInfo) (*langMatcher, error) {
+	if len(p.IncludeLangs) == 0 && len(p.ExcludeLangs) == 0 {
+		return nil, nil
+	}
+
+	compile := func(langs []string) ([]langPattern, error) {
+		patterns := make([]langPattern, 0, len(langs))
+		for _, lang := range langs {
+			name, _ := enry.GetLanguageByAlias(lang)
+			path, err := pathmatch.CompilePattern(query.LangToFileRegexp(lang), pathmatch.CompileOptions{
+				RegExp:        true,
+				CaseSensitive: p.PathPatternsAreCaseSensitive,
+			})
+			if err != nil {
+				return nil, err
+			}
+			patterns = append(patterns, langPattern{name: name, path: path})
+		}
+		return patterns, nil
+	}
+
+	include, err := compile(p.IncludeLangs)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compile(p.ExcludeLangs)
+	if err != nil {
+		return nil, err
+	}
+	return &langMatcher{include: include, exclude: exclude}, nil
+}
+
+// Match reports whether the file with the given name is in all included
+// languages and in none of the excluded languages. content is only called if
+// neither the name of the file nor its extension determine its language.
+func (m *langMatcher) Match(name string, content func() []byte) bool {
+	if m == nil {
+		return true
+	}
+
+	var (
+		detected     string
+		haveDetected bool
+	)
+	is := func(lang langPattern) bool {
+		if lang.path.MatchPath(name) {
+			return true
+		}
+		if !haveDetected {
+			detected = detectLanguage(name, content)
+			haveDetected = true
+		}
+		return detected == lang.name
+	}
+
+	for _, lang := range m.include {
+		if !is(lang) {
+			return false
+		}
+	}
+	for _, lang := range m.exclude {
+		if is(lang) {
+			return false
+		}
+	}
+	return true
+}
+
+// detectLanguage returns the language of the file with the given name. The
+// content of the file is only read if the name is ambiguous, which avoids
+// reading every file that doesn't match the extensions of a lang: filter.
+func detectLanguage(name string, content func() []byte) string {
+	if langs := enry.GetLanguagesByFilename(name, nil, nil); len(langs) == 1 {
+		return langs[0]
+	}
+	if langs := enry.GetLanguagesByExtension(name, nil, nil); len(langs) == 1 {
+		return langs[0]
+	}
+	return enry.GetLanguage(name, content())
+}
+
+func (m *langMatcher) String() string {
+	if m == nil {
+		return ""
+	}
+	var args []string
+	for _, lang := range m.include {
+		args = append(args, "lang:"+lang.name)
+	}
+	for _, lang := range m.exclude {
+		args = append(args, "-lang:"+lang.name)
+	}
+	return strings.Join(args, " ")
+}
@@ -55,6 +55,10 @@ type readerGrep struct {
 	// whether a file path matches (and should be searched).
 	matchPath pathmatch.PathMatcher
 
+	// matchLang is compiled from the include/exclude languages and reports
+	// whether a file is in the languages. It is nil if there are none.
+	matchLang *langMatcher
+
 	// literalSubstring is used to test if a file is worth considering for
 	// matches. literalSubstring is guaranteed to appear in any match found by
 	// re. It is the output of the longestLiteral function. It is only set if
@@ -120,10 +124,16 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 		return nil, err
 	}
 
+	matchLang, err := compileLangMatcher(p)
+	if err != nil {
+		return nil, err
+	}
+
 	return &readerGrep{
 		re:               re,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
+		matchLang:        matchLang,
 		literalSubstring: literalSubstring,
 	}, nil
 }
@@ -135,10 +145,19 @@ func (rg *readerGrep) Copy() *readerGrep {
 		re:               rg.re,
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
+		matchLang:        rg.matchLang,
 		literalSubstring: rg.literalSubstring,
 	}
 }
 
+// matchFile returns whether f matches rg's path patterns and languages, and
+// should be searched.
+func (rg *readerGrep) matchFile(zf *zipFile, f *srcFile) bool {
+	return rg.matchPath.MatchPath(f.Name) && rg.matchLang.Match(f.Name, func() []byte {
+		return zf.DataFor(f)
+	})
+}
+
 // matchString returns whether rg's regexp pattern matches s. It is intended to be
 // used to match file paths.
 func (rg *readerGrep) matchString(s string) bool {
@@ -263,6 +282,9 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 		span.SetTag("re", rg.re.String())
 	}
 	span.SetTag("path", rg.matchPath.String())
+	if rg.matchLang != nil {
+		span.SetTag("lang", rg.matchLang.String())
+	}
 	defer func() {
 		if err != nil {
 			ext.Error.Set(span, true)
@@ -294,8 +316,9 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
-		for _, f := range files {
-			if match := rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name); match == !isPatternNegated {
+		for i := range files {
+			f := &files[i]
+			if match := rg.matchFile(zf, f) && rg.matchString(f.Name); match == !isPatternNegated {
 				if ctx.Err() != nil {
 					return ctx.Err()
 				}
@@ -336,7 +359,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 				f := &files[idx]
 
 				// decide whether to process, record that decision
-				if !rg.matchPath.MatchPath(f.Name) {
+				if !rg.matchFile(zf, f) {
 					filesSkipped.Inc()
 					continue
 				}
@@ -399,6 +399,88 @@ func TestPathMatches(t *testing.T) {
 	}
 }
 
+// Tests that files without a known file extension are matched by the
+// language detected from their content.
+func TestLangMatches(t *testing.T) {
+	zipData, err := createZip(map[string]string{
+		"main.go":    "package main\n",
+		"bin/deploy": "#!/usr/bin/env python3\nprint('deploying')\n",
+		"bin/setup":  "#!/bin/bash\necho 'setting up'\n",
+		"README.md":  "# Hello World\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := mockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		includeLangs []string
+		excludeLangs []string
+		want         []string
+	}{
+		{includeLangs: []string{"python"}, want: []string{"bin/deploy"}},
+		{includeLangs: []string{"go"}, want: []string{"main.go"}},
+		{includeLangs: []string{"shell"}, want: []string{"bin/setup"}},
+		{excludeLangs: []string{"python", "markdown"}, want: []string{"bin/setup", "main.go"}},
+		{includeLangs: []string{"python"}, excludeLangs: []string{"python"}, want: []string{}},
+	}
+
+	for _, tc := range cases {
+		rg, err := compile(&protocol.PatternInfo{
+			Pattern:                "",
+			IncludeLangs:           tc.includeLangs,
+			ExcludeLangs:           tc.excludeLangs,
+			PathPatternsAreRegExps: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileMatches, _, err := regexSearchBatch(context.Background(), rg, zf, 10, true, true, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make([]string, len(fileMatches))
+		for i, fm := range fileMatches {
+			got[i] = fm.Path
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("lang:%v -lang:%v: got file matches %v, want %v", tc.includeLangs, tc.excludeLangs, got, tc.want)
+		}
+	}
+}
+
+func TestDetectLanguageReadsContentOnlyIfAmbiguous(t *testing.T) {
+	cases := []struct {
+		name        string
+		content     string
+		want        string
+		readContent bool
+	}{
+		{name: "main.go", want: "Go"},
+		{name: "Makefile", want: "Makefile"},
+		{name: "bin/deploy", content: "#!/usr/bin/env python3\n", want: "Python", readContent: true},
+	}
+
+	for _, tc := range cases {
+		read := false
+		got := detectLanguage(tc.name, func() []byte {
+			read = true
+			return []byte(tc.content)
+		})
+		if got != tc.want {
+			t.Errorf("%s: got language %q, want %q", tc.name, got, tc.want)
+		}
+		if read != tc.readContent {
+			t.Errorf("%s: read content %v, want %v", tc.name, read, tc.readContent)
+		}
+	}
+}
+
 // githubStore fetches from github and caches across test runs.
 var githubStore = &Store{
 	FetchTar: fetchTarFromGithub,
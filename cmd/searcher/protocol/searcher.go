@@ -120,6 +120,16 @@ type PatternInfo struct {
 	// Languages is the languages passed via the lang filters (e.g., "lang:c")
 	Languages []string
 
+	// IncludeLangs is a list of languages that must *all* match the returned
+	// files. A file matches a language if its path has one of the language's
+	// file extensions or if the language detected from its content is the
+	// language.
+	IncludeLangs []string `json:",omitempty"`
+
+	// ExcludeLangs is a list of languages that may not match the returned
+	// files.
+	ExcludeLangs []string `json:",omitempty"`
+
 	// CombyRule is a rule that constrains matching for structural search.
 	// It only applies when IsStructuralPat is true.
 	// As a temporary measure, the expression `where "backcompat" == "backcompat"` acts as
@@ -163,6 +173,9 @@ func (p *PatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLangs {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
 	if p.Select != "" {
 		args = append(args, fmt.Sprintf("select:%s", p.Select))
 	}
@@ -70,6 +70,10 @@ var errUnprocessableRequest = errors.New("unprocessable request: missing expecte
 //   - POST `/upload?uploadId={id},index={i}`
 //   - POST `/upload?uploadId={id},done=true`
 //
+// The uploaded payload may be either a gzipped LSIF index (newline-delimited JSON) or a gzipped
+// SCIP index (protobuf). The payload is stored as-is and the format is detected by the worker that
+// processes the upload.
+//
 // See the functions the following functions for details on how each request is handled:
 //
 //   - handleEnqueueSinglePayload
@@ -44,6 +44,7 @@ func (h *UploadHandler) handleEnqueueMultipartSetup(ctx context.Context, uploadS
 		NumParts:          uploadState.numParts,
 		UploadedParts:     nil,
 		UncompressedSize:  uploadState.uncompressedSize,
+		BaseUploadID:      nilIfZero(uploadState.baseUploadID),
 	})
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
@@ -42,6 +42,7 @@ func (h *UploadHandler) handleEnqueueSinglePayload(ctx context.Context, uploadSt
 		NumParts:          1,
 		UploadedParts:     []int{0},
 		UncompressedSize:  uploadState.uncompressedSize,
+		BaseUploadID:      nilIfZero(uploadState.baseUploadID),
 	})
 	if err != nil {
 		return nil, http.StatusInternalServerError, err
@@ -27,6 +27,7 @@ type uploadState struct {
 	indexer           string
 	indexerVersion    string
 	associatedIndexID int
+	baseUploadID      int
 	numParts          int
 	uploadedParts     []int
 	multipart         bool
@@ -56,6 +57,7 @@ func (h *UploadHandler) constructUploadState(ctx context.Context, r *http.Reques
 		indexer:           getQuery(r, "indexerName"),
 		indexerVersion:    getQuery(r, "indexerVersion"),
 		associatedIndexID: getQueryInt(r, "associatedIndexId"),
+		baseUploadID:      getQueryInt(r, "baseUploadId"),
 		numParts:          getQueryInt(r, "numParts"),
 		multipart:         hasQuery(r, "multiPart"),
 		suppliedIndex:     hasQuery(r, "index"),
@@ -80,6 +82,14 @@ func (h *UploadHandler) constructUploadState(ctx context.Context, r *http.Reques
 
 		// Stash repository id (user only gives us the name)
 		uploadState.repositoryID = repositoryID
+
+		if uploadState.baseUploadID != 0 {
+			// This upload contains only the documents that changed since the given base upload.
+			// Ensure that the base upload can supply the remaining documents.
+			if statusCode, err := h.ensureBaseUploadIsCompatible(ctx, uploadState); err != nil {
+				return uploadState, statusCode, err
+			}
+		}
 	} else {
 		// An upload identifier was supplied; this is a subsequent request of a multi-part
 		// upload. Fetch the upload record to ensure that it hasn't since been deleted by
@@ -103,6 +113,27 @@ func (h *UploadHandler) constructUploadState(ctx context.Context, r *http.Reques
 	return uploadState, 0, nil
 }
 
+// ensureBaseUploadIsCompatible ensures that the base upload referenced by the given upload state exists and
+// has been processed, and that it was produced by the same indexer for the same repository and root.
+func (h *UploadHandler) ensureBaseUploadIsCompatible(ctx context.Context, uploadState uploadState) (int, error) {
+	upload, exists, err := h.dbStore.GetUploadByID(ctx, uploadState.baseUploadID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !exists {
+		return http.StatusNotFound, errors.Errorf("base upload not found")
+	}
+
+	if upload.State != "completed" {
+		return http.StatusBadRequest, errors.Errorf("base upload has not been processed")
+	}
+	if upload.RepositoryID != uploadState.repositoryID || upload.Root != uploadState.root || upload.Indexer != uploadState.indexer {
+		return http.StatusBadRequest, errors.Errorf("base upload must have the same repository, root, and indexer")
+	}
+
+	return 0, nil
+}
+
 func ensureRepoAndCommitExist(ctx context.Context, logger log.Logger, db database.DB, repoName, commit string) (int, int, error) {
 	// 🚨 SECURITY: Bypass authz here; we've already determined that the current request is
 	// authorized to view the target repository; they are either a site admin or the code
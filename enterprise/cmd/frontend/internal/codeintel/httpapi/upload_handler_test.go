@@ -205,6 +205,56 @@ func TestHandleEnqueueSinglePayloadNoIndexerName(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueSinglePayloadIncompatibleBaseUpload(t *testing.T) {
+	setupRepoMocks(t)
+
+	logger := logtest.Scoped(t)
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(store.Upload{
+		ID:           24,
+		RepositoryID: 50,
+		Root:         "other/",
+		Indexer:      "lsif-go",
+		State:        "completed",
+	}, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":       []string{testCommit},
+		"root":         []string{"proj/"},
+		"repository":   []string{"github.com/test/test"},
+		"indexerName":  []string{"lsif-go"},
+		"baseUploadId": []string{"24"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	NewUploadHandler(
+		database.NewDB(logger, nil),
+		mockDBStore,
+		mockUploadStore,
+		true,
+		nil,
+		NewOperations(&observation.TestContext),
+	).ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusBadRequest, w.Code)
+	}
+	if len(mockDBStore.InsertUploadFunc.History()) != 0 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 0, len(mockDBStore.InsertUploadFunc.History()))
+	}
+}
+
 func TestHandleEnqueueMultipartSetup(t *testing.T) {
 	setupRepoMocks(t)
 
@@ -17,3 +17,10 @@ func getQueryInt(r *http.Request, name string) int {
 	value, _ := strconv.Atoi(r.URL.Query().Get(name))
 	return value
 }
+
+func nilIfZero(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
@@ -13,7 +13,10 @@ import (
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/graphql"
 	autoindexinggraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/transport/graphql"
 	codenavgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/transport/graphql"
+	codenavsearch "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/transport/search"
+	documentsgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/transport/graphql"
 	policiesgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/transport/graphql"
+	uploadsgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -33,6 +36,8 @@ func Init(ctx context.Context, db database.DB, config *Config, enterpriseService
 
 	executorResolver := executorgraphql.New(db)
 	codenavResolver := codenavgraphql.New(services.CodeNavSvc, services.gitserverClient, config.MaximumIndexesPerMonikerSearch, config.HunkCacheSize, oc("codenav"))
+	documentsResolver := documentsgraphql.GetResolver(services.DocumentsSvc)
+	uploadsResolver := uploadsgraphql.GetResolver(services.UploadsSvc)
 	policyResolver := policiesgraphql.New(services.PoliciesSvc, oc("policies"))
 	autoindexingResolver := autoindexinggraphql.New(services.AutoIndexingSvc, oc("autoindexing"))
 
@@ -41,6 +46,8 @@ func Init(ctx context.Context, db database.DB, config *Config, enterpriseService
 		services.lsifStore,
 		symbols.DefaultClient,
 		codenavResolver,
+		documentsResolver,
+		uploadsResolver,
 		executorResolver,
 		policyResolver,
 		autoindexingResolver,
@@ -50,6 +57,7 @@ func Init(ctx context.Context, db database.DB, config *Config, enterpriseService
 
 	enterpriseServices.CodeIntelResolver = codeintelgqlresolvers.NewResolver(db, services.gitserverClient, innerResolver, observationCtx)
 	enterpriseServices.NewCodeIntelUploadHandler = newUploadHandler(services)
+	enterpriseServices.CodeIntelSearchClient = codenavsearch.NewClient(services.CodeNavSvc, services.gitserverClient, config.MaximumIndexesPerMonikerSearch, config.HunkCacheSize)
 
 	return nil
 }
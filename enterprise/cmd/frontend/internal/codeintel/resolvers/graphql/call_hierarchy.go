@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+)
+
+type CallHierarchyCallConnectionResolver struct {
+	calls            []shared.CallHierarchyCall
+	cursor           *string
+	locationResolver *CachedLocationResolver
+}
+
+func NewCallHierarchyCallConnectionResolver(calls []shared.CallHierarchyCall, cursor *string, locationResolver *CachedLocationResolver) gql.CallHierarchyCallConnectionResolver {
+	return &CallHierarchyCallConnectionResolver{
+		calls:            calls,
+		cursor:           cursor,
+		locationResolver: locationResolver,
+	}
+}
+
+// Nodes resolves the calls of the connection. Calls whose function is defined at a commit not known
+// by gitserver are skipped.
+func (r *CallHierarchyCallConnectionResolver) Nodes(ctx context.Context) ([]gql.CallHierarchyCallResolver, error) {
+	resolvers := make([]gql.CallHierarchyCallResolver, 0, len(r.calls))
+	for _, call := range r.calls {
+		location, err := resolveLocation(ctx, r.locationResolver, uploadLocationToAdjustedLocations([]shared.UploadLocation{call.Item.Location})[0])
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			continue
+		}
+
+		resolvers = append(resolvers, &callHierarchyCallResolver{
+			item:             &callHierarchyItemResolver{name: call.Item.Name, location: location},
+			callSites:        uploadLocationToAdjustedLocations(call.CallSites),
+			locationResolver: r.locationResolver,
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *CallHierarchyCallConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	return graphqlutil.EncodeCursor(r.cursor), nil
+}
+
+type callHierarchyCallResolver struct {
+	item             gql.CallHierarchyItemResolver
+	callSites        []AdjustedLocation
+	locationResolver *CachedLocationResolver
+}
+
+func (r *callHierarchyCallResolver) Item() gql.CallHierarchyItemResolver {
+	return r.item
+}
+
+func (r *callHierarchyCallResolver) CallSites(ctx context.Context) ([]gql.LocationResolver, error) {
+	return resolveLocations(ctx, r.locationResolver, r.callSites)
+}
+
+type callHierarchyItemResolver struct {
+	name     string
+	location gql.LocationResolver
+}
+
+func (r *callHierarchyItemResolver) Name() string {
+	return r.name
+}
+
+func (r *callHierarchyItemResolver) Location(ctx context.Context) (gql.LocationResolver, error) {
+	return r.location, nil
+}
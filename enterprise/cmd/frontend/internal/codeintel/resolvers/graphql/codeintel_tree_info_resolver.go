@@ -118,6 +118,15 @@ func (r *codeIntelTreeInfoResolver) PreciseSupport(ctx context.Context) (*[]gql.
 	return &resolvers, nil
 }
 
+func (r *codeIntelTreeInfoResolver) PreciseCoverage(ctx context.Context, args *gql.GitTreePreciseCoverageArgs) (gql.CodeIntelCoverageResolver, error) {
+	report, err := r.resolver.UploadsServiceResolver().GetCoverageReport(ctx, int(r.repo.ID), r.commit, r.path, int(args.First))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCodeIntelCoverageResolver(report), nil
+}
+
 type codeIntelTreePreciseCoverageResolver struct {
 	confidence preciseSupportInferenceConfidence
 	indexer    gql.CodeIntelIndexerResolver
@@ -62,3 +62,17 @@ func (r *IndexConfigurationResolver) InferredConfiguration(ctx context.Context)
 
 	return strPtr(indented.String()), nil
 }
+
+func (r *IndexConfigurationResolver) InferenceScript(ctx context.Context) (_ *string, err error) {
+	defer r.errTracer.Collect(&err, log.String("indexConfigResolver.field", "inferenceScript"))
+	autoIndexingResolver := r.resolver.AutoIndexingResolver()
+	script, exists, err := autoIndexingResolver.GetInferenceScript(ctx, r.repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return strPtr(script), nil
+}
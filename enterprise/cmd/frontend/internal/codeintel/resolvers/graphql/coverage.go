@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"path/filepath"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+type codeIntelCoverageResolver struct {
+	coverage       shared.DirectoryCoverage
+	subdirectories []gql.CodeIntelCoverageResolver
+}
+
+// NewCodeIntelCoverageResolver returns a resolver for the coverage of the first directory in the
+// given coverage report. The remaining entries of the report are nested under their parent directory.
+func NewCodeIntelCoverageResolver(report []shared.DirectoryCoverage) gql.CodeIntelCoverageResolver {
+	if len(report) == 0 {
+		return &codeIntelCoverageResolver{}
+	}
+
+	resolversByPath := make(map[string]*codeIntelCoverageResolver, len(report))
+	for _, coverage := range report {
+		resolversByPath[coverage.Path] = &codeIntelCoverageResolver{coverage: coverage}
+	}
+
+	// The report is ordered by path, so subdirectories are appended in order
+	for _, coverage := range report[1:] {
+		parent, ok := resolversByPath[parentDirectory(coverage.Path)]
+		if !ok {
+			continue
+		}
+
+		parent.subdirectories = append(parent.subdirectories, resolversByPath[coverage.Path])
+	}
+
+	return resolversByPath[report[0].Path]
+}
+
+func (r *codeIntelCoverageResolver) Path() string        { return r.coverage.Path }
+func (r *codeIntelCoverageResolver) TotalFiles() int32   { return int32(r.coverage.TotalFiles) }
+func (r *codeIntelCoverageResolver) CoveredFiles() int32 { return int32(r.coverage.CoveredFiles) }
+
+func (r *codeIntelCoverageResolver) Indexers() []gql.CodeIntelIndexerCoverageResolver {
+	resolvers := make([]gql.CodeIntelIndexerCoverageResolver, 0, len(r.coverage.Indexers))
+	for _, indexer := range r.coverage.Indexers {
+		resolvers = append(resolvers, &codeIntelIndexerCoverageResolver{coverage: indexer})
+	}
+
+	return resolvers
+}
+
+func (r *codeIntelCoverageResolver) Languages() []gql.CodeIntelLanguageCoverageResolver {
+	resolvers := make([]gql.CodeIntelLanguageCoverageResolver, 0, len(r.coverage.Languages))
+	for _, language := range r.coverage.Languages {
+		resolvers = append(resolvers, &codeIntelLanguageCoverageResolver{coverage: language})
+	}
+
+	return resolvers
+}
+
+func (r *codeIntelCoverageResolver) Subdirectories() []gql.CodeIntelCoverageResolver {
+	if r.subdirectories == nil {
+		return []gql.CodeIntelCoverageResolver{}
+	}
+
+	return r.subdirectories
+}
+
+type codeIntelIndexerCoverageResolver struct {
+	coverage shared.IndexerCoverage
+}
+
+func (r *codeIntelIndexerCoverageResolver) Indexer() gql.CodeIntelIndexerResolver {
+	return indexerByName(r.coverage.Indexer)
+}
+
+func (r *codeIntelIndexerCoverageResolver) CoveredFiles() int32 {
+	return int32(r.coverage.CoveredFiles)
+}
+
+type codeIntelLanguageCoverageResolver struct {
+	coverage shared.LanguageCoverage
+}
+
+func (r *codeIntelLanguageCoverageResolver) Language() string  { return r.coverage.Language }
+func (r *codeIntelLanguageCoverageResolver) TotalFiles() int32 { return int32(r.coverage.TotalFiles) }
+func (r *codeIntelLanguageCoverageResolver) CoveredFiles() int32 {
+	return int32(r.coverage.CoveredFiles)
+}
+
+// parentDirectory returns the parent of the given repository-relative directory. The root
+// directory is denoted by the empty string.
+func parentDirectory(path string) string {
+	if dir := filepath.Dir(path); dir != "." {
+		return dir
+	}
+
+	return ""
+}
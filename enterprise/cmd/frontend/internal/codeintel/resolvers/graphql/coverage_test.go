@@ -0,0 +1,44 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestCodeIntelCoverageResolverSubdirectories(t *testing.T) {
+	resolver := NewCodeIntelCoverageResolver([]shared.DirectoryCoverage{
+		{Path: "", TotalFiles: 5, CoveredFiles: 3},
+		{Path: "cmd", TotalFiles: 3, CoveredFiles: 2},
+		{Path: "cmd/server", TotalFiles: 2, CoveredFiles: 1},
+		{Path: "web", TotalFiles: 1, CoveredFiles: 1},
+	})
+
+	var paths func(r gql.CodeIntelCoverageResolver) []string
+	paths = func(r gql.CodeIntelCoverageResolver) []string {
+		values := []string{r.Path()}
+		for _, subdirectory := range r.Subdirectories() {
+			for _, path := range paths(subdirectory) {
+				values = append(values, r.Path()+" > "+path)
+			}
+		}
+		return values
+	}
+
+	expectedPaths := []string{
+		"",
+		" > cmd",
+		" > cmd > cmd/server",
+		" > web",
+	}
+	if diff := cmp.Diff(expectedPaths, paths(resolver)); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	if indexer := (&codeIntelIndexerCoverageResolver{coverage: shared.IndexerCoverage{Indexer: "lsif-go"}}).Indexer(); indexer.URL() != lsifGo.URL() {
+		t.Errorf("unexpected indexer url. want=%q have=%q", lsifGo.URL(), indexer.URL())
+	}
+}
@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/go-lsp"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type DocumentResolver struct {
+	document         shared.Document
+	gitserver        GitserverClient
+	resolver         resolvers.Resolver
+	prefetcher       *Prefetcher
+	locationResolver *CachedLocationResolver
+	errTracer        *observation.ErrCollector
+}
+
+func NewDocumentResolver(document shared.Document, gitserver GitserverClient, resolver resolvers.Resolver, prefetcher *Prefetcher, locationResolver *CachedLocationResolver, errTracer *observation.ErrCollector) gql.CodeIntelDocumentResolver {
+	// Request the next batch of upload fetches to contain the document's upload. This
+	// allows the prefetcher.GetUploadByID invocation in the Upload method to batch its
+	// work with sibling resolvers, which share the same prefetcher instance.
+	prefetcher.MarkUpload(document.UploadID)
+
+	return &DocumentResolver{
+		document:         document,
+		gitserver:        gitserver,
+		resolver:         resolver,
+		prefetcher:       prefetcher,
+		locationResolver: locationResolver,
+		errTracer:        errTracer,
+	}
+}
+
+func (r *DocumentResolver) Upload(ctx context.Context) (_ gql.LSIFUploadResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("documentResolver.field", "upload"))
+
+	upload, exists, err := r.prefetcher.GetUploadByID(ctx, r.document.UploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Newf("upload %d not found", r.document.UploadID)
+	}
+
+	return NewUploadResolver(r.locationResolver.db, r.gitserver, r.resolver, upload, r.prefetcher, r.locationResolver, r.errTracer), nil
+}
+
+func (r *DocumentResolver) Occurrences() []gql.CodeIntelOccurrenceResolver {
+	resolvers := make([]gql.CodeIntelOccurrenceResolver, 0, len(r.document.Occurrences))
+	for _, occurrence := range r.document.Occurrences {
+		resolvers = append(resolvers, &OccurrenceResolver{occurrence: occurrence})
+	}
+
+	return resolvers
+}
+
+func (r *DocumentResolver) Hovers() []gql.CodeIntelHoverResolver {
+	ids := make([]string, 0, len(r.document.Hovers))
+	for id := range r.document.Hovers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resolvers := make([]gql.CodeIntelHoverResolver, 0, len(ids))
+	for _, id := range ids {
+		resolvers = append(resolvers, &DocumentHoverResolver{id: id, text: r.document.Hovers[id]})
+	}
+
+	return resolvers
+}
+
+type OccurrenceResolver struct {
+	occurrence shared.Occurrence
+}
+
+func (r *OccurrenceResolver) Range() gql.RangeResolver {
+	return gql.NewRangeResolver(lsp.Range{
+		Start: convertPosition(r.occurrence.Range.Start.Line, r.occurrence.Range.Start.Character),
+		End:   convertPosition(r.occurrence.Range.End.Line, r.occurrence.Range.End.Character),
+	})
+}
+
+func (r *OccurrenceResolver) SymbolRoles() []string {
+	var roles []string
+	if r.occurrence.SymbolRoles&shared.SymbolRoleDefinition != 0 {
+		roles = append(roles, "DEFINITION")
+	}
+	if r.occurrence.SymbolRoles&shared.SymbolRoleReference != 0 {
+		roles = append(roles, "REFERENCE")
+	}
+
+	return roles
+}
+
+func (r *OccurrenceResolver) Monikers() []gql.CodeIntelMonikerResolver {
+	resolvers := make([]gql.CodeIntelMonikerResolver, 0, len(r.occurrence.Monikers))
+	for _, moniker := range r.occurrence.Monikers {
+		resolvers = append(resolvers, &MonikerResolver{moniker: moniker})
+	}
+
+	return resolvers
+}
+
+func (r *OccurrenceResolver) HoverID() *string {
+	if r.occurrence.HoverID == "" {
+		return nil
+	}
+
+	return &r.occurrence.HoverID
+}
+
+type MonikerResolver struct {
+	moniker shared.Moniker
+}
+
+func (r *MonikerResolver) Kind() string       { return r.moniker.Kind }
+func (r *MonikerResolver) Scheme() string     { return r.moniker.Scheme }
+func (r *MonikerResolver) Identifier() string { return r.moniker.Identifier }
+
+type DocumentHoverResolver struct {
+	id   string
+	text string
+}
+
+func (r *DocumentHoverResolver) ID() string             { return r.id }
+func (r *DocumentHoverResolver) Markdown() gql.Markdown { return gql.Markdown(r.text) }
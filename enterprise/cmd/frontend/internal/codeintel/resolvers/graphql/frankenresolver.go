@@ -47,6 +47,10 @@ func (r *frankenResolver) UpdateRepositoryIndexConfiguration(ctx context.Context
 	return r.getAutoindexingServiceResolver().UpdateRepositoryIndexConfiguration(ctx, args)
 }
 
+func (r *frankenResolver) UpdateRepositoryInferenceScript(ctx context.Context, args *gql.UpdateRepositoryInferenceScriptArgs) (_ *gql.EmptyResponse, err error) {
+	return r.getAutoindexingServiceResolver().UpdateRepositoryInferenceScript(ctx, args)
+}
+
 func (r *frankenResolver) getUploadsServiceResolver() gql.UploadsServiceResolver {
 	return r.Resolver
 
@@ -70,6 +74,10 @@ func (r *frankenResolver) DeleteLSIFUpload(ctx context.Context, args *struct{ ID
 	return r.getUploadsServiceResolver().DeleteLSIFUpload(ctx, args)
 }
 
+func (r *frankenResolver) CodeIntelSemanticDiff(ctx context.Context, args *gql.CodeIntelSemanticDiffArgs) (_ []gql.CodeIntelSemanticDiffResolver, err error) {
+	return r.getUploadsServiceResolver().CodeIntelSemanticDiff(ctx, args)
+}
+
 func (r *frankenResolver) CommitGraph(ctx context.Context, id graphql.ID) (_ gql.CodeIntelligenceCommitGraphResolver, err error) {
 	return r.getUploadsServiceResolver().CommitGraph(ctx, id)
 }
@@ -105,6 +113,10 @@ func (r *frankenResolver) PreviewRepositoryFilter(ctx context.Context, args *gql
 	return r.getPoliciesServiceResolver().PreviewRepositoryFilter(ctx, args)
 }
 
+func (r *frankenResolver) PreviewCodeIntelligenceConfigurationPolicyImpact(ctx context.Context, args *gql.PreviewCodeIntelligenceConfigurationPolicyImpactArgs) (_ gql.CodeIntelligenceRetentionPolicyImpactConnectionResolver, err error) {
+	return r.getPoliciesServiceResolver().PreviewCodeIntelligenceConfigurationPolicyImpact(ctx, args)
+}
+
 func (r *frankenResolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *gql.PreviewGitObjectFilterArgs) (_ []gql.GitObjectFilterPreviewResolver, err error) {
 	return r.getPoliciesServiceResolver().PreviewGitObjectFilter(ctx, id, args)
 }
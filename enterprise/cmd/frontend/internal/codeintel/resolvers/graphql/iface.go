@@ -3,8 +3,6 @@ package graphql
 import (
 	"context"
 
-	"github.com/grafana/regexp"
-
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	policies "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/enterprise"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -15,6 +13,5 @@ type GitserverClient interface {
 	policies.GitserverClient
 	shared.GitserverClient
 
-	ListFiles(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error)
 	ListTags(ctx context.Context, repo api.RepoName, commitObjs ...string) ([]*gitdomain.Tag, error)
 }
@@ -92,6 +92,14 @@ var (
 		name: "lsif-dotnet",
 		urn:  "github.com/tcz717/LsifDotnet",
 	}
+	scipDotnet = codeIntelIndexerResolver{
+		name: "scip-dotnet",
+		urn:  "github.com/sourcegraph/scip-dotnet",
+	}
+	scipRuby = codeIntelIndexerResolver{
+		name: "scip-ruby",
+		urn:  "github.com/sourcegraph/scip-ruby",
+	}
 )
 
 var allIndexers = []gql.CodeIntelIndexerResolver{
@@ -113,6 +121,20 @@ var allIndexers = []gql.CodeIntelIndexerResolver{
 	&lsifPHP,
 	&lsifTerraform,
 	&lsifDotnet,
+	&scipDotnet,
+	&scipRuby,
+}
+
+// indexerByName returns the known indexer with the given name, or an indexer with only a name
+// if the indexer is not known.
+func indexerByName(name string) gql.CodeIntelIndexerResolver {
+	for _, indexer := range allIndexers {
+		if indexer.Name() == name {
+			return indexer
+		}
+	}
+
+	return &codeIntelIndexerResolver{name: name}
 }
 
 // A map of file extension to a list of indexers in order of recommendation
@@ -140,7 +162,8 @@ var languageToIndexer = map[string][]gql.CodeIntelIndexerResolver{
 	".rs":      {&rustAnalyzer},
 	".php":     {&lsifPHP},
 	".tf":      {&lsifTerraform},
-	".cs":      {&lsifDotnet},
+	".cs":      {&scipDotnet, &lsifDotnet},
+	".rb":      {&scipRuby},
 }
 
 var imageToIndexer = map[string]gql.CodeIntelIndexerResolver{
@@ -150,6 +173,9 @@ var imageToIndexer = map[string]gql.CodeIntelIndexerResolver{
 	"sourcegraph/lsif-node":       &lsifNode,
 	"sourcegraph/lsif-clang":      &lsifClang,
 	"davidrjenni/lsif-php":        &lsifPHP,
+	"sourcegraph/lsif-php":        &lsifPHP,
 	"sourcegraph/lsif-rust":       &rustAnalyzer,
 	"sourcegraph/scip-python":     &scipPython,
+	"sourcegraph/scip-dotnet":     &scipDotnet,
+	"sourcegraph/scip-ruby":       &scipRuby,
 }
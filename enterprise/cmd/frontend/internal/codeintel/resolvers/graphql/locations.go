@@ -270,5 +270,8 @@ func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolv
 	}
 
 	lspRange := convertRange(location.AdjustedRange)
+	if location.Confidence != nil {
+		return gql.NewApproximateLocationResolver(treeResolver, &lspRange, *location.Confidence), nil
+	}
 	return gql.NewLocationResolver(treeResolver, &lspRange), nil
 }
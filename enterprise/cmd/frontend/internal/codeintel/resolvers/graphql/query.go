@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/transport/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -22,9 +23,16 @@ const DefaultReferencesPageSize = 100
 // DefaultReferencesPageSize is the implementation result page size when no limit is supplied.
 const DefaultImplementationsPageSize = 100
 
+// DefaultCallHierarchyPageSize is the call site page size of call hierarchy results when no limit is supplied.
+const DefaultCallHierarchyPageSize = 100
+
 // DefaultDiagnosticsPageSize is the diagnostic result page size when no limit is supplied.
 const DefaultDiagnosticsPageSize = 100
 
+// DefaultSemanticDiffPageSize is the number of symbols read from each upload per page of a
+// semantic diff when no limit is supplied.
+const DefaultSemanticDiffPageSize = 1000
+
 // ErrIllegalLimit occurs when the user requests less than one object per page.
 var ErrIllegalLimit = errors.New("illegal limit")
 
@@ -36,6 +44,7 @@ var ErrIllegalBounds = errors.New("illegal bounds")
 // All code intel-specific behavior is delegated to the underlying resolver instance, which is defined
 // in the parent package.
 type QueryResolver struct {
+	args                    *gql.GitBlobLSIFDataArgs
 	gitBlobLSIFDataResolver graphql.GitBlobLSIFDataResolver
 	resolver                resolvers.Resolver
 	gitserver               GitserverClient
@@ -45,9 +54,10 @@ type QueryResolver struct {
 
 // NewQueryResolver creates a new QueryResolver with the given resolver that defines all code intel-specific
 // behavior. A cached location resolver instance is also given to the query resolver, which should be used
-// to resolve all location-related values.
-func NewQueryResolver(gitserver GitserverClient, gitBlobResolver graphql.GitBlobLSIFDataResolver, resolver resolvers.Resolver, locationResolver *CachedLocationResolver, errTracer *observation.ErrCollector) gql.GitBlobLSIFDataResolver {
+// to resolve all location-related values. The given arguments are those of the requested blob.
+func NewQueryResolver(gitserver GitserverClient, args *gql.GitBlobLSIFDataArgs, gitBlobResolver graphql.GitBlobLSIFDataResolver, resolver resolvers.Resolver, locationResolver *CachedLocationResolver, errTracer *observation.ErrCollector) gql.GitBlobLSIFDataResolver {
 	return &QueryResolver{
+		args:                    args,
 		gitBlobLSIFDataResolver: gitBlobResolver,
 		resolver:                resolver,
 		gitserver:               gitserver,
@@ -187,6 +197,48 @@ func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPaged
 	return NewLocationConnectionResolver(lct, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) IncomingCalls(ctx context.Context, args *gql.LSIFCallHierarchyArgs) (_ gql.CallHierarchyCallConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "incomingCalls"))
+
+	limit := derefInt32(args.First, DefaultCallHierarchyPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, cursor, err := r.gitBlobLSIFDataResolver.IncomingCalls(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallHierarchyCallConnectionResolver(calls, strPtr(cursor), r.locationResolver), nil
+}
+
+func (r *QueryResolver) OutgoingCalls(ctx context.Context, args *gql.LSIFCallHierarchyArgs) (_ gql.CallHierarchyCallConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "outgoingCalls"))
+
+	limit := derefInt32(args.First, DefaultCallHierarchyPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	calls, cursor, err := r.gitBlobLSIFDataResolver.OutgoingCalls(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCallHierarchyCallConnectionResolver(calls, strPtr(cursor), r.locationResolver), nil
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.HoverResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "hover"))
 
@@ -198,6 +250,30 @@ func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionAr
 	return NewHoverResolver(text, sharedRangeTolspRange(rx)), nil
 }
 
+func (r *QueryResolver) Documents(ctx context.Context) (_ []gql.CodeIntelDocumentResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "documents"))
+
+	documents, err := r.resolver.DocumentsResolver().Document(ctx, documents.DocumentOpts{
+		Repo:      r.args.Repo,
+		Commit:    string(r.args.Commit),
+		Path:      r.args.Path,
+		ExactPath: r.args.ExactPath,
+		Indexer:   r.args.ToolName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prefetcher := NewPrefetcher(r.resolver)
+
+	resolvers := make([]gql.CodeIntelDocumentResolver, 0, len(documents))
+	for _, document := range documents {
+		resolvers = append(resolvers, NewDocumentResolver(document, r.gitserver, r.resolver, prefetcher, r.locationResolver, r.errTracer))
+	}
+
+	return resolvers, nil
+}
+
 func (r *QueryResolver) LSIFUploads(ctx context.Context) (_ []gql.LSIFUploadResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "lsifUploads"))
 
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -12,8 +13,11 @@ import (
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
 	transportmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks/transport"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	documentsservice "github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
+	documentsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestRanges(t *testing.T) {
@@ -21,7 +25,7 @@ func TestRanges(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	args := &gql.LSIFRangesArgs{StartLine: 10, EndLine: 20}
 	if _, err := resolver.Ranges(context.Background(), args); err != nil {
@@ -44,7 +48,7 @@ func TestDefinitions(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
 	if _, err := resolver.Definitions(context.Background(), args); err != nil {
@@ -67,7 +71,7 @@ func TestReferences(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	offset := int32(25)
 	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
@@ -107,7 +111,7 @@ func TestReferencesDefaultLimit(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	args := &gql.LSIFPagedQueryPositionArgs{
 		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
@@ -134,7 +138,7 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), observation.NewErrorCollector())
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), observation.NewErrorCollector())
 
 	offset := int32(-1)
 	args := &gql.LSIFPagedQueryPositionArgs{
@@ -150,6 +154,87 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestIncomingCalls(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+
+	offset := int32(25)
+	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
+
+	args := &gql.LSIFCallHierarchyArgs{
+		Line:           10,
+		Character:      15,
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+		After:          &cursor,
+	}
+
+	if _, err := resolver.IncomingCalls(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockGitBlobResolver.IncomingCallsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockGitBlobResolver.IncomingCallsFunc.History()))
+	}
+	if val := mockGitBlobResolver.IncomingCallsFunc.History()[0].Arg1; val != 10 {
+		t.Fatalf("unexpected line. want=%d have=%d", 10, val)
+	}
+	if val := mockGitBlobResolver.IncomingCallsFunc.History()[0].Arg2; val != 15 {
+		t.Fatalf("unexpected character. want=%d have=%d", 15, val)
+	}
+	if val := mockGitBlobResolver.IncomingCallsFunc.History()[0].Arg3; val != 25 {
+		t.Fatalf("unexpected limit. want=%d have=%d", 25, val)
+	}
+	if val := mockGitBlobResolver.IncomingCallsFunc.History()[0].Arg4; val != "test-cursor" {
+		t.Fatalf("unexpected cursor. want=%s have=%s", "test-cursor", val)
+	}
+}
+
+func TestOutgoingCallsDefaultLimit(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+
+	args := &gql.LSIFCallHierarchyArgs{
+		Line:      10,
+		Character: 15,
+	}
+
+	if _, err := resolver.OutgoingCalls(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockGitBlobResolver.OutgoingCallsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockGitBlobResolver.OutgoingCallsFunc.History()))
+	}
+	if val := mockGitBlobResolver.OutgoingCallsFunc.History()[0].Arg3; val != DefaultCallHierarchyPageSize {
+		t.Fatalf("unexpected limit. want=%d have=%d", DefaultCallHierarchyPageSize, val)
+	}
+}
+
+func TestOutgoingCallsDefaultIllegalLimit(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), observation.NewErrorCollector())
+
+	offset := int32(-1)
+	args := &gql.LSIFCallHierarchyArgs{
+		Line:           10,
+		Character:      15,
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
+	}
+
+	if _, err := resolver.OutgoingCalls(context.Background(), args); err != ErrIllegalLimit {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalLimit, err)
+	}
+}
+
 func TestHover(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
@@ -157,7 +242,7 @@ func TestHover(t *testing.T) {
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
 	mockGitBlobResolver.HoverFunc.SetDefaultReturn("text", shared.Range{}, true, nil)
 	mockResolver := resolvermocks.NewMockResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, mockResolver, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, mockResolver, NewCachedLocationResolver(db), nil)
 
 	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
 	if _, err := resolver.Hover(context.Background(), args); err != nil {
@@ -175,12 +260,76 @@ func TestHover(t *testing.T) {
 	}
 }
 
+func TestDocuments(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, nil)
+
+	mockDocumentsResolver := resolvermocks.NewMockDocumentsResolver()
+	mockDocumentsResolver.DocumentFunc.SetDefaultReturn([]documentsshared.Document{
+		{
+			UploadID: 50,
+			Occurrences: []documentsshared.Occurrence{
+				{SymbolRoles: documentsshared.SymbolRoleDefinition, HoverID: "h1"},
+				{SymbolRoles: documentsshared.SymbolRoleReference},
+			},
+			Hovers: map[string]string{"h1": "text"},
+		},
+	}, nil)
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.DocumentsResolverFunc.SetDefaultReturn(mockDocumentsResolver)
+
+	blobArgs := &gql.GitBlobLSIFDataArgs{
+		Repo:      &types.Repo{ID: 42},
+		Commit:    "deadbeef",
+		Path:      "main.go",
+		ExactPath: true,
+		ToolName:  "lsif-go",
+	}
+	resolver := NewQueryResolver(nil, blobArgs, nil, mockResolver, NewCachedLocationResolver(db), nil)
+
+	documents, err := resolver.Documents(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockDocumentsResolver.DocumentFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockDocumentsResolver.DocumentFunc.History()))
+	}
+	expectedOpts := documentsservice.DocumentOpts{
+		Repo:      blobArgs.Repo,
+		Commit:    "deadbeef",
+		Path:      "main.go",
+		ExactPath: true,
+		Indexer:   "lsif-go",
+	}
+	if diff := cmp.Diff(expectedOpts, mockDocumentsResolver.DocumentFunc.History()[0].Arg1); diff != "" {
+		t.Fatalf("unexpected document options (-want +got):\n%s", diff)
+	}
+
+	if len(documents) != 1 {
+		t.Fatalf("unexpected number of documents. want=%d have=%d", 1, len(documents))
+	}
+	occurrences := documents[0].Occurrences()
+	if len(occurrences) != 2 {
+		t.Fatalf("unexpected number of occurrences. want=%d have=%d", 2, len(occurrences))
+	}
+	if diff := cmp.Diff([]string{"DEFINITION"}, occurrences[0].SymbolRoles()); diff != "" {
+		t.Errorf("unexpected symbol roles (-want +got):\n%s", diff)
+	}
+	if hoverID := occurrences[1].HoverID(); hoverID != nil {
+		t.Errorf("unexpected hover id. want=nil have=%q", *hoverID)
+	}
+	if hovers := documents[0].Hovers(); len(hovers) != 1 || hovers[0].ID() != "h1" || hovers[0].Markdown() != "text" {
+		t.Errorf("unexpected hovers")
+	}
+}
+
 func TestDiagnostics(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	offset := int32(25)
 	args := &gql.LSIFDiagnosticsArgs{
@@ -204,7 +353,7 @@ func TestDiagnosticsDefaultLimit(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), nil)
 
 	args := &gql.LSIFDiagnosticsArgs{
 		ConnectionArgs: graphqlutil.ConnectionArgs{},
@@ -227,7 +376,7 @@ func TestDiagnosticsDefaultIllegalLimit(t *testing.T) {
 	db := database.NewDB(logger, nil)
 
 	mockGitBlobResolver := transportmocks.NewMockGitBlobLSIFDataResolver()
-	resolver := NewQueryResolver(nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), observation.NewErrorCollector())
+	resolver := NewQueryResolver(nil, nil, mockGitBlobResolver, nil, NewCachedLocationResolver(db), observation.NewErrorCollector())
 
 	offset := int32(-1)
 	args := &gql.LSIFDiagnosticsArgs{
@@ -31,6 +31,7 @@ const (
 	DefaultConfigurationPolicyPageSize     = 50
 	DefaultRepositoryFilterPreviewPageSize = 50
 	DefaultRetentionPolicyMatchesPageSize  = 50
+	DefaultRetentionPolicyImpactPageSize   = 10
 )
 
 var errAutoIndexingNotEnabled = errors.New("precise code intelligence auto-indexing is not enabled")
@@ -337,7 +338,7 @@ func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDat
 		return nil, err
 	}
 
-	return NewQueryResolver(r.gitserver, gitBlobResolver, r.resolver, r.locationResolver, errTracer), nil
+	return NewQueryResolver(r.gitserver, args, gitBlobResolver, r.resolver, r.locationResolver, errTracer), nil
 }
 
 func (r *Resolver) GitBlobCodeIntelInfo(ctx context.Context, args *gql.GitTreeEntryCodeIntelInfoArgs) (_ gql.GitBlobCodeIntelSupportResolver, err error) {
@@ -644,6 +645,32 @@ func (r *Resolver) UpdateRepositoryIndexConfiguration(ctx context.Context, args
 	return &gql.EmptyResponse{}, nil
 }
 
+// 🚨 SECURITY: Only site admins may modify code intelligence inference scripts
+func (r *Resolver) UpdateRepositoryInferenceScript(ctx context.Context, args *gql.UpdateRepositoryInferenceScriptArgs) (_ *gql.EmptyResponse, err error) {
+	ctx, _, endObservation := r.observationContext.updateInferenceScript.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(args.Repository)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if !autoIndexingEnabled() {
+		return nil, errAutoIndexingNotEnabled
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	autoIndexingResolver := r.resolver.AutoIndexingResolver()
+	if err := autoIndexingResolver.UpdateInferenceScript(ctx, int(repositoryID), args.Script); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
 func (r *Resolver) PreviewRepositoryFilter(ctx context.Context, args *gql.PreviewRepositoryFilterArgs) (_ gql.RepositoryFilterPreviewResolver, err error) {
 	ctx, _, endObservation := r.observationContext.previewRepoFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -692,6 +719,89 @@ func (r *Resolver) PreviewRepositoryFilter(ctx context.Context, args *gql.Previe
 	}, nil
 }
 
+// 🚨 SECURITY: Only site admins may modify code intelligence configuration policies
+func (r *Resolver) PreviewCodeIntelligenceConfigurationPolicyImpact(ctx context.Context, args *gql.PreviewCodeIntelligenceConfigurationPolicyImpactArgs) (_ gql.CodeIntelligenceRetentionPolicyImpactConnectionResolver, err error) {
+	ctx, traceErrs, endObservation := r.observationContext.previewConfigurationPolicyImpact.WithErrors(ctx, &err, observation.Args{})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateConfigurationPolicy(args.CodeIntelConfigurationPolicy); err != nil {
+		return nil, err
+	}
+
+	offset, err := graphqlutil.DecodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := DefaultRetentionPolicyImpactPageSize
+	if args.First != nil {
+		pageSize = int(*args.First)
+	}
+
+	policy := shared.ConfigurationPolicy{
+		Name:                      args.Name,
+		RepositoryPatterns:        args.RepositoryPatterns,
+		Type:                      shared.GitObjectType(args.Type),
+		Pattern:                   args.Pattern,
+		RetentionEnabled:          args.RetentionEnabled,
+		RetentionDuration:         toDuration(args.RetentionDurationHours),
+		RetainIntermediateCommits: args.RetainIntermediateCommits,
+		IndexingEnabled:           args.IndexingEnabled,
+		IndexCommitMaxAge:         toDuration(args.IndexCommitMaxAgeHours),
+		IndexIntermediateCommits:  args.IndexIntermediateCommits,
+	}
+	if args.ID != nil {
+		id, err := unmarshalConfigurationPolicyGQLID(*args.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.ID = int(id)
+	} else if args.Repository != nil {
+		id64, err := unmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		id := int(id64)
+		policy.RepositoryID = &id
+	}
+
+	policyResolver, err := r.resolver.PoliciesResolver().PolicyResolverFactory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	impacts, totalCount, err := policyResolver.PreviewRetentionPolicyImpact(ctx, policy, pageSize, offset, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := NewPrefetcher(r.resolver)
+
+	resolvers := make([]gql.CodeIntelligenceRetentionPolicyImpactResolver, 0, len(impacts))
+	for _, impact := range impacts {
+		repo, err := backend.NewRepos(r.locationResolver.logger, r.db).Get(ctx, api.RepoID(impact.RepositoryID))
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers = append(resolvers, NewRetentionPolicyImpactResolver(r.db, r.gitserver, r.resolver, impact, repo, prefetcher, r.locationResolver, traceErrs))
+	}
+
+	return &retentionPolicyImpactConnectionResolver{
+		resolvers:  resolvers,
+		totalCount: totalCount,
+		offset:     offset,
+	}, nil
+}
+
 func (r *Resolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *gql.PreviewGitObjectFilterArgs) (_ []gql.GitObjectFilterPreviewResolver, err error) {
 	ctx, _, endObservation := r.observationContext.previewGitObjectFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type retentionPolicyImpactConnectionResolver struct {
+	resolvers  []gql.CodeIntelligenceRetentionPolicyImpactResolver
+	totalCount int
+	offset     int
+}
+
+var _ gql.CodeIntelligenceRetentionPolicyImpactConnectionResolver = &retentionPolicyImpactConnectionResolver{}
+
+func (r *retentionPolicyImpactConnectionResolver) Nodes(ctx context.Context) ([]gql.CodeIntelligenceRetentionPolicyImpactResolver, error) {
+	return r.resolvers, nil
+}
+
+func (r *retentionPolicyImpactConnectionResolver) TotalCount() int32 {
+	return int32(r.totalCount)
+}
+
+func (r *retentionPolicyImpactConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	return graphqlutil.EncodeIntCursor(toInt32(graphqlutil.NextOffset(r.offset, len(r.resolvers), r.totalCount)))
+}
+
+type retentionPolicyImpactResolver struct {
+	db               database.DB
+	gitserver        GitserverClient
+	resolver         resolvers.Resolver
+	impact           shared.RetentionPolicyImpact
+	repo             *types.Repo
+	prefetcher       *Prefetcher
+	locationResolver *CachedLocationResolver
+	traceErrs        *observation.ErrCollector
+}
+
+func NewRetentionPolicyImpactResolver(db database.DB, gitserver GitserverClient, resolver resolvers.Resolver, impact shared.RetentionPolicyImpact, repo *types.Repo, prefetcher *Prefetcher, locationResolver *CachedLocationResolver, traceErrs *observation.ErrCollector) gql.CodeIntelligenceRetentionPolicyImpactResolver {
+	// Request the next batch of upload fetches to contain all of the uploads referenced by
+	// this impact. This allows the prefetcher.GetUploadByID invocations in the upload list
+	// resolvers below to request all uploads of a page at once.
+	for _, id := range impact.ExpiredUploadIDs {
+		prefetcher.MarkUpload(id)
+	}
+	for _, id := range impact.ProtectedUploadIDs {
+		prefetcher.MarkUpload(id)
+	}
+
+	return &retentionPolicyImpactResolver{
+		db:               db,
+		gitserver:        gitserver,
+		resolver:         resolver,
+		impact:           impact,
+		repo:             repo,
+		prefetcher:       prefetcher,
+		locationResolver: locationResolver,
+		traceErrs:        traceErrs,
+	}
+}
+
+func (r *retentionPolicyImpactResolver) Repository() *gql.RepositoryResolver {
+	return gql.NewRepositoryResolver(r.db, r.repo)
+}
+
+func (r *retentionPolicyImpactResolver) ExpiredUploads(ctx context.Context) ([]gql.LSIFUploadResolver, error) {
+	return r.uploadResolvers(ctx, r.impact.ExpiredUploadIDs)
+}
+
+func (r *retentionPolicyImpactResolver) ProtectedUploads(ctx context.Context) ([]gql.LSIFUploadResolver, error) {
+	return r.uploadResolvers(ctx, r.impact.ProtectedUploadIDs)
+}
+
+func (r *retentionPolicyImpactResolver) ReclaimedBytes() gql.BigInt {
+	return gql.BigInt{Int: r.impact.ReclaimedBytes}
+}
+
+func (r *retentionPolicyImpactResolver) RetainedBytes() gql.BigInt {
+	return gql.BigInt{Int: r.impact.RetainedBytes}
+}
+
+func (r *retentionPolicyImpactResolver) UnsizedUploads() int32 {
+	return int32(r.impact.UnsizedUploads)
+}
+
+func (r *retentionPolicyImpactResolver) uploadResolvers(ctx context.Context, ids []int) ([]gql.LSIFUploadResolver, error) {
+	uploadResolvers := make([]gql.LSIFUploadResolver, 0, len(ids))
+	for _, id := range ids {
+		upload, exists, err := r.prefetcher.GetUploadByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			// The upload has been deleted since the impact was computed
+			continue
+		}
+
+		uploadResolvers = append(uploadResolvers, NewUploadResolver(r.db, r.gitserver, r.resolver, upload, r.prefetcher, r.locationResolver, r.traceErrs))
+	}
+
+	return uploadResolvers, nil
+}
@@ -0,0 +1,288 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/go-lsp"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type SemanticDiffResolver struct {
+	diff             shared.SemanticDiff
+	base             dbstore.Upload
+	head             dbstore.Upload
+	gitserver        GitserverClient
+	resolver         resolvers.Resolver
+	prefetcher       *Prefetcher
+	locationResolver *CachedLocationResolver
+	errTracer        *observation.ErrCollector
+}
+
+func NewSemanticDiffResolver(diff shared.SemanticDiff, base, head dbstore.Upload, gitserver GitserverClient, resolver resolvers.Resolver, prefetcher *Prefetcher, locationResolver *CachedLocationResolver, errTracer *observation.ErrCollector) gql.CodeIntelSemanticDiffResolver {
+	return &SemanticDiffResolver{
+		diff:             diff,
+		base:             base,
+		head:             head,
+		gitserver:        gitserver,
+		resolver:         resolver,
+		prefetcher:       prefetcher,
+		locationResolver: locationResolver,
+		errTracer:        errTracer,
+	}
+}
+
+func (r *SemanticDiffResolver) Base(ctx context.Context) (gql.LSIFUploadResolver, error) {
+	return NewUploadResolver(r.locationResolver.db, r.gitserver, r.resolver, r.base, r.prefetcher, r.locationResolver, r.errTracer), nil
+}
+
+func (r *SemanticDiffResolver) Head(ctx context.Context) (gql.LSIFUploadResolver, error) {
+	return NewUploadResolver(r.locationResolver.db, r.gitserver, r.resolver, r.head, r.prefetcher, r.locationResolver, r.errTracer), nil
+}
+
+func (r *SemanticDiffResolver) AddedDefinitions() []gql.CodeIntelSemanticDiffSymbolResolver {
+	return r.symbolResolvers(r.head, r.diff.AddedDefinitions)
+}
+
+func (r *SemanticDiffResolver) RemovedDefinitions() []gql.CodeIntelSemanticDiffSymbolResolver {
+	return r.symbolResolvers(r.base, r.diff.RemovedDefinitions)
+}
+
+func (r *SemanticDiffResolver) ChangedDefinitions() []gql.CodeIntelSemanticDiffChangeResolver {
+	resolvers := make([]gql.CodeIntelSemanticDiffChangeResolver, 0, len(r.diff.ChangedDefinitions))
+	for _, change := range r.diff.ChangedDefinitions {
+		resolvers = append(resolvers, &SemanticDiffChangeResolver{
+			base: r.symbolResolver(r.base, change.Base),
+			head: r.symbolResolver(r.head, change.Head),
+		})
+	}
+
+	return resolvers
+}
+
+func (r *SemanticDiffResolver) BrokenReferences() []gql.CodeIntelSemanticDiffReferenceResolver {
+	resolvers := make([]gql.CodeIntelSemanticDiffReferenceResolver, 0, len(r.diff.BrokenReferences))
+	for _, reference := range r.diff.BrokenReferences {
+		resolvers = append(resolvers, &SemanticDiffReferenceResolver{
+			reference:        reference,
+			upload:           r.head,
+			locationResolver: r.locationResolver,
+		})
+	}
+
+	return resolvers
+}
+
+func (r *SemanticDiffResolver) PageInfo() *graphqlutil.PageInfo {
+	if r.diff.Next == nil {
+		return graphqlutil.HasNextPage(false)
+	}
+
+	cursor, _ := json.Marshal(semanticDiffCursor{
+		BaseUploadID: r.diff.BaseUploadID,
+		HeadUploadID: r.diff.HeadUploadID,
+		After:        *r.diff.Next,
+	})
+	return graphqlutil.EncodeCursor(strPtr(string(cursor)))
+}
+
+// semanticDiffCursor continues the comparison of a pair of uploads. The pairs of uploads compared
+// for two commits are paged separately, so the cursor identifies the pair it belongs to.
+type semanticDiffCursor struct {
+	BaseUploadID int
+	HeadUploadID int
+	After        shared.SymbolKey
+}
+
+func (r *SemanticDiffResolver) symbolResolvers(upload dbstore.Upload, symbols []shared.Symbol) []gql.CodeIntelSemanticDiffSymbolResolver {
+	resolvers := make([]gql.CodeIntelSemanticDiffSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, r.symbolResolver(upload, symbol))
+	}
+
+	return resolvers
+}
+
+func (r *SemanticDiffResolver) symbolResolver(upload dbstore.Upload, symbol shared.Symbol) gql.CodeIntelSemanticDiffSymbolResolver {
+	return &SemanticDiffSymbolResolver{symbol: symbol, upload: upload, locationResolver: r.locationResolver}
+}
+
+type SemanticDiffSymbolResolver struct {
+	symbol           shared.Symbol
+	upload           dbstore.Upload
+	locationResolver *CachedLocationResolver
+}
+
+func (r *SemanticDiffSymbolResolver) Scheme() string     { return r.symbol.Scheme }
+func (r *SemanticDiffSymbolResolver) Identifier() string { return r.symbol.Identifier }
+
+func (r *SemanticDiffSymbolResolver) Location(ctx context.Context) (gql.LocationResolver, error) {
+	return resolveUploadLocation(ctx, r.locationResolver, r.upload, r.symbol.Location)
+}
+
+func (r *SemanticDiffSymbolResolver) Hover() *gql.Markdown {
+	if r.symbol.Hover == "" {
+		return nil
+	}
+
+	hover := gql.Markdown(r.symbol.Hover)
+	return &hover
+}
+
+type SemanticDiffChangeResolver struct {
+	base gql.CodeIntelSemanticDiffSymbolResolver
+	head gql.CodeIntelSemanticDiffSymbolResolver
+}
+
+func (r *SemanticDiffChangeResolver) Base() gql.CodeIntelSemanticDiffSymbolResolver { return r.base }
+func (r *SemanticDiffChangeResolver) Head() gql.CodeIntelSemanticDiffSymbolResolver { return r.head }
+
+type SemanticDiffReferenceResolver struct {
+	reference        shared.SymbolReference
+	upload           dbstore.Upload
+	locationResolver *CachedLocationResolver
+}
+
+func (r *SemanticDiffReferenceResolver) Scheme() string     { return r.reference.Scheme }
+func (r *SemanticDiffReferenceResolver) Identifier() string { return r.reference.Identifier }
+
+func (r *SemanticDiffReferenceResolver) Location(ctx context.Context) (gql.LocationResolver, error) {
+	return resolveUploadLocation(ctx, r.locationResolver, r.upload, r.reference.Location)
+}
+
+// resolveUploadLocation creates a LocationResolver for the given location at the commit of the given
+// upload. This function may return a nil resolver if the upload's commit is not known by gitserver.
+func resolveUploadLocation(ctx context.Context, locationResolver *CachedLocationResolver, upload dbstore.Upload, location shared.Location) (gql.LocationResolver, error) {
+	treeResolver, err := locationResolver.Path(ctx, api.RepoID(upload.RepositoryID), upload.Commit, location.Path)
+	if err != nil || treeResolver == nil {
+		return nil, err
+	}
+
+	lspRange := lsp.Range{
+		Start: convertPosition(location.Range.Start.Line, location.Range.Start.Character),
+		End:   convertPosition(location.Range.End.Line, location.Range.End.Character),
+	}
+	return gql.NewLocationResolver(treeResolver, &lspRange), nil
+}
+
+// 🚨 SECURITY: dbstore layer handles authz for GetUploadByID
+func (r *UploadResolver) SemanticDiff(ctx context.Context, args *gql.LSIFUploadSemanticDiffArgs) (_ gql.CodeIntelSemanticDiffResolver, err error) {
+	defer r.traceErrs.Collect(&err, log.String("uploadResolver.field", "semanticDiff"))
+
+	baseID, err := unmarshalLSIFUploadGQLID(args.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	base, exists, err := r.prefetcher.GetUploadByID(ctx, int(baseID))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	opts, err := makeSemanticDiffOpts(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+	if opts.After != nil && (opts.BaseUploadID != base.ID || opts.HeadUploadID != r.upload.ID) {
+		return nil, errors.New("invalid cursor: the cursor belongs to a comparison of other uploads")
+	}
+
+	diff, exists, err := r.resolver.DocumentsResolver().SemanticDiff(ctx, base.ID, r.upload.ID, opts)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return NewSemanticDiffResolver(diff, base, r.upload, r.gitserver, r.resolver, r.prefetcher, r.locationResolver, r.traceErrs), nil
+}
+
+// 🚨 SECURITY: The repository resolver checks that the user can read the repository before
+// resolving the requested revisions, and the dbstore layer handles authz for GetUploadByID
+func (r *Resolver) CodeIntelSemanticDiff(ctx context.Context, args *gql.CodeIntelSemanticDiffArgs) (_ []gql.CodeIntelSemanticDiffResolver, err error) {
+	ctx, traceErrs, endObservation := r.observationContext.codeIntelSemanticDiff.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(args.RepositoryID)),
+		log.String("baseCommit", string(args.BaseCommit)),
+		log.String("headCommit", string(args.HeadCommit)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := gql.UnmarshalRepositoryID(args.RepositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := makeSemanticDiffOpts(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := r.resolver.DocumentsResolver().CommitSemanticDiff(ctx, int(repositoryID), string(args.BaseCommit), string(args.HeadCommit), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := NewPrefetcher(r.resolver)
+	for _, diff := range diffs {
+		prefetcher.MarkUpload(diff.BaseUploadID)
+		prefetcher.MarkUpload(diff.HeadUploadID)
+	}
+
+	resolvers := make([]gql.CodeIntelSemanticDiffResolver, 0, len(diffs))
+	for _, diff := range diffs {
+		base, exists, err := prefetcher.GetUploadByID(ctx, diff.BaseUploadID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.Newf("upload %d not found", diff.BaseUploadID)
+		}
+		head, exists, err := prefetcher.GetUploadByID(ctx, diff.HeadUploadID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.Newf("upload %d not found", diff.HeadUploadID)
+		}
+
+		resolvers = append(resolvers, NewSemanticDiffResolver(diff, base, head, r.gitserver, r.resolver, prefetcher, r.locationResolver, traceErrs))
+	}
+
+	return resolvers, nil
+}
+
+// makeSemanticDiffOpts validates the given page arguments of a semantic diff.
+func makeSemanticDiffOpts(first *int32, after *string) (documents.SemanticDiffOpts, error) {
+	limit := derefInt32(first, DefaultSemanticDiffPageSize)
+	if limit <= 0 {
+		return documents.SemanticDiffOpts{}, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(after)
+	if err != nil {
+		return documents.SemanticDiffOpts{}, err
+	}
+
+	opts := documents.SemanticDiffOpts{Limit: limit}
+	if cursor != "" {
+		var c semanticDiffCursor
+		if err := json.Unmarshal([]byte(cursor), &c); err != nil {
+			return documents.SemanticDiffOpts{}, errors.Wrap(err, "invalid cursor")
+		}
+		opts.After = &c.After
+		opts.BaseUploadID = c.BaseUploadID
+		opts.HeadUploadID = c.HeadUploadID
+	}
+
+	return opts, nil
+}
@@ -12,6 +12,7 @@ type AdjustedLocation struct {
 	Path           string
 	AdjustedCommit string
 	AdjustedRange  lsifstore.Range
+	Confidence     *float64 // nil if the adjusted range is exact
 }
 
 // AdjustedDiagnostic is a diagnostic from within a particular upload. The adjusted commit denotes
@@ -135,13 +135,7 @@ func (r *UploadResolver) RetentionPolicyOverview(ctx context.Context, args *gql.
 }
 
 func (r *UploadResolver) Indexer() gql.CodeIntelIndexerResolver {
-	for _, indexer := range allIndexers {
-		if indexer.Name() == r.upload.Indexer {
-			return indexer
-		}
-	}
-
-	return &codeIntelIndexerResolver{name: r.upload.Indexer}
+	return indexerByName(r.upload.Indexer)
 }
 
 func (r *UploadResolver) DocumentPaths(ctx context.Context, args *gql.LSIFUploadDocumentPathsQueryArgs) (gql.LSIFUploadDocumentPathsConnectionResolver, error) {
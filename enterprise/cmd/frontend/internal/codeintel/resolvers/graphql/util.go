@@ -201,6 +201,7 @@ func uploadLocationToAdjustedLocations(location []shared.UploadLocation) []Adjus
 			Path:           loc.Path,
 			AdjustedCommit: loc.TargetCommit,
 			AdjustedRange:  adjustedRange,
+			Confidence:     loc.Confidence,
 		})
 	}
 
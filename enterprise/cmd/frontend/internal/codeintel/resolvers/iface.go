@@ -7,9 +7,12 @@ import (
 	autoindexingShared "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared"
 	autoindexinggraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/transport/graphql"
 	codenavgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/transport/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
+	documentsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
 	policiesgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
@@ -38,6 +41,16 @@ type CodeNavResolver interface {
 	GitBlobLSIFDataResolverFactory(ctx context.Context, repo *types.Repo, commit, path, toolName string, exactPath bool) (_ codenavgraphql.GitBlobLSIFDataResolver, err error)
 }
 
+type DocumentsResolver interface {
+	Document(ctx context.Context, opts documents.DocumentOpts) (_ []documentsshared.Document, err error)
+	SemanticDiff(ctx context.Context, baseUploadID, headUploadID int, opts documents.SemanticDiffOpts) (_ documentsshared.SemanticDiff, _ bool, err error)
+	CommitSemanticDiff(ctx context.Context, repositoryID int, baseCommit, headCommit string, opts documents.SemanticDiffOpts) (_ []documentsshared.SemanticDiff, err error)
+}
+
+type UploadsServiceResolver interface {
+	GetCoverageReport(ctx context.Context, repositoryID int, commit, path string, limit int) (_ []uploadsshared.DirectoryCoverage, err error)
+}
+
 type PoliciesResolver interface {
 	PolicyResolverFactory(ctx context.Context) (_ policiesgraphql.PolicyResolver, err error)
 }
@@ -55,5 +68,8 @@ type AutoIndexingResolver interface {
 	InferedIndexConfigurationHints(ctx context.Context, repositoryID int, commit string) ([]config.IndexJobHint, error)       // in the service InferIndexConfiguration second return
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, configuration string) error                 // simple dbstore
 
+	GetInferenceScript(ctx context.Context, repositoryID int) (string, bool, error)
+	UpdateInferenceScript(ctx context.Context, repositoryID int, script string) error
+
 	IndexConnectionResolverFromFactory(opts autoindexingShared.GetIndexesOptions) *autoindexinggraphql.IndexesResolver
 }
@@ -13,10 +13,439 @@ import (
 	graphqlbackend "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	resolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	documents "github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
 	dbstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	graphql "github.com/sourcegraph/sourcegraph/internal/services/executors/transport/graphql"
 )
 
+// MockDocumentsResolver is a mock implementation of the DocumentsResolver
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockDocumentsResolver struct {
+	// CommitSemanticDiffFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitSemanticDiff.
+	CommitSemanticDiffFunc *DocumentsResolverCommitSemanticDiffFunc
+	// DocumentFunc is an instance of a mock function object controlling the
+	// behavior of the method Document.
+	DocumentFunc *DocumentsResolverDocumentFunc
+	// SemanticDiffFunc is an instance of a mock function object controlling
+	// the behavior of the method SemanticDiff.
+	SemanticDiffFunc *DocumentsResolverSemanticDiffFunc
+}
+
+// NewMockDocumentsResolver creates a new mock of the DocumentsResolver
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockDocumentsResolver() *MockDocumentsResolver {
+	return &MockDocumentsResolver{
+		CommitSemanticDiffFunc: &DocumentsResolverCommitSemanticDiffFunc{
+			defaultHook: func(context.Context, int, string, string, documents.SemanticDiffOpts) (r0 []shared.SemanticDiff, r1 error) {
+				return
+			},
+		},
+		DocumentFunc: &DocumentsResolverDocumentFunc{
+			defaultHook: func(context.Context, documents.DocumentOpts) (r0 []shared.Document, r1 error) {
+				return
+			},
+		},
+		SemanticDiffFunc: &DocumentsResolverSemanticDiffFunc{
+			defaultHook: func(context.Context, int, int, documents.SemanticDiffOpts) (r0 shared.SemanticDiff, r1 bool, r2 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockDocumentsResolver creates a new mock of the
+// DocumentsResolver interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockDocumentsResolver() *MockDocumentsResolver {
+	return &MockDocumentsResolver{
+		CommitSemanticDiffFunc: &DocumentsResolverCommitSemanticDiffFunc{
+			defaultHook: func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error) {
+				panic("unexpected invocation of MockDocumentsResolver.CommitSemanticDiff")
+			},
+		},
+		DocumentFunc: &DocumentsResolverDocumentFunc{
+			defaultHook: func(context.Context, documents.DocumentOpts) ([]shared.Document, error) {
+				panic("unexpected invocation of MockDocumentsResolver.Document")
+			},
+		},
+		SemanticDiffFunc: &DocumentsResolverSemanticDiffFunc{
+			defaultHook: func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error) {
+				panic("unexpected invocation of MockDocumentsResolver.SemanticDiff")
+			},
+		},
+	}
+}
+
+// NewMockDocumentsResolverFrom creates a new mock of the
+// MockDocumentsResolver interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockDocumentsResolverFrom(i resolvers.DocumentsResolver) *MockDocumentsResolver {
+	return &MockDocumentsResolver{
+		CommitSemanticDiffFunc: &DocumentsResolverCommitSemanticDiffFunc{
+			defaultHook: i.CommitSemanticDiff,
+		},
+		DocumentFunc: &DocumentsResolverDocumentFunc{
+			defaultHook: i.Document,
+		},
+		SemanticDiffFunc: &DocumentsResolverSemanticDiffFunc{
+			defaultHook: i.SemanticDiff,
+		},
+	}
+}
+
+// DocumentsResolverCommitSemanticDiffFunc describes the behavior when the
+// CommitSemanticDiff method of the parent MockDocumentsResolver instance is
+// invoked.
+type DocumentsResolverCommitSemanticDiffFunc struct {
+	defaultHook func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error)
+	hooks       []func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error)
+	history     []DocumentsResolverCommitSemanticDiffFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitSemanticDiff delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDocumentsResolver) CommitSemanticDiff(v0 context.Context, v1 int, v2 string, v3 string, v4 documents.SemanticDiffOpts) ([]shared.SemanticDiff, error) {
+	r0, r1 := m.CommitSemanticDiffFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CommitSemanticDiffFunc.appendCall(DocumentsResolverCommitSemanticDiffFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitSemanticDiff
+// method of the parent MockDocumentsResolver instance is invoked and the
+// hook queue is empty.
+func (f *DocumentsResolverCommitSemanticDiffFunc) SetDefaultHook(hook func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitSemanticDiff method of the parent MockDocumentsResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DocumentsResolverCommitSemanticDiffFunc) PushHook(hook func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DocumentsResolverCommitSemanticDiffFunc) SetDefaultReturn(r0 []shared.SemanticDiff, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DocumentsResolverCommitSemanticDiffFunc) PushReturn(r0 []shared.SemanticDiff, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error) {
+		return r0, r1
+	})
+}
+
+func (f *DocumentsResolverCommitSemanticDiffFunc) nextHook() func(context.Context, int, string, string, documents.SemanticDiffOpts) ([]shared.SemanticDiff, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DocumentsResolverCommitSemanticDiffFunc) appendCall(r0 DocumentsResolverCommitSemanticDiffFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DocumentsResolverCommitSemanticDiffFuncCall
+// objects describing the invocations of this function.
+func (f *DocumentsResolverCommitSemanticDiffFunc) History() []DocumentsResolverCommitSemanticDiffFuncCall {
+	f.mutex.Lock()
+	history := make([]DocumentsResolverCommitSemanticDiffFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DocumentsResolverCommitSemanticDiffFuncCall is an object that describes
+// an invocation of method CommitSemanticDiff on an instance of
+// MockDocumentsResolver.
+type DocumentsResolverCommitSemanticDiffFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 documents.SemanticDiffOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SemanticDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DocumentsResolverCommitSemanticDiffFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DocumentsResolverCommitSemanticDiffFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DocumentsResolverDocumentFunc describes the behavior when the Document
+// method of the parent MockDocumentsResolver instance is invoked.
+type DocumentsResolverDocumentFunc struct {
+	defaultHook func(context.Context, documents.DocumentOpts) ([]shared.Document, error)
+	hooks       []func(context.Context, documents.DocumentOpts) ([]shared.Document, error)
+	history     []DocumentsResolverDocumentFuncCall
+	mutex       sync.Mutex
+}
+
+// Document delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockDocumentsResolver) Document(v0 context.Context, v1 documents.DocumentOpts) ([]shared.Document, error) {
+	r0, r1 := m.DocumentFunc.nextHook()(v0, v1)
+	m.DocumentFunc.appendCall(DocumentsResolverDocumentFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Document method of
+// the parent MockDocumentsResolver instance is invoked and the hook queue
+// is empty.
+func (f *DocumentsResolverDocumentFunc) SetDefaultHook(hook func(context.Context, documents.DocumentOpts) ([]shared.Document, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Document method of the parent MockDocumentsResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DocumentsResolverDocumentFunc) PushHook(hook func(context.Context, documents.DocumentOpts) ([]shared.Document, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DocumentsResolverDocumentFunc) SetDefaultReturn(r0 []shared.Document, r1 error) {
+	f.SetDefaultHook(func(context.Context, documents.DocumentOpts) ([]shared.Document, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DocumentsResolverDocumentFunc) PushReturn(r0 []shared.Document, r1 error) {
+	f.PushHook(func(context.Context, documents.DocumentOpts) ([]shared.Document, error) {
+		return r0, r1
+	})
+}
+
+func (f *DocumentsResolverDocumentFunc) nextHook() func(context.Context, documents.DocumentOpts) ([]shared.Document, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DocumentsResolverDocumentFunc) appendCall(r0 DocumentsResolverDocumentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DocumentsResolverDocumentFuncCall objects
+// describing the invocations of this function.
+func (f *DocumentsResolverDocumentFunc) History() []DocumentsResolverDocumentFuncCall {
+	f.mutex.Lock()
+	history := make([]DocumentsResolverDocumentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DocumentsResolverDocumentFuncCall is an object that describes an
+// invocation of method Document on an instance of MockDocumentsResolver.
+type DocumentsResolverDocumentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 documents.DocumentOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Document
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DocumentsResolverDocumentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DocumentsResolverDocumentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DocumentsResolverSemanticDiffFunc describes the behavior when the
+// SemanticDiff method of the parent MockDocumentsResolver instance is
+// invoked.
+type DocumentsResolverSemanticDiffFunc struct {
+	defaultHook func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error)
+	hooks       []func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error)
+	history     []DocumentsResolverSemanticDiffFuncCall
+	mutex       sync.Mutex
+}
+
+// SemanticDiff delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDocumentsResolver) SemanticDiff(v0 context.Context, v1 int, v2 int, v3 documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error) {
+	r0, r1, r2 := m.SemanticDiffFunc.nextHook()(v0, v1, v2, v3)
+	m.SemanticDiffFunc.appendCall(DocumentsResolverSemanticDiffFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the SemanticDiff method
+// of the parent MockDocumentsResolver instance is invoked and the hook
+// queue is empty.
+func (f *DocumentsResolverSemanticDiffFunc) SetDefaultHook(hook func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SemanticDiff method of the parent MockDocumentsResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DocumentsResolverSemanticDiffFunc) PushHook(hook func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DocumentsResolverSemanticDiffFunc) SetDefaultReturn(r0 shared.SemanticDiff, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DocumentsResolverSemanticDiffFunc) PushReturn(r0 shared.SemanticDiff, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DocumentsResolverSemanticDiffFunc) nextHook() func(context.Context, int, int, documents.SemanticDiffOpts) (shared.SemanticDiff, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DocumentsResolverSemanticDiffFunc) appendCall(r0 DocumentsResolverSemanticDiffFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DocumentsResolverSemanticDiffFuncCall
+// objects describing the invocations of this function.
+func (f *DocumentsResolverSemanticDiffFunc) History() []DocumentsResolverSemanticDiffFuncCall {
+	f.mutex.Lock()
+	history := make([]DocumentsResolverSemanticDiffFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DocumentsResolverSemanticDiffFuncCall is an object that describes an
+// invocation of method SemanticDiff on an instance of
+// MockDocumentsResolver.
+type DocumentsResolverSemanticDiffFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 documents.SemanticDiffOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.SemanticDiff
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DocumentsResolverSemanticDiffFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DocumentsResolverSemanticDiffFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockResolver is a mock implementation of the Resolver interface (from the
 // package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -37,6 +466,9 @@ type MockResolver struct {
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *ResolverDeleteUploadByIDFunc
+	// DocumentsResolverFunc is an instance of a mock function object
+	// controlling the behavior of the method DocumentsResolver.
+	DocumentsResolverFunc *ResolverDocumentsResolverFunc
 	// ExecutorResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method ExecutorResolver.
 	ExecutorResolverFunc *ResolverExecutorResolverFunc
@@ -68,6 +500,9 @@ type MockResolver struct {
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
+	// UploadsServiceResolverFunc is an instance of a mock function object
+	// controlling the behavior of the method UploadsServiceResolver.
+	UploadsServiceResolverFunc *ResolverUploadsServiceResolverFunc
 }
 
 // NewMockResolver creates a new mock of the Resolver interface. All methods
@@ -99,6 +534,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		DocumentsResolverFunc: &ResolverDocumentsResolverFunc{
+			defaultHook: func() (r0 resolvers.DocumentsResolver) {
+				return
+			},
+		},
 		ExecutorResolverFunc: &ResolverExecutorResolverFunc{
 			defaultHook: func() (r0 graphql.Resolver) {
 				return
@@ -149,6 +589,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		UploadsServiceResolverFunc: &ResolverUploadsServiceResolverFunc{
+			defaultHook: func() (r0 resolvers.UploadsServiceResolver) {
+				return
+			},
+		},
 	}
 }
 
@@ -181,6 +626,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.DeleteUploadByID")
 			},
 		},
+		DocumentsResolverFunc: &ResolverDocumentsResolverFunc{
+			defaultHook: func() resolvers.DocumentsResolver {
+				panic("unexpected invocation of MockResolver.DocumentsResolver")
+			},
+		},
 		ExecutorResolverFunc: &ResolverExecutorResolverFunc{
 			defaultHook: func() graphql.Resolver {
 				panic("unexpected invocation of MockResolver.ExecutorResolver")
@@ -231,6 +681,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.UploadConnectionResolver")
 			},
 		},
+		UploadsServiceResolverFunc: &ResolverUploadsServiceResolverFunc{
+			defaultHook: func() resolvers.UploadsServiceResolver {
+				panic("unexpected invocation of MockResolver.UploadsServiceResolver")
+			},
+		},
 	}
 }
 
@@ -253,6 +708,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DocumentsResolverFunc: &ResolverDocumentsResolverFunc{
+			defaultHook: i.DocumentsResolver,
+		},
 		ExecutorResolverFunc: &ResolverExecutorResolverFunc{
 			defaultHook: i.ExecutorResolver,
 		},
@@ -283,6 +741,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
+		UploadsServiceResolverFunc: &ResolverUploadsServiceResolverFunc{
+			defaultHook: i.UploadsServiceResolver,
+		},
 	}
 }
 
@@ -806,6 +1267,105 @@ func (c ResolverDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverDocumentsResolverFunc describes the behavior when the
+// DocumentsResolver method of the parent MockResolver instance is invoked.
+type ResolverDocumentsResolverFunc struct {
+	defaultHook func() resolvers.DocumentsResolver
+	hooks       []func() resolvers.DocumentsResolver
+	history     []ResolverDocumentsResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// DocumentsResolver delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) DocumentsResolver() resolvers.DocumentsResolver {
+	r0 := m.DocumentsResolverFunc.nextHook()()
+	m.DocumentsResolverFunc.appendCall(ResolverDocumentsResolverFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DocumentsResolver
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverDocumentsResolverFunc) SetDefaultHook(hook func() resolvers.DocumentsResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DocumentsResolver method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverDocumentsResolverFunc) PushHook(hook func() resolvers.DocumentsResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverDocumentsResolverFunc) SetDefaultReturn(r0 resolvers.DocumentsResolver) {
+	f.SetDefaultHook(func() resolvers.DocumentsResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverDocumentsResolverFunc) PushReturn(r0 resolvers.DocumentsResolver) {
+	f.PushHook(func() resolvers.DocumentsResolver {
+		return r0
+	})
+}
+
+func (f *ResolverDocumentsResolverFunc) nextHook() func() resolvers.DocumentsResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDocumentsResolverFunc) appendCall(r0 ResolverDocumentsResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverDocumentsResolverFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverDocumentsResolverFunc) History() []ResolverDocumentsResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDocumentsResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDocumentsResolverFuncCall is an object that describes an
+// invocation of method DocumentsResolver on an instance of MockResolver.
+type ResolverDocumentsResolverFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.DocumentsResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDocumentsResolverFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDocumentsResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverExecutorResolverFunc describes the behavior when the
 // ExecutorResolver method of the parent MockResolver instance is invoked.
 type ResolverExecutorResolverFunc struct {
@@ -1894,3 +2454,272 @@ func (c ResolverUploadConnectionResolverFuncCall) Args() []interface{} {
 func (c ResolverUploadConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ResolverUploadsServiceResolverFunc describes the behavior when the
+// UploadsServiceResolver method of the parent MockResolver instance is
+// invoked.
+type ResolverUploadsServiceResolverFunc struct {
+	defaultHook func() resolvers.UploadsServiceResolver
+	hooks       []func() resolvers.UploadsServiceResolver
+	history     []ResolverUploadsServiceResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadsServiceResolver delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) UploadsServiceResolver() resolvers.UploadsServiceResolver {
+	r0 := m.UploadsServiceResolverFunc.nextHook()()
+	m.UploadsServiceResolverFunc.appendCall(ResolverUploadsServiceResolverFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UploadsServiceResolver method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverUploadsServiceResolverFunc) SetDefaultHook(hook func() resolvers.UploadsServiceResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadsServiceResolver method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverUploadsServiceResolverFunc) PushHook(hook func() resolvers.UploadsServiceResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverUploadsServiceResolverFunc) SetDefaultReturn(r0 resolvers.UploadsServiceResolver) {
+	f.SetDefaultHook(func() resolvers.UploadsServiceResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverUploadsServiceResolverFunc) PushReturn(r0 resolvers.UploadsServiceResolver) {
+	f.PushHook(func() resolvers.UploadsServiceResolver {
+		return r0
+	})
+}
+
+func (f *ResolverUploadsServiceResolverFunc) nextHook() func() resolvers.UploadsServiceResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUploadsServiceResolverFunc) appendCall(r0 ResolverUploadsServiceResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUploadsServiceResolverFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverUploadsServiceResolverFunc) History() []ResolverUploadsServiceResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUploadsServiceResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUploadsServiceResolverFuncCall is an object that describes an
+// invocation of method UploadsServiceResolver on an instance of
+// MockResolver.
+type ResolverUploadsServiceResolverFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.UploadsServiceResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUploadsServiceResolverFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUploadsServiceResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockUploadsServiceResolver is a mock implementation of the
+// UploadsServiceResolver interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockUploadsServiceResolver struct {
+	// GetCoverageReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCoverageReport.
+	GetCoverageReportFunc *UploadsServiceResolverGetCoverageReportFunc
+}
+
+// NewMockUploadsServiceResolver creates a new mock of the
+// UploadsServiceResolver interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockUploadsServiceResolver() *MockUploadsServiceResolver {
+	return &MockUploadsServiceResolver{
+		GetCoverageReportFunc: &UploadsServiceResolverGetCoverageReportFunc{
+			defaultHook: func(context.Context, int, string, string, int) (r0 []shared1.DirectoryCoverage, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockUploadsServiceResolver creates a new mock of the
+// UploadsServiceResolver interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockUploadsServiceResolver() *MockUploadsServiceResolver {
+	return &MockUploadsServiceResolver{
+		GetCoverageReportFunc: &UploadsServiceResolverGetCoverageReportFunc{
+			defaultHook: func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error) {
+				panic("unexpected invocation of MockUploadsServiceResolver.GetCoverageReport")
+			},
+		},
+	}
+}
+
+// NewMockUploadsServiceResolverFrom creates a new mock of the
+// MockUploadsServiceResolver interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockUploadsServiceResolverFrom(i resolvers.UploadsServiceResolver) *MockUploadsServiceResolver {
+	return &MockUploadsServiceResolver{
+		GetCoverageReportFunc: &UploadsServiceResolverGetCoverageReportFunc{
+			defaultHook: i.GetCoverageReport,
+		},
+	}
+}
+
+// UploadsServiceResolverGetCoverageReportFunc describes the behavior when
+// the GetCoverageReport method of the parent MockUploadsServiceResolver
+// instance is invoked.
+type UploadsServiceResolverGetCoverageReportFunc struct {
+	defaultHook func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error)
+	hooks       []func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error)
+	history     []UploadsServiceResolverGetCoverageReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCoverageReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadsServiceResolver) GetCoverageReport(v0 context.Context, v1 int, v2 string, v3 string, v4 int) ([]shared1.DirectoryCoverage, error) {
+	r0, r1 := m.GetCoverageReportFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetCoverageReportFunc.appendCall(UploadsServiceResolverGetCoverageReportFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetCoverageReport
+// method of the parent MockUploadsServiceResolver instance is invoked and
+// the hook queue is empty.
+func (f *UploadsServiceResolverGetCoverageReportFunc) SetDefaultHook(hook func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCoverageReport method of the parent MockUploadsServiceResolver
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadsServiceResolverGetCoverageReportFunc) PushHook(hook func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceResolverGetCoverageReportFunc) SetDefaultReturn(r0 []shared1.DirectoryCoverage, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceResolverGetCoverageReportFunc) PushReturn(r0 []shared1.DirectoryCoverage, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadsServiceResolverGetCoverageReportFunc) nextHook() func(context.Context, int, string, string, int) ([]shared1.DirectoryCoverage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceResolverGetCoverageReportFunc) appendCall(r0 UploadsServiceResolverGetCoverageReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadsServiceResolverGetCoverageReportFuncCall objects describing the
+// invocations of this function.
+func (f *UploadsServiceResolverGetCoverageReportFunc) History() []UploadsServiceResolverGetCoverageReportFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceResolverGetCoverageReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceResolverGetCoverageReportFuncCall is an object that
+// describes an invocation of method GetCoverageReport on an instance of
+// MockUploadsServiceResolver.
+type UploadsServiceResolverGetCoverageReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.DirectoryCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceResolverGetCoverageReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceResolverGetCoverageReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -31,9 +31,15 @@ type MockGitBlobLSIFDataResolver struct {
 	// ImplementationsFunc is an instance of a mock function object
 	// controlling the behavior of the method Implementations.
 	ImplementationsFunc *GitBlobLSIFDataResolverImplementationsFunc
+	// IncomingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method IncomingCalls.
+	IncomingCallsFunc *GitBlobLSIFDataResolverIncomingCallsFunc
 	// LSIFUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method LSIFUploads.
 	LSIFUploadsFunc *GitBlobLSIFDataResolverLSIFUploadsFunc
+	// OutgoingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutgoingCalls.
+	OutgoingCallsFunc *GitBlobLSIFDataResolverOutgoingCallsFunc
 	// RangesFunc is an instance of a mock function object controlling the
 	// behavior of the method Ranges.
 	RangesFunc *GitBlobLSIFDataResolverRangesFunc
@@ -70,11 +76,21 @@ func NewMockGitBlobLSIFDataResolver() *MockGitBlobLSIFDataResolver {
 				return
 			},
 		},
+		IncomingCallsFunc: &GitBlobLSIFDataResolverIncomingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+				return
+			},
+		},
 		LSIFUploadsFunc: &GitBlobLSIFDataResolverLSIFUploadsFunc{
 			defaultHook: func(context.Context) (r0 []shared.Dump, r1 error) {
 				return
 			},
 		},
+		OutgoingCallsFunc: &GitBlobLSIFDataResolverOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+				return
+			},
+		},
 		RangesFunc: &GitBlobLSIFDataResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) (r0 []shared.AdjustedCodeIntelligenceRange, r1 error) {
 				return
@@ -118,11 +134,21 @@ func NewStrictMockGitBlobLSIFDataResolver() *MockGitBlobLSIFDataResolver {
 				panic("unexpected invocation of MockGitBlobLSIFDataResolver.Implementations")
 			},
 		},
+		IncomingCallsFunc: &GitBlobLSIFDataResolverIncomingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+				panic("unexpected invocation of MockGitBlobLSIFDataResolver.IncomingCalls")
+			},
+		},
 		LSIFUploadsFunc: &GitBlobLSIFDataResolverLSIFUploadsFunc{
 			defaultHook: func(context.Context) ([]shared.Dump, error) {
 				panic("unexpected invocation of MockGitBlobLSIFDataResolver.LSIFUploads")
 			},
 		},
+		OutgoingCallsFunc: &GitBlobLSIFDataResolverOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+				panic("unexpected invocation of MockGitBlobLSIFDataResolver.OutgoingCalls")
+			},
+		},
 		RangesFunc: &GitBlobLSIFDataResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) ([]shared.AdjustedCodeIntelligenceRange, error) {
 				panic("unexpected invocation of MockGitBlobLSIFDataResolver.Ranges")
@@ -158,9 +184,15 @@ func NewMockGitBlobLSIFDataResolverFrom(i graphql.GitBlobLSIFDataResolver) *Mock
 		ImplementationsFunc: &GitBlobLSIFDataResolverImplementationsFunc{
 			defaultHook: i.Implementations,
 		},
+		IncomingCallsFunc: &GitBlobLSIFDataResolverIncomingCallsFunc{
+			defaultHook: i.IncomingCalls,
+		},
 		LSIFUploadsFunc: &GitBlobLSIFDataResolverLSIFUploadsFunc{
 			defaultHook: i.LSIFUploads,
 		},
+		OutgoingCallsFunc: &GitBlobLSIFDataResolverOutgoingCallsFunc{
+			defaultHook: i.OutgoingCalls,
+		},
 		RangesFunc: &GitBlobLSIFDataResolverRangesFunc{
 			defaultHook: i.Ranges,
 		},
@@ -642,6 +674,130 @@ func (c GitBlobLSIFDataResolverImplementationsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// GitBlobLSIFDataResolverIncomingCallsFunc describes the behavior when the
+// IncomingCalls method of the parent MockGitBlobLSIFDataResolver instance
+// is invoked.
+type GitBlobLSIFDataResolverIncomingCallsFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)
+	history     []GitBlobLSIFDataResolverIncomingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// IncomingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitBlobLSIFDataResolver) IncomingCalls(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]shared.CallHierarchyCall, string, error) {
+	r0, r1, r2 := m.IncomingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.IncomingCallsFunc.appendCall(GitBlobLSIFDataResolverIncomingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the IncomingCalls method
+// of the parent MockGitBlobLSIFDataResolver instance is invoked and the
+// hook queue is empty.
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IncomingCalls method of the parent MockGitBlobLSIFDataResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) PushHook(hook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) SetDefaultReturn(r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) PushReturn(r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) nextHook() func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) appendCall(r0 GitBlobLSIFDataResolverIncomingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitBlobLSIFDataResolverIncomingCallsFuncCall objects describing the
+// invocations of this function.
+func (f *GitBlobLSIFDataResolverIncomingCallsFunc) History() []GitBlobLSIFDataResolverIncomingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitBlobLSIFDataResolverIncomingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitBlobLSIFDataResolverIncomingCallsFuncCall is an object that describes
+// an invocation of method IncomingCalls on an instance of
+// MockGitBlobLSIFDataResolver.
+type GitBlobLSIFDataResolverIncomingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.CallHierarchyCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitBlobLSIFDataResolverIncomingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitBlobLSIFDataResolverIncomingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // GitBlobLSIFDataResolverLSIFUploadsFunc describes the behavior when the
 // LSIFUploads method of the parent MockGitBlobLSIFDataResolver instance is
 // invoked.
@@ -750,6 +906,130 @@ func (c GitBlobLSIFDataResolverLSIFUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitBlobLSIFDataResolverOutgoingCallsFunc describes the behavior when the
+// OutgoingCalls method of the parent MockGitBlobLSIFDataResolver instance
+// is invoked.
+type GitBlobLSIFDataResolverOutgoingCallsFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)
+	history     []GitBlobLSIFDataResolverOutgoingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// OutgoingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitBlobLSIFDataResolver) OutgoingCalls(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]shared.CallHierarchyCall, string, error) {
+	r0, r1, r2 := m.OutgoingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.OutgoingCallsFunc.appendCall(GitBlobLSIFDataResolverOutgoingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the OutgoingCalls method
+// of the parent MockGitBlobLSIFDataResolver instance is invoked and the
+// hook queue is empty.
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutgoingCalls method of the parent MockGitBlobLSIFDataResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) PushHook(hook func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) SetDefaultReturn(r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) PushReturn(r0 []shared.CallHierarchyCall, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) nextHook() func(context.Context, int, int, int, string) ([]shared.CallHierarchyCall, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) appendCall(r0 GitBlobLSIFDataResolverOutgoingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitBlobLSIFDataResolverOutgoingCallsFuncCall objects describing the
+// invocations of this function.
+func (f *GitBlobLSIFDataResolverOutgoingCallsFunc) History() []GitBlobLSIFDataResolverOutgoingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitBlobLSIFDataResolverOutgoingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitBlobLSIFDataResolverOutgoingCallsFuncCall is an object that describes
+// an invocation of method OutgoingCalls on an instance of
+// MockGitBlobLSIFDataResolver.
+type GitBlobLSIFDataResolverOutgoingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.CallHierarchyCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitBlobLSIFDataResolverOutgoingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitBlobLSIFDataResolverOutgoingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // GitBlobLSIFDataResolverRangesFunc describes the behavior when the Ranges
 // method of the parent MockGitBlobLSIFDataResolver instance is invoked.
 type GitBlobLSIFDataResolverRangesFunc struct {
@@ -33,6 +33,9 @@ type MockResolver struct {
 	// GetIndexesByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetIndexesByIDs.
 	GetIndexesByIDsFunc *ResolverGetIndexesByIDsFunc
+	// GetInferenceScriptFunc is an instance of a mock function object
+	// controlling the behavior of the method GetInferenceScript.
+	GetInferenceScriptFunc *ResolverGetInferenceScriptFunc
 	// GetLastIndexScanForRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetLastIndexScanForRepository.
@@ -60,6 +63,9 @@ type MockResolver struct {
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *ResolverUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateInferenceScriptFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateInferenceScript.
+	UpdateInferenceScriptFunc *ResolverUpdateInferenceScriptFunc
 }
 
 // NewMockResolver creates a new mock of the Resolver interface. All methods
@@ -86,6 +92,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		GetInferenceScriptFunc: &ResolverGetInferenceScriptFunc{
+			defaultHook: func(context.Context, int) (r0 string, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetLastIndexScanForRepositoryFunc: &ResolverGetLastIndexScanForRepositoryFunc{
 			defaultHook: func(context.Context, int) (r0 *time.Time, r1 error) {
 				return
@@ -121,6 +132,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		UpdateInferenceScriptFunc: &ResolverUpdateInferenceScriptFunc{
+			defaultHook: func(context.Context, int, string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -148,6 +164,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.GetIndexesByIDs")
 			},
 		},
+		GetInferenceScriptFunc: &ResolverGetInferenceScriptFunc{
+			defaultHook: func(context.Context, int) (string, bool, error) {
+				panic("unexpected invocation of MockResolver.GetInferenceScript")
+			},
+		},
 		GetLastIndexScanForRepositoryFunc: &ResolverGetLastIndexScanForRepositoryFunc{
 			defaultHook: func(context.Context, int) (*time.Time, error) {
 				panic("unexpected invocation of MockResolver.GetLastIndexScanForRepository")
@@ -183,6 +204,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.UpdateIndexConfigurationByRepositoryID")
 			},
 		},
+		UpdateInferenceScriptFunc: &ResolverUpdateInferenceScriptFunc{
+			defaultHook: func(context.Context, int, string) error {
+				panic("unexpected invocation of MockResolver.UpdateInferenceScript")
+			},
+		},
 	}
 }
 
@@ -202,6 +228,9 @@ func NewMockResolverFrom(i graphql.Resolver) *MockResolver {
 		GetIndexesByIDsFunc: &ResolverGetIndexesByIDsFunc{
 			defaultHook: i.GetIndexesByIDs,
 		},
+		GetInferenceScriptFunc: &ResolverGetInferenceScriptFunc{
+			defaultHook: i.GetInferenceScript,
+		},
 		GetLastIndexScanForRepositoryFunc: &ResolverGetLastIndexScanForRepositoryFunc{
 			defaultHook: i.GetLastIndexScanForRepository,
 		},
@@ -223,6 +252,9 @@ func NewMockResolverFrom(i graphql.Resolver) *MockResolver {
 		UpdateIndexConfigurationByRepositoryIDFunc: &ResolverUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateInferenceScriptFunc: &ResolverUpdateInferenceScriptFunc{
+			defaultHook: i.UpdateInferenceScript,
+		},
 	}
 }
 
@@ -670,6 +702,117 @@ func (c ResolverGetIndexesByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverGetInferenceScriptFunc describes the behavior when the
+// GetInferenceScript method of the parent MockResolver instance is invoked.
+type ResolverGetInferenceScriptFunc struct {
+	defaultHook func(context.Context, int) (string, bool, error)
+	hooks       []func(context.Context, int) (string, bool, error)
+	history     []ResolverGetInferenceScriptFuncCall
+	mutex       sync.Mutex
+}
+
+// GetInferenceScript delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) GetInferenceScript(v0 context.Context, v1 int) (string, bool, error) {
+	r0, r1, r2 := m.GetInferenceScriptFunc.nextHook()(v0, v1)
+	m.GetInferenceScriptFunc.appendCall(ResolverGetInferenceScriptFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetInferenceScript
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverGetInferenceScriptFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetInferenceScript method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverGetInferenceScriptFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverGetInferenceScriptFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverGetInferenceScriptFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverGetInferenceScriptFunc) nextHook() func(context.Context, int) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetInferenceScriptFunc) appendCall(r0 ResolverGetInferenceScriptFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetInferenceScriptFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverGetInferenceScriptFunc) History() []ResolverGetInferenceScriptFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetInferenceScriptFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetInferenceScriptFuncCall is an object that describes an
+// invocation of method GetInferenceScript on an instance of MockResolver.
+type ResolverGetInferenceScriptFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetInferenceScriptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetInferenceScriptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverGetLastIndexScanForRepositoryFunc describes the behavior when the
 // GetLastIndexScanForRepository method of the parent MockResolver instance
 // is invoked.
@@ -1460,3 +1603,113 @@ func (c ResolverUpdateIndexConfigurationByRepositoryIDFuncCall) Args() []interfa
 func (c ResolverUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ResolverUpdateInferenceScriptFunc describes the behavior when the
+// UpdateInferenceScript method of the parent MockResolver instance is
+// invoked.
+type ResolverUpdateInferenceScriptFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []ResolverUpdateInferenceScriptFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateInferenceScript delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) UpdateInferenceScript(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.UpdateInferenceScriptFunc.nextHook()(v0, v1, v2)
+	m.UpdateInferenceScriptFunc.appendCall(ResolverUpdateInferenceScriptFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateInferenceScript method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverUpdateInferenceScriptFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateInferenceScript method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverUpdateInferenceScriptFunc) PushHook(hook func(context.Context, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverUpdateInferenceScriptFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverUpdateInferenceScriptFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+func (f *ResolverUpdateInferenceScriptFunc) nextHook() func(context.Context, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUpdateInferenceScriptFunc) appendCall(r0 ResolverUpdateInferenceScriptFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUpdateInferenceScriptFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverUpdateInferenceScriptFunc) History() []ResolverUpdateInferenceScriptFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUpdateInferenceScriptFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUpdateInferenceScriptFuncCall is an object that describes an
+// invocation of method UpdateInferenceScript on an instance of
+// MockResolver.
+type ResolverUpdateInferenceScriptFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUpdateInferenceScriptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUpdateInferenceScriptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -35,6 +35,8 @@ type Resolver interface {
 
 	ExecutorResolver() executor.Resolver
 	CodeNavResolver() CodeNavResolver
+	DocumentsResolver() DocumentsResolver
+	UploadsServiceResolver() UploadsServiceResolver
 	PoliciesResolver() PoliciesResolver
 	AutoIndexingResolver() AutoIndexingResolver
 }
@@ -53,6 +55,8 @@ type resolver struct {
 
 	executorResolver     executor.Resolver
 	codenavResolver      CodeNavResolver
+	documentsResolver    DocumentsResolver
+	uploadsResolver      UploadsServiceResolver
 	policiesResolver     PoliciesResolver
 	autoIndexingResolver AutoIndexingResolver
 }
@@ -63,6 +67,8 @@ func NewResolver(
 	lsifStore LSIFStore,
 	symbolsClient *symbolsClient.Client,
 	codenavResolver CodeNavResolver,
+	documentsResolver DocumentsResolver,
+	uploadsResolver UploadsServiceResolver,
 	executorResolver executor.Resolver,
 	policiesResolver PoliciesResolver,
 	autoIndexingResolver AutoIndexingResolver,
@@ -74,6 +80,8 @@ func NewResolver(
 
 		executorResolver:     executorResolver,
 		codenavResolver:      codenavResolver,
+		documentsResolver:    documentsResolver,
+		uploadsResolver:      uploadsResolver,
 		policiesResolver:     policiesResolver,
 		autoIndexingResolver: autoIndexingResolver,
 	}
@@ -83,6 +91,14 @@ func (r *resolver) CodeNavResolver() CodeNavResolver {
 	return r.codenavResolver
 }
 
+func (r *resolver) DocumentsResolver() DocumentsResolver {
+	return r.documentsResolver
+}
+
+func (r *resolver) UploadsServiceResolver() UploadsServiceResolver {
+	return r.uploadsResolver
+}
+
 func (r *resolver) PoliciesResolver() PoliciesResolver {
 	return r.policiesResolver
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/httpapi"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
@@ -43,6 +44,7 @@ type Services struct {
 	AutoIndexingSvc *autoindexing.Service
 	UploadsSvc      *uploads.Service
 	CodeNavSvc      *codenav.Service
+	DocumentsSvc    *documents.Service
 	PoliciesSvc     *policies.Service
 }
 
@@ -76,6 +78,7 @@ func NewServices(ctx context.Context, config *Config, siteConfig conftypes.Watch
 	// Initialize services
 	uploadSvc := uploads.GetService(db, codeIntelLsifStore, gitserverClient)
 	codenavSvc := codenav.GetService(db, codeIntelLsifStore, uploadSvc, gitserverClient)
+	documentsSvc := documents.GetService(codeIntelLsifStore, codenavSvc, gitserverClient, config.HunkCacheSize)
 	policySvc := policies.GetService(db, uploadSvc, gitserverClient)
 	autoindexingSvc := autoindexing.GetService(db, uploadSvc, gitserverClient, repoUpdaterClient)
 
@@ -115,6 +118,7 @@ func NewServices(ctx context.Context, config *Config, siteConfig conftypes.Watch
 		AutoIndexingSvc: autoindexingSvc,
 		UploadsSvc:      uploadSvc,
 		CodeNavSvc:      codenavSvc,
+		DocumentsSvc:    documentsSvc,
 		PoliciesSvc:     policySvc,
 	}, nil
 }
@@ -175,6 +175,8 @@ func pathAndCommitFromResult(m result.Match) (string, string) {
 	switch v := m.(type) {
 	case *result.FileMatch:
 		return v.Path, string(v.CommitID)
+	case *result.CaptureMatch:
+		return v.Path, string(v.CommitID)
 	case *result.CommitMatch:
 		return "", string(v.Commit.ID)
 	case *result.RepoMatch:
@@ -234,7 +236,7 @@ func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db datab
 	log15.Debug("compute", "search", searchQuery)
 
 	patternType := "regexp"
-	job, err := gql.NewBatchSearchImplementer(ctx, logger, db, &gql.SearchArgs{Query: searchQuery, PatternType: &patternType})
+	job, err := gql.NewBatchSearchImplementer(ctx, logger, db, nil, &gql.SearchArgs{Query: searchQuery, PatternType: &patternType})
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, se
 	}
 
 	patternType := "regexp"
-	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), nil)
 	inputs, err := searchClient.Plan(ctx, "", &patternType, searchQuery, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
 		close(eventsC)
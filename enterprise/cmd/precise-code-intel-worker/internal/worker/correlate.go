@@ -0,0 +1,268 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// correlateUpload reads the given raw upload data and returns the correlated bundle data. Uploads
+// may be either newline-delimited LSIF JSON or a SCIP protobuf index. SCIP indexes are converted to
+// LSIF elements one document at a time so that both formats are written to the same lsifstore
+// tables.
+func correlateUpload(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	br := bufio.NewReader(r)
+
+	isSCIP, err := isSCIPPayload(br)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSCIP {
+		groupedBundleData, err := conversion.Correlate(ctx, br, root, getChildren)
+		if err != nil {
+			return nil, errors.Wrap(err, "conversion.Correlate")
+		}
+
+		return groupedBundleData, nil
+	}
+
+	groupedBundleData, err := correlateSCIPUpload(ctx, br, root, getChildren)
+	if err != nil {
+		return nil, errors.Wrap(err, "correlateSCIPUpload")
+	}
+
+	return groupedBundleData, nil
+}
+
+// correlateSCIPUpload converts the SCIP index read from the given reader to LSIF and correlates
+// it. The global symbols of the index must be known before the occurrences of any document can be
+// converted, and the external symbols usually follow the documents, so the index is read several
+// times: it is first copied to a temporary file, and each pass then decodes one document at a
+// time. Neither the index nor the converted elements are held in memory in their entirety.
+func correlateSCIPUpload(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	f, err := os.CreateTemp("", "scip-upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return nil, errors.Wrap(err, "copying index")
+	}
+
+	// readFields calls fn with each top-level field of the index
+	readFields := func(fn func(fieldNumber protowire.Number, value []byte) error) error {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		return readSCIPFields(bufio.NewReader(f), func(fieldNumber protowire.Number, value []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			return fn(fieldNumber, value)
+		})
+	}
+
+	correlator := conversion.NewElementCorrelator(root)
+	converter := newSCIPConverter(correlator.Correlate)
+
+	// The metadata and the external symbols come first
+	var metadata *scip.Metadata
+	var externalSymbols []*scip.SymbolInformation
+	if err := readFields(func(fieldNumber protowire.Number, value []byte) error {
+		switch fieldNumber {
+		case scipMetadataFieldNumber:
+			if metadata == nil {
+				metadata = &scip.Metadata{}
+			}
+			// Repeated occurrences of a singular message field are merged
+			if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(value, metadata); err != nil {
+				return errors.Wrap(err, "proto.Unmarshal")
+			}
+
+		case scipExternalSymbolsFieldNumber:
+			var symbol scip.SymbolInformation
+			if err := proto.Unmarshal(value, &symbol); err != nil {
+				return errors.Wrap(err, "proto.Unmarshal")
+			}
+			externalSymbols = append(externalSymbols, &symbol)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := converter.emitMetadata(metadata); err != nil {
+		return nil, err
+	}
+	if err := converter.emitExternalSymbols(externalSymbols); err != nil {
+		return nil, err
+	}
+	externalSymbols = nil
+
+	// Then the symbols defined by each document, followed by the occurrences of each document
+	for _, emit := range []func(document *scip.Document) error{converter.emitDocumentSymbols, converter.emitDocument} {
+		if err := readFields(func(fieldNumber protowire.Number, value []byte) error {
+			if fieldNumber != scipDocumentsFieldNumber {
+				return nil
+			}
+
+			var document scip.Document
+			if err := proto.Unmarshal(value, &document); err != nil {
+				return errors.Wrap(err, "proto.Unmarshal")
+			}
+
+			return emit(&document)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return correlator.Finish(ctx, getChildren)
+}
+
+// readSCIPFields reads a SCIP index from the given reader one top-level field at a time and calls
+// the given function with the number and encoded value of each length-delimited field. The value
+// is only valid until the function returns, so that the raw payload, which can be several gigabytes
+// for large repositories, is never held in memory in its entirety.
+func readSCIPFields(r *bufio.Reader, fn func(fieldNumber protowire.Number, value []byte) error) error {
+	var buf bytes.Buffer
+	for {
+		tag, err := binary.ReadUvarint(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+
+		fieldNumber, wireType := protowire.DecodeTag(tag)
+		if wireType != protowire.BytesType {
+			if err := skipSCIPField(r, wireType); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		length, err := binary.ReadUvarint(r)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+
+		buf.Reset()
+		if _, err := io.CopyN(&buf, r, int64(length)); err != nil {
+			return unexpectedEOF(err)
+		}
+
+		if err := fn(fieldNumber, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+}
+
+// skipSCIPField discards the value of an unknown non-length-delimited field from the given reader.
+func skipSCIPField(r *bufio.Reader, wireType protowire.Type) error {
+	var err error
+	switch wireType {
+	case protowire.VarintType:
+		_, err = binary.ReadUvarint(r)
+	case protowire.Fixed32Type:
+		_, err = r.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = r.Discard(8)
+	default:
+		return errors.Newf("unsupported wire type %d", wireType)
+	}
+
+	return unexpectedEOF(err)
+}
+
+// unexpectedEOF converts an EOF error encountered in the middle of a field to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// isSCIPPayload determines if the given reader contains a SCIP index rather than LSIF JSON. LSIF
+// uploads are newline-delimited JSON objects, while SCIP indexes are binary protobuf messages whose
+// top-level fields may occur in any order and may be repeated. The tag byte of the metadata field
+// is also a newline and field lengths may be encoded as any byte, so the payload is not sniffed for
+// JSON. Instead, the buffered prefix of the payload is decoded as a sequence of top-level fields of
+// a SCIP index. The payload is a SCIP index if each of these fields decodes as the message it holds,
+// up to the end of the payload or up to a field exceeding the buffer. Empty payloads are treated as
+// LSIF so that the correlator reports the missing metadata vertex.
+func isSCIPPayload(r *bufio.Reader) (bool, error) {
+	prefix, err := r.Peek(r.Size())
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	// Fields cut off by the end of the payload, rather than by the buffer, are invalid
+	complete := err == io.EOF
+
+	if len(prefix) == 0 {
+		return false, nil
+	}
+
+	for len(prefix) > 0 {
+		fieldNumber, wireType, n := protowire.ConsumeTag(prefix)
+		if n < 0 {
+			return !complete && len(prefix) < binary.MaxVarintLen64, nil
+		}
+		if wireType != protowire.BytesType {
+			return false, nil
+		}
+
+		var message proto.Message
+		switch fieldNumber {
+		case scipMetadataFieldNumber:
+			message = &scip.Metadata{}
+		case scipDocumentsFieldNumber:
+			message = &scip.Document{}
+		case scipExternalSymbolsFieldNumber:
+			message = &scip.SymbolInformation{}
+		default:
+			return false, nil
+		}
+
+		value, m := protowire.ConsumeBytes(prefix[n:])
+		if m < 0 {
+			return !complete, nil
+		}
+		if err := proto.Unmarshal(value, message); err != nil || len(message.ProtoReflect().GetUnknown()) > 0 {
+			return false, nil
+		}
+
+		prefix = prefix[n+m:]
+	}
+
+	return true, nil
+}
+
+const (
+	scipMetadataFieldNumber        = 1
+	scipDocumentsFieldNumber       = 2
+	scipExternalSymbolsFieldNumber = 3
+)
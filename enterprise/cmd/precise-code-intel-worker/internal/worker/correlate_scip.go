@@ -0,0 +1,347 @@
+package worker
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// scipConverter converts a SCIP index into LSIF elements one document at a time. It emits the
+// same elements in the same order as scip.ConvertSCIPToLSIF, which requires the entire index to
+// be decoded in memory, but only retains the LSIF identifiers of the global symbols between
+// documents. The index is converted with the following calls, in order:
+//
+//   - emitMetadata with the metadata of the index,
+//   - emitExternalSymbols with the external symbols of the index,
+//   - emitDocumentSymbols with each document of the index, and
+//   - emitDocument with each document of the index.
+type scipConverter struct {
+	// correlate receives each converted element. Conversion stops once it returns an error.
+	correlate func(element reader.Element) error
+	err       error
+
+	id                   int
+	projectRoot          string
+	symbolToResultSet    map[string]*scipSymbolIDs
+	inverseRelationships map[string][]*scip.Relationship
+	packageToGraphID     map[string]int
+}
+
+// scipSymbolIDs holds the LSIF identifiers of the elements emitted for a SCIP symbol.
+type scipSymbolIDs struct {
+	ResultSet            int
+	DefinitionResult     int
+	ReferenceResult      int
+	ImplementationResult int
+	HoverResult          int
+}
+
+func newSCIPConverter(correlate func(element reader.Element) error) *scipConverter {
+	return &scipConverter{
+		correlate:            correlate,
+		symbolToResultSet:    map[string]*scipSymbolIDs{},
+		inverseRelationships: map[string][]*scip.Relationship{},
+		packageToGraphID:     map[string]int{},
+	}
+}
+
+// emitMetadata emits the metaData vertex of the index.
+func (c *scipConverter) emitMetadata(metadata *scip.Metadata) error {
+	if metadata == nil {
+		return errors.New(".Metadata is nil")
+	}
+	if metadata.ToolInfo == nil {
+		return errors.New(".Metadata.ToolInfo is nil")
+	}
+
+	positionEncoding := ""
+	switch metadata.TextDocumentEncoding {
+	case scip.TextEncoding_UTF8:
+		positionEncoding = "utf-8"
+	case scip.TextEncoding_UTF16:
+		positionEncoding = "utf-16"
+	default:
+		return errors.New(".Metadata.TextDocumentEncoding does not have value utf-8 or utf-16")
+	}
+
+	c.projectRoot = metadata.ProjectRoot
+	c.emitVertex("metaData", reader.MetaData{
+		Version:          "0.4.3", // Hardcoded LSIF version.
+		ProjectRoot:      metadata.ProjectRoot,
+		PositionEncoding: positionEncoding,
+		ToolInfo: reader.ToolInfo{
+			Name:    metadata.ToolInfo.Name,
+			Version: metadata.ToolInfo.Version,
+		},
+	})
+
+	return c.err
+}
+
+// emitExternalSymbols emits the result sets of the given imported symbols.
+func (c *scipConverter) emitExternalSymbols(symbols []*scip.SymbolInformation) error {
+	for _, symbol := range symbols {
+		c.symbolToResultSet[symbol.Symbol] = c.emitResultSet(symbol, "import")
+	}
+
+	return c.err
+}
+
+// emitDocumentSymbols emits the result sets of the global symbols defined in the given document.
+func (c *scipConverter) emitDocumentSymbols(document *scip.Document) error {
+	for _, exportedSymbol := range document.Symbols {
+		c.registerInverseRelationships(exportedSymbol)
+		if scip.IsGlobalSymbol(exportedSymbol.Symbol) {
+			// Local symbols are skipped here because we handle them when emitting
+			// the ranges of individual documents.
+			c.symbolToResultSet[exportedSymbol.Symbol] = c.emitResultSet(exportedSymbol, "export")
+		}
+	}
+
+	return c.err
+}
+
+// emitDocument emits all range vertices for the occurrences in the given document, along with
+// associated item edges to link ranges with result sets.
+func (c *scipConverter) emitDocument(doc *scip.Document) error {
+	uri := filepath.Join(c.projectRoot, doc.RelativePath)
+	documentID := c.emitVertex("document", uri)
+
+	documentSymbolTable := map[string]*scip.SymbolInformation{}
+	localSymbolInformationTable := map[string]*scipSymbolIDs{}
+	for _, info := range doc.Symbols {
+		documentSymbolTable[info.Symbol] = info
+
+		// Build symbol information table for Document-local symbols only.
+		if scip.IsLocalSymbol(info.Symbol) {
+			localSymbolInformationTable[info.Symbol] = c.emitResultSet(info, "local")
+		}
+
+		// Emit "implementation" monikers for external symbols (monikers with kind "import")
+		for _, relationship := range info.Relationships {
+			if relationship.IsImplementation {
+				relationshipIDs := c.getOrInsertSymbolIDs(relationship.Symbol, localSymbolInformationTable)
+				if relationshipIDs.DefinitionResult > 0 {
+					// Not an imported symbol
+					continue
+				}
+				infoIDs := c.getOrInsertSymbolIDs(info.Symbol, localSymbolInformationTable)
+				c.emitMonikerVertex(relationship.Symbol, "implementation", infoIDs.ResultSet)
+			}
+		}
+	}
+
+	var rangeIDs []int
+	for _, occ := range doc.Occurrences {
+		rangeID, err := c.emitRange(occ.Range)
+		if err != nil {
+			// Silently skip invalid ranges, as scip.ConvertSCIPToLSIF does.
+			continue
+		}
+		rangeIDs = append(rangeIDs, rangeID)
+		resultIDs := c.getOrInsertSymbolIDs(occ.Symbol, localSymbolInformationTable)
+		c.emitEdge("next", reader.Edge{OutV: rangeID, InV: resultIDs.ResultSet})
+		isDefinition := occ.SymbolRoles&int32(scip.SymbolRole_Definition) != 0
+		if isDefinition && resultIDs.DefinitionResult > 0 {
+			c.emitEdge("item", reader.Edge{OutV: resultIDs.DefinitionResult, InVs: []int{rangeID}, Document: documentID})
+			if symbolInfo, ok := documentSymbolTable[occ.Symbol]; ok {
+				c.emitRelationships(rangeID, documentID, resultIDs, localSymbolInformationTable, symbolInfo)
+			}
+		}
+		// reference
+		c.emitEdge("item", reader.Edge{OutV: resultIDs.ReferenceResult, InVs: []int{rangeID}, Document: documentID})
+	}
+	// Unlike scip.ConvertSCIPToLSIF, which panics, skip the contains edge of documents
+	// without valid occurrences
+	if len(rangeIDs) > 0 {
+		c.emitEdge("contains", reader.Edge{OutV: documentID, InVs: rangeIDs})
+	}
+
+	return c.err
+}
+
+// emitResultSet emits the result set along with the definition, reference and hover results of
+// the given symbol.
+func (c *scipConverter) emitResultSet(info *scip.SymbolInformation, monikerKind string) *scipSymbolIDs {
+	if ids, ok := c.symbolToResultSet[info.Symbol]; ok {
+		return ids
+	}
+	// Separate documentation sections with a horizontal Markdown rule, as indexers that emit
+	// LSIF directly do.
+	hover := strings.Join(info.Documentation, "\n\n---\n\n")
+	definitionResult := -1
+	hasDefinition := monikerKind == "export" || monikerKind == "local"
+	if hasDefinition {
+		definitionResult = c.emitVertex("definitionResult", nil)
+	}
+	ids := &scipSymbolIDs{
+		ResultSet:            c.emitVertex("resultSet", reader.ResultSet{}),
+		DefinitionResult:     definitionResult,
+		ReferenceResult:      c.emitVertex("referenceResult", nil),
+		ImplementationResult: -1,
+		HoverResult:          c.emitVertex("hoverResult", hover),
+	}
+	if hasDefinition {
+		c.emitEdge("textDocument/definition", reader.Edge{OutV: ids.ResultSet, InV: ids.DefinitionResult})
+	}
+	c.emitEdge("textDocument/references", reader.Edge{OutV: ids.ResultSet, InV: ids.ReferenceResult})
+	c.emitEdge("textDocument/hover", reader.Edge{OutV: ids.ResultSet, InV: ids.HoverResult})
+	if monikerKind == "export" || monikerKind == "import" {
+		c.emitMonikerVertex(info.Symbol, monikerKind, ids.ResultSet)
+	}
+	return ids
+}
+
+// emitRelationships emits the reference and implementation results of the relationships of the
+// given symbol, and of the symbols related to it.
+func (c *scipConverter) emitRelationships(rangeID, documentID int, resultIDs *scipSymbolIDs, localResultIDs map[string]*scipSymbolIDs, info *scip.SymbolInformation) {
+	var allReferenceResultIDs []int
+	for _, relationship := range c.inverseRelationships[info.Symbol] {
+		allReferenceResultIDs = append(allReferenceResultIDs, c.emitRelationship(relationship, rangeID, documentID, localResultIDs)...)
+	}
+	for _, relationship := range info.Relationships {
+		allReferenceResultIDs = append(allReferenceResultIDs, c.emitRelationship(relationship, rangeID, documentID, localResultIDs)...)
+	}
+	if len(allReferenceResultIDs) > 0 {
+		c.emitEdge("item", reader.Edge{OutV: resultIDs.ReferenceResult, InVs: allReferenceResultIDs, Document: documentID})
+	}
+}
+
+func (c *scipConverter) emitRelationship(relationship *scip.Relationship, rangeID, documentID int, localResultIDs map[string]*scipSymbolIDs) []int {
+	relationshipIDs := c.getOrInsertSymbolIDs(relationship.Symbol, localResultIDs)
+
+	if relationship.IsImplementation {
+		if relationshipIDs.ImplementationResult < 0 {
+			relationshipIDs.ImplementationResult = c.emitVertex("implementationResult", nil)
+			c.emitEdge("textDocument/implementation", reader.Edge{OutV: relationshipIDs.ResultSet, InV: relationshipIDs.ImplementationResult})
+		}
+		c.emitEdge("item", reader.Edge{OutV: relationshipIDs.ImplementationResult, InVs: []int{rangeID}, Document: documentID})
+	}
+
+	if relationship.IsReference {
+		c.emitEdge("item", reader.Edge{OutV: relationshipIDs.ReferenceResult, InVs: []int{rangeID}, Document: documentID})
+		return []int{relationshipIDs.ReferenceResult}
+	}
+
+	return nil
+}
+
+// emitMonikerVertex emits the moniker vertex of the given symbol and, if the symbol names a
+// package, the accompanying packageInformation vertex.
+func (c *scipConverter) emitMonikerVertex(symbolID string, kind string, resultSetID int) {
+	symbol, err := scip.ParsePartialSymbol(symbolID, false)
+	if err != nil || symbol == nil || symbol.Scheme == "" {
+		// Silently ignore symbols that are missing the scheme, as scip.ConvertSCIPToLSIF does.
+		return
+	}
+	scheme := symbol.Scheme
+	if symbol.Package != nil {
+		// The Sourcegraph backend uses the scheme of monikers where it should use the
+		// manager of the package information instead.
+		switch symbol.Scheme {
+		case "scip-java", "lsif-java":
+			scheme = "semanticdb"
+		case "scip-typescript", "lsif-typescript":
+			scheme = "npm"
+		}
+	}
+	monikerID := c.emitVertex("moniker", reader.Moniker{
+		Kind:       kind,
+		Scheme:     scheme,
+		Identifier: symbolID,
+	})
+	c.emitEdge("moniker", reader.Edge{OutV: resultSetID, InV: monikerID})
+	if symbol.Package != nil &&
+		symbol.Package.Manager != "" &&
+		symbol.Package.Name != "" &&
+		symbol.Package.Version != "" {
+		packageID := c.emitPackage(symbol.Package)
+		c.emitEdge("packageInformation", reader.Edge{OutV: monikerID, InV: packageID})
+	}
+}
+
+func (c *scipConverter) emitPackage(pkg *scip.Package) int {
+	id := pkg.ID()
+	if graphID, ok := c.packageToGraphID[id]; ok {
+		return graphID
+	}
+
+	graphID := c.emitVertex("packageInformation", reader.PackageInformation{
+		Name:    pkg.Name,
+		Version: pkg.Version,
+		Manager: pkg.Manager,
+	})
+	c.packageToGraphID[id] = graphID
+	return graphID
+}
+
+func (c *scipConverter) emitRange(scipRange []int32) (int, error) {
+	var startLine, startCharacter, endLine, endCharacter int32
+	switch len(scipRange) {
+	case 3:
+		startLine, startCharacter, endLine, endCharacter = scipRange[0], scipRange[1], scipRange[0], scipRange[2]
+	case 4:
+		startLine, startCharacter, endLine, endCharacter = scipRange[0], scipRange[1], scipRange[2], scipRange[3]
+	default:
+		return 0, errors.Newf("invalid SCIP range %v", scipRange)
+	}
+
+	return c.emitVertex("range", reader.Range{
+		RangeData: protocol.RangeData{
+			Start: protocol.Pos{Line: int(startLine), Character: int(startCharacter)},
+			End:   protocol.Pos{Line: int(endLine), Character: int(endCharacter)},
+		},
+	}), nil
+}
+
+// registerInverseRelationships records the relationships of the given symbol in the opposite
+// direction, e.g. from an interface to the struct implementing it.
+func (c *scipConverter) registerInverseRelationships(info *scip.SymbolInformation) {
+	for _, relationship := range info.Relationships {
+		c.inverseRelationships[relationship.Symbol] = append(c.inverseRelationships[relationship.Symbol], &scip.Relationship{
+			Symbol:           info.Symbol,
+			IsReference:      relationship.IsReference,
+			IsImplementation: relationship.IsImplementation,
+			IsTypeDefinition: relationship.IsTypeDefinition,
+		})
+	}
+}
+
+func (c *scipConverter) getOrInsertSymbolIDs(symbol string, localResultSetTable map[string]*scipSymbolIDs) *scipSymbolIDs {
+	resultSetTable := c.symbolToResultSet
+	if scip.IsLocalSymbol(symbol) {
+		resultSetTable = localResultSetTable
+	}
+	ids, ok := resultSetTable[symbol]
+	if !ok {
+		ids = c.emitResultSet(&scip.SymbolInformation{Symbol: symbol}, "import")
+		resultSetTable[symbol] = ids
+	}
+	return ids
+}
+
+func (c *scipConverter) emitVertex(label string, payload any) int {
+	return c.emit("vertex", label, payload)
+}
+
+func (c *scipConverter) emitEdge(label string, payload reader.Edge) {
+	c.emit("edge", label, payload)
+}
+
+func (c *scipConverter) emit(ty, label string, payload any) int {
+	c.id++
+	if c.err == nil {
+		c.err = c.correlate(reader.Element{
+			ID:      c.id,
+			Type:    ty,
+			Label:   label,
+			Payload: payload,
+		})
+	}
+	return c.id
+}
@@ -0,0 +1,310 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestCorrelateUploadSCIP(t *testing.T) {
+	const symbol = "scip-go gomod github.com/test/pkg v1.2.3 `github.com/test/pkg`/Foo()."
+
+	index := &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:             &scip.ToolInfo{Name: "scip-go", Version: "0.1.0"},
+			ProjectRoot:          "file:///test/root/",
+			TextDocumentEncoding: scip.TextEncoding_UTF8,
+		},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "foo.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 5, 8}, Symbol: symbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: symbol, Documentation: []string{"```go\nfunc Foo()\n```"}},
+				},
+			},
+			{
+				RelativePath: "bar.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{3, 2, 5}, Symbol: symbol},
+				},
+			},
+		},
+	}
+
+	payload, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	groupedBundleData, err := correlateUpload(context.Background(), bytes.NewReader(payload), "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating upload: %s", err)
+	}
+
+	var paths []string
+	for document := range groupedBundleData.Documents {
+		paths = append(paths, document.Path)
+	}
+	for range groupedBundleData.ResultChunks {
+	}
+	for range groupedBundleData.Definitions {
+	}
+	for range groupedBundleData.References {
+	}
+	for range groupedBundleData.Implementations {
+	}
+	sort.Strings(paths)
+
+	if diff := cmp.Diff([]string{"bar.go", "foo.go"}, paths); diff != "" {
+		t.Errorf("unexpected document paths (-want +got):\n%s", diff)
+	}
+
+	expectedPackages := []precise.Package{
+		{Scheme: "scip-go", Name: "github.com/test/pkg", Version: "v1.2.3"},
+	}
+	if diff := cmp.Diff(expectedPackages, groupedBundleData.Packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsSCIPPayload(t *testing.T) {
+	testCases := map[string]bool{
+		"":                         false,
+		"   \n":                    false,
+		`{"id":1,"type":"vertex"}`: false,
+		"\n\t{\"id\":1}":           false,
+		"\x12\x00":                 true,
+		"\n{\"id\":1}\n":           false,
+		"\n\n{\"id\":1}":           false,
+		"\n" + strings.Repeat(" ", 40) + "{\"id\":1}": false,
+	}
+
+	for input, expected := range testCases {
+		isSCIP, err := isSCIPPayload(bufio.NewReader(strings.NewReader(input)))
+		if err != nil {
+			t.Fatalf("unexpected error detecting payload format: %s", err)
+		}
+		if isSCIP != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", input, expected, isSCIP)
+		}
+	}
+}
+
+func TestIsSCIPPayloadMetadataLength(t *testing.T) {
+	// The varint-encoded length of the metadata follows its tag byte (a newline). These lengths
+	// are encoded as a tab, a newline, a carriage return, a space, an opening brace, and bytes
+	// with their high bit set.
+	for _, length := range []int{9, 10, 13, 32, 123, 200, 5000} {
+		// The project root field is a tag byte, the varint-encoded length of the root, and the root
+		rootLength := length - 1 - protowire.SizeVarint(uint64(length))
+		metadata := &scip.Metadata{ProjectRoot: strings.Repeat("a", rootLength)}
+
+		for _, index := range []*scip.Index{
+			{Metadata: metadata},
+			{Metadata: metadata, Documents: []*scip.Document{{RelativePath: "foo.go"}}},
+		} {
+			payload, err := proto.Marshal(index)
+			if err != nil {
+				t.Fatalf("unexpected error marshalling index: %s", err)
+			}
+			if encodedLength, _ := protowire.ConsumeVarint(payload[1:]); int(encodedLength) != length {
+				t.Fatalf("unexpected metadata length. want=%d have=%d", length, encodedLength)
+			}
+
+			isSCIP, err := isSCIPPayload(bufio.NewReader(bytes.NewReader(payload)))
+			if err != nil {
+				t.Fatalf("unexpected error detecting payload format: %s", err)
+			}
+			if !isSCIP {
+				t.Errorf("expected payload with metadata length %d to be detected as SCIP", length)
+			}
+		}
+	}
+}
+
+func TestIsSCIPPayloadFieldOrder(t *testing.T) {
+	// The length of this metadata is encoded as an opening brace
+	metadata, err := proto.Marshal(&scip.Metadata{ProjectRoot: strings.Repeat("a", 121)})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling metadata: %s", err)
+	}
+	document, err := proto.Marshal(&scip.Document{RelativePath: "foo.go"})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling document: %s", err)
+	}
+	symbol, err := proto.Marshal(&scip.SymbolInformation{Symbol: "local 1"})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling symbol: %s", err)
+	}
+
+	field := func(fieldNumber protowire.Number, value []byte) []byte {
+		b := protowire.AppendTag(nil, fieldNumber, protowire.BytesType)
+		return protowire.AppendBytes(b, value)
+	}
+	payload := func(fields ...[]byte) []byte {
+		return bytes.Join(fields, nil)
+	}
+
+	testCases := map[string][]byte{
+		"repeated metadata":          payload(field(scipMetadataFieldNumber, metadata), field(scipMetadataFieldNumber, metadata), field(scipDocumentsFieldNumber, document)),
+		"metadata after documents":   payload(field(scipDocumentsFieldNumber, document), field(scipMetadataFieldNumber, metadata)),
+		"metadata after symbols":     payload(field(scipExternalSymbolsFieldNumber, symbol), field(scipMetadataFieldNumber, metadata)),
+		"metadata between documents": payload(field(scipDocumentsFieldNumber, document), field(scipMetadataFieldNumber, metadata), field(scipDocumentsFieldNumber, document)),
+	}
+
+	for name, input := range testCases {
+		isSCIP, err := isSCIPPayload(bufio.NewReader(bytes.NewReader(input)))
+		if err != nil {
+			t.Fatalf("unexpected error detecting payload format: %s", err)
+		}
+		if !isSCIP {
+			t.Errorf("expected payload with %s to be detected as SCIP", name)
+		}
+	}
+}
+
+func TestReadSCIPFields(t *testing.T) {
+	index := &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:    &scip.ToolInfo{Name: "scip-go", Version: "0.1.0"},
+			ProjectRoot: "file:///test/root/",
+		},
+		Documents: []*scip.Document{
+			{RelativePath: "foo.go", Occurrences: []*scip.Occurrence{{Range: []int32{1, 5, 8}, Symbol: "local 1"}}},
+			{RelativePath: "bar.go"},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: "scip-go gomod github.com/dep/pkg v0.1.0 `github.com/dep/pkg`/Bar().", Documentation: []string{"docs"}},
+		},
+	}
+
+	payload, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	decoded := &scip.Index{}
+	if err := readSCIPFields(bufio.NewReader(bytes.NewReader(payload)), func(fieldNumber protowire.Number, value []byte) error {
+		switch fieldNumber {
+		case scipMetadataFieldNumber:
+			decoded.Metadata = &scip.Metadata{}
+			return proto.Unmarshal(value, decoded.Metadata)
+		case scipDocumentsFieldNumber:
+			var document scip.Document
+			decoded.Documents = append(decoded.Documents, &document)
+			return proto.Unmarshal(value, &document)
+		case scipExternalSymbolsFieldNumber:
+			var symbol scip.SymbolInformation
+			decoded.ExternalSymbols = append(decoded.ExternalSymbols, &symbol)
+			return proto.Unmarshal(value, &symbol)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error reading index: %s", err)
+	}
+	if !proto.Equal(index, decoded) {
+		t.Errorf("unexpected index. want=%v have=%v", index, decoded)
+	}
+
+	noop := func(protowire.Number, []byte) error { return nil }
+	if err := readSCIPFields(bufio.NewReader(bytes.NewReader(payload[:len(payload)-1])), noop); err == nil {
+		t.Errorf("expected an error reading a truncated index")
+	}
+}
+
+func TestSCIPConverter(t *testing.T) {
+	const (
+		iface   = "scip-go gomod github.com/test/pkg v1.2.3 `github.com/test/pkg`/Iface#"
+		impl    = "scip-go gomod github.com/test/pkg v1.2.3 `github.com/test/pkg`/Impl#"
+		foo     = "scip-go gomod github.com/test/pkg v1.2.3 `github.com/test/pkg`/Foo()."
+		dep     = "scip-go gomod github.com/dep/pkg v0.1.0 `github.com/dep/pkg`/Bar()."
+		depIfc  = "scip-go gomod github.com/dep/pkg v0.1.0 `github.com/dep/pkg`/Reader#"
+		unknown = "scip-go gomod github.com/other/pkg v0.1.0 `github.com/other/pkg`/Baz()."
+	)
+	definition := int32(scip.SymbolRole_Definition)
+
+	index := &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:             &scip.ToolInfo{Name: "scip-go", Version: "0.1.0"},
+			ProjectRoot:          "file:///test/root/",
+			TextDocumentEncoding: scip.TextEncoding_UTF16,
+		},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "iface.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 5, 10}, Symbol: iface, SymbolRoles: definition},
+					{Range: []int32{3, 1, 4}, Symbol: "local 1", SymbolRoles: definition},
+					{Range: []int32{4, 1, 4}, Symbol: "local 1"},
+					{Range: []int32{5}, Symbol: foo},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: iface, Documentation: []string{"interface", "more docs"}},
+					{Symbol: "local 1"},
+				},
+			},
+			{
+				RelativePath: "impl.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 5, 2, 3}, Symbol: impl, SymbolRoles: definition},
+					{Range: []int32{6, 2, 5}, Symbol: foo, SymbolRoles: definition},
+					{Range: []int32{7, 2, 5}, Symbol: dep},
+					{Range: []int32{8, 2, 5}, Symbol: unknown},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: impl, Relationships: []*scip.Relationship{
+						{Symbol: iface, IsImplementation: true, IsReference: true},
+						{Symbol: depIfc, IsImplementation: true},
+					}},
+					{Symbol: foo, Documentation: []string{"func Foo()"}},
+				},
+			},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: dep, Documentation: []string{"func Bar()"}},
+			{Symbol: depIfc},
+		},
+	}
+
+	expected, err := scip.ConvertSCIPToLSIF(index)
+	if err != nil {
+		t.Fatalf("unexpected error converting index: %s", err)
+	}
+
+	var elements []reader.Element
+	converter := newSCIPConverter(func(element reader.Element) error {
+		elements = append(elements, element)
+		return nil
+	})
+	if err := converter.emitMetadata(index.Metadata); err != nil {
+		t.Fatalf("unexpected error emitting metadata: %s", err)
+	}
+	if err := converter.emitExternalSymbols(index.ExternalSymbols); err != nil {
+		t.Fatalf("unexpected error emitting external symbols: %s", err)
+	}
+	for _, emit := range []func(document *scip.Document) error{converter.emitDocumentSymbols, converter.emitDocument} {
+		for _, document := range index.Documents {
+			if err := emit(document); err != nil {
+				t.Fatalf("unexpected error emitting document: %s", err)
+			}
+		}
+	}
+
+	if diff := cmp.Diff(expected, elements); diff != "" {
+		t.Errorf("unexpected elements (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,810 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"golang.org/x/sync/errgroup"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// deltaUpload is the data correlated from a delta upload, which contains only the documents that
+// changed since its base upload, prepared to be merged with the data of the base upload into a
+// complete dump.
+//
+// The documents of the base upload that were not replaced by the delta upload and still exist at its
+// commit are shared with the delta upload rather than copied. Their ranges keep referring to the result
+// sets of the base upload, so the result chunks of the dump are those of the base upload with locations
+// in replaced or deleted documents removed, combined with the result sets of the delta upload. To keep
+// the result sets of both uploads from colliding, the result identifiers of the delta upload are namespaced
+// by its upload identifier and the number of result chunks of the base upload is retained.
+//
+// A result set of the delta upload is linked to a result set of the base upload when a range of a changed
+// document and a range of the previous version of that document carry the same (non-local) moniker, or have
+// the same position and hover text. Linked result sets are merged into the result set of the base upload so
+// that navigation between changed and unchanged documents works in both directions.
+//
+// The result chunks and moniker locations of the base upload are streamed from the store while they are
+// written. Only the changed documents, the previous versions of those documents, and the result chunks of
+// linked result sets are read into memory.
+type deltaUpload struct {
+	baseUploadID    int
+	numResultChunks int
+	documents       map[string]precise.DocumentData
+	sharedPaths     []string
+	shared          map[string]struct{}
+
+	// results holds, indexed by result chunk, the result sets of the delta upload that were not linked
+	// as well as the merged result sets of the base upload that were linked.
+	results map[int]map[precise.ID][]precise.DocumentPathRangeID
+	// linked is the set of result identifiers of the base upload that are replaced by an entry in results.
+	linked map[precise.ID]struct{}
+
+	definitions     map[string]map[string]map[string][]precise.LocationData
+	references      map[string]map[string]map[string][]precise.LocationData
+	implementations map[string]map[string]map[string][]precise.LocationData
+
+	// packageReferences are the package references of the delta upload, along with references made by
+	// shared documents to packages that are no longer provided by the dump.
+	packageReferences []precise.PackageReference
+	// excludedPackages and excludedPackageReferences are the packages and package references of the base
+	// upload that were only provided or made by the replaced or deleted documents.
+	excludedPackages          []precise.Package
+	excludedPackageReferences []precise.Package
+}
+
+// sharedDocumentBatchSize is the number of shared documents read at once when checking which packages
+// of the base upload are still provided or referenced by the dump.
+const sharedDocumentBatchSize = 100
+
+// resultChunkReadBatchSize is the number of result chunks of the base upload read at once when merging
+// linked result sets.
+const resultChunkReadBatchSize = 50
+
+// prepareDeltaUpload reads the correlated data of the given delta upload and prepares it to be merged
+// with the data of its base upload by writeDeltaData.
+func prepareDeltaUpload(
+	ctx context.Context,
+	lsifStore LSIFStore,
+	upload store.Upload,
+	groupedBundleData *precise.GroupedBundleDataChans,
+	getChildren pathexistence.GetChildrenFunc,
+) (*deltaUpload, error) {
+	baseUploadID := *upload.BaseUploadID
+	bundle := precise.GroupedBundleDataChansToMaps(groupedBundleData)
+
+	meta, err := lsifStore.ReadMeta(ctx, baseUploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.ReadMeta")
+	}
+
+	basePaths, err := lsifStore.AllDocumentPaths(ctx, baseUploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.AllDocumentPaths")
+	}
+	if len(basePaths) == 0 {
+		return nil, errors.Newf("base upload %d has no code intelligence data", baseUploadID)
+	}
+
+	replacedPaths := make([]string, 0, len(bundle.Documents))
+	candidatePaths := make([]string, 0, len(basePaths))
+	for _, path := range basePaths {
+		if _, ok := bundle.Documents[path]; ok {
+			replacedPaths = append(replacedPaths, path)
+		} else {
+			candidatePaths = append(candidatePaths, path)
+		}
+	}
+
+	checker, err := pathexistence.NewExistenceChecker(ctx, upload.Root, candidatePaths, getChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedPaths := make([]string, 0, len(candidatePaths))
+	deletedPaths := make([]string, 0, len(candidatePaths))
+	shared := make(map[string]struct{}, len(candidatePaths))
+	for _, path := range candidatePaths {
+		if checker.Exists(path) {
+			sharedPaths = append(sharedPaths, path)
+			shared[path] = struct{}{}
+		} else {
+			deletedPaths = append(deletedPaths, path)
+		}
+	}
+
+	results := namespaceResults(upload.ID, bundle)
+	linker := newResultSetLinker(bundle.Documents)
+	replacedPackages := newMonikerPackages()
+
+	if err := lsifStore.VisitDocuments(ctx, baseUploadID, replacedPaths, func(path string, document precise.DocumentData) {
+		linker.link(path, document)
+		replacedPackages.add(document)
+	}); err != nil {
+		return nil, errors.Wrap(err, "store.VisitDocuments")
+	}
+	if err := lsifStore.VisitDocuments(ctx, baseUploadID, deletedPaths, func(_ string, document precise.DocumentData) {
+		replacedPackages.add(document)
+	}); err != nil {
+		return nil, errors.Wrap(err, "store.VisitDocuments")
+	}
+
+	delta := &deltaUpload{
+		baseUploadID:    baseUploadID,
+		numResultChunks: meta.NumResultChunks,
+		documents:       bundle.Documents,
+		sharedPaths:     sharedPaths,
+		shared:          shared,
+		results:         map[int]map[precise.ID][]precise.DocumentPathRangeID{},
+		linked:          map[precise.ID]struct{}{},
+		definitions:     bundle.Definitions,
+		references:      bundle.References,
+		implementations: bundle.Implementations,
+	}
+
+	if err := delta.mergeLinkedResults(ctx, lsifStore, linker, results); err != nil {
+		return nil, err
+	}
+	if err := delta.reconcilePackages(ctx, lsifStore, bundle.Packages, bundle.PackageReferences, replacedPackages); err != nil {
+		return nil, err
+	}
+
+	return delta, nil
+}
+
+// namespaceResults namespaces the result identifiers of the given delta bundle by the given upload
+// identifier so that they do not collide with the result identifiers referenced by the documents of
+// the base upload. The documents of the bundle are modified in place. The result sets of the bundle
+// are returned keyed by their namespaced identifier.
+func namespaceResults(uploadID int, bundle *precise.GroupedBundleDataMaps) map[precise.ID][]precise.DocumentPathRangeID {
+	results := map[precise.ID][]precise.DocumentPathRangeID{}
+	for _, resultChunk := range bundle.ResultChunks {
+		for id, documentIDRangeIDs := range resultChunk.DocumentIDRangeIDs {
+			locations := make([]precise.DocumentPathRangeID, 0, len(documentIDRangeIDs))
+			for _, documentIDRangeID := range documentIDRangeIDs {
+				locations = append(locations, precise.DocumentPathRangeID{
+					Path:    resultChunk.DocumentPaths[documentIDRangeID.DocumentID],
+					RangeID: documentIDRangeID.RangeID,
+				})
+			}
+			results[deltaResultID(uploadID, id)] = locations
+		}
+	}
+
+	for _, document := range bundle.Documents {
+		for id, r := range document.Ranges {
+			r.DefinitionResultID = deltaResultID(uploadID, r.DefinitionResultID)
+			r.ReferenceResultID = deltaResultID(uploadID, r.ReferenceResultID)
+			r.ImplementationResultID = deltaResultID(uploadID, r.ImplementationResultID)
+			document.Ranges[id] = r
+		}
+	}
+
+	return results
+}
+
+// deltaResultID namespaces the given result identifier of a delta upload. Empty identifiers are
+// returned unchanged.
+func deltaResultID(uploadID int, id precise.ID) precise.ID {
+	if id == "" {
+		return ""
+	}
+
+	return precise.ID(fmt.Sprintf("%d:%s", uploadID, id))
+}
+
+// mergeLinkedResults merges each result set of the base upload that was linked to a result set of the
+// delta upload with the result sets it was linked to. Ranges of the changed documents referring to a
+// linked result set are rewritten to refer to the merged result set of the base upload instead.
+func (d *deltaUpload) mergeLinkedResults(ctx context.Context, lsifStore LSIFStore, linker *resultSetLinker, results map[precise.ID][]precise.DocumentPathRangeID) error {
+	classes := linker.classes()
+
+	// Map each linked result set of the delta upload to the smallest linked result set of the base upload
+	representatives := map[precise.ID]precise.ID{}
+	baseIDsByIndex := map[int][]precise.ID{}
+	for _, class := range classes {
+		for _, id := range class.deltaIDs {
+			representatives[id] = class.baseIDs[0]
+		}
+		for _, id := range class.baseIDs {
+			index := precise.HashKey(id, d.numResultChunks)
+			baseIDsByIndex[index] = append(baseIDsByIndex[index], id)
+			d.linked[id] = struct{}{}
+		}
+	}
+
+	baseLocations := make(map[precise.ID][]precise.DocumentPathRangeID, len(d.linked))
+	indexes := make([]int, 0, len(baseIDsByIndex))
+	for index := range baseIDsByIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for len(indexes) > 0 {
+		var batch []int
+		if len(indexes) <= resultChunkReadBatchSize {
+			batch, indexes = indexes, nil
+		} else {
+			batch, indexes = indexes[:resultChunkReadBatchSize], indexes[resultChunkReadBatchSize:]
+		}
+
+		resultChunks, err := lsifStore.ReadResultChunks(ctx, d.baseUploadID, batch)
+		if err != nil {
+			return errors.Wrap(err, "store.ReadResultChunks")
+		}
+
+		for index, resultChunk := range resultChunks {
+			for _, id := range baseIDsByIndex[index] {
+				baseLocations[id] = d.sharedLocations(resultChunk, id)
+			}
+		}
+	}
+
+	for _, class := range classes {
+		var locations []precise.DocumentPathRangeID
+		for _, id := range class.baseIDs {
+			locations = append(locations, baseLocations[id]...)
+		}
+		for _, id := range class.deltaIDs {
+			locations = append(locations, results[id]...)
+			delete(results, id)
+		}
+		locations = deduplicateLocations(locations)
+
+		for _, id := range class.baseIDs {
+			results[id] = locations
+		}
+	}
+
+	for id, locations := range results {
+		index := precise.HashKey(id, d.numResultChunks)
+		if _, ok := d.results[index]; !ok {
+			d.results[index] = map[precise.ID][]precise.DocumentPathRangeID{}
+		}
+		d.results[index][id] = locations
+	}
+
+	rewrite := func(id precise.ID) precise.ID {
+		if representative, ok := representatives[id]; ok {
+			return representative
+		}
+		return id
+	}
+
+	for _, document := range d.documents {
+		for id, r := range document.Ranges {
+			r.DefinitionResultID = rewrite(r.DefinitionResultID)
+			r.ReferenceResultID = rewrite(r.ReferenceResultID)
+			r.ImplementationResultID = rewrite(r.ImplementationResultID)
+			document.Ranges[id] = r
+		}
+	}
+
+	return nil
+}
+
+// sharedLocations returns the locations of the given result set of the base upload that fall within a
+// shared document.
+func (d *deltaUpload) sharedLocations(resultChunk precise.ResultChunkData, id precise.ID) []precise.DocumentPathRangeID {
+	documentIDRangeIDs := resultChunk.DocumentIDRangeIDs[id]
+
+	locations := make([]precise.DocumentPathRangeID, 0, len(documentIDRangeIDs))
+	for _, documentIDRangeID := range documentIDRangeIDs {
+		path := resultChunk.DocumentPaths[documentIDRangeID.DocumentID]
+		if _, ok := d.shared[path]; ok {
+			locations = append(locations, precise.DocumentPathRangeID{Path: path, RangeID: documentIDRangeID.RangeID})
+		}
+	}
+
+	return locations
+}
+
+func deduplicateLocations(locations []precise.DocumentPathRangeID) []precise.DocumentPathRangeID {
+	seen := make(map[precise.DocumentPathRangeID]struct{}, len(locations))
+
+	filtered := locations[:0]
+	for _, location := range locations {
+		if _, ok := seen[location]; ok {
+			continue
+		}
+
+		seen[location] = struct{}{}
+		filtered = append(filtered, location)
+	}
+
+	return filtered
+}
+
+// reconcilePackages determines which packages and package references of the base upload must not be
+// carried over into the dump. Only packages provided or referenced by a replaced or deleted document are
+// at risk of no longer belonging to the dump; the shared documents are read only until each of them is
+// found to still be provided or referenced.
+func (d *deltaUpload) reconcilePackages(ctx context.Context, lsifStore LSIFStore, packages []precise.Package, packageReferences []precise.PackageReference, replaced *monikerPackages) error {
+	exports := replaced.exports
+	imports := replaced.imports
+	for _, pkg := range packages {
+		delete(exports, pkg)
+		delete(imports, pkg)
+	}
+	for _, packageReference := range packageReferences {
+		delete(imports, packageReference.Package)
+	}
+
+	// Packages no longer provided by the dump may still be referenced by the shared documents
+	sharedImports := map[precise.Package]struct{}{}
+
+	paths := d.sharedPaths
+	for len(paths) > 0 && (len(exports) > 0 || len(imports) > 0) {
+		var batch []string
+		if len(paths) <= sharedDocumentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:sharedDocumentBatchSize], paths[sharedDocumentBatchSize:]
+		}
+
+		shared := newMonikerPackages()
+		if err := lsifStore.VisitDocuments(ctx, d.baseUploadID, batch, func(_ string, document precise.DocumentData) {
+			shared.add(document)
+		}); err != nil {
+			return errors.Wrap(err, "store.VisitDocuments")
+		}
+
+		for pkg := range shared.exports {
+			delete(exports, pkg)
+		}
+		for pkg := range shared.imports {
+			delete(imports, pkg)
+			sharedImports[pkg] = struct{}{}
+		}
+	}
+
+	d.packageReferences = packageReferences
+	for pkg := range exports {
+		d.excludedPackages = append(d.excludedPackages, pkg)
+
+		// The scan above only stops early once every package at risk is found to still be provided,
+		// so sharedImports holds every package imported by a shared document at this point.
+		if _, ok := sharedImports[pkg]; ok {
+			d.packageReferences = append(d.packageReferences, precise.PackageReference{Package: pkg})
+		}
+	}
+	for pkg := range imports {
+		d.excludedPackageReferences = append(d.excludedPackageReferences, pkg)
+	}
+
+	return nil
+}
+
+// monikerPackages is the set of packages provided (exported) and referenced (imported or implemented)
+// through the monikers of a set of documents.
+type monikerPackages struct {
+	exports map[precise.Package]struct{}
+	imports map[precise.Package]struct{}
+}
+
+func newMonikerPackages() *monikerPackages {
+	return &monikerPackages{
+		exports: map[precise.Package]struct{}{},
+		imports: map[precise.Package]struct{}{},
+	}
+}
+
+func (p *monikerPackages) add(document precise.DocumentData) {
+	for _, moniker := range document.Monikers {
+		if moniker.PackageInformationID == "" {
+			continue
+		}
+
+		packageInformation := document.PackageInformation[moniker.PackageInformationID]
+		pkg := precise.Package{
+			Scheme:  moniker.Scheme,
+			Name:    packageInformation.Name,
+			Version: packageInformation.Version,
+		}
+
+		switch moniker.Kind {
+		case "export":
+			p.exports[pkg] = struct{}{}
+		case "import", "implementation":
+			p.imports[pkg] = struct{}{}
+		}
+	}
+}
+
+// resultSetLinker links the result sets of the changed documents of a delta upload with the result
+// sets of the previous versions of those documents.
+type resultSetLinker struct {
+	ranges  map[linkKey][]precise.RangeData
+	parents map[precise.ID]precise.ID
+	isDelta map[precise.ID]bool
+}
+
+// linkKey identifies a range across two versions of a document: either by position and hover text
+// (when path is set) or by a non-local moniker.
+type linkKey struct {
+	path                                                 string
+	startLine, startCharacter, endLine, endCharacter     int
+	hover, monikerKind, monikerScheme, monikerIdentifier string
+}
+
+func newResultSetLinker(documents map[string]precise.DocumentData) *resultSetLinker {
+	linker := &resultSetLinker{
+		ranges:  map[linkKey][]precise.RangeData{},
+		parents: map[precise.ID]precise.ID{},
+		isDelta: map[precise.ID]bool{},
+	}
+
+	for path, document := range documents {
+		for _, r := range document.Ranges {
+			for _, key := range linkKeys(path, document, r) {
+				linker.ranges[key] = append(linker.ranges[key], r)
+			}
+		}
+	}
+
+	return linker
+}
+
+func linkKeys(path string, document precise.DocumentData, r precise.RangeData) []linkKey {
+	var keys []linkKey
+	if hover := document.HoverResults[r.HoverResultID]; hover != "" {
+		keys = append(keys, linkKey{
+			path:           path,
+			startLine:      r.StartLine,
+			startCharacter: r.StartCharacter,
+			endLine:        r.EndLine,
+			endCharacter:   r.EndCharacter,
+			hover:          hover,
+		})
+	}
+
+	for _, monikerID := range r.MonikerIDs {
+		// Local monikers are not stable across indexer runs
+		if moniker := document.Monikers[monikerID]; moniker.Kind != "local" {
+			keys = append(keys, linkKey{
+				monikerKind:       moniker.Kind,
+				monikerScheme:     moniker.Scheme,
+				monikerIdentifier: moniker.Identifier,
+			})
+		}
+	}
+
+	return keys
+}
+
+// link links the result sets of the ranges of the given previous version of a changed document with
+// the result sets of the matching ranges of the changed documents.
+func (l *resultSetLinker) link(path string, document precise.DocumentData) {
+	for _, r := range document.Ranges {
+		for _, key := range linkKeys(path, document, r) {
+			for _, other := range l.ranges[key] {
+				l.union(r.DefinitionResultID, other.DefinitionResultID)
+				l.union(r.ReferenceResultID, other.ReferenceResultID)
+				l.union(r.ImplementationResultID, other.ImplementationResultID)
+			}
+		}
+	}
+}
+
+func (l *resultSetLinker) union(baseID, deltaID precise.ID) {
+	if baseID == "" || deltaID == "" {
+		return
+	}
+
+	l.isDelta[deltaID] = true
+	if _, ok := l.isDelta[baseID]; !ok {
+		l.isDelta[baseID] = false
+	}
+
+	if a, b := l.find(baseID), l.find(deltaID); a != b {
+		l.parents[b] = a
+	}
+}
+
+func (l *resultSetLinker) find(id precise.ID) precise.ID {
+	parent, ok := l.parents[id]
+	if !ok || parent == id {
+		return id
+	}
+
+	root := l.find(parent)
+	l.parents[id] = root
+	return root
+}
+
+// linkedResultSets is a set of linked result sets. Identifiers are sorted.
+type linkedResultSets struct {
+	baseIDs  []precise.ID
+	deltaIDs []precise.ID
+}
+
+// classes returns the sets of linked result sets.
+func (l *resultSetLinker) classes() []linkedResultSets {
+	classesByRoot := map[precise.ID]*linkedResultSets{}
+	for id, isDelta := range l.isDelta {
+		root := l.find(id)
+		if _, ok := classesByRoot[root]; !ok {
+			classesByRoot[root] = &linkedResultSets{}
+		}
+
+		if isDelta {
+			classesByRoot[root].deltaIDs = append(classesByRoot[root].deltaIDs, id)
+		} else {
+			classesByRoot[root].baseIDs = append(classesByRoot[root].baseIDs, id)
+		}
+	}
+
+	classes := make([]linkedResultSets, 0, len(classesByRoot))
+	for _, class := range classesByRoot {
+		sort.Slice(class.baseIDs, func(i, j int) bool { return class.baseIDs[i] < class.baseIDs[j] })
+		sort.Slice(class.deltaIDs, func(i, j int) bool { return class.deltaIDs[i] < class.deltaIDs[j] })
+		classes = append(classes, *class)
+	}
+
+	return classes
+}
+
+// writeDeltaData transactionally writes the given delta upload into the given LSIF store. The documents
+// of the base upload that did not change are shared rather than written, and the result chunks and moniker
+// locations of the base upload are streamed from the store (outside of the transaction) while the merged
+// values are written.
+func writeDeltaData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, delta *deltaUpload, trace observation.TraceLogger) (err error) {
+	tx, err := lsifStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.WriteMeta(ctx, upload.ID, precise.MetaData{NumResultChunks: delta.numResultChunks}); err != nil {
+		return errors.Wrap(err, "store.WriteMeta")
+	}
+
+	documents := make(chan precise.KeyedDocumentData, len(delta.documents))
+	for path, document := range delta.documents {
+		documents <- precise.KeyedDocumentData{Path: path, Document: document}
+	}
+	close(documents)
+
+	count, err := tx.WriteDocuments(ctx, upload.ID, documents)
+	if err != nil {
+		return errors.Wrap(err, "store.WriteDocuments")
+	}
+	trace.Log(otlog.Uint32("numDocuments", count))
+
+	count, err = tx.ShareDocuments(ctx, delta.baseUploadID, upload.ID, delta.sharedPaths)
+	if err != nil {
+		return errors.Wrap(err, "store.ShareDocuments")
+	}
+	trace.Log(otlog.Uint32("numSharedDocuments", count))
+
+	count, err = pipe(ctx, func(ctx context.Context, send func(precise.IndexedResultChunkData) bool) error {
+		return delta.mergeResultChunks(ctx, lsifStore, send)
+	}, func(ctx context.Context, ch chan precise.IndexedResultChunkData) (uint32, error) {
+		return tx.WriteResultChunks(ctx, upload.ID, ch)
+	})
+	if err != nil {
+		return errors.Wrap(err, "store.WriteResultChunks")
+	}
+	trace.Log(otlog.Uint32("numResultChunks", count))
+
+	for _, monikers := range []struct {
+		name  string
+		visit func(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error
+		write func(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (uint32, error)
+		delta map[string]map[string]map[string][]precise.LocationData
+	}{
+		{"Definitions", lsifStore.VisitDefinitions, tx.WriteDefinitions, delta.definitions},
+		{"References", lsifStore.VisitReferences, tx.WriteReferences, delta.references},
+		{"Implementations", lsifStore.VisitImplementations, tx.WriteImplementations, delta.implementations},
+	} {
+		count, err = pipe(ctx, func(ctx context.Context, send func(precise.MonikerLocations) bool) error {
+			return delta.mergeMonikerLocations(ctx, monikers.visit, monikers.delta, send)
+		}, func(ctx context.Context, ch chan precise.MonikerLocations) (uint32, error) {
+			return monikers.write(ctx, upload.ID, ch)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "store.Write%s", monikers.name)
+		}
+		trace.Log(otlog.Uint32("num"+monikers.name, count))
+	}
+
+	if err := tx.WriteDataSize(ctx, upload.ID); err != nil {
+		return errors.Wrap(err, "store.WriteDataSize")
+	}
+
+	return nil
+}
+
+// pipe calls produce and consume concurrently, sending each value produced over a channel read by consume.
+// If either function fails, the context passed to both is canceled.
+func pipe[T any](
+	ctx context.Context,
+	produce func(ctx context.Context, send func(T) bool) error,
+	consume func(ctx context.Context, ch chan T) (uint32, error),
+) (count uint32, _ error) {
+	g, ctx := errgroup.WithContext(ctx)
+	ch := make(chan T)
+
+	g.Go(func() error {
+		defer close(ch)
+
+		return produce(ctx, func(v T) bool {
+			select {
+			case ch <- v:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	})
+
+	g.Go(func() (err error) {
+		count, err = consume(ctx, ch)
+		return err
+	})
+
+	return count, g.Wait()
+}
+
+// mergeResultChunks sends the result chunks of the dump in order of their indexes. Each result chunk is
+// the result chunk of the base upload with the same index, without linked result sets and locations outside
+// of the shared documents, combined with the result sets of the delta upload hashed to that index.
+func (d *deltaUpload) mergeResultChunks(ctx context.Context, lsifStore LSIFStore, send func(precise.IndexedResultChunkData) bool) error {
+	next := 0
+	emit := func(index int, base precise.ResultChunkData) bool {
+		results := map[precise.ID][]precise.DocumentPathRangeID{}
+		for id := range base.DocumentIDRangeIDs {
+			if _, ok := d.linked[id]; ok {
+				continue
+			}
+			if locations := d.sharedLocations(base, id); len(locations) > 0 {
+				results[id] = locations
+			}
+		}
+		for id, locations := range d.results[index] {
+			results[id] = locations
+		}
+
+		if len(results) == 0 {
+			return true
+		}
+		return send(precise.IndexedResultChunkData{Index: index, ResultChunk: makeResultChunk(results)})
+	}
+
+	// The visitor cannot abort the visit, so once the consumer has stopped the remaining
+	// result chunks of the base upload are skipped.
+	stopped := false
+	if err := lsifStore.VisitResultChunks(ctx, d.baseUploadID, func(index int, resultChunk precise.ResultChunkData) {
+		for ; next < index && !stopped; next++ {
+			stopped = !emit(next, precise.ResultChunkData{})
+		}
+		if stopped {
+			return
+		}
+
+		stopped = !emit(index, resultChunk)
+		next = index + 1
+	}); err != nil {
+		return err
+	}
+	if stopped {
+		return ctx.Err()
+	}
+
+	for ; next < d.numResultChunks; next++ {
+		if !emit(next, precise.ResultChunkData{}) {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// makeResultChunk creates a result chunk containing the given result sets.
+func makeResultChunk(results map[precise.ID][]precise.DocumentPathRangeID) precise.ResultChunkData {
+	ids := make([]precise.ID, 0, len(results))
+	for id := range results {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	resultChunk := precise.ResultChunkData{
+		DocumentPaths:      map[precise.ID]string{},
+		DocumentIDRangeIDs: make(map[precise.ID][]precise.DocumentIDRangeID, len(results)),
+	}
+	documentIDs := map[string]precise.ID{}
+
+	for _, id := range ids {
+		documentIDRangeIDs := make([]precise.DocumentIDRangeID, 0, len(results[id]))
+		for _, location := range results[id] {
+			documentID, ok := documentIDs[location.Path]
+			if !ok {
+				documentID = precise.ID(strconv.Itoa(len(documentIDs) + 1))
+				documentIDs[location.Path] = documentID
+				resultChunk.DocumentPaths[documentID] = location.Path
+			}
+
+			documentIDRangeIDs = append(documentIDRangeIDs, precise.DocumentIDRangeID{
+				DocumentID: documentID,
+				RangeID:    location.RangeID,
+			})
+		}
+
+		resultChunk.DocumentIDRangeIDs[id] = documentIDRangeIDs
+	}
+
+	return resultChunk
+}
+
+// mergeMonikerLocations sends the moniker locations of the base upload that fall within a shared document,
+// combined with the given moniker locations of the delta upload, which are keyed by kind, scheme, and identifier.
+// Locations of the same scheme and identifier are merged into a single value as they are stored in the same row.
+func (d *deltaUpload) mergeMonikerLocations(
+	ctx context.Context,
+	visit func(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error,
+	delta map[string]map[string]map[string][]precise.LocationData,
+	send func(precise.MonikerLocations) bool,
+) error {
+	type monikerKey struct{ scheme, identifier string }
+	deltaMonikerLocations := map[monikerKey]precise.MonikerLocations{}
+	for kind, kindMap := range delta {
+		for scheme, identMap := range kindMap {
+			for identifier, locations := range identMap {
+				key := monikerKey{scheme, identifier}
+				monikerLocations := deltaMonikerLocations[key]
+				monikerLocations.Kind = kind
+				monikerLocations.Scheme = scheme
+				monikerLocations.Identifier = identifier
+				monikerLocations.Locations = append(monikerLocations.Locations, locations...)
+				deltaMonikerLocations[key] = monikerLocations
+			}
+		}
+	}
+
+	stopped := false
+	if err := visit(ctx, d.baseUploadID, func(monikerLocations precise.MonikerLocations) {
+		if stopped {
+			return
+		}
+
+		locations := make([]precise.LocationData, 0, len(monikerLocations.Locations))
+		for _, location := range monikerLocations.Locations {
+			if _, ok := d.shared[location.URI]; ok {
+				locations = append(locations, location)
+			}
+		}
+
+		key := monikerKey{monikerLocations.Scheme, monikerLocations.Identifier}
+		if deltaLocations, ok := deltaMonikerLocations[key]; ok {
+			locations = append(locations, deltaLocations.Locations...)
+			monikerLocations.Kind = deltaLocations.Kind
+			delete(deltaMonikerLocations, key)
+		}
+
+		if len(locations) > 0 {
+			monikerLocations.Locations = locations
+			stopped = !send(monikerLocations)
+		}
+	}); err != nil {
+		return err
+	}
+	if stopped {
+		return ctx.Err()
+	}
+
+	for _, monikerLocations := range deltaMonikerLocations {
+		if !send(monikerLocations) {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,565 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// TestDeltaUploadMatchesFullUpload merges a delta upload with its base upload and checks that every
+// range of the resulting dump resolves to the same definitions, references, and hover text as it does in
+// a full upload of the same commit, and that the moniker locations and packages of both dumps agree.
+func TestDeltaUploadMatchesFullUpload(t *testing.T) {
+	pkg := &testMoniker{kind: "export", scheme: "gomod", identifier: "pkg.F", name: "pkg", version: "v1"}
+	pkgK := &testMoniker{kind: "export", scheme: "gomod", identifier: "pkg.K", name: "pkg", version: "v1"}
+	ext := &testMoniker{kind: "import", scheme: "gomod", identifier: "ext.H", name: "ext", version: "v1"}
+	gone := &testMoniker{kind: "import", scheme: "gomod", identifier: "gone.X", name: "gone", version: "v1"}
+
+	// a.go defines F, b.go defines G (without a moniker) and references both, c.go references both as
+	// well as an external package, and d.go references G and a package referenced nowhere else.
+	a := []testRange{
+		{id: "a1", line: 1, hover: "func F()", def: "F.def", ref: "F.ref", isDef: true, moniker: pkg},
+		{id: "a2", line: 5, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+	}
+	c := []testRange{
+		{id: "c1", line: 1, hover: "func G()", def: "G.def", ref: "G.ref"},
+		{id: "c2", line: 2, hover: "func H()", ref: "H.ref", moniker: ext},
+		{id: "c3", line: 3, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+	}
+	base := makeTestBundle(3, map[string][]testRange{
+		"a.go": a,
+		"b.go": {
+			{id: "b1", line: 2, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+			{id: "b2", line: 3, hover: "func G()", def: "G.def", ref: "G.ref", isDef: true},
+			{id: "b3", line: 4, hover: "func G()", def: "G.def", ref: "G.ref"},
+		},
+		"c.go": c,
+		"d.go": {
+			{id: "d1", line: 1, hover: "func G()", def: "G.def", ref: "G.ref"},
+			{id: "d2", line: 2, hover: "func X()", ref: "X.ref", moniker: gone},
+		},
+	})
+
+	// b.go gains references to F and G, d.go is deleted, and e.go is added to reference F and define K.
+	changed := map[string][]testRange{
+		"b.go": {
+			{id: "n1", line: 2, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+			{id: "n2", line: 3, hover: "func G()", def: "G.def", ref: "G.ref", isDef: true},
+			{id: "n3", line: 4, hover: "func G()", def: "G.def", ref: "G.ref"},
+			{id: "n4", line: 10, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+			{id: "n5", line: 11, hover: "func G()", def: "G.def", ref: "G.ref"},
+		},
+		"e.go": {
+			{id: "n6", line: 1, hover: "func F()", def: "F.def", ref: "F.ref", moniker: pkg},
+			{id: "n7", line: 2, hover: "func K()", def: "K.def", ref: "K.ref", isDef: true, moniker: pkgK},
+		},
+	}
+	full := makeTestBundle(2, map[string][]testRange{"a.go": a, "b.go": changed["b.go"], "c.go": c, "e.go": changed["e.go"]})
+	delta := makeTestBundle(1, changed)
+
+	lsifStore := newFakeLSIFStore()
+	lsifStore.add(1, base)
+
+	getChildren := func(ctx context.Context, dirnames []string) (map[string][]string, error) {
+		return map[string][]string{"": {"a.go", "b.go", "c.go", "e.go"}}, nil
+	}
+
+	baseUploadID := 1
+	upload := store.Upload{ID: 2, BaseUploadID: &baseUploadID}
+	prepared, err := prepareDeltaUpload(context.Background(), lsifStore, upload, precise.GroupedBundleDataMapsToChans(context.Background(), delta), getChildren)
+	if err != nil {
+		t.Fatalf("unexpected error preparing delta upload: %s", err)
+	}
+	if err := writeDeltaData(context.Background(), lsifStore, upload, prepared, observation.TestTraceLogger(logtest.Scoped(t))); err != nil {
+		t.Fatalf("unexpected error writing delta upload: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"a.go", "c.go"}, lsifStore.shared[2]); diff != "" {
+		t.Errorf("unexpected shared documents (-want +got):\n%s", diff)
+	}
+
+	merged := lsifStore.bundle(2)
+	for path, document := range full.Documents {
+		for _, r := range document.Ranges {
+			want, err := precise.Query(full, path, r.StartLine, r.StartCharacter)
+			if err != nil {
+				t.Fatalf("unexpected error querying full upload: %s", err)
+			}
+			have, err := precise.Query(merged, path, r.StartLine, r.StartCharacter)
+			if err != nil {
+				t.Fatalf("unexpected error querying delta upload: %s", err)
+			}
+
+			if diff := cmp.Diff(normalizeQueryResults(want), normalizeQueryResults(have)); diff != "" {
+				t.Errorf("unexpected results at %s:%d (-want +got):\n%s", path, r.StartLine, diff)
+			}
+		}
+	}
+
+	for name, tables := range map[string][2]map[string]map[string]map[string][]precise.LocationData{
+		"definitions":     {full.Definitions, merged.Definitions},
+		"references":      {full.References, merged.References},
+		"implementations": {full.Implementations, merged.Implementations},
+	} {
+		if diff := cmp.Diff(normalizeMonikerLocations(tables[0]), normalizeMonikerLocations(tables[1])); diff != "" {
+			t.Errorf("unexpected %s (-want +got):\n%s", name, diff)
+		}
+	}
+
+	// Emulate the package data written by the handler
+	packages := map[precise.Package]struct{}{}
+	for _, p := range delta.Packages {
+		packages[p] = struct{}{}
+	}
+	for _, p := range base.Packages {
+		if !containsPackage(prepared.excludedPackages, p) {
+			packages[p] = struct{}{}
+		}
+	}
+	packageReferences := map[precise.Package]struct{}{}
+	for _, r := range prepared.packageReferences {
+		packageReferences[r.Package] = struct{}{}
+	}
+	for _, r := range base.PackageReferences {
+		if !containsPackage(prepared.excludedPackageReferences, r.Package) {
+			packageReferences[r.Package] = struct{}{}
+		}
+	}
+	for p := range packages {
+		delete(packageReferences, p)
+	}
+
+	if diff := cmp.Diff(packageSet(full.Packages), packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(packageReferenceSet(full.PackageReferences), packageReferences); diff != "" {
+		t.Errorf("unexpected package references (-want +got):\n%s", diff)
+	}
+}
+
+type testMoniker struct {
+	kind, scheme, identifier, name, version string
+}
+
+// testRange is a single-character range at the start of the given line. The result sets of a bundle are
+// derived from its ranges: the definition result of a range holds the ranges marked as the definition,
+// and its reference result holds every range referring to the same reference result.
+type testRange struct {
+	id       precise.ID
+	line     int
+	hover    string
+	def, ref precise.ID
+	isDef    bool
+	moniker  *testMoniker
+}
+
+func makeTestBundle(numResultChunks int, documents map[string][]testRange) *precise.GroupedBundleDataMaps {
+	bundle := &precise.GroupedBundleDataMaps{
+		Meta:            precise.MetaData{NumResultChunks: numResultChunks},
+		Documents:       map[string]precise.DocumentData{},
+		ResultChunks:    map[int]precise.ResultChunkData{},
+		Definitions:     map[string]map[string]map[string][]precise.LocationData{},
+		References:      map[string]map[string]map[string][]precise.LocationData{},
+		Implementations: map[string]map[string]map[string][]precise.LocationData{},
+	}
+
+	packages := map[precise.Package]struct{}{}
+	packageReferences := map[precise.Package]struct{}{}
+	results := map[precise.ID][]precise.DocumentPathRangeID{}
+
+	for path, ranges := range documents {
+		document := precise.DocumentData{
+			Ranges:             map[precise.ID]precise.RangeData{},
+			HoverResults:       map[precise.ID]string{},
+			Monikers:           map[precise.ID]precise.MonikerData{},
+			PackageInformation: map[precise.ID]precise.PackageInformationData{},
+		}
+
+		for _, r := range ranges {
+			hoverID := precise.ID("h:" + string(r.id))
+			document.HoverResults[hoverID] = r.hover
+
+			data := precise.RangeData{
+				StartLine:          r.line,
+				EndLine:            r.line,
+				EndCharacter:       1,
+				DefinitionResultID: r.def,
+				ReferenceResultID:  r.ref,
+				HoverResultID:      hoverID,
+			}
+
+			location := precise.DocumentPathRangeID{Path: path, RangeID: r.id}
+			if r.isDef {
+				results[r.def] = append(results[r.def], location)
+			}
+			results[r.ref] = append(results[r.ref], location)
+
+			if m := r.moniker; m != nil {
+				monikerID := precise.ID("m:" + string(r.id))
+				packageInformationID := precise.ID("p:" + string(r.id))
+				data.MonikerIDs = []precise.ID{monikerID}
+				document.Monikers[monikerID] = precise.MonikerData{Kind: m.kind, Scheme: m.scheme, Identifier: m.identifier, PackageInformationID: packageInformationID}
+				document.PackageInformation[packageInformationID] = precise.PackageInformationData{Name: m.name, Version: m.version}
+
+				table := bundle.References
+				if r.isDef {
+					table = bundle.Definitions
+				}
+				if _, ok := table[m.kind]; !ok {
+					table[m.kind] = map[string]map[string][]precise.LocationData{}
+				}
+				if _, ok := table[m.kind][m.scheme]; !ok {
+					table[m.kind][m.scheme] = map[string][]precise.LocationData{}
+				}
+				table[m.kind][m.scheme][m.identifier] = append(table[m.kind][m.scheme][m.identifier], precise.LocationData{
+					URI:          path,
+					StartLine:    r.line,
+					EndLine:      r.line,
+					EndCharacter: 1,
+				})
+
+				pkg := precise.Package{Scheme: m.scheme, Name: m.name, Version: m.version}
+				if m.kind == "export" {
+					packages[pkg] = struct{}{}
+				} else {
+					packageReferences[pkg] = struct{}{}
+				}
+			}
+
+			document.Ranges[r.id] = data
+		}
+
+		bundle.Documents[path] = document
+	}
+
+	resultsByIndex := map[int]map[precise.ID][]precise.DocumentPathRangeID{}
+	for id, locations := range results {
+		index := precise.HashKey(id, numResultChunks)
+		if _, ok := resultsByIndex[index]; !ok {
+			resultsByIndex[index] = map[precise.ID][]precise.DocumentPathRangeID{}
+		}
+		resultsByIndex[index][id] = locations
+	}
+	for index, results := range resultsByIndex {
+		bundle.ResultChunks[index] = makeResultChunk(results)
+	}
+
+	for pkg := range packages {
+		bundle.Packages = append(bundle.Packages, pkg)
+		delete(packageReferences, pkg)
+	}
+	for pkg := range packageReferences {
+		bundle.PackageReferences = append(bundle.PackageReferences, precise.PackageReference{Package: pkg})
+	}
+
+	return bundle
+}
+
+func normalizeQueryResults(results []precise.QueryResult) []precise.QueryResult {
+	for _, result := range results {
+		sortLocations(result.Definitions)
+		sortLocations(result.References)
+	}
+
+	return results
+}
+
+func normalizeMonikerLocations(table map[string]map[string]map[string][]precise.LocationData) map[string][]precise.LocationData {
+	normalized := map[string][]precise.LocationData{}
+	for _, kindMap := range table {
+		for scheme, identMap := range kindMap {
+			for identifier, locations := range identMap {
+				key := scheme + ":" + identifier
+				normalized[key] = append(normalized[key], locations...)
+				sortLocations(normalized[key])
+			}
+		}
+	}
+
+	return normalized
+}
+
+func sortLocations(locations []precise.LocationData) {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].URI != locations[j].URI {
+			return locations[i].URI < locations[j].URI
+		}
+		return precise.CompareLocations(locations[i], locations[j]) < 0
+	})
+}
+
+func containsPackage(packages []precise.Package, pkg precise.Package) bool {
+	for _, p := range packages {
+		if p == pkg {
+			return true
+		}
+	}
+
+	return false
+}
+
+func packageSet(packages []precise.Package) map[precise.Package]struct{} {
+	set := map[precise.Package]struct{}{}
+	for _, p := range packages {
+		set[p] = struct{}{}
+	}
+
+	return set
+}
+
+func packageReferenceSet(packageReferences []precise.PackageReference) map[precise.Package]struct{} {
+	set := map[precise.Package]struct{}{}
+	for _, r := range packageReferences {
+		set[r.Package] = struct{}{}
+	}
+
+	return set
+}
+
+// fakeLSIFStore is an in-memory LSIFStore that mirrors the way the codeintel database shares document
+// rows between a delta upload and the upload storing them.
+type fakeLSIFStore struct {
+	meta            map[int]precise.MetaData
+	documents       map[int]map[string]precise.DocumentData
+	shared          map[int][]string
+	sources         map[int]map[string]int
+	resultChunks    map[int]map[int]precise.ResultChunkData
+	definitions     map[int][]precise.MonikerLocations
+	references      map[int][]precise.MonikerLocations
+	implementations map[int][]precise.MonikerLocations
+}
+
+var _ LSIFStore = &fakeLSIFStore{}
+
+func newFakeLSIFStore() *fakeLSIFStore {
+	return &fakeLSIFStore{
+		meta:            map[int]precise.MetaData{},
+		documents:       map[int]map[string]precise.DocumentData{},
+		shared:          map[int][]string{},
+		sources:         map[int]map[string]int{},
+		resultChunks:    map[int]map[int]precise.ResultChunkData{},
+		definitions:     map[int][]precise.MonikerLocations{},
+		references:      map[int][]precise.MonikerLocations{},
+		implementations: map[int][]precise.MonikerLocations{},
+	}
+}
+
+// add stores the given bundle under the given identifier.
+func (s *fakeLSIFStore) add(bundleID int, bundle *precise.GroupedBundleDataMaps) {
+	chans := precise.GroupedBundleDataMapsToChans(context.Background(), bundle)
+	_ = s.WriteMeta(context.Background(), bundleID, chans.Meta)
+	_, _ = s.WriteDocuments(context.Background(), bundleID, chans.Documents)
+	_, _ = s.WriteResultChunks(context.Background(), bundleID, chans.ResultChunks)
+	_, _ = s.WriteDefinitions(context.Background(), bundleID, chans.Definitions)
+	_, _ = s.WriteReferences(context.Background(), bundleID, chans.References)
+	_, _ = s.WriteImplementations(context.Background(), bundleID, chans.Implementations)
+}
+
+// bundle reads the bundle stored under the given identifier, including the documents it shares.
+func (s *fakeLSIFStore) bundle(bundleID int) *precise.GroupedBundleDataMaps {
+	documents := map[string]precise.DocumentData{}
+	for path, document := range s.documents[bundleID] {
+		documents[path] = document
+	}
+	for path, sourceID := range s.sources[bundleID] {
+		documents[path] = s.documents[sourceID][path]
+	}
+
+	toMap := func(monikerLocations []precise.MonikerLocations) map[string]map[string]map[string][]precise.LocationData {
+		ch := make(chan precise.MonikerLocations, len(monikerLocations))
+		for _, l := range monikerLocations {
+			ch <- l
+		}
+		close(ch)
+
+		return precise.GroupedBundleDataChansToMaps(&precise.GroupedBundleDataChans{
+			Documents:       closedChan[precise.KeyedDocumentData](),
+			ResultChunks:    closedChan[precise.IndexedResultChunkData](),
+			Definitions:     ch,
+			References:      closedChan[precise.MonikerLocations](),
+			Implementations: closedChan[precise.MonikerLocations](),
+		}).Definitions
+	}
+
+	return &precise.GroupedBundleDataMaps{
+		Meta:            s.meta[bundleID],
+		Documents:       documents,
+		ResultChunks:    s.resultChunks[bundleID],
+		Definitions:     toMap(s.definitions[bundleID]),
+		References:      toMap(s.references[bundleID]),
+		Implementations: toMap(s.implementations[bundleID]),
+	}
+}
+
+func closedChan[T any]() chan T {
+	ch := make(chan T)
+	close(ch)
+	return ch
+}
+
+func (s *fakeLSIFStore) Transact(ctx context.Context) (LSIFStore, error) { return s, nil }
+func (s *fakeLSIFStore) Done(err error) error                            { return err }
+
+func (s *fakeLSIFStore) WriteMeta(ctx context.Context, bundleID int, meta precise.MetaData) error {
+	s.meta[bundleID] = meta
+	return nil
+}
+
+func (s *fakeLSIFStore) WriteDataSize(ctx context.Context, bundleID int) error { return nil }
+
+func (s *fakeLSIFStore) WriteDocuments(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (count uint32, _ error) {
+	s.documents[bundleID] = map[string]precise.DocumentData{}
+	for document := range documents {
+		s.documents[bundleID][document.Path] = document.Document
+		count++
+	}
+
+	return count, nil
+}
+
+func (s *fakeLSIFStore) ShareDocuments(ctx context.Context, sourceBundleID, targetBundleID int, paths []string) (count uint32, _ error) {
+	s.sources[targetBundleID] = map[string]int{}
+	for _, path := range paths {
+		if _, ok := s.documents[sourceBundleID][path]; ok {
+			s.sources[targetBundleID][path] = sourceBundleID
+		} else if sourceID, ok := s.sources[sourceBundleID][path]; ok {
+			s.sources[targetBundleID][path] = sourceID
+		} else {
+			continue
+		}
+
+		s.shared[targetBundleID] = append(s.shared[targetBundleID], path)
+		count++
+	}
+	sort.Strings(s.shared[targetBundleID])
+
+	return count, nil
+}
+
+func (s *fakeLSIFStore) WriteResultChunks(ctx context.Context, bundleID int, resultChunks chan precise.IndexedResultChunkData) (count uint32, _ error) {
+	s.resultChunks[bundleID] = map[int]precise.ResultChunkData{}
+	for resultChunk := range resultChunks {
+		if _, ok := s.resultChunks[bundleID][resultChunk.Index]; ok {
+			return 0, fmt.Errorf("duplicate result chunk %d", resultChunk.Index)
+		}
+
+		s.resultChunks[bundleID][resultChunk.Index] = resultChunk.ResultChunk
+		count++
+	}
+
+	return count, nil
+}
+
+func (s *fakeLSIFStore) WriteDefinitions(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (uint32, error) {
+	return writeFakeMonikers(s.definitions, bundleID, monikerLocations)
+}
+
+func (s *fakeLSIFStore) WriteReferences(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (uint32, error) {
+	return writeFakeMonikers(s.references, bundleID, monikerLocations)
+}
+
+func (s *fakeLSIFStore) WriteImplementations(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (uint32, error) {
+	return writeFakeMonikers(s.implementations, bundleID, monikerLocations)
+}
+
+func writeFakeMonikers(table map[int][]precise.MonikerLocations, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, _ error) {
+	seen := map[string]struct{}{}
+	for l := range monikerLocations {
+		// Mirror the primary key of the moniker tables
+		key := l.Scheme + ":" + l.Identifier
+		if _, ok := seen[key]; ok {
+			return 0, fmt.Errorf("duplicate moniker %s", key)
+		}
+		seen[key] = struct{}{}
+
+		table[bundleID] = append(table[bundleID], l)
+		count++
+	}
+
+	return count, nil
+}
+
+func (s *fakeLSIFStore) ReadMeta(ctx context.Context, bundleID int) (precise.MetaData, error) {
+	return s.meta[bundleID], nil
+}
+
+func (s *fakeLSIFStore) AllDocumentPaths(ctx context.Context, bundleID int) ([]string, error) {
+	paths := make([]string, 0, len(s.documents[bundleID])+len(s.sources[bundleID]))
+	for path := range s.documents[bundleID] {
+		paths = append(paths, path)
+	}
+	for path := range s.sources[bundleID] {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (s *fakeLSIFStore) VisitDocuments(ctx context.Context, bundleID int, paths []string, f func(path string, document precise.DocumentData)) error {
+	for _, path := range paths {
+		if document, ok := s.documents[bundleID][path]; ok {
+			f(path, document)
+		} else if sourceID, ok := s.sources[bundleID][path]; ok {
+			f(path, s.documents[sourceID][path])
+		}
+	}
+
+	return nil
+}
+
+func (s *fakeLSIFStore) ReadResultChunks(ctx context.Context, bundleID int, indexes []int) (map[int]precise.ResultChunkData, error) {
+	resultChunks := map[int]precise.ResultChunkData{}
+	for _, index := range indexes {
+		if resultChunk, ok := s.resultChunks[bundleID][index]; ok {
+			resultChunks[index] = resultChunk
+		}
+	}
+
+	return resultChunks, nil
+}
+
+func (s *fakeLSIFStore) VisitResultChunks(ctx context.Context, bundleID int, f func(index int, resultChunk precise.ResultChunkData)) error {
+	indexes := make([]int, 0, len(s.resultChunks[bundleID]))
+	for index := range s.resultChunks[bundleID] {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		f(index, s.resultChunks[bundleID][index])
+	}
+
+	return nil
+}
+
+func (s *fakeLSIFStore) VisitDefinitions(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error {
+	return visitFakeMonikers(s.definitions[bundleID], f)
+}
+
+func (s *fakeLSIFStore) VisitReferences(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error {
+	return visitFakeMonikers(s.references[bundleID], f)
+}
+
+func (s *fakeLSIFStore) VisitImplementations(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error {
+	return visitFakeMonikers(s.implementations[bundleID], f)
+}
+
+func visitFakeMonikers(monikerLocations []precise.MonikerLocations, f func(precise.MonikerLocations)) error {
+	for _, l := range monikerLocations {
+		// The kind of a moniker is not stored
+		l.Kind = ""
+		f(l)
+	}
+
+	return nil
+}
@@ -25,7 +25,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
-	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -146,14 +145,31 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 	}
 
 	return false, withUploadData(ctx, logger, h.uploadStore, upload.ID, trace, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		groupedBundleData, err := correlateUpload(ctx, r, upload.Root, getChildren)
 		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+			return err
+		}
+
+		// A delta upload contains only the documents that changed since its base upload. Merge it with
+		// the data of the base upload so that the resulting dump is indistinguishable from a full upload.
+		var delta *deltaUpload
+		if upload.BaseUploadID != nil {
+			trace.Log(otlog.Int("baseUploadID", *upload.BaseUploadID))
+
+			if delta, err = prepareDeltaUpload(ctx, h.lsifStore, upload, groupedBundleData, getChildren); err != nil {
+				return errors.Wrap(err, "prepareDeltaUpload")
+			}
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
-		// different transaction context (managed by the writeData function).
-		if err := writeData(ctx, h.lsifStore, upload, repo, isDefaultBranch, groupedBundleData, trace); err != nil {
+		// different transaction context (managed by the writeData and writeDeltaData functions).
+		write := func() error {
+			if delta != nil {
+				return writeDeltaData(ctx, h.lsifStore, upload, delta, trace)
+			}
+			return writeData(ctx, h.lsifStore, upload, repo, isDefaultBranch, groupedBundleData, trace)
+		}
+		if err := write(); err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
 				// upload record up to this point, but failed to perform the transaction below. We can
@@ -166,6 +182,11 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 			}
 		}
 
+		packageReferences := groupedBundleData.PackageReferences
+		if delta != nil {
+			packageReferences = delta.packageReferences
+		}
+
 		// Start a nested transaction with Postgres savepoints. In the event that something after this
 		// point fails, we want to update the upload record with an error message but do not want to
 		// alter any other data in the database. Rolling back to this savepoint will allow us to discard
@@ -199,10 +220,19 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 			if err := tx.UpdatePackages(ctx, upload.ID, groupedBundleData.Packages); err != nil {
 				return errors.Wrap(err, "store.UpdatePackages")
 			}
-			trace.Log(otlog.Int("packageReferences", len(groupedBundleData.Packages)))
-			if err := tx.UpdatePackageReferences(ctx, upload.ID, groupedBundleData.PackageReferences); err != nil {
+			trace.Log(otlog.Int("packageReferences", len(packageReferences)))
+			if err := tx.UpdatePackageReferences(ctx, upload.ID, packageReferences); err != nil {
 				return errors.Wrap(err, "store.UpdatePackageReferences")
 			}
+			if delta != nil {
+				// Carry over the package data of the base upload describing the documents shared with it.
+				if err := tx.CopyPackages(ctx, delta.baseUploadID, upload.ID, delta.excludedPackages); err != nil {
+					return errors.Wrap(err, "store.CopyPackages")
+				}
+				if err := tx.CopyPackageReferences(ctx, delta.baseUploadID, upload.ID, delta.excludedPackageReferences); err != nil {
+					return errors.Wrap(err, "store.CopyPackageReferences")
+				}
+			}
 
 			// When inserting a new completed upload record, update the reference counts both to it from
 			// existing uploads, as well as the reference counts to all of this new upload's dependencies.
@@ -283,8 +313,8 @@ func requeueIfCloningOrCommitUnknown(ctx context.Context, logger log.Logger, db
 }
 
 // withUploadData will invoke the given function with a reader of the upload's raw data. The
-// consumer should expect raw newline-delimited JSON content. If the function returns without
-// an error, the upload file will be deleted.
+// consumer should expect either raw newline-delimited JSON content or a SCIP protobuf index. If
+// the function returns without an error, the upload file will be deleted.
 func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadstore.Store, id int, trace observation.TraceLogger, fn func(r io.Reader) error) error {
 	uploadFilename := fmt.Sprintf("upload-%d.lsif.gz", id)
 
@@ -357,6 +387,10 @@ func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, re
 	}
 	trace.Log(otlog.Uint32("numImplementations", count))
 
+	if err := tx.WriteDataSize(ctx, upload.ID); err != nil {
+		return errors.Wrap(err, "store.WriteDataSize")
+	}
+
 	return nil
 }
 
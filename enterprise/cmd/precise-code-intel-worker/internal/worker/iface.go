@@ -23,6 +23,8 @@ type DBStore interface {
 	RepoName(ctx context.Context, id int) (string, error)
 	UpdatePackages(ctx context.Context, dumpID int, packages []precise.Package) error
 	UpdatePackageReferences(ctx context.Context, dumpID int, packageReferences []precise.PackageReference) error
+	CopyPackages(ctx context.Context, sourceDumpID, targetDumpID int, excluded []precise.Package) error
+	CopyPackageReferences(ctx context.Context, sourceDumpID, targetDumpID int, excluded []precise.Package) error
 	UpdateReferenceCounts(ctx context.Context, ids []int, dependencyUpdateType dbstore.DependencyReferenceCountUpdateType) (updatedUploads int, err error)
 	MarkRepositoryAsDirty(ctx context.Context, repositoryID int) error
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
@@ -52,11 +54,22 @@ type LSIFStore interface {
 	Done(err error) error
 
 	WriteMeta(ctx context.Context, bundleID int, meta precise.MetaData) error
+	WriteDataSize(ctx context.Context, bundleID int) error
 	WriteDocuments(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (count uint32, err error)
 	WriteResultChunks(ctx context.Context, bundleID int, resultChunks chan precise.IndexedResultChunkData) (count uint32, err error)
 	WriteDefinitions(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
 	WriteReferences(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
 	WriteImplementations(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
+	ShareDocuments(ctx context.Context, sourceBundleID, targetBundleID int, paths []string) (count uint32, err error)
+
+	ReadMeta(ctx context.Context, bundleID int) (precise.MetaData, error)
+	AllDocumentPaths(ctx context.Context, bundleID int) ([]string, error)
+	VisitDocuments(ctx context.Context, bundleID int, paths []string, f func(path string, document precise.DocumentData)) error
+	ReadResultChunks(ctx context.Context, bundleID int, indexes []int) (map[int]precise.ResultChunkData, error)
+	VisitResultChunks(ctx context.Context, bundleID int, f func(index int, resultChunk precise.ResultChunkData)) error
+	VisitDefinitions(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error
+	VisitReferences(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error
+	VisitImplementations(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error
 }
 
 type LSIFStoreShim struct {
@@ -25,6 +25,12 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockDBStore struct {
+	// CopyPackageReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyPackageReferences.
+	CopyPackageReferencesFunc *DBStoreCopyPackageReferencesFunc
+	// CopyPackagesFunc is an instance of a mock function object controlling
+	// the behavior of the method CopyPackages.
+	CopyPackagesFunc *DBStoreCopyPackagesFunc
 	// DeleteOverlappingDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteOverlappingDumps.
 	DeleteOverlappingDumpsFunc *DBStoreDeleteOverlappingDumpsFunc
@@ -71,6 +77,16 @@ type MockDBStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		CopyPackageReferencesFunc: &DBStoreCopyPackageReferencesFunc{
+			defaultHook: func(context.Context, int, int, []precise.Package) (r0 error) {
+				return
+			},
+		},
+		CopyPackagesFunc: &DBStoreCopyPackagesFunc{
+			defaultHook: func(context.Context, int, int, []precise.Package) (r0 error) {
+				return
+			},
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, string) (r0 error) {
 				return
@@ -143,6 +159,16 @@ func NewMockDBStore() *MockDBStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		CopyPackageReferencesFunc: &DBStoreCopyPackageReferencesFunc{
+			defaultHook: func(context.Context, int, int, []precise.Package) error {
+				panic("unexpected invocation of MockDBStore.CopyPackageReferences")
+			},
+		},
+		CopyPackagesFunc: &DBStoreCopyPackagesFunc{
+			defaultHook: func(context.Context, int, int, []precise.Package) error {
+				panic("unexpected invocation of MockDBStore.CopyPackages")
+			},
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, string) error {
 				panic("unexpected invocation of MockDBStore.DeleteOverlappingDumps")
@@ -215,6 +241,12 @@ func NewStrictMockDBStore() *MockDBStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	return &MockDBStore{
+		CopyPackageReferencesFunc: &DBStoreCopyPackageReferencesFunc{
+			defaultHook: i.CopyPackageReferences,
+		},
+		CopyPackagesFunc: &DBStoreCopyPackagesFunc{
+			defaultHook: i.CopyPackages,
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: i.DeleteOverlappingDumps,
 		},
@@ -257,6 +289,229 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	}
 }
 
+// DBStoreCopyPackageReferencesFunc describes the behavior when the
+// CopyPackageReferences method of the parent MockDBStore instance is
+// invoked.
+type DBStoreCopyPackageReferencesFunc struct {
+	defaultHook func(context.Context, int, int, []precise.Package) error
+	hooks       []func(context.Context, int, int, []precise.Package) error
+	history     []DBStoreCopyPackageReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyPackageReferences delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) CopyPackageReferences(v0 context.Context, v1 int, v2 int, v3 []precise.Package) error {
+	r0 := m.CopyPackageReferencesFunc.nextHook()(v0, v1, v2, v3)
+	m.CopyPackageReferencesFunc.appendCall(DBStoreCopyPackageReferencesFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CopyPackageReferences method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreCopyPackageReferencesFunc) SetDefaultHook(hook func(context.Context, int, int, []precise.Package) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyPackageReferences method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreCopyPackageReferencesFunc) PushHook(hook func(context.Context, int, int, []precise.Package) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBStoreCopyPackageReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []precise.Package) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBStoreCopyPackageReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, []precise.Package) error {
+		return r0
+	})
+}
+
+func (f *DBStoreCopyPackageReferencesFunc) nextHook() func(context.Context, int, int, []precise.Package) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCopyPackageReferencesFunc) appendCall(r0 DBStoreCopyPackageReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCopyPackageReferencesFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreCopyPackageReferencesFunc) History() []DBStoreCopyPackageReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCopyPackageReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCopyPackageReferencesFuncCall is an object that describes an
+// invocation of method CopyPackageReferences on an instance of MockDBStore.
+type DBStoreCopyPackageReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []precise.Package
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCopyPackageReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCopyPackageReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreCopyPackagesFunc describes the behavior when the CopyPackages
+// method of the parent MockDBStore instance is invoked.
+type DBStoreCopyPackagesFunc struct {
+	defaultHook func(context.Context, int, int, []precise.Package) error
+	hooks       []func(context.Context, int, int, []precise.Package) error
+	history     []DBStoreCopyPackagesFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyPackages delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) CopyPackages(v0 context.Context, v1 int, v2 int, v3 []precise.Package) error {
+	r0 := m.CopyPackagesFunc.nextHook()(v0, v1, v2, v3)
+	m.CopyPackagesFunc.appendCall(DBStoreCopyPackagesFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CopyPackages method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreCopyPackagesFunc) SetDefaultHook(hook func(context.Context, int, int, []precise.Package) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyPackages method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreCopyPackagesFunc) PushHook(hook func(context.Context, int, int, []precise.Package) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBStoreCopyPackagesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []precise.Package) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBStoreCopyPackagesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, []precise.Package) error {
+		return r0
+	})
+}
+
+func (f *DBStoreCopyPackagesFunc) nextHook() func(context.Context, int, int, []precise.Package) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCopyPackagesFunc) appendCall(r0 DBStoreCopyPackagesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCopyPackagesFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreCopyPackagesFunc) History() []DBStoreCopyPackagesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCopyPackagesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCopyPackagesFuncCall is an object that describes an invocation of
+// method CopyPackages on an instance of MockDBStore.
+type DBStoreCopyPackagesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []precise.Package
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCopyPackagesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCopyPackagesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBStoreDeleteOverlappingDumpsFunc describes the behavior when the
 // DeleteOverlappingDumps method of the parent MockDBStore instance is
 // invoked.
@@ -2214,12 +2469,42 @@ func (c GitserverClientResolveRevisionFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockLSIFStore struct {
+	// AllDocumentPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method AllDocumentPaths.
+	AllDocumentPathsFunc *LSIFStoreAllDocumentPathsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LSIFStoreDoneFunc
+	// ReadMetaFunc is an instance of a mock function object controlling the
+	// behavior of the method ReadMeta.
+	ReadMetaFunc *LSIFStoreReadMetaFunc
+	// ReadResultChunksFunc is an instance of a mock function object
+	// controlling the behavior of the method ReadResultChunks.
+	ReadResultChunksFunc *LSIFStoreReadResultChunksFunc
+	// ShareDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method ShareDocuments.
+	ShareDocumentsFunc *LSIFStoreShareDocumentsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LSIFStoreTransactFunc
+	// VisitDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method VisitDefinitions.
+	VisitDefinitionsFunc *LSIFStoreVisitDefinitionsFunc
+	// VisitDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method VisitDocuments.
+	VisitDocumentsFunc *LSIFStoreVisitDocumentsFunc
+	// VisitImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method VisitImplementations.
+	VisitImplementationsFunc *LSIFStoreVisitImplementationsFunc
+	// VisitReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method VisitReferences.
+	VisitReferencesFunc *LSIFStoreVisitReferencesFunc
+	// VisitResultChunksFunc is an instance of a mock function object
+	// controlling the behavior of the method VisitResultChunks.
+	VisitResultChunksFunc *LSIFStoreVisitResultChunksFunc
+	// WriteDataSizeFunc is an instance of a mock function object
+	// controlling the behavior of the method WriteDataSize.
+	WriteDataSizeFunc *LSIFStoreWriteDataSizeFunc
 	// WriteDefinitionsFunc is an instance of a mock function object
 	// controlling the behavior of the method WriteDefinitions.
 	WriteDefinitionsFunc *LSIFStoreWriteDefinitionsFunc
@@ -2244,16 +2529,66 @@ type MockLSIFStore struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		AllDocumentPathsFunc: &LSIFStoreAllDocumentPathsFunc{
+			defaultHook: func(context.Context, int) (r0 []string, r1 error) {
+				return
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
 			},
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: func(context.Context, int) (r0 precise.MetaData, r1 error) {
+				return
+			},
+		},
+		ReadResultChunksFunc: &LSIFStoreReadResultChunksFunc{
+			defaultHook: func(context.Context, int, []int) (r0 map[int]precise.ResultChunkData, r1 error) {
+				return
+			},
+		},
+		ShareDocumentsFunc: &LSIFStoreShareDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (r0 uint32, r1 error) {
+				return
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 LSIFStore, r1 error) {
 				return
 			},
 		},
+		VisitDefinitionsFunc: &LSIFStoreVisitDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		VisitDocumentsFunc: &LSIFStoreVisitDocumentsFunc{
+			defaultHook: func(context.Context, int, []string, func(path string, document precise.DocumentData)) (r0 error) {
+				return
+			},
+		},
+		VisitImplementationsFunc: &LSIFStoreVisitImplementationsFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		VisitReferencesFunc: &LSIFStoreVisitReferencesFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		VisitResultChunksFunc: &LSIFStoreVisitResultChunksFunc{
+			defaultHook: func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) (r0 error) {
+				return
+			},
+		},
+		WriteDataSizeFunc: &LSIFStoreWriteDataSizeFunc{
+			defaultHook: func(context.Context, int) (r0 error) {
+				return
+			},
+		},
 		WriteDefinitionsFunc: &LSIFStoreWriteDefinitionsFunc{
 			defaultHook: func(context.Context, int, chan precise.MonikerLocations) (r0 uint32, r1 error) {
 				return
@@ -2291,16 +2626,66 @@ func NewMockLSIFStore() *MockLSIFStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		AllDocumentPathsFunc: &LSIFStoreAllDocumentPathsFunc{
+			defaultHook: func(context.Context, int) ([]string, error) {
+				panic("unexpected invocation of MockLSIFStore.AllDocumentPaths")
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockLSIFStore.Done")
 			},
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: func(context.Context, int) (precise.MetaData, error) {
+				panic("unexpected invocation of MockLSIFStore.ReadMeta")
+			},
+		},
+		ReadResultChunksFunc: &LSIFStoreReadResultChunksFunc{
+			defaultHook: func(context.Context, int, []int) (map[int]precise.ResultChunkData, error) {
+				panic("unexpected invocation of MockLSIFStore.ReadResultChunks")
+			},
+		},
+		ShareDocumentsFunc: &LSIFStoreShareDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (uint32, error) {
+				panic("unexpected invocation of MockLSIFStore.ShareDocuments")
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (LSIFStore, error) {
 				panic("unexpected invocation of MockLSIFStore.Transact")
 			},
 		},
+		VisitDefinitionsFunc: &LSIFStoreVisitDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.VisitDefinitions")
+			},
+		},
+		VisitDocumentsFunc: &LSIFStoreVisitDocumentsFunc{
+			defaultHook: func(context.Context, int, []string, func(path string, document precise.DocumentData)) error {
+				panic("unexpected invocation of MockLSIFStore.VisitDocuments")
+			},
+		},
+		VisitImplementationsFunc: &LSIFStoreVisitImplementationsFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.VisitImplementations")
+			},
+		},
+		VisitReferencesFunc: &LSIFStoreVisitReferencesFunc{
+			defaultHook: func(context.Context, int, func(precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.VisitReferences")
+			},
+		},
+		VisitResultChunksFunc: &LSIFStoreVisitResultChunksFunc{
+			defaultHook: func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+				panic("unexpected invocation of MockLSIFStore.VisitResultChunks")
+			},
+		},
+		WriteDataSizeFunc: &LSIFStoreWriteDataSizeFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockLSIFStore.WriteDataSize")
+			},
+		},
 		WriteDefinitionsFunc: &LSIFStoreWriteDefinitionsFunc{
 			defaultHook: func(context.Context, int, chan precise.MonikerLocations) (uint32, error) {
 				panic("unexpected invocation of MockLSIFStore.WriteDefinitions")
@@ -2338,12 +2723,42 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 // All methods delegate to the given implementation, unless overwritten.
 func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 	return &MockLSIFStore{
+		AllDocumentPathsFunc: &LSIFStoreAllDocumentPathsFunc{
+			defaultHook: i.AllDocumentPaths,
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: i.ReadMeta,
+		},
+		ReadResultChunksFunc: &LSIFStoreReadResultChunksFunc{
+			defaultHook: i.ReadResultChunks,
+		},
+		ShareDocumentsFunc: &LSIFStoreShareDocumentsFunc{
+			defaultHook: i.ShareDocuments,
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		VisitDefinitionsFunc: &LSIFStoreVisitDefinitionsFunc{
+			defaultHook: i.VisitDefinitions,
+		},
+		VisitDocumentsFunc: &LSIFStoreVisitDocumentsFunc{
+			defaultHook: i.VisitDocuments,
+		},
+		VisitImplementationsFunc: &LSIFStoreVisitImplementationsFunc{
+			defaultHook: i.VisitImplementations,
+		},
+		VisitReferencesFunc: &LSIFStoreVisitReferencesFunc{
+			defaultHook: i.VisitReferences,
+		},
+		VisitResultChunksFunc: &LSIFStoreVisitResultChunksFunc{
+			defaultHook: i.VisitResultChunks,
+		},
+		WriteDataSizeFunc: &LSIFStoreWriteDataSizeFunc{
+			defaultHook: i.WriteDataSize,
+		},
 		WriteDefinitionsFunc: &LSIFStoreWriteDefinitionsFunc{
 			defaultHook: i.WriteDefinitions,
 		},
@@ -2365,26 +2780,134 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 	}
 }
 
-// LSIFStoreDoneFunc describes the behavior when the Done method of the
-// parent MockLSIFStore instance is invoked.
-type LSIFStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []LSIFStoreDoneFuncCall
+// LSIFStoreAllDocumentPathsFunc describes the behavior when the
+// AllDocumentPaths method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreAllDocumentPathsFunc struct {
+	defaultHook func(context.Context, int) ([]string, error)
+	hooks       []func(context.Context, int) ([]string, error)
+	history     []LSIFStoreAllDocumentPathsFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockLSIFStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(LSIFStoreDoneFuncCall{v0, r0})
-	return r0
+// AllDocumentPaths delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) AllDocumentPaths(v0 context.Context, v1 int) ([]string, error) {
+	r0, r1 := m.AllDocumentPathsFunc.nextHook()(v0, v1)
+	m.AllDocumentPathsFunc.appendCall(LSIFStoreAllDocumentPathsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockLSIFStore instance is invoked and the hook queue is empty.
-func (f *LSIFStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the AllDocumentPaths
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreAllDocumentPathsFunc) SetDefaultHook(hook func(context.Context, int) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AllDocumentPaths method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreAllDocumentPathsFunc) PushHook(hook func(context.Context, int) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreAllDocumentPathsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreAllDocumentPathsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreAllDocumentPathsFunc) nextHook() func(context.Context, int) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreAllDocumentPathsFunc) appendCall(r0 LSIFStoreAllDocumentPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreAllDocumentPathsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreAllDocumentPathsFunc) History() []LSIFStoreAllDocumentPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreAllDocumentPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreAllDocumentPathsFuncCall is an object that describes an
+// invocation of method AllDocumentPaths on an instance of MockLSIFStore.
+type LSIFStoreAllDocumentPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreAllDocumentPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreAllDocumentPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreDoneFunc describes the behavior when the Done method of the
+// parent MockLSIFStore instance is invoked.
+type LSIFStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []LSIFStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLSIFStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(LSIFStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
@@ -2466,34 +2989,34 @@ func (c LSIFStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// LSIFStoreTransactFunc describes the behavior when the Transact method of
+// LSIFStoreReadMetaFunc describes the behavior when the ReadMeta method of
 // the parent MockLSIFStore instance is invoked.
-type LSIFStoreTransactFunc struct {
-	defaultHook func(context.Context) (LSIFStore, error)
-	hooks       []func(context.Context) (LSIFStore, error)
-	history     []LSIFStoreTransactFuncCall
+type LSIFStoreReadMetaFunc struct {
+	defaultHook func(context.Context, int) (precise.MetaData, error)
+	hooks       []func(context.Context, int) (precise.MetaData, error)
+	history     []LSIFStoreReadMetaFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
+// ReadMeta delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockLSIFStore) Transact(v0 context.Context) (LSIFStore, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(LSIFStoreTransactFuncCall{v0, r0, r1})
+func (m *MockLSIFStore) ReadMeta(v0 context.Context, v1 int) (precise.MetaData, error) {
+	r0, r1 := m.ReadMetaFunc.nextHook()(v0, v1)
+	m.ReadMetaFunc.appendCall(LSIFStoreReadMetaFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
+// SetDefaultHook sets function that is called when the ReadMeta method of
 // the parent MockLSIFStore instance is invoked and the hook queue is empty.
-func (f *LSIFStoreTransactFunc) SetDefaultHook(hook func(context.Context) (LSIFStore, error)) {
+func (f *LSIFStoreReadMetaFunc) SetDefaultHook(hook func(context.Context, int) (precise.MetaData, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockLSIFStore instance invokes the hook at
+// ReadMeta method of the parent MockLSIFStore instance invokes the hook at
 // the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *LSIFStoreTransactFunc) PushHook(hook func(context.Context) (LSIFStore, error)) {
+func (f *LSIFStoreReadMetaFunc) PushHook(hook func(context.Context, int) (precise.MetaData, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2501,20 +3024,20 @@ func (f *LSIFStoreTransactFunc) PushHook(hook func(context.Context) (LSIFStore,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LSIFStoreTransactFunc) SetDefaultReturn(r0 LSIFStore, r1 error) {
-	f.SetDefaultHook(func(context.Context) (LSIFStore, error) {
+func (f *LSIFStoreReadMetaFunc) SetDefaultReturn(r0 precise.MetaData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (precise.MetaData, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LSIFStoreTransactFunc) PushReturn(r0 LSIFStore, r1 error) {
-	f.PushHook(func(context.Context) (LSIFStore, error) {
+func (f *LSIFStoreReadMetaFunc) PushReturn(r0 precise.MetaData, r1 error) {
+	f.PushHook(func(context.Context, int) (precise.MetaData, error) {
 		return r0, r1
 	})
 }
 
-func (f *LSIFStoreTransactFunc) nextHook() func(context.Context) (LSIFStore, error) {
+func (f *LSIFStoreReadMetaFunc) nextHook() func(context.Context, int) (precise.MetaData, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2527,32 +3050,35 @@ func (f *LSIFStoreTransactFunc) nextHook() func(context.Context) (LSIFStore, err
 	return hook
 }
 
-func (f *LSIFStoreTransactFunc) appendCall(r0 LSIFStoreTransactFuncCall) {
+func (f *LSIFStoreReadMetaFunc) appendCall(r0 LSIFStoreReadMetaFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LSIFStoreTransactFuncCall objects
+// History returns a sequence of LSIFStoreReadMetaFuncCall objects
 // describing the invocations of this function.
-func (f *LSIFStoreTransactFunc) History() []LSIFStoreTransactFuncCall {
+func (f *LSIFStoreReadMetaFunc) History() []LSIFStoreReadMetaFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStoreTransactFuncCall, len(f.history))
+	history := make([]LSIFStoreReadMetaFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStoreTransactFuncCall is an object that describes an invocation of
-// method Transact on an instance of MockLSIFStore.
-type LSIFStoreTransactFuncCall struct {
+// LSIFStoreReadMetaFuncCall is an object that describes an invocation of
+// method ReadMeta on an instance of MockLSIFStore.
+type LSIFStoreReadMetaFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 LSIFStore
+	Result0 precise.MetaData
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2560,16 +3086,995 @@ type LSIFStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LSIFStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c LSIFStoreReadMetaFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LSIFStoreTransactFuncCall) Results() []interface{} {
+func (c LSIFStoreReadMetaFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreReadResultChunksFunc describes the behavior when the
+// ReadResultChunks method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreReadResultChunksFunc struct {
+	defaultHook func(context.Context, int, []int) (map[int]precise.ResultChunkData, error)
+	hooks       []func(context.Context, int, []int) (map[int]precise.ResultChunkData, error)
+	history     []LSIFStoreReadResultChunksFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadResultChunks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ReadResultChunks(v0 context.Context, v1 int, v2 []int) (map[int]precise.ResultChunkData, error) {
+	r0, r1 := m.ReadResultChunksFunc.nextHook()(v0, v1, v2)
+	m.ReadResultChunksFunc.appendCall(LSIFStoreReadResultChunksFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReadResultChunks
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreReadResultChunksFunc) SetDefaultHook(hook func(context.Context, int, []int) (map[int]precise.ResultChunkData, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadResultChunks method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreReadResultChunksFunc) PushHook(hook func(context.Context, int, []int) (map[int]precise.ResultChunkData, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreReadResultChunksFunc) SetDefaultReturn(r0 map[int]precise.ResultChunkData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, []int) (map[int]precise.ResultChunkData, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreReadResultChunksFunc) PushReturn(r0 map[int]precise.ResultChunkData, r1 error) {
+	f.PushHook(func(context.Context, int, []int) (map[int]precise.ResultChunkData, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreReadResultChunksFunc) nextHook() func(context.Context, int, []int) (map[int]precise.ResultChunkData, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreReadResultChunksFunc) appendCall(r0 LSIFStoreReadResultChunksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreReadResultChunksFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreReadResultChunksFunc) History() []LSIFStoreReadResultChunksFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreReadResultChunksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreReadResultChunksFuncCall is an object that describes an
+// invocation of method ReadResultChunks on an instance of MockLSIFStore.
+type LSIFStoreReadResultChunksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]precise.ResultChunkData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreReadResultChunksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreReadResultChunksFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreShareDocumentsFunc describes the behavior when the
+// ShareDocuments method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreShareDocumentsFunc struct {
+	defaultHook func(context.Context, int, int, []string) (uint32, error)
+	hooks       []func(context.Context, int, int, []string) (uint32, error)
+	history     []LSIFStoreShareDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// ShareDocuments delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ShareDocuments(v0 context.Context, v1 int, v2 int, v3 []string) (uint32, error) {
+	r0, r1 := m.ShareDocumentsFunc.nextHook()(v0, v1, v2, v3)
+	m.ShareDocumentsFunc.appendCall(LSIFStoreShareDocumentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ShareDocuments
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreShareDocumentsFunc) SetDefaultHook(hook func(context.Context, int, int, []string) (uint32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ShareDocuments method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreShareDocumentsFunc) PushHook(hook func(context.Context, int, int, []string) (uint32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreShareDocumentsFunc) SetDefaultReturn(r0 uint32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreShareDocumentsFunc) PushReturn(r0 uint32, r1 error) {
+	f.PushHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreShareDocumentsFunc) nextHook() func(context.Context, int, int, []string) (uint32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreShareDocumentsFunc) appendCall(r0 LSIFStoreShareDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreShareDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreShareDocumentsFunc) History() []LSIFStoreShareDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreShareDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreShareDocumentsFuncCall is an object that describes an invocation
+// of method ShareDocuments on an instance of MockLSIFStore.
+type LSIFStoreShareDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 uint32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreShareDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreShareDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreTransactFunc describes the behavior when the Transact method of
+// the parent MockLSIFStore instance is invoked.
+type LSIFStoreTransactFunc struct {
+	defaultHook func(context.Context) (LSIFStore, error)
+	hooks       []func(context.Context) (LSIFStore, error)
+	history     []LSIFStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLSIFStore) Transact(v0 context.Context) (LSIFStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(LSIFStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreTransactFunc) SetDefaultHook(hook func(context.Context) (LSIFStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockLSIFStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LSIFStoreTransactFunc) PushHook(hook func(context.Context) (LSIFStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreTransactFunc) SetDefaultReturn(r0 LSIFStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (LSIFStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreTransactFunc) PushReturn(r0 LSIFStore, r1 error) {
+	f.PushHook(func(context.Context) (LSIFStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreTransactFunc) nextHook() func(context.Context) (LSIFStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreTransactFunc) appendCall(r0 LSIFStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreTransactFunc) History() []LSIFStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreTransactFuncCall is an object that describes an invocation of
+// method Transact on an instance of MockLSIFStore.
+type LSIFStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 LSIFStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreVisitDefinitionsFunc describes the behavior when the
+// VisitDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreVisitDefinitionsFunc struct {
+	defaultHook func(context.Context, int, func(precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(precise.MonikerLocations)) error
+	history     []LSIFStoreVisitDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// VisitDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) VisitDefinitions(v0 context.Context, v1 int, v2 func(precise.MonikerLocations)) error {
+	r0 := m.VisitDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.VisitDefinitionsFunc.appendCall(LSIFStoreVisitDefinitionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the VisitDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreVisitDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VisitDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreVisitDefinitionsFunc) PushHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreVisitDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreVisitDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreVisitDefinitionsFunc) nextHook() func(context.Context, int, func(precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreVisitDefinitionsFunc) appendCall(r0 LSIFStoreVisitDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreVisitDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreVisitDefinitionsFunc) History() []LSIFStoreVisitDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreVisitDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreVisitDefinitionsFuncCall is an object that describes an
+// invocation of method VisitDefinitions on an instance of MockLSIFStore.
+type LSIFStoreVisitDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreVisitDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreVisitDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreVisitDocumentsFunc describes the behavior when the
+// VisitDocuments method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreVisitDocumentsFunc struct {
+	defaultHook func(context.Context, int, []string, func(path string, document precise.DocumentData)) error
+	hooks       []func(context.Context, int, []string, func(path string, document precise.DocumentData)) error
+	history     []LSIFStoreVisitDocumentsFuncCall
+	mutex       sync.Mutex
+}
+
+// VisitDocuments delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) VisitDocuments(v0 context.Context, v1 int, v2 []string, v3 func(path string, document precise.DocumentData)) error {
+	r0 := m.VisitDocumentsFunc.nextHook()(v0, v1, v2, v3)
+	m.VisitDocumentsFunc.appendCall(LSIFStoreVisitDocumentsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the VisitDocuments
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreVisitDocumentsFunc) SetDefaultHook(hook func(context.Context, int, []string, func(path string, document precise.DocumentData)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VisitDocuments method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreVisitDocumentsFunc) PushHook(hook func(context.Context, int, []string, func(path string, document precise.DocumentData)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreVisitDocumentsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []string, func(path string, document precise.DocumentData)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreVisitDocumentsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []string, func(path string, document precise.DocumentData)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreVisitDocumentsFunc) nextHook() func(context.Context, int, []string, func(path string, document precise.DocumentData)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreVisitDocumentsFunc) appendCall(r0 LSIFStoreVisitDocumentsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreVisitDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreVisitDocumentsFunc) History() []LSIFStoreVisitDocumentsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreVisitDocumentsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreVisitDocumentsFuncCall is an object that describes an invocation
+// of method VisitDocuments on an instance of MockLSIFStore.
+type LSIFStoreVisitDocumentsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 func(path string, document precise.DocumentData)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreVisitDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreVisitDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreVisitImplementationsFunc describes the behavior when the
+// VisitImplementations method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreVisitImplementationsFunc struct {
+	defaultHook func(context.Context, int, func(precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(precise.MonikerLocations)) error
+	history     []LSIFStoreVisitImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// VisitImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) VisitImplementations(v0 context.Context, v1 int, v2 func(precise.MonikerLocations)) error {
+	r0 := m.VisitImplementationsFunc.nextHook()(v0, v1, v2)
+	m.VisitImplementationsFunc.appendCall(LSIFStoreVisitImplementationsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the VisitImplementations
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreVisitImplementationsFunc) SetDefaultHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VisitImplementations method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreVisitImplementationsFunc) PushHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreVisitImplementationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreVisitImplementationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreVisitImplementationsFunc) nextHook() func(context.Context, int, func(precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreVisitImplementationsFunc) appendCall(r0 LSIFStoreVisitImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreVisitImplementationsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreVisitImplementationsFunc) History() []LSIFStoreVisitImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreVisitImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreVisitImplementationsFuncCall is an object that describes an
+// invocation of method VisitImplementations on an instance of
+// MockLSIFStore.
+type LSIFStoreVisitImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreVisitImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreVisitImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreVisitReferencesFunc describes the behavior when the
+// VisitReferences method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreVisitReferencesFunc struct {
+	defaultHook func(context.Context, int, func(precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(precise.MonikerLocations)) error
+	history     []LSIFStoreVisitReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// VisitReferences delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) VisitReferences(v0 context.Context, v1 int, v2 func(precise.MonikerLocations)) error {
+	r0 := m.VisitReferencesFunc.nextHook()(v0, v1, v2)
+	m.VisitReferencesFunc.appendCall(LSIFStoreVisitReferencesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the VisitReferences
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreVisitReferencesFunc) SetDefaultHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VisitReferences method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreVisitReferencesFunc) PushHook(hook func(context.Context, int, func(precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreVisitReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreVisitReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreVisitReferencesFunc) nextHook() func(context.Context, int, func(precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreVisitReferencesFunc) appendCall(r0 LSIFStoreVisitReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreVisitReferencesFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreVisitReferencesFunc) History() []LSIFStoreVisitReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreVisitReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreVisitReferencesFuncCall is an object that describes an
+// invocation of method VisitReferences on an instance of MockLSIFStore.
+type LSIFStoreVisitReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreVisitReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreVisitReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreVisitResultChunksFunc describes the behavior when the
+// VisitResultChunks method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreVisitResultChunksFunc struct {
+	defaultHook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error
+	hooks       []func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error
+	history     []LSIFStoreVisitResultChunksFuncCall
+	mutex       sync.Mutex
+}
+
+// VisitResultChunks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) VisitResultChunks(v0 context.Context, v1 int, v2 func(index int, resultChunk precise.ResultChunkData)) error {
+	r0 := m.VisitResultChunksFunc.nextHook()(v0, v1, v2)
+	m.VisitResultChunksFunc.appendCall(LSIFStoreVisitResultChunksFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the VisitResultChunks
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreVisitResultChunksFunc) SetDefaultHook(hook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VisitResultChunks method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreVisitResultChunksFunc) PushHook(hook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreVisitResultChunksFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreVisitResultChunksFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreVisitResultChunksFunc) nextHook() func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreVisitResultChunksFunc) appendCall(r0 LSIFStoreVisitResultChunksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreVisitResultChunksFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreVisitResultChunksFunc) History() []LSIFStoreVisitResultChunksFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreVisitResultChunksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreVisitResultChunksFuncCall is an object that describes an
+// invocation of method VisitResultChunks on an instance of MockLSIFStore.
+type LSIFStoreVisitResultChunksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(index int, resultChunk precise.ResultChunkData)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreVisitResultChunksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreVisitResultChunksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreWriteDataSizeFunc describes the behavior when the WriteDataSize
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreWriteDataSizeFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []LSIFStoreWriteDataSizeFuncCall
+	mutex       sync.Mutex
+}
+
+// WriteDataSize delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) WriteDataSize(v0 context.Context, v1 int) error {
+	r0 := m.WriteDataSizeFunc.nextHook()(v0, v1)
+	m.WriteDataSizeFunc.appendCall(LSIFStoreWriteDataSizeFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WriteDataSize method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreWriteDataSizeFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WriteDataSize method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreWriteDataSizeFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreWriteDataSizeFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreWriteDataSizeFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreWriteDataSizeFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreWriteDataSizeFunc) appendCall(r0 LSIFStoreWriteDataSizeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreWriteDataSizeFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreWriteDataSizeFunc) History() []LSIFStoreWriteDataSizeFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreWriteDataSizeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreWriteDataSizeFuncCall is an object that describes an invocation
+// of method WriteDataSize on an instance of MockLSIFStore.
+type LSIFStoreWriteDataSizeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreWriteDataSizeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreWriteDataSizeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LSIFStoreWriteDefinitionsFunc describes the behavior when the
 // WriteDefinitions method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreWriteDefinitionsFunc struct {
@@ -108,7 +108,7 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 }
 
 func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
-	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), nil)
 	inputs, err := searchClient.Plan(ctx, "V3", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
 		return nil, errcode.MakeNonRetryable(err)
@@ -116,7 +116,7 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 
 	// Inline job creation so we can mutate the commit job before running it
 	clients := searchClient.JobClients()
-	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	planJob, err := newPlanJob(inputs)
 	if err != nil {
 		return nil, errcode.MakeNonRetryable(err)
 	}
@@ -163,13 +163,18 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, err
 	}
 
-	results := make([]*result.CommitMatch, len(agg.Results))
-	for i, res := range agg.Results {
+	results := make([]*result.CommitMatch, 0, len(agg.Results))
+	for _, res := range agg.Results {
 		cm, ok := res.(*result.CommitMatch)
 		if !ok {
+			if inputs.Join != nil {
+				// The other operands of a join only constrain which commits
+				// match, we only notify about commits.
+				continue
+			}
 			return nil, errors.Errorf("expected search to only return commit matches, but got type %T", res)
 		}
-		results[i] = cm
+		results = append(results, cm)
 	}
 
 	return results, nil
@@ -179,14 +184,14 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
 // be searched from the beginning.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
-	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), nil)
 	inputs, err := searchClient.Plan(ctx, "V3", nil, query, search.Streaming, settings, envvar.SourcegraphDotComMode())
 	if err != nil {
 		return err
 	}
 
 	clients := searchClient.JobClients()
-	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	planJob, err := newPlanJob(inputs)
 	if err != nil {
 		return err
 	}
@@ -209,6 +214,15 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 	return err
 }
 
+// newPlanJob creates the job for a monitor query, which may be a join of
+// several subqueries.
+func newPlanJob(inputs *search.Inputs) (job.Job, error) {
+	if inputs.Join != nil {
+		return jobutil.NewJoinPlanJob(inputs, inputs.Join)
+	}
+	return jobutil.NewPlanJob(inputs, inputs.Plan)
+}
+
 var ErrInvalidMonitorQuery = errors.New("code monitor cannot use different patterns for different repos")
 
 func limitConcurrency(in job.Job) job.Job {
@@ -225,6 +239,9 @@ func limitConcurrency(in job.Job) job.Job {
 }
 
 func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err error) {
+	// The operands of a join may use any search type, as long as only one of
+	// them searches commits.
+	isJoin := job.HasDescendent[*jobutil.JoinJob](in)
 	commitSearchJobCount := 0
 	return job.Map(in, func(j job.Job) job.Job {
 		switch v := j.(type) {
@@ -241,7 +258,7 @@ func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err
 			// removed since it's not used
 			return jobutil.NewNoopJob()
 		default:
-			if len(j.Children()) == 0 {
+			if len(j.Children()) == 0 && !isJoin {
 				if err == nil {
 					err = errors.Errorf("found invalid atom job type %T for code monitor search", j)
 				}
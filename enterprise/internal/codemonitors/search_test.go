@@ -10,6 +10,7 @@ import (
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -96,6 +97,46 @@ func TestAddCodeMonitorHook(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("joined queries", func(t *testing.T) {
+		newJoinJob := func(t *testing.T, input string) job.Job {
+			join, err := query.ParseJoin(input, query.SearchTypeLiteral)
+			require.NoError(t, err)
+			inputs := &search.Inputs{
+				UserSettings:        &schema.Settings{},
+				PatternType:         query.SearchTypeLiteral,
+				Protocol:            search.Streaming,
+				Features:            &search.Features{},
+				OnSourcegraphDotCom: true,
+				Join:                join,
+			}
+			j, err := newPlanJob(inputs)
+			require.NoError(t, err)
+			return j
+		}
+
+		t.Run("hooks the commit search", func(t *testing.T) {
+			j := newJoinJob(t, `(type:symbol Foo) AND (type:diff author:bob) join:file`)
+			require.True(t, job.HasDescendent[*jobutil.JoinJob](j))
+
+			hooked := 0
+			j, err := addCodeMonitorHook(j, func(context.Context, database.DB, commit.GitserverClient, *gitprotocol.SearchRequest, api.RepoID, commit.DoSearchFunc) error {
+				return nil
+			})
+			require.NoError(t, err)
+			job.VisitType(j, func(cj *commit.SearchJob) {
+				require.NotNil(t, cj.CodeMonitorSearchWrapper)
+				hooked++
+			})
+			require.Equal(t, 1, hooked)
+		})
+
+		t.Run("errors on multiple commit searches", func(t *testing.T) {
+			j := newJoinJob(t, `(type:commit Foo) AND (type:diff author:bob) join:repo`)
+			_, err := addCodeMonitorHook(j, nil)
+			require.Error(t, err)
+		})
+	})
 }
 
 func TestCodeMonitorHook(t *testing.T) {
@@ -83,6 +83,21 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			return []string{strings.Join(chunks, "")}
 		}
 
+		return chunks
+	case *result.CaptureMatch:
+		if onlyPath {
+			return []string{m.Path}
+		}
+
+		chunks := make([]string, 0, len(m.Captures))
+		for _, c := range m.Captures {
+			chunks = append(chunks, c.Value)
+		}
+
+		if kind == "output.structural" {
+			return []string{strings.Join(chunks, "")}
+		}
+
 		return chunks
 	case *result.CommitDiffMatch:
 		var sb strings.Builder
@@ -121,6 +121,17 @@ func TestRun(t *testing.T) {
 		"bob: (1)\nbob: (2)\nbob: (3)\n").
 		Equal(t, test(`content:output((\d) -> $author: ($1))`, commitMatch("a 1 b 2 c 3")))
 
+	autogold.Want(
+		"outputs the values of capture matches",
+		"version 2.7 in my/awesome/path.ml\nversion 3.9 in my/awesome/path.ml\n").
+		Equal(t, test(`content:output((.+) -> version $1 in $path)`, &result.CaptureMatch{
+			File: result.File{
+				Repo: types.MinimalRepo{Name: "my/awesome/repo"},
+				Path: "my/awesome/path.ml",
+			},
+			Captures: []result.Capture{{Value: "2.7", Count: 2}, {Value: "3.9", Count: 1}},
+		}))
+
 	autogold.Want(
 		"works with boundary assertions",
 		"test\nstring\n").
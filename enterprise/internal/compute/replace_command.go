@@ -51,9 +51,16 @@ func replace(ctx context.Context, content []byte, matchPattern MatchPattern, rep
 }
 
 func (c *Replace) Run(ctx context.Context, db database.DB, r result.Match) (Result, error) {
+	var file *result.File
 	switch m := r.(type) {
 	case *result.FileMatch:
-		content, err := gitserver.NewClient(db).ReadFile(ctx, m.Repo.Name, m.CommitID, m.Path, authz.DefaultSubRepoPermsChecker)
+		file = &m.File
+	case *result.CaptureMatch:
+		file = &m.File
+	}
+
+	if file != nil {
+		content, err := gitserver.NewClient(db).ReadFile(ctx, file.Repo.Name, file.CommitID, file.Path, authz.DefaultSubRepoPermsChecker)
 		if err != nil {
 			return nil, err
 		}
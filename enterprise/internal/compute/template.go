@@ -278,6 +278,15 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Content: content,
 			Lang:    lang,
 		}
+	case *result.CaptureMatch:
+		lang, _ := enry.GetLanguageByExtension(m.Path)
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Path:    m.Path,
+			Commit:  string(m.CommitID),
+			Content: content,
+			Lang:    lang,
+		}
 	case *result.CommitMatch:
 		return &MetaEnvironment{
 			Repo:    string(m.Repo.Name),
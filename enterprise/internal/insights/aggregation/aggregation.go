@@ -58,6 +58,15 @@ func newEventMatch(event result.Match) *eventMatch {
 			ResultCount:  match.ResultCount(),
 			ChunkMatches: match.ChunkMatches,
 		}
+	case *result.CaptureMatch:
+		lang, _ := enry.GetLanguageByExtension(match.Path)
+		return &eventMatch{
+			Repo:        string(match.Repo.Name),
+			RepoID:      int32(match.Repo.ID),
+			Path:        match.Path,
+			Lang:        lang,
+			ResultCount: match.ResultCount(),
+		}
 	case *result.RepoMatch:
 		return &eventMatch{
 			Repo:        string(match.RepoName().Name),
@@ -152,6 +161,15 @@ func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	}
 
 	return func(r result.Match) (map[MatchKey]int, error) {
+		if cm, ok := r.(*result.CaptureMatch); ok {
+			// The values were already captured by the search
+			matches := make(map[MatchKey]int, len(cm.Captures))
+			for _, c := range cm.Captures {
+				matches[MatchKey{Repo: string(cm.Repo.Name), RepoID: int32(cm.Repo.ID), Group: c.Value}] += c.Count
+			}
+			return matches, nil
+		}
+
 		match := newEventMatch(r)
 		if len(match.ChunkMatches) != 0 {
 			matches := map[MatchKey]int{}
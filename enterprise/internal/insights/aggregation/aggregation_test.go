@@ -393,6 +393,17 @@ func TestCaptureGroupAggregation(t *testing.T) {
 			`repo:^github\.com/sourcegraph/sourcegraph python([0-9]\.[0-9]) case:yes`,
 			autogold.Want("capture match respects case:yes", map[string]int{}),
 		},
+		{
+			types.CAPTURE_GROUP_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{&result.CaptureMatch{
+					File:     result.File{Repo: internaltypes.MinimalRepo{Name: "myRepo", ID: 1}, Path: "file.go"},
+					Captures: []result.Capture{{Value: "2.7", Count: 2}, {Value: "3.9", Count: 1}},
+				}},
+			},
+			`python([0-9]\.[0-9])`,
+			autogold.Want("counts values of capture matches", map[string]int{"2.7": 2, "3.9": 1}),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
@@ -89,6 +89,13 @@ func TabulationDecoder() (streamhttp.FrontendStreamDecoder, *TabulationResult) {
 					count := len(match.Symbols)
 					tr.TotalCount += count
 					addCount(match.Repository, match.RepositoryID, count)
+				case *streamhttp.EventCaptureMatch:
+					count := 0
+					for _, capture := range match.Captures {
+						count += capture.Count
+					}
+					tr.TotalCount += count
+					addCount(match.Repository, match.RepositoryID, count)
 				}
 			}
 		},
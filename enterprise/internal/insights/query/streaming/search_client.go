@@ -25,7 +25,7 @@ func NewInsightsSearchClient(db database.DB) SearchClient {
 	logger := log.Scoped("insightsSearchClient", "")
 	return &insightsSearchClient{
 		db:           db,
-		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), nil),
 	}
 }
 
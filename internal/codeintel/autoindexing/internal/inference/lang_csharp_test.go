@@ -0,0 +1,77 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestCSharpGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution with projects",
+			repositoryContents: map[string]string{
+				"App.sln":                    "",
+				"src/App/App.csproj":         "",
+				"src/Lib/Lib.csproj":         "",
+				"tools/Gen/Gen.csproj":       "",
+				"src/App/bin/Debug/x.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-dotnet:autoindex",
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-dotnet:autoindex",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "projects without solution",
+			repositoryContents: map[string]string{
+				"a/A.csproj":     "",
+				"b/B.sln":        "",
+				"b/sub/B.csproj": "",
+				"tests/T.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "a",
+							Image:    "sourcegraph/scip-dotnet:autoindex",
+							Commands: []string{"dotnet restore A.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "a",
+					Indexer:     "sourcegraph/scip-dotnet:autoindex",
+					IndexerArgs: []string{"scip-dotnet", "index", "A.csproj"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "b",
+							Image:    "sourcegraph/scip-dotnet:autoindex",
+							Commands: []string{"dotnet restore B.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "b",
+					Indexer:     "sourcegraph/scip-dotnet:autoindex",
+					IndexerArgs: []string{"scip-dotnet", "index", "B.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -45,6 +45,58 @@ func TestGoGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "go modules in test and vendor directories",
+			repositoryContents: map[string]string{
+				"go.mod":                     "",
+				"testdata/go.mod":            "",
+				"vendor/github.com/x/go.mod": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/lsif-go:latest",
+							Commands: []string{"go mod download"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/lsif-go:latest",
+					IndexerArgs: []string{"lsif-go", "--no-animation"},
+					Outfile:     "",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "testdata",
+							Image:    "sourcegraph/lsif-go:latest",
+							Commands: []string{"go mod download"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "testdata",
+					Indexer:     "sourcegraph/lsif-go:latest",
+					IndexerArgs: []string{"lsif-go", "--no-animation"},
+					Outfile:     "",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "vendor/github.com/x",
+							Image:    "sourcegraph/lsif-go:latest",
+							Commands: []string{"go mod download"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "vendor/github.com/x",
+					Indexer:     "sourcegraph/lsif-go:latest",
+					IndexerArgs: []string{"lsif-go", "--no-animation"},
+					Outfile:     "",
+				},
+			},
+		},
 		generatorTestCase{
 			description: "go files in root",
 			repositoryContents: map[string]string{
@@ -0,0 +1,92 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"build.gradle.kts":              "",
+				"app/build.gradle.kts":          "",
+				"lib/build.gradle.kts":          "",
+				"src/main/kotlin/Main.kt":       "",
+				"test/fixture/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "independent gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"server/build.gradle.kts":      "",
+				"server/core/build.gradle.kts": "",
+				"android/app/build.gradle.kts": "",
+				"legacy/build.gradle":          "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "android/app",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "groovy root build with kotlin subprojects",
+			repositoryContents: map[string]string{
+				"build.gradle":         "",
+				"app/build.gradle.kts": "",
+				"lib/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "groovy builds without kotlin build scripts (no match)",
+			repositoryContents: map[string]string{
+				"build.gradle":     "",
+				"app/build.gradle": "",
+			},
+			expected: []config.IndexJob{},
+		},
+		generatorTestCase{
+			description: "kotlin files without gradle build (no match)",
+			repositoryContents: map[string]string{
+				"src/main/kotlin/Main.kt": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
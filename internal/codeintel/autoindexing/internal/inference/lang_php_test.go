@@ -0,0 +1,51 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":                        "",
+				"packages/foo/composer.json":           "",
+				"vendor/monolog/monolog/composer.json": "",
+				"tests/fixtures/composer.json":         "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "composer:latest",
+							Commands: []string{"composer install --no-scripts --no-interaction"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/lsif-php:autoindex",
+					IndexerArgs: []string{"lsif-php"},
+					Outfile:     "dump.lsif",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/foo",
+							Image:    "composer:latest",
+							Commands: []string{"composer install --no-scripts --no-interaction"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/foo",
+					Indexer:     "sourcegraph/lsif-php:autoindex",
+					IndexerArgs: []string{"lsif-php"},
+					Outfile:     "dump.lsif",
+				},
+			},
+		},
+	)
+}
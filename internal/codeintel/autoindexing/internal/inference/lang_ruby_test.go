@@ -0,0 +1,58 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "bundler projects",
+			repositoryContents: map[string]string{
+				"Gemfile":                   "",
+				"gems/foo/Gemfile":          "",
+				"vendor/bundle/bar/Gemfile": "",
+				"test/Gemfile":              "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-ruby:autoindex",
+							Commands: []string{"bundle install"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "gems/foo",
+							Image:    "sourcegraph/scip-ruby:autoindex",
+							Commands: []string{"bundle install"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "gems/foo",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "ruby files without Gemfile (no match)",
+			repositoryContents: map[string]string{
+				"lib/foo.rb": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "sbt builds",
+			repositoryContents: map[string]string{
+				"build.sbt":             "",
+				"core/build.sbt":        "",
+				"other/app/build.sbt":   "",
+				"other/app/x/build.sbt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "nested sbt builds",
+			repositoryContents: map[string]string{
+				"a/build.sbt":     "",
+				"a/sub/build.sbt": "",
+				"b/build.sbt":     "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "a",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "b",
+					Indexer:     "sourcegraph/scip-java:autoindex",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "sbt build with lsif-java.json",
+			repositoryContents: map[string]string{
+				"build.sbt":                 "",
+				"lsif-java.json":            "",
+				"src/main/scala/Main.scala": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=scip"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -103,6 +103,40 @@ func TestTypeScriptGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "tsconfig in test and node_modules directories",
+			repositoryContents: map[string]string{
+				"tsconfig.json":                  "",
+				"test/fixtures/tsconfig.json":    "",
+				"node_modules/foo/tsconfig.json": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-typescript:autoindex",
+					IndexerArgs: []string{"scip-typescript", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "node_modules/foo",
+					Indexer:     "sourcegraph/scip-typescript:autoindex",
+					IndexerArgs: []string{"scip-typescript", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "test/fixtures",
+					Indexer:     "sourcegraph/scip-typescript:autoindex",
+					IndexerArgs: []string{"scip-typescript", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
 		generatorTestCase{
 			description: "typescript installation steps",
 			repositoryContents: map[string]string{
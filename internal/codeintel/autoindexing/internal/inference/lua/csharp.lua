@@ -0,0 +1,76 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/scip-dotnet:autoindex"
+local outfile = "index.scip"
+
+local exclude_segments = util.with_new_head(shared.exclude_segments, "bin")
+table.insert(exclude_segments, "obj")
+
+local make_job = function(root, target)
+  return {
+    steps = {
+      {
+        root = root,
+        image = indexer,
+        commands = { "dotnet restore " .. target },
+      },
+    },
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index", target },
+    outfile = outfile,
+  }
+end
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_extension "sln",
+    patterns.path_extension "csproj",
+  },
+
+  -- Invoked when solution or project files exist
+  generate = function(_, paths)
+    paths = util.without_segments(paths, exclude_segments)
+
+    local jobs = {}
+    local solution_dirs = {}
+
+    -- Index each solution, which covers all of the projects it references
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if string.sub(base, -4) == ".sln" and solution_dirs[root] == nil then
+        table.insert(jobs, make_job(root, base))
+        solution_dirs[root] = true
+      end
+    end
+
+    -- Index each project that is not nested under a solution directly
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+      local base = path.basename(paths[i])
+
+      if string.sub(base, -7) == ".csproj" then
+        local has_solution = false
+        local ancestors = path.ancestors(paths[i])
+        for j = 1, #ancestors do
+          if solution_dirs[ancestors[j]] then
+            has_solution = true
+          end
+        end
+
+        if not has_solution then
+          table.insert(jobs, make_job(root, base))
+        end
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -0,0 +1,58 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/scip-java:autoindex"
+local outfile = "index.scip"
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "build.gradle.kts",
+    patterns.path_basename "build.gradle",
+    patterns.path_literal "lsif-java.json",
+  },
+
+  -- Invoked when Gradle build files exist. Builds nested within another
+  -- build are subprojects and are indexed along with their parent, which
+  -- may itself be a Groovy build. Only builds containing a Kotlin build
+  -- script are indexed here.
+  generate = function(_, paths)
+    -- Repositories with an explicit lsif-java.json are indexed by sg.java
+    if util.contains(paths, "lsif-java.json") then
+      return {}
+    end
+
+    paths = util.without_segments(paths, shared.exclude_segments)
+
+    local kotlin_dirs = {}
+    for i = 1, #paths do
+      if path.basename(paths[i]) == "build.gradle.kts" then
+        local dir = path.dirname(paths[i])
+        kotlin_dirs[dir] = true
+
+        local ancestors = path.ancestors(dir)
+        for j = 1, #ancestors do
+          kotlin_dirs[ancestors[j]] = true
+        end
+      end
+    end
+
+    local jobs = {}
+    for _, root in ipairs(util.outermost_dirs(paths)) do
+      if kotlin_dirs[root] then
+        table.insert(jobs, {
+          steps = {},
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-java", "index", "--build-tool=gradle" },
+          outfile = outfile,
+        })
+      end
+    end
+
+    return jobs
+  end,
+}
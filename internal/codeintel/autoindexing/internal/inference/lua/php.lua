@@ -0,0 +1,45 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/lsif-php:autoindex"
+local composer = "composer:latest"
+
+local exclude_segments = util.with_new_head(shared.exclude_segments, "vendor")
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "composer.json",
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    paths = util.without_segments(paths, exclude_segments)
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = composer,
+            -- Dependencies are only needed for their sources; don't run any
+            -- project-defined hooks during installation.
+            commands = { "composer install --no-scripts --no-interaction" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "lsif-php" },
+        outfile = "dump.lsif",
+      })
+    end
+
+    return jobs
+  end,
+}
@@ -0,0 +1,43 @@
+local path = require "path"
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/scip-ruby:autoindex"
+local outfile = "index.scip"
+
+local exclude_segments = util.with_new_head(shared.exclude_segments, "vendor")
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "Gemfile",
+  },
+
+  -- Invoked when Gemfile files exist
+  generate = function(_, paths)
+    paths = util.without_segments(paths, exclude_segments)
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "bundle install" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-ruby", "--index-file", outfile, "." },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
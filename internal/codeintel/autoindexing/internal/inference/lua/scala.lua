@@ -0,0 +1,39 @@
+local patterns = require "sg.patterns"
+local recognizers = require "sg.recognizers"
+
+local shared = loadfile "shared.lua"()
+local util = loadfile "util.lua"()
+
+local indexer = "sourcegraph/scip-java:autoindex"
+local outfile = "index.scip"
+
+return recognizers.path_recognizer {
+  patterns = {
+    patterns.path_basename "build.sbt",
+    patterns.path_literal "lsif-java.json",
+  },
+
+  -- Invoked when build.sbt files exist. Builds nested within another
+  -- build are subprojects and are indexed along with their parent.
+  generate = function(_, paths)
+    -- Repositories with an explicit lsif-java.json are indexed by sg.java
+    if util.contains(paths, "lsif-java.json") then
+      return {}
+    end
+
+    paths = util.without_segments(paths, shared.exclude_segments)
+
+    local jobs = {}
+    for _, root in ipairs(util.outermost_dirs(paths)) do
+      table.insert(jobs, {
+        steps = {},
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-java", "index", "--build-tool=sbt" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
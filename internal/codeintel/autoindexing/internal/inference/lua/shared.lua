@@ -1,14 +1,22 @@
 local patterns = require "sg.patterns"
 
-local exclude_paths = patterns.path_combine {
-  patterns.path_segment "example",
-  patterns.path_segment "examples",
-  patterns.path_segment "integration",
-  patterns.path_segment "test",
-  patterns.path_segment "testdata",
-  patterns.path_segment "tests",
+local exclude_segments = {
+  "example",
+  "examples",
+  "integration",
+  "test",
+  "testdata",
+  "tests",
 }
 
+local exclude_path_segments = {}
+for _, segment in ipairs(exclude_segments) do
+  table.insert(exclude_path_segments, patterns.path_segment(segment))
+end
+
+local exclude_paths = patterns.path_combine(exclude_path_segments)
+
 return {
+  exclude_segments = exclude_segments,
   exclude_paths = exclude_paths,
 }
@@ -1,3 +1,5 @@
+local path = require "path"
+
 local contains = function(table, element)
   for i = 1, #table do
     if table[i] == element then
@@ -36,9 +38,61 @@ local with_new_head = function(slice, element)
   return new
 end
 
+-- Returns the directories of the given paths that are not nested within the
+-- directory of another given path, in the order they were given.
+local outermost_dirs = function(paths)
+  local dirs = {}
+  for i = 1, #paths do
+    dirs[path.dirname(paths[i])] = true
+  end
+
+  local outermost = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+
+    local nested = false
+    if dir ~= "" then
+      local ancestors = path.ancestors(dir)
+      for j = 1, #ancestors do
+        if dirs[ancestors[j]] then
+          nested = true
+        end
+      end
+    end
+
+    if not nested and not contains(outermost, dir) then
+      table.insert(outermost, dir)
+    end
+  end
+
+  return outermost
+end
+
+-- Returns the given paths whose directories do not contain any of the given
+-- segments, in the order they were given.
+local without_segments = function(paths, segments)
+  local filtered = {}
+  for i = 1, #paths do
+    local excluded = false
+    for segment in string.gmatch(path.dirname(paths[i]), "[^/]+") do
+      if contains(segments, segment) then
+        excluded = true
+      end
+    end
+
+    if not excluded then
+      table.insert(filtered, paths[i])
+    end
+  end
+
+  return filtered
+end
+
 return {
   contains = contains,
   contains_any = contains_any,
+  outermost_dirs = outermost_dirs,
   reverse = reverse,
   with_new_head = with_new_head,
+  without_segments = without_segments,
 }
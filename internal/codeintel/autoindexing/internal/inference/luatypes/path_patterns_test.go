@@ -0,0 +1,60 @@
+package luatypes
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFlattenPattern(t *testing.T) {
+	segments := NewCombinedPattern([]*PathPattern{
+		NewPattern("(^|/)test(/|$)"),
+		NewPattern("(^|/)vendor(/|$)"),
+	})
+
+	pattern := NewCombinedPattern([]*PathPattern{
+		NewPattern("(^|/)go\\.mod$"),
+		NewCombinedPattern([]*PathPattern{
+			NewPattern("(^|/)[^/]+.go$"),
+		}),
+		NewExcludePattern([]*PathPattern{segments}),
+	})
+
+	testCases := []struct {
+		inverted bool
+		expected []string
+	}{
+		// Patterns nested under an exclude pattern never contribute to the
+		// included patterns.
+		{inverted: false, expected: []string{"(^|/)[^/]+.go$", "(^|/)go\\.mod$"}},
+
+		// Only patterns that are themselves inverted contribute to the excluded
+		// patterns. Combined patterns nested under an exclude pattern are not
+		// inverted, so they exclude nothing.
+		{inverted: true, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		patterns := FlattenPattern(pattern, testCase.inverted)
+		sort.Strings(patterns)
+
+		if diff := cmp.Diff(testCase.expected, patterns); diff != "" {
+			t.Errorf("unexpected patterns (inverted=%v) (-want +got):\n%s", testCase.inverted, diff)
+		}
+	}
+}
+
+func TestFlattenPatterns(t *testing.T) {
+	patterns := FlattenPatterns([]*PathPattern{
+		NewPattern("^lsif-java\\.json$"),
+		NewCombinedPattern([]*PathPattern{NewPattern("(^|/)build\\.sbt$")}),
+		NewExcludePattern([]*PathPattern{NewPattern("(^|/)test(/|$)")}),
+	}, false)
+	sort.Strings(patterns)
+
+	expected := []string{"(^|/)build\\.sbt$", "^lsif-java\\.json$"}
+	if diff := cmp.Diff(expected, patterns); diff != "" {
+		t.Errorf("unexpected patterns (-want +got):\n%s", diff)
+	}
+}
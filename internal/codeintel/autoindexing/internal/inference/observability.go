@@ -16,6 +16,7 @@ type operations struct {
 	resolveFileContents        *observation.Operation
 	resolvePaths               *observation.Operation
 	setupRecognizers           *observation.Operation
+	validateOverrideScript     *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -43,5 +44,6 @@ func newOperations(observationContext *observation.Context) *operations {
 		resolveFileContents:        op("resolveFileContents"),
 		resolvePaths:               op("resolvePaths"),
 		setupRecognizers:           op("setupRecognizers"),
+		validateOverrideScript:     op("ValidateOverrideScript"),
 	}
 }
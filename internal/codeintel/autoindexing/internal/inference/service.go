@@ -69,11 +69,12 @@ func newService(
 	}
 }
 
-// InferIndexJobs invokes the given script in a fresh Lua sandbox. The return value of this script
+// InferIndexJobs invokes the given scripts in a fresh Lua sandbox. The return value of each script
 // is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers
-// will overwrite them (to disable or change default behavior). Each recognizer's generate function
-// is invoked and the resulting index jobs are combined into a flattened list.
-func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit, overrideScript string) (_ []config.IndexJob, err error) {
+// or with the recognizers of a preceding script will overwrite them (to disable or change default
+// behavior). Each recognizer's generate function is invoked and the resulting index jobs are
+// combined into a flattened list.
+func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit string, overrideScripts ...string) (_ []config.IndexJob, err error) {
 	ctx, _, endObservation := s.operations.inferIndexJobs.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -96,7 +97,7 @@ func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit,
 		},
 	}
 
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScripts, functionTable)
 	if err != nil {
 		return nil, err
 	}
@@ -113,11 +114,12 @@ func (s *Service) InferIndexJobs(ctx context.Context, repo api.RepoName, commit,
 	return jobs, nil
 }
 
-// InferIndexJobHints invokes the given script in a fresh Lua sandbox. The return value of this script
+// InferIndexJobHints invokes the given scripts in a fresh Lua sandbox. The return value of each script
 // is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers
-// will overwrite them (to disable or change default behavior). Each recognizer's hints function is
-// invoked and the resulting index job hints are combined into a flattened list.
-func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, commit, overrideScript string) (_ []config.IndexJobHint, err error) {
+// or with the recognizers of a preceding script will overwrite them (to disable or change default
+// behavior). Each recognizer's hints function is invoked and the resulting index job hints are
+// combined into a flattened list.
+func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, commit string, overrideScripts ...string) (_ []config.IndexJobHint, err error) {
 	ctx, _, endObservation := s.operations.inferIndexJobHints.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -140,7 +142,7 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 		},
 	}
 
-	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScript, functionTable)
+	jobOrHints, err := s.inferIndexJobOrHints(ctx, repo, commit, overrideScripts, functionTable)
 	if err != nil {
 		return nil, err
 	}
@@ -157,16 +159,36 @@ func (s *Service) InferIndexJobHints(ctx context.Context, repo api.RepoName, com
 	return jobHints, nil
 }
 
-// inferIndexJobOrHints invokes the given script in a fresh Lua sandbox. The return value of this script
-// is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers will
-// overwrite them (to disable or change default behavior). Each recognizer's callback function is invoked
+// ValidateOverrideScript invokes the given script in a fresh Lua sandbox and ensures that it returns
+// a table of recognizer instances (or false values to disable a default recognizer) that can be used
+// as the override script of InferIndexJobs and InferIndexJobHints. No recognizer is invoked.
+func (s *Service) ValidateOverrideScript(ctx context.Context, overrideScript string) (err error) {
+	ctx, _, endObservation := s.operations.validateOverrideScript.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	sandbox, err := s.createSandbox(ctx)
+	if err != nil {
+		return err
+	}
+	defer sandbox.Close()
+
+	if _, err := s.setupRecognizers(ctx, sandbox, []string{overrideScript}); err != nil {
+		return errors.Wrap(err, "invalid inference script")
+	}
+
+	return nil
+}
+
+// inferIndexJobOrHints invokes the given scripts in a fresh Lua sandbox. The return value of each script
+// is assumed to be a table of recognizer instances. Keys conflicting with the default recognizers or with
+// the recognizers of a preceding script will overwrite them (to disable or change default behavior). Each recognizer's callback function is invoked
 // and the resulting values are combined into a flattened list. See InferIndexJobs and InferIndexJobHints
 // for concrete implementations of the given function table.
 func (s *Service) inferIndexJobOrHints(
 	ctx context.Context,
 	repo api.RepoName,
 	commit string,
-	overrideScript string,
+	overrideScripts []string,
 	invocationContextMethods invocationFunctionTable,
 ) ([]indexJobOrHint, error) {
 	sandbox, err := s.createSandbox(ctx)
@@ -175,7 +197,7 @@ func (s *Service) inferIndexJobOrHints(
 	}
 	defer sandbox.Close()
 
-	recognizers, err := s.setupRecognizers(ctx, sandbox, overrideScript)
+	recognizers, err := s.setupRecognizers(ctx, sandbox, overrideScripts)
 	if err != nil || len(recognizers) == 0 {
 		return nil, err
 	}
@@ -206,9 +228,9 @@ func (s *Service) createSandbox(ctx context.Context) (_ *luasandbox.Sandbox, err
 	return sandbox, nil
 }
 
-// setupRecognizers runs the given default and override scripts in the given sandbox and converts the
-// script return values to a list of recognizer instances.
-func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScript string) (_ []*luatypes.Recognizer, err error) {
+// setupRecognizers runs the default script and then each of the given override scripts in order in the
+// given sandbox and converts the script return values to a list of recognizer instances.
+func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sandbox, overrideScripts []string) (_ []*luatypes.Recognizer, err error) {
 	ctx, _, endObservation := s.operations.setupRecognizers.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
@@ -223,7 +245,11 @@ func (s *Service) setupRecognizers(ctx context.Context, sandbox *luasandbox.Sand
 		return nil, err
 	}
 
-	if overrideScript != "" {
+	for _, overrideScript := range overrideScripts {
+		if overrideScript == "" {
+			continue
+		}
+
 		rawRecognizers, err := sandbox.RunScript(ctx, opts, overrideScript)
 		if err != nil {
 			return nil, err
@@ -125,6 +125,65 @@ func TestOverrideGenerators(t *testing.T) {
 	)
 }
 
+func TestOverrideGeneratorsInOrder(t *testing.T) {
+	overrideScript := func(indexer string) string {
+		return `
+			local path = require("path")
+			local patterns = require("sg.patterns")
+			local recognizers = require("sg.recognizers")
+
+			local custom_recognizer = recognizers.path_recognizer {
+				patterns = { patterns.path_basename("sg-test") },
+
+				generate = function(_, paths)
+					local jobs = {}
+					for i = 1, #paths do
+						table.insert(jobs, {
+							steps = {},
+							root = path.dirname(paths[i]),
+							indexer = "` + indexer + `",
+							indexer_args = {},
+							outfile = "",
+						})
+					end
+
+					return jobs
+				end,
+			}
+
+			local recognizers = {}
+			recognizers["sg.test"] = false -- Disable builtin recognizer
+			recognizers["mycompany.test"] = custom_recognizer
+			return recognizers
+		`
+	}
+
+	service := testService(t, map[string]string{
+		"sg-test":     "",
+		"foo/sg-test": "",
+	})
+
+	jobs, err := service.InferIndexJobs(
+		context.Background(),
+		api.RepoName("github.com/test/test"),
+		"HEAD",
+		overrideScript("test-global"),
+		overrideScript("test-repo"),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error inferring jobs: %s", err)
+	}
+
+	// mycompany.test of the second script replaces the one of the first script
+	expected := []config.IndexJob{
+		{Indexer: "test-repo", Root: ""},
+		{Indexer: "test-repo", Root: "foo"},
+	}
+	if diff := cmp.Diff(sortIndexJobs(expected), sortIndexJobs(jobs)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
 type generatorTestCase struct {
 	description        string
 	overrideScript     string
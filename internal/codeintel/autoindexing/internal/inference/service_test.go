@@ -45,3 +45,39 @@ func testService(t *testing.T, repositoryContents map[string]string) *Service {
 
 	return newService(sandboxService, gitService, ratelimit.NewInstrumentedLimiter("TestInference", rate.NewLimiter(rate.Limit(100), 1)), 100, 1024*1024, &observation.TestContext)
 }
+
+func TestValidateOverrideScript(t *testing.T) {
+	service := testService(t, nil)
+
+	validScripts := []string{
+		`return {}`,
+		`return { ["sg.test"] = false }`,
+		`
+			local patterns = require("sg.patterns")
+			local recognizers = require("sg.recognizers")
+
+			return {
+				["mycompany.test"] = recognizers.path_recognizer {
+					patterns = { patterns.path_basename("sg-test") },
+					generate = function(_, paths) return {} end,
+				},
+			}
+		`,
+	}
+	for _, script := range validScripts {
+		if err := service.ValidateOverrideScript(context.Background(), script); err != nil {
+			t.Errorf("unexpected error validating script %q: %s", script, err)
+		}
+	}
+
+	invalidScripts := []string{
+		`return {`,
+		`error("oops")`,
+		`return { ["mycompany.test"] = "not a recognizer" }`,
+	}
+	for _, script := range invalidScripts {
+		if err := service.ValidateOverrideScript(context.Background(), script); err == nil {
+			t.Errorf("expected error validating script %q", script)
+		}
+	}
+}
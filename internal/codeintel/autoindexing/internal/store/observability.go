@@ -27,6 +27,10 @@ type operations struct {
 	// Index Configuration
 	getIndexConfigurationByRepositoryID    *observation.Operation
 	updateIndexConfigurationByRepositoryID *observation.Operation
+
+	// Inference scripts
+	getInferenceScriptByRepositoryID    *observation.Operation
+	updateInferenceScriptByRepositoryID *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -65,5 +69,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		// Index Configuration
 		getIndexConfigurationByRepositoryID:    op("GetIndexConfigurationByRepositoryID"),
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
+
+		// Inference scripts
+		getInferenceScriptByRepositoryID:    op("GetInferenceScriptByRepositoryID"),
+		updateInferenceScriptByRepositoryID: op("UpdateInferenceScriptByRepositoryID"),
 	}
 }
@@ -33,6 +33,10 @@ type Store interface {
 	// Index configurations
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (_ shared.IndexConfiguration, _ bool, err error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) (err error)
+
+	// Inference scripts
+	GetInferenceScriptByRepositoryID(ctx context.Context, repositoryID int) (_ string, _ bool, err error)
+	UpdateInferenceScriptByRepositoryID(ctx context.Context, repositoryID int, script string) (err error)
 }
 
 // store manages the autoindexing store.
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetInferenceScriptByRepositoryID returns the Lua script that overrides the inference recognizers
+// for a repository.
+func (s *store) GetInferenceScriptByRepositoryID(ctx context.Context, repositoryID int) (_ string, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getInferenceScriptByRepositoryID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanFirstString(s.db.Query(ctx, sqlf.Sprintf(getInferenceScriptByRepositoryIDQuery, repositoryID)))
+}
+
+const getInferenceScriptByRepositoryIDQuery = `
+-- source: internal/codeintel/autoindexing/internal/store/store_inference_scripts.go:GetInferenceScriptByRepositoryID
+SELECT s.script FROM codeintel_inference_scripts s WHERE s.repository_id = %s
+`
+
+// UpdateInferenceScriptByRepositoryID sets the Lua script that overrides the inference recognizers
+// for a repository. An empty script removes the override.
+func (s *store) UpdateInferenceScriptByRepositoryID(ctx context.Context, repositoryID int, script string) (err error) {
+	ctx, _, endObservation := s.operations.updateInferenceScriptByRepositoryID.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if script == "" {
+		return s.db.Exec(ctx, sqlf.Sprintf(deleteInferenceScriptByRepositoryIDQuery, repositoryID))
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(updateInferenceScriptByRepositoryIDQuery, repositoryID, script))
+}
+
+const updateInferenceScriptByRepositoryIDQuery = `
+-- source: internal/codeintel/autoindexing/internal/store/store_inference_scripts.go:UpdateInferenceScriptByRepositoryID
+INSERT INTO codeintel_inference_scripts (repository_id, script) VALUES (%s, %s)
+	ON CONFLICT (repository_id) DO UPDATE SET script = EXCLUDED.script, updated_at = NOW()
+`
+
+const deleteInferenceScriptByRepositoryIDQuery = `
+-- source: internal/codeintel/autoindexing/internal/store/store_inference_scripts.go:UpdateInferenceScriptByRepositoryID
+DELETE FROM codeintel_inference_scripts WHERE repository_id = %s
+`
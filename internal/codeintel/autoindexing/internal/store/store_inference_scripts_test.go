@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUpdateInferenceScriptByRepositoryID(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	query := sqlf.Sprintf(
+		`INSERT INTO repo (id, name) VALUES (%s, %s)`,
+		42,
+		"github.com/baz/honk",
+	)
+	if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+		t.Fatalf("unexpected error inserting repo: %s", err)
+	}
+
+	if _, ok, err := store.GetInferenceScriptByRepositoryID(context.Background(), 42); err != nil {
+		t.Fatalf("unexpected error while fetching inference script: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected inference script")
+	}
+
+	for _, expectedScript := range []string{`return {}`, `return require("sg.recognizers")`} {
+		if err := store.UpdateInferenceScriptByRepositoryID(context.Background(), 42, expectedScript); err != nil {
+			t.Fatalf("unexpected error while updating inference script: %s", err)
+		}
+		if script, ok, err := store.GetInferenceScriptByRepositoryID(context.Background(), 42); err != nil {
+			t.Fatalf("unexpected error while fetching inference script: %s", err)
+		} else if !ok {
+			t.Fatalf("expected an inference script")
+		} else if script != expectedScript {
+			t.Errorf("unexpected inference script. want=%q have=%q", expectedScript, script)
+		}
+	}
+
+	if err := store.UpdateInferenceScriptByRepositoryID(context.Background(), 42, ""); err != nil {
+		t.Fatalf("unexpected error while removing inference script: %s", err)
+	}
+	if _, ok, err := store.GetInferenceScriptByRepositoryID(context.Background(), 42); err != nil {
+		t.Fatalf("unexpected error while fetching inference script: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected inference script after removal")
+	}
+}
@@ -47,6 +47,10 @@ type MockStore struct {
 	// GetIndexesByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetIndexesByIDs.
 	GetIndexesByIDsFunc *StoreGetIndexesByIDsFunc
+	// GetInferenceScriptByRepositoryIDFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetInferenceScriptByRepositoryID.
+	GetInferenceScriptByRepositoryIDFunc *StoreGetInferenceScriptByRepositoryIDFunc
 	// GetLastIndexScanForRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetLastIndexScanForRepository.
@@ -67,6 +71,10 @@ type MockStore struct {
 	// function object controlling the behavior of the method
 	// UpdateIndexConfigurationByRepositoryID.
 	UpdateIndexConfigurationByRepositoryIDFunc *StoreUpdateIndexConfigurationByRepositoryIDFunc
+	// UpdateInferenceScriptByRepositoryIDFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// UpdateInferenceScriptByRepositoryID.
+	UpdateInferenceScriptByRepositoryIDFunc *StoreUpdateInferenceScriptByRepositoryIDFunc
 	// UpdateSourcedCommitsFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSourcedCommits.
 	UpdateSourcedCommitsFunc *StoreUpdateSourcedCommitsFunc
@@ -111,6 +119,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetInferenceScriptByRepositoryIDFunc: &StoreGetInferenceScriptByRepositoryIDFunc{
+			defaultHook: func(context.Context, int) (r0 string, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetLastIndexScanForRepositoryFunc: &StoreGetLastIndexScanForRepositoryFunc{
 			defaultHook: func(context.Context, int) (r0 *time.Time, r1 error) {
 				return
@@ -141,6 +154,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateInferenceScriptByRepositoryIDFunc: &StoreUpdateInferenceScriptByRepositoryIDFunc{
+			defaultHook: func(context.Context, int, string) (r0 error) {
+				return
+			},
+		},
 		UpdateSourcedCommitsFunc: &StoreUpdateSourcedCommitsFunc{
 			defaultHook: func(context.Context, int, string, time.Time) (r0 int, r1 error) {
 				return
@@ -188,6 +206,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetIndexesByIDs")
 			},
 		},
+		GetInferenceScriptByRepositoryIDFunc: &StoreGetInferenceScriptByRepositoryIDFunc{
+			defaultHook: func(context.Context, int) (string, bool, error) {
+				panic("unexpected invocation of MockStore.GetInferenceScriptByRepositoryID")
+			},
+		},
 		GetLastIndexScanForRepositoryFunc: &StoreGetLastIndexScanForRepositoryFunc{
 			defaultHook: func(context.Context, int) (*time.Time, error) {
 				panic("unexpected invocation of MockStore.GetLastIndexScanForRepository")
@@ -218,6 +241,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateIndexConfigurationByRepositoryID")
 			},
 		},
+		UpdateInferenceScriptByRepositoryIDFunc: &StoreUpdateInferenceScriptByRepositoryIDFunc{
+			defaultHook: func(context.Context, int, string) error {
+				panic("unexpected invocation of MockStore.UpdateInferenceScriptByRepositoryID")
+			},
+		},
 		UpdateSourcedCommitsFunc: &StoreUpdateSourcedCommitsFunc{
 			defaultHook: func(context.Context, int, string, time.Time) (int, error) {
 				panic("unexpected invocation of MockStore.UpdateSourcedCommits")
@@ -251,6 +279,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetIndexesByIDsFunc: &StoreGetIndexesByIDsFunc{
 			defaultHook: i.GetIndexesByIDs,
 		},
+		GetInferenceScriptByRepositoryIDFunc: &StoreGetInferenceScriptByRepositoryIDFunc{
+			defaultHook: i.GetInferenceScriptByRepositoryID,
+		},
 		GetLastIndexScanForRepositoryFunc: &StoreGetLastIndexScanForRepositoryFunc{
 			defaultHook: i.GetLastIndexScanForRepository,
 		},
@@ -269,6 +300,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateIndexConfigurationByRepositoryIDFunc: &StoreUpdateIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.UpdateIndexConfigurationByRepositoryID,
 		},
+		UpdateInferenceScriptByRepositoryIDFunc: &StoreUpdateInferenceScriptByRepositoryIDFunc{
+			defaultHook: i.UpdateInferenceScriptByRepositoryID,
+		},
 		UpdateSourcedCommitsFunc: &StoreUpdateSourcedCommitsFunc{
 			defaultHook: i.UpdateSourcedCommits,
 		},
@@ -1059,6 +1093,121 @@ func (c StoreGetIndexesByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetInferenceScriptByRepositoryIDFunc describes the behavior when the
+// GetInferenceScriptByRepositoryID method of the parent MockStore instance
+// is invoked.
+type StoreGetInferenceScriptByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int) (string, bool, error)
+	hooks       []func(context.Context, int) (string, bool, error)
+	history     []StoreGetInferenceScriptByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetInferenceScriptByRepositoryID delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetInferenceScriptByRepositoryID(v0 context.Context, v1 int) (string, bool, error) {
+	r0, r1, r2 := m.GetInferenceScriptByRepositoryIDFunc.nextHook()(v0, v1)
+	m.GetInferenceScriptByRepositoryIDFunc.appendCall(StoreGetInferenceScriptByRepositoryIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetInferenceScriptByRepositoryID method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetInferenceScriptByRepositoryID method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) nextHook() func(context.Context, int) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) appendCall(r0 StoreGetInferenceScriptByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetInferenceScriptByRepositoryIDFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetInferenceScriptByRepositoryIDFunc) History() []StoreGetInferenceScriptByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetInferenceScriptByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetInferenceScriptByRepositoryIDFuncCall is an object that describes
+// an invocation of method GetInferenceScriptByRepositoryID on an instance
+// of MockStore.
+type StoreGetInferenceScriptByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetInferenceScriptByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetInferenceScriptByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetLastIndexScanForRepositoryFunc describes the behavior when the
 // GetLastIndexScanForRepository method of the parent MockStore instance is
 // invoked.
@@ -1724,6 +1873,119 @@ func (c StoreUpdateIndexConfigurationByRepositoryIDFuncCall) Results() []interfa
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateInferenceScriptByRepositoryIDFunc describes the behavior when
+// the UpdateInferenceScriptByRepositoryID method of the parent MockStore
+// instance is invoked.
+type StoreUpdateInferenceScriptByRepositoryIDFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []StoreUpdateInferenceScriptByRepositoryIDFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateInferenceScriptByRepositoryID delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) UpdateInferenceScriptByRepositoryID(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.UpdateInferenceScriptByRepositoryIDFunc.nextHook()(v0, v1, v2)
+	m.UpdateInferenceScriptByRepositoryIDFunc.appendCall(StoreUpdateInferenceScriptByRepositoryIDFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateInferenceScriptByRepositoryID method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateInferenceScriptByRepositoryID method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) PushHook(hook func(context.Context, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) nextHook() func(context.Context, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) appendCall(r0 StoreUpdateInferenceScriptByRepositoryIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreUpdateInferenceScriptByRepositoryIDFuncCall objects describing the
+// invocations of this function.
+func (f *StoreUpdateInferenceScriptByRepositoryIDFunc) History() []StoreUpdateInferenceScriptByRepositoryIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateInferenceScriptByRepositoryIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateInferenceScriptByRepositoryIDFuncCall is an object that
+// describes an invocation of method UpdateInferenceScriptByRepositoryID on
+// an instance of MockStore.
+type StoreUpdateInferenceScriptByRepositoryIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateInferenceScriptByRepositoryIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateInferenceScriptByRepositoryIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdateSourcedCommitsFunc describes the behavior when the
 // UpdateSourcedCommits method of the parent MockStore instance is invoked.
 type StoreUpdateSourcedCommitsFunc struct {
@@ -2653,6 +2915,9 @@ type MockInferenceService struct {
 	// InferIndexJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method InferIndexJobs.
 	InferIndexJobsFunc *InferenceServiceInferIndexJobsFunc
+	// ValidateOverrideScriptFunc is an instance of a mock function object
+	// controlling the behavior of the method ValidateOverrideScript.
+	ValidateOverrideScriptFunc *InferenceServiceValidateOverrideScriptFunc
 }
 
 // NewMockInferenceService creates a new mock of the InferenceService
@@ -2661,12 +2926,17 @@ type MockInferenceService struct {
 func NewMockInferenceService() *MockInferenceService {
 	return &MockInferenceService{
 		InferIndexJobHintsFunc: &InferenceServiceInferIndexJobHintsFunc{
-			defaultHook: func(context.Context, api.RepoName, string, string) (r0 []config.IndexJobHint, r1 error) {
+			defaultHook: func(context.Context, api.RepoName, string, ...string) (r0 []config.IndexJobHint, r1 error) {
 				return
 			},
 		},
 		InferIndexJobsFunc: &InferenceServiceInferIndexJobsFunc{
-			defaultHook: func(context.Context, api.RepoName, string, string) (r0 []config.IndexJob, r1 error) {
+			defaultHook: func(context.Context, api.RepoName, string, ...string) (r0 []config.IndexJob, r1 error) {
+				return
+			},
+		},
+		ValidateOverrideScriptFunc: &InferenceServiceValidateOverrideScriptFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
 				return
 			},
 		},
@@ -2678,15 +2948,20 @@ func NewMockInferenceService() *MockInferenceService {
 func NewStrictMockInferenceService() *MockInferenceService {
 	return &MockInferenceService{
 		InferIndexJobHintsFunc: &InferenceServiceInferIndexJobHintsFunc{
-			defaultHook: func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error) {
+			defaultHook: func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error) {
 				panic("unexpected invocation of MockInferenceService.InferIndexJobHints")
 			},
 		},
 		InferIndexJobsFunc: &InferenceServiceInferIndexJobsFunc{
-			defaultHook: func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error) {
+			defaultHook: func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error) {
 				panic("unexpected invocation of MockInferenceService.InferIndexJobs")
 			},
 		},
+		ValidateOverrideScriptFunc: &InferenceServiceValidateOverrideScriptFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockInferenceService.ValidateOverrideScript")
+			},
+		},
 	}
 }
 
@@ -2701,6 +2976,9 @@ func NewMockInferenceServiceFrom(i shared.InferenceService) *MockInferenceServic
 		InferIndexJobsFunc: &InferenceServiceInferIndexJobsFunc{
 			defaultHook: i.InferIndexJobs,
 		},
+		ValidateOverrideScriptFunc: &InferenceServiceValidateOverrideScriptFunc{
+			defaultHook: i.ValidateOverrideScript,
+		},
 	}
 }
 
@@ -2708,16 +2986,16 @@ func NewMockInferenceServiceFrom(i shared.InferenceService) *MockInferenceServic
 // InferIndexJobHints method of the parent MockInferenceService instance is
 // invoked.
 type InferenceServiceInferIndexJobHintsFunc struct {
-	defaultHook func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error)
-	hooks       []func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error)
+	defaultHook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error)
+	hooks       []func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error)
 	history     []InferenceServiceInferIndexJobHintsFuncCall
 	mutex       sync.Mutex
 }
 
 // InferIndexJobHints delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockInferenceService) InferIndexJobHints(v0 context.Context, v1 api.RepoName, v2 string, v3 string) ([]config.IndexJobHint, error) {
-	r0, r1 := m.InferIndexJobHintsFunc.nextHook()(v0, v1, v2, v3)
+func (m *MockInferenceService) InferIndexJobHints(v0 context.Context, v1 api.RepoName, v2 string, v3 ...string) ([]config.IndexJobHint, error) {
+	r0, r1 := m.InferIndexJobHintsFunc.nextHook()(v0, v1, v2, v3...)
 	m.InferIndexJobHintsFunc.appendCall(InferenceServiceInferIndexJobHintsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
@@ -2725,7 +3003,7 @@ func (m *MockInferenceService) InferIndexJobHints(v0 context.Context, v1 api.Rep
 // SetDefaultHook sets function that is called when the InferIndexJobHints
 // method of the parent MockInferenceService instance is invoked and the
 // hook queue is empty.
-func (f *InferenceServiceInferIndexJobHintsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error)) {
+func (f *InferenceServiceInferIndexJobHintsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error)) {
 	f.defaultHook = hook
 }
 
@@ -2734,7 +3012,7 @@ func (f *InferenceServiceInferIndexJobHintsFunc) SetDefaultHook(hook func(contex
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *InferenceServiceInferIndexJobHintsFunc) PushHook(hook func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error)) {
+func (f *InferenceServiceInferIndexJobHintsFunc) PushHook(hook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2743,19 +3021,19 @@ func (f *InferenceServiceInferIndexJobHintsFunc) PushHook(hook func(context.Cont
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *InferenceServiceInferIndexJobHintsFunc) SetDefaultReturn(r0 []config.IndexJobHint, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *InferenceServiceInferIndexJobHintsFunc) PushReturn(r0 []config.IndexJobHint, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error) {
+	f.PushHook(func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error) {
 		return r0, r1
 	})
 }
 
-func (f *InferenceServiceInferIndexJobHintsFunc) nextHook() func(context.Context, api.RepoName, string, string) ([]config.IndexJobHint, error) {
+func (f *InferenceServiceInferIndexJobHintsFunc) nextHook() func(context.Context, api.RepoName, string, ...string) ([]config.IndexJobHint, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2798,9 +3076,9 @@ type InferenceServiceInferIndexJobHintsFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	// Arg3 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg3 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []config.IndexJobHint
@@ -2812,7 +3090,12 @@ type InferenceServiceInferIndexJobHintsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c InferenceServiceInferIndexJobHintsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	trailing := []interface{}{}
+	for _, val := range c.Arg3 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1, c.Arg2}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
@@ -2825,16 +3108,16 @@ func (c InferenceServiceInferIndexJobHintsFuncCall) Results() []interface{} {
 // InferIndexJobs method of the parent MockInferenceService instance is
 // invoked.
 type InferenceServiceInferIndexJobsFunc struct {
-	defaultHook func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error)
-	hooks       []func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error)
+	defaultHook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error)
+	hooks       []func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error)
 	history     []InferenceServiceInferIndexJobsFuncCall
 	mutex       sync.Mutex
 }
 
 // InferIndexJobs delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockInferenceService) InferIndexJobs(v0 context.Context, v1 api.RepoName, v2 string, v3 string) ([]config.IndexJob, error) {
-	r0, r1 := m.InferIndexJobsFunc.nextHook()(v0, v1, v2, v3)
+func (m *MockInferenceService) InferIndexJobs(v0 context.Context, v1 api.RepoName, v2 string, v3 ...string) ([]config.IndexJob, error) {
+	r0, r1 := m.InferIndexJobsFunc.nextHook()(v0, v1, v2, v3...)
 	m.InferIndexJobsFunc.appendCall(InferenceServiceInferIndexJobsFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
@@ -2842,7 +3125,7 @@ func (m *MockInferenceService) InferIndexJobs(v0 context.Context, v1 api.RepoNam
 // SetDefaultHook sets function that is called when the InferIndexJobs
 // method of the parent MockInferenceService instance is invoked and the
 // hook queue is empty.
-func (f *InferenceServiceInferIndexJobsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error)) {
+func (f *InferenceServiceInferIndexJobsFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error)) {
 	f.defaultHook = hook
 }
 
@@ -2850,7 +3133,7 @@ func (f *InferenceServiceInferIndexJobsFunc) SetDefaultHook(hook func(context.Co
 // InferIndexJobs method of the parent MockInferenceService instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *InferenceServiceInferIndexJobsFunc) PushHook(hook func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error)) {
+func (f *InferenceServiceInferIndexJobsFunc) PushHook(hook func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2859,19 +3142,19 @@ func (f *InferenceServiceInferIndexJobsFunc) PushHook(hook func(context.Context,
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *InferenceServiceInferIndexJobsFunc) SetDefaultReturn(r0 []config.IndexJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *InferenceServiceInferIndexJobsFunc) PushReturn(r0 []config.IndexJob, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error) {
+	f.PushHook(func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error) {
 		return r0, r1
 	})
 }
 
-func (f *InferenceServiceInferIndexJobsFunc) nextHook() func(context.Context, api.RepoName, string, string) ([]config.IndexJob, error) {
+func (f *InferenceServiceInferIndexJobsFunc) nextHook() func(context.Context, api.RepoName, string, ...string) ([]config.IndexJob, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2914,9 +3197,9 @@ type InferenceServiceInferIndexJobsFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	// Arg3 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg3 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []config.IndexJob
@@ -2928,7 +3211,12 @@ type InferenceServiceInferIndexJobsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c InferenceServiceInferIndexJobsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	trailing := []interface{}{}
+	for _, val := range c.Arg3 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1, c.Arg2}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
@@ -2937,6 +3225,115 @@ func (c InferenceServiceInferIndexJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// InferenceServiceValidateOverrideScriptFunc describes the behavior when
+// the ValidateOverrideScript method of the parent MockInferenceService
+// instance is invoked.
+type InferenceServiceValidateOverrideScriptFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []InferenceServiceValidateOverrideScriptFuncCall
+	mutex       sync.Mutex
+}
+
+// ValidateOverrideScript delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockInferenceService) ValidateOverrideScript(v0 context.Context, v1 string) error {
+	r0 := m.ValidateOverrideScriptFunc.nextHook()(v0, v1)
+	m.ValidateOverrideScriptFunc.appendCall(InferenceServiceValidateOverrideScriptFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ValidateOverrideScript method of the parent MockInferenceService instance
+// is invoked and the hook queue is empty.
+func (f *InferenceServiceValidateOverrideScriptFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ValidateOverrideScript method of the parent MockInferenceService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *InferenceServiceValidateOverrideScriptFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *InferenceServiceValidateOverrideScriptFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *InferenceServiceValidateOverrideScriptFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *InferenceServiceValidateOverrideScriptFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InferenceServiceValidateOverrideScriptFunc) appendCall(r0 InferenceServiceValidateOverrideScriptFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// InferenceServiceValidateOverrideScriptFuncCall objects describing the
+// invocations of this function.
+func (f *InferenceServiceValidateOverrideScriptFunc) History() []InferenceServiceValidateOverrideScriptFuncCall {
+	f.mutex.Lock()
+	history := make([]InferenceServiceValidateOverrideScriptFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InferenceServiceValidateOverrideScriptFuncCall is an object that
+// describes an invocation of method ValidateOverrideScript on an instance
+// of MockInferenceService.
+type InferenceServiceValidateOverrideScriptFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InferenceServiceValidateOverrideScriptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InferenceServiceValidateOverrideScriptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockRepoUpdaterClient is a mock implementation of the RepoUpdaterClient
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/shared)
@@ -28,6 +28,10 @@ type operations struct {
 	getIndexConfigurationByRepositoryID    *observation.Operation
 	updateIndexConfigurationByRepositoryID *observation.Operation
 	inferIndexConfiguration                *observation.Operation
+
+	// Inference scripts
+	getInferenceScriptByRepositoryID    *observation.Operation
+	updateInferenceScriptByRepositoryID *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -66,5 +70,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		getIndexConfigurationByRepositoryID:    op("GetIndexConfigurationByRepositoryID"),
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
 		inferIndexConfiguration:                op("InferIndexConfiguration"),
+
+		// Inference scripts
+		getInferenceScriptByRepositoryID:    op("GetInferenceScriptByRepositoryID"),
+		updateInferenceScriptByRepositoryID: op("UpdateInferenceScriptByRepositoryID"),
 	}
 }
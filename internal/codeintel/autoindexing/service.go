@@ -41,6 +41,10 @@ type service interface {
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (_ shared.IndexConfiguration, _ bool, err error)
 	InferIndexConfiguration(ctx context.Context, repositoryID int, commit string, bypassLimit bool) (_ *config.IndexConfiguration, hints []config.IndexJobHint, err error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) (err error)
+
+	// Inference scripts
+	GetInferenceScriptByRepositoryID(ctx context.Context, repositoryID int) (_ string, _ bool, err error)
+	UpdateInferenceScriptByRepositoryID(ctx context.Context, repositoryID int, script string) (err error)
 }
 
 type Service struct {
@@ -301,6 +305,51 @@ func (s *Service) queueIndexForRepositoryAndCommit(ctx context.Context, reposito
 
 var overrideScript = os.Getenv("SRC_CODEINTEL_INFERENCE_OVERRIDE_SCRIPT")
 
+// GetInferenceScriptByRepositoryID returns the Lua script stored for the given repository that
+// overrides the default inference recognizers.
+func (s *Service) GetInferenceScriptByRepositoryID(ctx context.Context, repositoryID int) (_ string, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getInferenceScriptByRepositoryID.With(ctx, &err, observation.Args{
+		LogFields: []otlog.Field{otlog.Int("repositoryID", repositoryID)},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.GetInferenceScriptByRepositoryID(ctx, repositoryID)
+}
+
+// UpdateInferenceScriptByRepositoryID validates and stores the Lua script that overrides the default
+// inference recognizers for the given repository. An empty script restores the default behavior.
+func (s *Service) UpdateInferenceScriptByRepositoryID(ctx context.Context, repositoryID int, script string) (err error) {
+	ctx, _, endObservation := s.operations.updateInferenceScriptByRepositoryID.With(ctx, &err, observation.Args{
+		LogFields: []otlog.Field{otlog.Int("repositoryID", repositoryID)},
+	})
+	defer endObservation(1, observation.Args{})
+
+	if script != "" {
+		if err := s.inferenceService.ValidateOverrideScript(ctx, script); err != nil {
+			return err
+		}
+	}
+
+	return s.store.UpdateInferenceScriptByRepositoryID(ctx, repositoryID, script)
+}
+
+// getOverrideScripts returns the Lua scripts that override the default inference recognizers for
+// the given repository, in the order they are applied. The script set by the
+// SRC_CODEINTEL_INFERENCE_OVERRIDE_SCRIPT environment variable applies to every repository, and a
+// script stored for the repository is applied after it so that its recognizers replace the global
+// recognizers with the same name.
+func (s *Service) getOverrideScripts(ctx context.Context, repositoryID int) ([]string, error) {
+	script, ok, err := s.store.GetInferenceScriptByRepositoryID(ctx, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetInferenceScriptByRepositoryID")
+	}
+	if !ok {
+		return []string{overrideScript}, nil
+	}
+
+	return []string{overrideScript, script}, nil
+}
+
 // inferIndexJobsFromRepositoryStructure collects the result of  InferIndexJobs over all registered recognizers.
 func (s *Service) inferIndexJobsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, bypassLimit bool) ([]config.IndexJob, error) {
 	repoName, err := s.uploadSvc.GetRepoName(ctx, repositoryID)
@@ -308,7 +357,12 @@ func (s *Service) inferIndexJobsFromRepositoryStructure(ctx context.Context, rep
 		return nil, err
 	}
 
-	indexes, err := s.inferenceService.InferIndexJobs(ctx, api.RepoName(repoName), commit, overrideScript)
+	scripts, err := s.getOverrideScripts(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := s.inferenceService.InferIndexJobs(ctx, api.RepoName(repoName), commit, scripts...)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +382,12 @@ func (s *Service) inferIndexJobHintsFromRepositoryStructure(ctx context.Context,
 		return nil, err
 	}
 
-	indexes, err := s.inferenceService.InferIndexJobHints(ctx, api.RepoName(repoName), commit, overrideScript)
+	scripts, err := s.getOverrideScripts(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := s.inferenceService.InferIndexJobHints(ctx, api.RepoName(repoName), commit, scripts...)
 	if err != nil {
 		return nil, err
 	}
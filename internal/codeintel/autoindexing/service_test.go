@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func init() {
@@ -377,7 +378,7 @@ func TestQueueIndexesInferred(t *testing.T) {
 	mockUploadSvc := NewMockUploadService()
 	mockUploadSvc.GetRepoNameFunc.SetDefaultHook(func(ctx context.Context, i int) (string, error) { return fmt.Sprintf("%d", i), nil })
 	inferenceService := NewMockInferenceService()
-	inferenceService.InferIndexJobsFunc.SetDefaultHook(func(ctx context.Context, rn api.RepoName, s1, s2 string) ([]config.IndexJob, error) {
+	inferenceService.InferIndexJobsFunc.SetDefaultHook(func(ctx context.Context, rn api.RepoName, s1 string, s2 ...string) ([]config.IndexJob, error) {
 		switch rn {
 		case "42":
 			return []config.IndexJob{{Root: ""}}, nil
@@ -426,6 +427,88 @@ func TestQueueIndexesInferred(t *testing.T) {
 	}
 }
 
+func TestQueueIndexesInferredWithInferenceScript(t *testing.T) {
+	globalScript := overrideScript
+	overrideScript = "return {global = false}"
+	t.Cleanup(func() { overrideScript = globalScript })
+
+	mockDBStore := NewMockStore()
+	mockDBStore.InsertIndexesFunc.SetDefaultHook(func(ctx context.Context, indexes []shared.Index) ([]shared.Index, error) { return indexes, nil })
+	mockDBStore.GetInferenceScriptByRepositoryIDFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) (string, bool, error) {
+		if repositoryID == 42 {
+			return "return {}", true, nil
+		}
+
+		return "", false, nil
+	})
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, rev string) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c%d", repositoryID)), nil
+	})
+	mockUploadSvc := NewMockUploadService()
+	mockUploadSvc.GetRepoNameFunc.SetDefaultHook(func(ctx context.Context, i int) (string, error) { return fmt.Sprintf("%d", i), nil })
+	inferenceService := NewMockInferenceService()
+
+	scheduler := newService(mockDBStore, mockUploadSvc, mockGitserverClient, nil, inferenceService, &observation.TestContext)
+
+	for _, id := range []int{42, 43} {
+		if _, err := scheduler.QueueIndexes(context.Background(), id, "HEAD", "", false, false); err != nil {
+			t.Fatalf("unexpected error performing update: %s", err)
+		}
+	}
+
+	scripts := map[api.RepoName][]string{}
+	for _, call := range inferenceService.InferIndexJobsFunc.History() {
+		scripts[call.Arg1] = call.Arg3
+	}
+
+	// The script stored for a repository is applied after the global script
+	expectedScripts := map[api.RepoName][]string{
+		"42": {"return {global = false}", "return {}"},
+		"43": {"return {global = false}"},
+	}
+	if diff := cmp.Diff(expectedScripts, scripts); diff != "" {
+		t.Errorf("unexpected override scripts (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateInferenceScriptByRepositoryID(t *testing.T) {
+	mockDBStore := NewMockStore()
+	inferenceService := NewMockInferenceService()
+	inferenceService.ValidateOverrideScriptFunc.SetDefaultHook(func(ctx context.Context, script string) error {
+		if script != "return {}" {
+			return errors.New("invalid inference script")
+		}
+
+		return nil
+	})
+
+	svc := newService(mockDBStore, NewMockUploadService(), NewMockGitserverClient(), nil, inferenceService, &observation.TestContext)
+
+	if err := svc.UpdateInferenceScriptByRepositoryID(context.Background(), 42, "return {"); err == nil {
+		t.Fatalf("expected error updating inference script")
+	}
+	if err := svc.UpdateInferenceScriptByRepositoryID(context.Background(), 42, "return {}"); err != nil {
+		t.Fatalf("unexpected error updating inference script: %s", err)
+	}
+	if err := svc.UpdateInferenceScriptByRepositoryID(context.Background(), 42, ""); err != nil {
+		t.Fatalf("unexpected error removing inference script: %s", err)
+	}
+
+	if len(inferenceService.ValidateOverrideScriptFunc.History()) != 2 {
+		t.Errorf("unexpected number of calls to ValidateOverrideScript. want=%d have=%d", 2, len(inferenceService.ValidateOverrideScriptFunc.History()))
+	}
+
+	var scripts []string
+	for _, call := range mockDBStore.UpdateInferenceScriptByRepositoryIDFunc.History() {
+		scripts = append(scripts, call.Arg2)
+	}
+	if diff := cmp.Diff([]string{"return {}", ""}, scripts); diff != "" {
+		t.Errorf("unexpected stored scripts (-want +got):\n%s", diff)
+	}
+}
+
 func TestQueueIndexesInferredTooLarge(t *testing.T) {
 	mockDBStore := NewMockStore()
 	mockDBStore.InsertIndexesFunc.SetDefaultHook(func(ctx context.Context, indexes []shared.Index) ([]shared.Index, error) { return indexes, nil })
@@ -487,7 +570,7 @@ func TestQueueIndexesForPackage(t *testing.T) {
 	mockUploadSvc.GetRepoNameFunc.SetDefaultHook(func(ctx context.Context, i int) (string, error) { return fmt.Sprintf("%d", i), nil })
 
 	inferenceService := NewMockInferenceService()
-	inferenceService.InferIndexJobsFunc.SetDefaultHook(func(ctx context.Context, rn api.RepoName, s1, s2 string) ([]config.IndexJob, error) {
+	inferenceService.InferIndexJobsFunc.SetDefaultHook(func(ctx context.Context, rn api.RepoName, s1 string, s2 ...string) ([]config.IndexJob, error) {
 		return []config.IndexJob{
 			{
 				Root: "",
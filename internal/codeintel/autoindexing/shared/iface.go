@@ -24,8 +24,9 @@ type GitserverClient interface {
 }
 
 type InferenceService interface {
-	InferIndexJobs(ctx context.Context, repo api.RepoName, commit, overrideScript string) ([]config.IndexJob, error)
-	InferIndexJobHints(ctx context.Context, repo api.RepoName, commit, overrideScript string) ([]config.IndexJobHint, error)
+	InferIndexJobs(ctx context.Context, repo api.RepoName, commit string, overrideScripts ...string) ([]config.IndexJob, error)
+	InferIndexJobHints(ctx context.Context, repo api.RepoName, commit string, overrideScripts ...string) ([]config.IndexJobHint, error)
+	ValidateOverrideScript(ctx context.Context, overrideScript string) error
 }
 
 type UploadService interface {
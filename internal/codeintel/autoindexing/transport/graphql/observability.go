@@ -21,6 +21,10 @@ type operations struct {
 	updateIndexConfigurationByRepositoryID *observation.Operation
 	inferedIndexConfiguration              *observation.Operation
 	inferedIndexConfigurationHints         *observation.Operation
+
+	// Inference scripts
+	getInferenceScript    *observation.Operation
+	updateInferenceScript *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -53,5 +57,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
 		inferedIndexConfiguration:              op("InferedIndexConfiguration"),
 		inferedIndexConfigurationHints:         op("InferedIndexConfigurationHints"),
+
+		// Inference scripts
+		getInferenceScript:    op("GetInferenceScript"),
+		updateInferenceScript: op("UpdateInferenceScript"),
 	}
 }
@@ -27,6 +27,10 @@ type Resolver interface {
 	InferedIndexConfigurationHints(ctx context.Context, repositoryID int, commit string) ([]config.IndexJobHint, error)       // in the service InferIndexConfiguration second return
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, configuration string) error                 // simple dbstore
 
+	// Inference scripts
+	GetInferenceScript(ctx context.Context, repositoryID int) (string, bool, error)   // GetInferenceScriptByRepositoryID
+	UpdateInferenceScript(ctx context.Context, repositoryID int, script string) error // validated in the inference sandbox
+
 	// Index Connection Factory
 	IndexConnectionResolverFromFactory(opts shared.GetIndexesOptions) *IndexesResolver // for the resolver
 }
@@ -156,6 +160,24 @@ func (r *resolver) UpdateIndexConfigurationByRepositoryID(ctx context.Context, r
 	return r.svc.UpdateIndexConfigurationByRepositoryID(ctx, repositoryID, []byte(configuration))
 }
 
+func (r *resolver) GetInferenceScript(ctx context.Context, repositoryID int) (_ string, _ bool, err error) {
+	ctx, _, endObservation := r.operations.getInferenceScript.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{log.Int("repositoryID", repositoryID)},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return r.svc.GetInferenceScriptByRepositoryID(ctx, repositoryID)
+}
+
+func (r *resolver) UpdateInferenceScript(ctx context.Context, repositoryID int, script string) (err error) {
+	ctx, _, endObservation := r.operations.updateInferenceScript.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{log.Int("repositoryID", repositoryID)},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return r.svc.UpdateInferenceScriptByRepositoryID(ctx, repositoryID, script)
+}
+
 func (r *resolver) IndexConnectionResolverFromFactory(opts shared.GetIndexesOptions) *IndexesResolver {
 	return NewIndexesResolver(r.svc, opts)
 }
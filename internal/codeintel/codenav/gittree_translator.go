@@ -2,6 +2,7 @@ package codenav
 
 import (
 	"context"
+	"os"
 	"strconv"
 	"strings"
 
@@ -10,7 +11,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // GitTreeTranslator translates a position within a git tree at a source commit into the
@@ -34,8 +37,27 @@ type GitTreeTranslator interface {
 	// that the translation was successful. If revese is true, then the source and target commits
 	// are swapped.
 	GetTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx shared.Range, reverse bool) (string, shared.Range, bool, error)
+
+	// GetTargetCommitRangesFromSourceRanges translates the given ranges of a single file from the source commit
+	// into the given target commit like GetTargetCommitRangeFromSourceRange, but reads the diffs between the
+	// commits only once. The target commit's path and ranges are returned, along with a flag for each range
+	// indicating that its translation was successful. If revese is true, then the source and target commits
+	// are swapped.
+	GetTargetCommitRangesFromSourceRanges(ctx context.Context, commit, path string, rxs []shared.Range, reverse bool) (string, []shared.Range, []bool, error)
+
+	// ApproximateTargetCommitRangeFromSourceRange translates the given range from the source commit into the
+	// given target commit like GetTargetCommitRangeFromSourceRange, but approximates the range when its lines
+	// were edited between the commits instead of failing. The confidence of the translation is returned along
+	// with the target commit's path and range: 1 if the range was translated exactly, and less than 1 if it
+	// was approximated.
+	ApproximateTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx shared.Range, reverse bool) (string, shared.Range, float64, bool, error)
 }
 
+// approximatedLineConfidence is the confidence of a line translated through a single diff in which
+// the line was edited. The confidence of a translation through multiple diffs is the product of the
+// confidences of each diff.
+const approximatedLineConfidence = 0.5
+
 type gitTreeTranslator struct {
 	client           shared.GitserverClient
 	localRequestArgs *requestArgs
@@ -82,24 +104,40 @@ func NewGitTreeTranslator(client shared.GitserverClient, args *requestArgs, hunk
 }
 
 // GetTargetCommitPathFromSourcePath translates the given path from the source commit into the given target
-// commit. If revese is true, then the source and target commits are swapped.
+// commit. If revese is true, then the source and target commits are swapped. Renames of the file between
+// the two commits are followed.
 func (g *gitTreeTranslator) GetTargetCommitPathFromSourcePath(ctx context.Context, commit, path string, reverse bool) (string, bool, error) {
-	return path, true, nil
+	sourceCommit, targetCommit := g.localRequestArgs.commit, commit
+	if reverse {
+		sourceCommit, targetCommit = targetCommit, sourceCommit
+	}
+
+	revisions, err := g.readPathRevisions(ctx, sourceCommit, path, targetCommit)
+	if err != nil {
+		return "", false, err
+	}
+
+	return revisions[len(revisions)-1].path, true, nil
 }
 
 // GetTargetCommitPositionFromSourcePosition translates the given position from the source commit into the given
 // target commit. The target commit path and position are returned, along with a boolean flag
 // indicating that the translation was successful. If revese is true, then the source and
 // target commits are swapped.
-// TODO: No todo just letting me know that I updated path just on this one. Need to do it like that.
 func (g *gitTreeTranslator) GetTargetCommitPositionFromSourcePosition(ctx context.Context, commit string, px shared.Position, reverse bool) (string, shared.Position, bool, error) {
-	hunks, err := g.readCachedHunks(ctx, g.localRequestArgs.repo, g.localRequestArgs.commit, commit, g.localRequestArgs.path, reverse)
+	path, steps, err := g.readTranslationSteps(ctx, commit, g.localRequestArgs.path, reverse)
 	if err != nil {
 		return "", shared.Position{}, false, err
 	}
 
-	commitPosition, ok := translatePosition(hunks, px)
-	return g.localRequestArgs.path, commitPosition, ok, nil
+	for _, hunks := range steps {
+		var ok bool
+		if px, ok = translatePosition(hunks, px); !ok {
+			return path, shared.Position{}, false, nil
+		}
+	}
+
+	return path, px, true, nil
 }
 
 // GetTargetCommitRangeFromSourceRange translates the given range from the source commit into the given target
@@ -107,33 +145,207 @@ func (g *gitTreeTranslator) GetTargetCommitPositionFromSourcePosition(ctx contex
 // that the translation was successful. If revese is true, then the source and target commits
 // are swapped.
 func (g *gitTreeTranslator) GetTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx shared.Range, reverse bool) (string, shared.Range, bool, error) {
-	hunks, err := g.readCachedHunks(ctx, g.localRequestArgs.repo, g.localRequestArgs.commit, commit, path, reverse)
+	path, steps, err := g.readTranslationSteps(ctx, commit, path, reverse)
 	if err != nil {
 		return "", shared.Range{}, false, err
 	}
 
-	commitRange, ok := translateRange(hunks, rx)
-	return path, commitRange, ok, nil
+	for _, hunks := range steps {
+		var ok bool
+		if rx, ok = translateRange(hunks, rx); !ok {
+			return path, shared.Range{}, false, nil
+		}
+	}
+
+	return path, rx, true, nil
 }
 
-// readCachedHunks returns a position-ordered slice of changes (additions or deletions) of
-// the given path between the given source and target commits. If reverse is true, then the
-// source and target commits are swapped. If the git tree translator has a hunk cache, it
-// will read from it before attempting to contact a remote server, and populate the cache
-// with new results
-func (g *gitTreeTranslator) readCachedHunks(ctx context.Context, repo *types.Repo, sourceCommit, targetCommit, path string, reverse bool) ([]*diff.Hunk, error) {
-	if sourceCommit == targetCommit {
-		return nil, nil
+// GetTargetCommitRangesFromSourceRanges translates the given ranges of a single file from the source commit
+// into the given target commit. The target commit path and ranges are returned, along with a flag for each
+// range indicating that its translation was successful. If revese is true, then the source and target commits
+// are swapped.
+func (g *gitTreeTranslator) GetTargetCommitRangesFromSourceRanges(ctx context.Context, commit, path string, rxs []shared.Range, reverse bool) (string, []shared.Range, []bool, error) {
+	path, steps, err := g.readTranslationSteps(ctx, commit, path, reverse)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	translated := make([]shared.Range, len(rxs))
+	oks := make([]bool, len(rxs))
+outer:
+	for i, rx := range rxs {
+		for _, hunks := range steps {
+			var ok bool
+			if rx, ok = translateRange(hunks, rx); !ok {
+				continue outer
+			}
+		}
+
+		translated[i], oks[i] = rx, true
+	}
+
+	return path, translated, oks, nil
+}
+
+// ApproximateTargetCommitRangeFromSourceRange translates the given range from the source commit into the
+// given target commit like GetTargetCommitRangeFromSourceRange, but approximates the range when its lines
+// were edited between the commits instead of failing. The confidence of the translation is returned along
+// with the target commit's path and range. If revese is true, then the source and target commits are swapped.
+func (g *gitTreeTranslator) ApproximateTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx shared.Range, reverse bool) (string, shared.Range, float64, bool, error) {
+	path, steps, err := g.readTranslationSteps(ctx, commit, path, reverse)
+	if err != nil {
+		return "", shared.Range{}, 0, false, err
+	}
+
+	confidence := 1.0
+	for _, hunks := range steps {
+		var ok, approximated bool
+		if rx, approximated, ok = approximateRange(hunks, rx); !ok {
+			return path, shared.Range{}, 0, false, nil
+		}
+		if approximated {
+			confidence *= approximatedLineConfidence
+		}
 	}
+
+	return path, rx, confidence, true, nil
+}
+
+// pathRevision is a path of a file at a particular commit.
+type pathRevision struct {
+	commit string
+	path   string
+}
+
+// readTranslationSteps returns the path in the target commit of the given path in the source commit, along
+// with the hunks of the diffs through which positions are translated from the source to the target commit.
+// If reverse is true, then the source and target commits are swapped.
+func (g *gitTreeTranslator) readTranslationSteps(ctx context.Context, commit, path string, reverse bool) (string, [][]*diff.Hunk, error) {
+	sourceCommit, targetCommit := g.localRequestArgs.commit, commit
 	if reverse {
 		sourceCommit, targetCommit = targetCommit, sourceCommit
 	}
 
+	if sourceCommit == targetCommit {
+		return path, [][]*diff.Hunk{nil}, nil
+	}
+
+	// A single diff of the path between the commits suffices unless the path does not exist in one of
+	// the commits, in which case the file is followed through its renames
+	hunks, err := g.readCachedRevisionHunks(ctx, g.localRequestArgs.repo, pathRevision{commit: sourceCommit, path: path}, pathRevision{commit: targetCommit, path: path})
+	if err == nil {
+		return path, [][]*diff.Hunk{hunks}, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", nil, err
+	}
+
+	revisions, err := g.readRenamedPathRevisions(ctx, sourceCommit, path, targetCommit)
+	if err != nil {
+		return "", nil, err
+	}
+
+	steps := make([][]*diff.Hunk, 0, len(revisions)-1)
+	for i := 1; i < len(revisions); i++ {
+		hunks, err := g.readCachedRevisionHunks(ctx, g.localRequestArgs.repo, revisions[i-1], revisions[i])
+		if err != nil {
+			return "", nil, err
+		}
+
+		steps = append(steps, hunks)
+	}
+
+	return revisions[len(revisions)-1].path, steps, nil
+}
+
+// readPathRevisions returns the revisions of the file with the given path in the source commit through
+// which the file is followed into the target commit. Renames of the file are only looked up if the path
+// does not exist in both commits. See readRenamedPathRevisions.
+func (g *gitTreeTranslator) readPathRevisions(ctx context.Context, sourceCommit, path, targetCommit string) ([]pathRevision, error) {
+	source := pathRevision{commit: sourceCommit, path: path}
+	target := pathRevision{commit: targetCommit, path: path}
+	if sourceCommit == targetCommit {
+		return []pathRevision{source, target}, nil
+	}
+
+	if _, err := g.readCachedRevisionHunks(ctx, g.localRequestArgs.repo, source, target); err == nil {
+		return []pathRevision{source, target}, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return g.readRenamedPathRevisions(ctx, sourceCommit, path, targetCommit)
+}
+
+// readRenamedPathRevisions returns the revisions of the file with the given path in the source commit
+// through which the file is followed into the target commit, in the style of `git log --follow`. The first
+// revision is that of the source commit and the last revision is that of the target commit. In between,
+// there is a revision before each rename of the file between the source commit and the merge base of the
+// commits, and a revision after each rename of the file between the merge base and the target commit.
+func (g *gitTreeTranslator) readRenamedPathRevisions(ctx context.Context, sourceCommit, path, targetCommit string) ([]pathRevision, error) {
+	renames, err := g.readCachedRenames(ctx, g.localRequestArgs.repo, sourceCommit, targetCommit, path)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []pathRevision{{commit: sourceCommit, path: path}}
+
+	// Undo the renames made since the merge base on the side of the source commit, newest first
+	for i := len(renames) - 1; i >= 0; i-- {
+		if rename := renames[i]; rename.Left && rename.NewPath == path {
+			path = rename.OldPath
+			revisions = append(revisions, pathRevision{commit: rename.Commit + "^", path: path})
+		}
+	}
+
+	// Apply the renames made since the merge base on the side of the target commit, oldest first
+	for _, rename := range renames {
+		if !rename.Left && rename.OldPath == path {
+			path = rename.NewPath
+			revisions = append(revisions, pathRevision{commit: rename.Commit, path: path})
+		}
+	}
+
+	return append(revisions, pathRevision{commit: targetCommit, path: path}), nil
+}
+
+// readCachedRenames returns the renames of the file with the given path in the source commit made by the
+// commits reachable from exactly one of the given commits, oldest first. If the git tree translator has a
+// hunk cache, it will read from it before attempting to contact a remote server, and populate the cache
+// with new results.
+func (g *gitTreeTranslator) readCachedRenames(ctx context.Context, repo *types.Repo, sourceCommit, targetCommit, path string) ([]gitserver.RenamedFile, error) {
 	if g.hunkCache == nil {
-		return g.readHunks(ctx, repo, sourceCommit, targetCommit, path)
+		return g.client.RenamedFiles(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, sourceCommit, targetCommit, path)
+	}
+
+	key := makeKey("renames", strconv.FormatInt(int64(repo.ID), 10), sourceCommit, targetCommit, path)
+	if renames, ok := g.hunkCache.Get(key); ok {
+		if renames == nil {
+			return nil, nil
+		}
+
+		return renames.([]gitserver.RenamedFile), nil
 	}
 
-	key := makeKey(strconv.FormatInt(int64(repo.ID), 10), sourceCommit, targetCommit, path)
+	renames, err := g.client.RenamedFiles(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, sourceCommit, targetCommit, path)
+	if err != nil {
+		return nil, err
+	}
+
+	g.hunkCache.Set(key, renames, int64(len(renames)+1))
+
+	return renames, nil
+}
+
+// readCachedRevisionHunks returns a position-ordered slice of changes (additions or deletions) between
+// the given source and target revisions of a file. If the git tree translator has a hunk cache, it will
+// read from it before attempting to contact a remote server, and populate the cache with new results.
+func (g *gitTreeTranslator) readCachedRevisionHunks(ctx context.Context, repo *types.Repo, source, target pathRevision) ([]*diff.Hunk, error) {
+	if g.hunkCache == nil {
+		return g.client.DiffFileRevisions(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, source.commit, source.path, target.commit, target.path)
+	}
+
+	key := makeKey(strconv.FormatInt(int64(repo.ID), 10), source.commit, source.path, target.commit, target.path)
 	if hunks, ok := g.hunkCache.Get(key); ok {
 		if hunks == nil {
 			return nil, nil
@@ -142,7 +354,7 @@ func (g *gitTreeTranslator) readCachedHunks(ctx context.Context, repo *types.Rep
 		return hunks.([]*diff.Hunk), nil
 	}
 
-	hunks, err := g.readHunks(ctx, repo, sourceCommit, targetCommit, path)
+	hunks, err := g.client.DiffFileRevisions(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, source.commit, source.path, target.commit, target.path)
 	if err != nil {
 		return nil, err
 	}
@@ -152,12 +364,6 @@ func (g *gitTreeTranslator) readCachedHunks(ctx context.Context, repo *types.Rep
 	return hunks, nil
 }
 
-// readHunks returns a position-ordered slice of changes (additions or deletions) of
-// the given path between the given source and target commits.
-func (g *gitTreeTranslator) readHunks(ctx context.Context, repo *types.Repo, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error) {
-	return g.client.DiffPath(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, sourceCommit, targetCommit, path)
-}
-
 // findHunk returns the last thunk that does not begin after the given line.
 func findHunk(hunks []*diff.Hunk, line int) *diff.Hunk {
 	i := 0
@@ -272,6 +478,77 @@ func translateLineNumbers(hunks []*diff.Hunk, line int) (int, bool) {
 	panic("Malformed hunk body")
 }
 
+// approximateRange translates the given range like translateRange, but approximates the endpoints of the
+// range that are on edited lines with approximateLineNumber. This function returns a flag indicating that
+// the range was approximated, and a flag indicating that the translation was successful.
+func approximateRange(hunks []*diff.Hunk, r shared.Range) (_ shared.Range, approximated, ok bool) {
+	startLine, startApproximated, ok := approximateLineNumber(hunks, r.Start.Line)
+	if !ok {
+		return shared.Range{}, false, false
+	}
+
+	endLine, endApproximated, ok := approximateLineNumber(hunks, r.End.Line)
+	if !ok {
+		return shared.Range{}, false, false
+	}
+
+	start := shared.Position{Line: startLine, Character: r.Start.Character}
+	end := shared.Position{Line: endLine, Character: r.End.Character}
+	if end.Line < start.Line || (end.Line == start.Line && end.Character < start.Character) {
+		end = start
+	}
+
+	return shared.Range{Start: start, End: end}, startApproximated || endApproximated, true
+}
+
+// approximateLineNumber translates the given line number like translateLineNumbers, but lines that were
+// edited are mapped to the line of the target file at the same position within the edited hunk instead of
+// failing. This function returns a flag indicating that the line was approximated, and a flag indicating
+// that the translation was successful. A translation fails only when the hunk deletes the entire file.
+func approximateLineNumber(hunks []*diff.Hunk, line int) (_ int, approximated, ok bool) {
+	if targetLine, ok := translateLineNumbers(hunks, line); ok {
+		return targetLine, false, true
+	}
+
+	// Translate from bundle/lsp zero-index to git diff one-index
+	line = line + 1
+
+	hunk := findHunk(hunks, line)
+	if hunk == nil || hunk.NewStartLine == 0 {
+		return 0, false, false
+	}
+	if hunk.NewLines == 0 {
+		// The hunk only deletes lines, which git positions after the line preceding the deletion
+		return int(hunk.NewStartLine) - 1, true, true
+	}
+
+	// Walk the delta like translateLineNumbers until the given line, counting the lines of the
+	// target file preceding it.
+	sourceOffset := int(hunk.OrigStartLine)
+	targetOffset := int(hunk.NewStartLine)
+	for _, deltaLine := range strings.Split(string(hunk.Body), "\n") {
+		isAdded := strings.HasPrefix(deltaLine, "+")
+		isRemoved := strings.HasPrefix(deltaLine, "-")
+
+		if !isAdded {
+			sourceOffset++
+		}
+		if sourceOffset-1 == line {
+			break
+		}
+		if !isRemoved {
+			targetOffset++
+		}
+	}
+
+	if lastTargetLine := int(hunk.NewStartLine + hunk.NewLines - 1); targetOffset > lastTargetLine {
+		targetOffset = lastTargetLine
+	}
+
+	// Translate from git diff one-index to bundle/lsp zero-index
+	return targetOffset - 1, true, true
+}
+
 func makeKey(parts ...string) string {
 	return strings.Join(parts, ":")
 }
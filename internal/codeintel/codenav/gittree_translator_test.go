@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/go-diff/diff"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
 	codeintelgitserver "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -21,6 +24,13 @@ import (
 var client = codeintelgitserver.New(database.NewMockDB(), NewMockDBStore(), &observation.TestContext)
 
 func TestGetTargetCommitPathFromSourcePath(t *testing.T) {
+	t.Cleanup(func() {
+		gitserver.Mocks.ExecReader = nil
+	})
+	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
 	args := &requestArgs{
 		repo:   &types.Repo{ID: 50},
 		commit: "deadbeef1",
@@ -40,12 +50,130 @@ func TestGetTargetCommitPathFromSourcePath(t *testing.T) {
 	}
 }
 
+func TestGetTargetCommitPathFromSourcePathRenamed(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RenamedFilesFunc.SetDefaultReturn([]codeintelgitserver.RenamedFile{
+		{Commit: "c1", OldPath: "baz.go", NewPath: "foo/bar.go", Left: true},
+		{Commit: "c2", OldPath: "foo/bar.go", NewPath: "foo/unrelated.go", Left: true},
+		{Commit: "c3", OldPath: "baz.go", NewPath: "qux.go", Left: false},
+		{Commit: "c4", OldPath: "qux.go", NewPath: "quux.go", Left: false},
+	}, nil)
+	mockGitserverClient.DiffFileRevisionsFunc.SetDefaultReturn(nil, os.ErrNotExist)
+
+	args := &requestArgs{
+		repo:   &types.Repo{ID: 50},
+		commit: "deadbeef1",
+		path:   "foo/bar.go",
+	}
+	adjuster := NewGitTreeTranslator(mockGitserverClient, args, nil)
+	path, ok, err := adjuster.GetTargetCommitPathFromSourcePath(context.Background(), "deadbeef2", "foo/bar.go", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !ok {
+		t.Errorf("expected translation to succeed")
+	}
+	if path != "quux.go" {
+		t.Errorf("unexpected path. want=%s have=%s", "quux.go", path)
+	}
+
+	history := mockGitserverClient.RenamedFilesFunc.History()
+	if len(history) != 1 || history[0].Arg3 != "deadbeef1" || history[0].Arg4 != "deadbeef2" || history[0].Arg5 != "foo/bar.go" {
+		t.Errorf("unexpected RenamedFiles calls: %v", history)
+	}
+}
+
+func TestApproximateTargetCommitRangeFromSourceRangeRenamed(t *testing.T) {
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RenamedFilesFunc.SetDefaultReturn([]codeintelgitserver.RenamedFile{
+		{Commit: "c1", OldPath: "resources/image.go", NewPath: "resources/images/image.go", Left: false},
+	}, nil)
+
+	hugoHunks := readTestHunks(t, hugoDiff)
+	mockGitserverClient.DiffFileRevisionsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error) {
+		switch {
+		case sourceCommit == "deadbeef2" && sourcePath == "resources/image.go" && targetCommit == "c1" && targetPath == "resources/images/image.go":
+			return hugoHunks, nil
+		case sourceCommit == "c1" && sourcePath == "resources/images/image.go" && targetCommit == "deadbeef1" && targetPath == "resources/images/image.go":
+			return nil, nil
+		case sourceCommit == "deadbeef2" && sourcePath == "resources/image.go" && targetCommit == "deadbeef1" && targetPath == "resources/image.go":
+			// The file does not exist under its old path in the target commit
+			return nil, os.ErrNotExist
+		}
+
+		t.Errorf("unexpected diff of %s:%s and %s:%s", sourceCommit, sourcePath, targetCommit, targetPath)
+		return nil, nil
+	})
+
+	args := &requestArgs{
+		repo:   &types.Repo{ID: 50},
+		commit: "deadbeef1",
+		path:   "resources/images/image.go",
+	}
+	adjuster := NewGitTreeTranslator(mockGitserverClient, args, nil)
+
+	testCases := []struct {
+		name               string
+		rIn                shared.Range
+		expectedRange      shared.Range
+		expectedConfidence float64
+	}{
+		{
+			name: "exact",
+			rIn: shared.Range{
+				Start: shared.Position{Line: 302, Character: 15},
+				End:   shared.Position{Line: 305, Character: 20},
+			},
+			expectedRange: shared.Range{
+				Start: shared.Position{Line: 294, Character: 15},
+				End:   shared.Position{Line: 297, Character: 20},
+			},
+			expectedConfidence: 1,
+		},
+		{
+			name: "edited",
+			rIn: shared.Range{
+				Start: shared.Position{Line: 38, Character: 1},
+				End:   shared.Position{Line: 38, Character: 10},
+			},
+			expectedRange: shared.Range{
+				Start: shared.Position{Line: 38, Character: 1},
+				End:   shared.Position{Line: 38, Character: 10},
+			},
+			expectedConfidence: approximatedLineConfidence,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path, rOut, confidence, ok, err := adjuster.ApproximateTargetCommitRangeFromSourceRange(context.Background(), "deadbeef2", "resources/image.go", testCase.rIn, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !ok {
+				t.Errorf("expected translation to succeed")
+			}
+			if path != "resources/images/image.go" {
+				t.Errorf("unexpected path. want=%s have=%s", "resources/images/image.go", path)
+			}
+			if diff := cmp.Diff(testCase.expectedRange, rOut); diff != "" {
+				t.Errorf("unexpected range (-want +got):\n%s", diff)
+			}
+			if confidence != testCase.expectedConfidence {
+				t.Errorf("unexpected confidence. want=%f have=%f", testCase.expectedConfidence, confidence)
+			}
+		})
+	}
+}
+
 func TestGetTargetCommitPositionFromSourcePosition(t *testing.T) {
 	t.Cleanup(func() {
 		gitserver.Mocks.ExecReader = nil
 	})
 	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"}
+		expectedArgs := []string{"diff", "deadbeef1:/foo/bar.go", "deadbeef2:/foo/bar.go"}
 		if diff := cmp.Diff(expectedArgs, args); diff != "" {
 			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
 		}
@@ -116,7 +244,7 @@ func TestGetTargetCommitPositionFromSourcePositionReverse(t *testing.T) {
 		gitserver.Mocks.ExecReader = nil
 	})
 	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef2", "deadbeef1", "--", "/foo/bar.go"}
+		expectedArgs := []string{"diff", "deadbeef2:/foo/bar.go", "deadbeef1:/foo/bar.go"}
 		if diff := cmp.Diff(expectedArgs, args); diff != "" {
 			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
 		}
@@ -155,7 +283,7 @@ func TestGetTargetCommitRangeFromSourceRange(t *testing.T) {
 		gitserver.Mocks.ExecReader = nil
 	})
 	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef1", "deadbeef2", "--", "/foo/bar.go"}
+		expectedArgs := []string{"diff", "deadbeef1:/foo/bar.go", "deadbeef2:/foo/bar.go"}
 		if diff := cmp.Diff(expectedArgs, args); diff != "" {
 			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
 		}
@@ -235,7 +363,7 @@ func TestGetTargetCommitRangeFromSourceRangeReverse(t *testing.T) {
 		gitserver.Mocks.ExecReader = nil
 	})
 	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
-		expectedArgs := []string{"diff", "deadbeef2", "deadbeef1", "--", "/foo/bar.go"}
+		expectedArgs := []string{"diff", "deadbeef2:/foo/bar.go", "deadbeef1:/foo/bar.go"}
 		if diff := cmp.Diff(expectedArgs, args); diff != "" {
 			t.Errorf("unexpected exec reader args (-want +got):\n%s", diff)
 		}
@@ -275,6 +403,53 @@ func TestGetTargetCommitRangeFromSourceRangeReverse(t *testing.T) {
 	}
 }
 
+func TestGetTargetCommitRangesFromSourceRanges(t *testing.T) {
+	t.Cleanup(func() {
+		gitserver.Mocks.ExecReader = nil
+	})
+	numDiffs := 0
+	gitserver.Mocks.ExecReader = func(args []string) (reader io.ReadCloser, err error) {
+		numDiffs++
+		return io.NopCloser(bytes.NewReader([]byte(hugoDiff))), nil
+	}
+
+	rIns := []shared.Range{
+		{Start: shared.Position{Line: 302, Character: 15}, End: shared.Position{Line: 305, Character: 20}},
+		{Start: shared.Position{Line: 300, Character: 1}, End: shared.Position{Line: 300, Character: 4}},
+		{Start: shared.Position{Line: 10, Character: 3}, End: shared.Position{Line: 10, Character: 6}},
+	}
+
+	args := &requestArgs{
+		repo:   &types.Repo{ID: 50},
+		commit: "deadbeef1",
+		path:   "/foo/bar.go",
+	}
+	adjuster := NewGitTreeTranslator(client, args, nil)
+	path, rOuts, oks, err := adjuster.GetTargetCommitRangesFromSourceRanges(context.Background(), "deadbeef2", "/foo/bar.go", rIns, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if path != "/foo/bar.go" {
+		t.Errorf("unexpected path. want=%s have=%s", "/foo/bar.go", path)
+	}
+	if numDiffs != 1 {
+		t.Errorf("unexpected number of diffs. want=%d have=%d", 1, numDiffs)
+	}
+	if diff := cmp.Diff([]bool{true, false, true}, oks); diff != "" {
+		t.Errorf("unexpected translation flags (-want +got):\n%s", diff)
+	}
+
+	expectedRanges := []shared.Range{
+		{Start: shared.Position{Line: 294, Character: 15}, End: shared.Position{Line: 297, Character: 20}},
+		{},
+		{Start: shared.Position{Line: 10, Character: 3}, End: shared.Position{Line: 10, Character: 6}},
+	}
+	if diff := cmp.Diff(expectedRanges, rOuts); diff != "" {
+		t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+	}
+}
+
 type gitTreeTranslatorTestCase struct {
 	diff         string // The git diff output
 	diffName     string // The git diff output name
@@ -418,3 +593,31 @@ func TestRawGetTargetCommitPositionFromSourcePosition(t *testing.T) {
 		})
 	}
 }
+
+func TestRawApproximateLineNumber(t *testing.T) {
+	for _, testCase := range append(append([]gitTreeTranslatorTestCase(nil), hugoTestCases...), prometheusTestCases...) {
+		name := fmt.Sprintf("%s : %s", testCase.diffName, testCase.description)
+
+		t.Run(name, func(t *testing.T) {
+			line, approximated, ok := approximateLineNumber(readTestHunks(t, testCase.diff), testCase.line-1)
+			if !ok {
+				t.Fatalf("expected approximation to succeed")
+			}
+			if approximated == testCase.expectedOk {
+				t.Errorf("unexpected approximated. want=%v have=%v", !testCase.expectedOk, approximated)
+			}
+			if testCase.expectedOk && line+1 != testCase.expectedLine {
+				t.Errorf("unexpected line. want=%d have=%d", testCase.expectedLine, line+1) // 0-index -> 1-index
+			}
+		})
+	}
+}
+
+func readTestHunks(t *testing.T, rawDiff string) []*diff.Hunk {
+	diff, err := diff.NewFileDiffReader(bytes.NewReader([]byte(rawDiff))).Read()
+	if err != nil {
+		t.Fatalf("unexpected error reading file diff: %s", err)
+	}
+
+	return diff.Hunks
+}
@@ -25,7 +25,11 @@ type LsifStore interface {
 
 	// Monikers
 	GetMonikersByPosition(ctx context.Context, uploadID int, path string, line, character int) (_ [][]precise.MonikerData, err error)
+	GetImportMonikersByPositions(ctx context.Context, uploadID int, path string, positions []shared.Position) (_ [][]precise.QualifiedMonikerData, err error)
 	GetBulkMonikerLocations(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit, offset int) (_ []shared.Location, totalCount int, err error)
+	GetBulkMonikerLocationsByMoniker(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit int) (_ [][]shared.Location, err error)
+	GetExportedMonikers(ctx context.Context, uploadID int, path string) (_ []precise.QualifiedMonikerData, err error)
+	GetBulkMonikerReferenceCount(ctx context.Context, uploadIDs []int, monikers []precise.MonikerData) (_ int, err error)
 
 	// Packages
 	GetPackageInformation(ctx context.Context, uploadID int, path, packageInformationID string) (_ precise.PackageInformationData, _ bool, err error)
@@ -40,6 +44,9 @@ type LsifStore interface {
 	GetRanges(ctx context.Context, bundleID int, path string, startLine, endLine int) (_ []shared.CodeIntelligenceRange, err error)
 
 	GetPathExists(ctx context.Context, bundleID int, path string) (_ bool, err error)
+
+	// Functions
+	GetFunctionDefinitions(ctx context.Context, bundleID int, path string) (_ []shared.FunctionDefinition, err error)
 }
 
 type store struct {
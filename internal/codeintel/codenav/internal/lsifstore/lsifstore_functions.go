@@ -0,0 +1,62 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+)
+
+// GetFunctionDefinitions returns the functions, methods, and constructors defined within a single
+// document, ordered by the start of their extent. Only definitions for which the indexer reported
+// the full range of the definition are returned.
+func (s *store) GetFunctionDefinitions(ctx context.Context, bundleID int, path string) (_ []shared.FunctionDefinition, err error) {
+	ctx, trace, endObservation := s.operations.getFunctionDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(rangesDocumentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	trace.Log(log.Int("numRanges", len(documentData.Document.Ranges)))
+
+	functions := make([]shared.FunctionDefinition, 0)
+	for _, r := range documentData.Document.Ranges {
+		if r.Symbol == nil || !isFunctionKind(r.Symbol.Kind) {
+			continue
+		}
+
+		functions = append(functions, shared.FunctionDefinition{
+			DumpID: bundleID,
+			Path:   path,
+			Name:   r.Symbol.Name,
+			Range:  newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter),
+			Extent: newRange(r.Symbol.FullStartLine, r.Symbol.FullStartCharacter, r.Symbol.FullEndLine, r.Symbol.FullEndCharacter),
+		})
+	}
+	sort.Slice(functions, func(i, j int) bool {
+		return compareBundleRanges(functions[i].Extent, functions[j].Extent)
+	})
+	trace.Log(log.Int("numFunctions", len(functions)))
+
+	return functions, nil
+}
+
+// isFunctionKind returns true if symbols of the given kind can be called.
+func isFunctionKind(kind protocol.SymbolKind) bool {
+	switch kind {
+	case protocol.Function, protocol.Method, protocol.Constructor:
+		return true
+	}
+
+	return false
+}
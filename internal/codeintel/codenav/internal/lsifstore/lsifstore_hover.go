@@ -51,7 +51,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path = %s
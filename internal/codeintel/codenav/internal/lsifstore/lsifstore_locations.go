@@ -82,7 +82,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path = %s
@@ -298,7 +298,7 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path IN (%s)
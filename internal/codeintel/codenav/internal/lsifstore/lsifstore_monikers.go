@@ -9,6 +9,7 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -65,13 +66,183 @@ SELECT
 	NULL AS packages,
 	NULL AS diagnostics
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path = %s
 LIMIT 1
 `
 
+// GetImportMonikersByPositions returns, for each of the given positions within the given document, the
+// import monikers attached to the ranges containing the position along with the package information they
+// were imported from. Monikers without package information cannot be defined in another upload and are
+// skipped. The document is read once for all positions.
+func (s *store) GetImportMonikersByPositions(ctx context.Context, uploadID int, path string, positions []shared.Position) (_ [][]precise.QualifiedMonikerData, err error) {
+	ctx, trace, endObservation := s.operations.getImportMonikersByPositions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.String("path", path),
+		log.Int("numPositions", len(positions)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(importMonikersDocumentQuery, uploadID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+	trace.Log(log.Int("numRanges", len(documentData.Document.Ranges)))
+
+	monikers := make([][]precise.QualifiedMonikerData, 0, len(positions))
+	for _, position := range positions {
+		var positionMonikers []precise.QualifiedMonikerData
+		for _, r := range precise.FindRanges(documentData.Document.Ranges, position.Line, position.Character) {
+			for _, monikerID := range r.MonikerIDs {
+				moniker, ok := documentData.Document.Monikers[monikerID]
+				if !ok || moniker.Kind != "import" || moniker.PackageInformationID == "" {
+					continue
+				}
+				packageInformationData, ok := documentData.Document.PackageInformation[moniker.PackageInformationID]
+				if !ok {
+					continue
+				}
+
+				positionMonikers = append(positionMonikers, precise.QualifiedMonikerData{
+					MonikerData:            moniker,
+					PackageInformationData: packageInformationData,
+				})
+			}
+		}
+
+		monikers = append(monikers, positionMonikers)
+	}
+
+	return monikers, nil
+}
+
+const importMonikersDocumentQuery = `
+-- source: internal/codeintel/codenav/internal/lsifstore/lsifstore_monikers.go:GetImportMonikersByPositions
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	NULL AS hovers,
+	monikers,
+	packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_all_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// GetExportedMonikers returns the distinct export monikers attached to ranges within the given document
+// along with the package information they were exported under. Monikers without package information
+// cannot be referenced from another upload and are skipped.
+func (s *store) GetExportedMonikers(ctx context.Context, uploadID int, path string) (_ []precise.QualifiedMonikerData, err error) {
+	ctx, trace, endObservation := s.operations.getExportedMonikers.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(exportedMonikersDocumentQuery, uploadID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	monikers := make([]precise.QualifiedMonikerData, 0, len(documentData.Document.Monikers))
+	for _, moniker := range documentData.Document.Monikers {
+		if moniker.Kind != "export" || moniker.PackageInformationID == "" {
+			continue
+		}
+		packageInformationData, ok := documentData.Document.PackageInformation[moniker.PackageInformationID]
+		if !ok {
+			continue
+		}
+
+		key := moniker.Scheme + ":" + moniker.Identifier
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		monikers = append(monikers, precise.QualifiedMonikerData{
+			MonikerData:            moniker,
+			PackageInformationData: packageInformationData,
+		})
+	}
+	trace.Log(log.Int("numMonikers", len(monikers)))
+
+	return monikers, nil
+}
+
+const exportedMonikersDocumentQuery = `
+-- source: internal/codeintel/codenav/internal/lsifstore/lsifstore_monikers.go:GetExportedMonikers
+SELECT
+	dump_id,
+	path,
+	data,
+	NULL AS ranges,
+	NULL AS hovers,
+	monikers,
+	packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_all_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// GetBulkMonikerReferenceCount returns the number of reference locations within the given uploads with
+// an attached moniker whose scheme+identifier matches one of the given monikers.
+func (s *store) GetBulkMonikerReferenceCount(ctx context.Context, uploadIDs []int, monikers []precise.MonikerData) (_ int, err error) {
+	ctx, trace, endObservation := s.operations.getBulkMonikerReferenceCount.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numUploadIDs", len(uploadIDs)),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+		log.Int("numMonikers", len(monikers)),
+		log.String("monikers", monikersToString(monikers)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 || len(monikers) == 0 {
+		return 0, nil
+	}
+
+	idQueries := make([]*sqlf.Query, 0, len(uploadIDs))
+	for _, id := range uploadIDs {
+		idQueries = append(idQueries, sqlf.Sprintf("%s", id))
+	}
+
+	monikerQueries := make([]*sqlf.Query, 0, len(monikers))
+	for _, moniker := range monikers {
+		monikerQueries = append(monikerQueries, sqlf.Sprintf("(%s, %s)", moniker.Scheme, moniker.Identifier))
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		bulkMonikerReferenceCountQuery,
+		sqlf.Join(idQueries, ", "),
+		sqlf.Join(monikerQueries, ", "),
+	)))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("count", count))
+
+	return count, nil
+}
+
+const bulkMonikerReferenceCountQuery = `
+-- source: internal/codeintel/codenav/internal/lsifstore/lsifstore_monikers.go:GetBulkMonikerReferenceCount
+SELECT COALESCE(SUM(num_locations), 0)
+FROM lsif_data_references
+WHERE dump_id IN (%s) AND (scheme, identifier) IN (%s)
+`
+
 // GetBulkMonikerLocations returns the locations (within one of the given uploads) with an attached moniker
 // whose scheme+identifier matches one of the given monikers. This method also returns the size of the
 // complete result set to aid in pagination.
@@ -151,6 +322,69 @@ outer:
 	return locations, totalCount, nil
 }
 
+// GetBulkMonikerLocationsByMoniker returns, for each of the given monikers, the locations within the given
+// uploads with an attached moniker whose scheme+identifier matches it. At most limit locations are returned
+// for each moniker.
+func (s *store) GetBulkMonikerLocationsByMoniker(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit int) (_ [][]shared.Location, err error) {
+	ctx, trace, endObservation := s.operations.getBulkMonikerLocationsByMoniker.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("tableName", tableName),
+		log.Int("numUploadIDs", len(uploadIDs)),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+		log.Int("numMonikers", len(monikers)),
+		log.String("monikers", monikersToString(monikers)),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	locations := make([][]shared.Location, len(monikers))
+	if len(uploadIDs) == 0 || len(monikers) == 0 {
+		return locations, nil
+	}
+
+	idQueries := make([]*sqlf.Query, 0, len(uploadIDs))
+	for _, id := range uploadIDs {
+		idQueries = append(idQueries, sqlf.Sprintf("%s", id))
+	}
+
+	indexes := make(map[string][]int, len(monikers))
+	monikerQueries := make([]*sqlf.Query, 0, len(monikers))
+	for i, arg := range monikers {
+		key := arg.Scheme + ":" + arg.Identifier
+		indexes[key] = append(indexes[key], i)
+		monikerQueries = append(monikerQueries, sqlf.Sprintf("(%s, %s)", arg.Scheme, arg.Identifier))
+	}
+
+	query := sqlf.Sprintf(
+		bulkMonikerResultsQuery,
+		sqlf.Sprintf(fmt.Sprintf("lsif_data_%s", tableName)),
+		sqlf.Join(idQueries, ", "),
+		sqlf.Join(monikerQueries, ", "),
+	)
+	locationData, err := s.scanQualifiedMonikerLocations(s.db.Query(ctx, query))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDumps", len(locationData)))
+
+	for _, monikerLocations := range locationData {
+		for _, i := range indexes[monikerLocations.Scheme+":"+monikerLocations.Identifier] {
+			for _, row := range monikerLocations.Locations {
+				if len(locations[i]) >= limit {
+					break
+				}
+
+				locations[i] = append(locations[i], shared.Location{
+					DumpID: monikerLocations.DumpID,
+					Path:   row.URI,
+					Range:  newRange(row.StartLine, row.StartCharacter, row.EndLine, row.EndCharacter),
+				})
+			}
+		}
+	}
+
+	return locations, nil
+}
+
 const bulkMonikerResultsQuery = `
 -- source: internal/codeintel/stores/lsifstore/monikers.go:BulkMonikerResults
 SELECT dump_id, scheme, identifier, data
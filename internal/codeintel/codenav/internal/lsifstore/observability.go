@@ -8,18 +8,23 @@ import (
 )
 
 type operations struct {
-	getReferences          *observation.Operation
-	getImplementations     *observation.Operation
-	getHover               *observation.Operation
-	getDefinitions         *observation.Operation
-	getDiagnostics         *observation.Operation
-	getRanges              *observation.Operation
-	getStencil             *observation.Operation
-	getExists              *observation.Operation
-	getMonikersByPosition  *observation.Operation
-	getPackageInformation  *observation.Operation
-	getBulkMonikerResults  *observation.Operation
-	getLocationsWithinFile *observation.Operation
+	getReferences                    *observation.Operation
+	getImplementations               *observation.Operation
+	getHover                         *observation.Operation
+	getDefinitions                   *observation.Operation
+	getDiagnostics                   *observation.Operation
+	getRanges                        *observation.Operation
+	getStencil                       *observation.Operation
+	getExists                        *observation.Operation
+	getMonikersByPosition            *observation.Operation
+	getImportMonikersByPositions     *observation.Operation
+	getExportedMonikers              *observation.Operation
+	getBulkMonikerReferenceCount     *observation.Operation
+	getPackageInformation            *observation.Operation
+	getBulkMonikerResults            *observation.Operation
+	getBulkMonikerLocationsByMoniker *observation.Operation
+	getLocationsWithinFile           *observation.Operation
+	getFunctionDefinitions           *observation.Operation
 
 	locations *observation.Operation
 }
@@ -50,18 +55,23 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		getReferences:          op("GetReferences"),
-		getImplementations:     op("GetImplementations"),
-		getHover:               op("GetHover"),
-		getDefinitions:         op("GetDefinitions"),
-		getDiagnostics:         op("GetDiagnostics"),
-		getRanges:              op("GetRanges"),
-		getStencil:             op("GetStencil"),
-		getExists:              op("GetExists"),
-		getMonikersByPosition:  op("GetMonikersByPosition"),
-		getPackageInformation:  op("GetPackageInformation"),
-		getBulkMonikerResults:  op("GetBulkMonikerResults"),
-		getLocationsWithinFile: op("GetLocationsWithinFile"),
+		getReferences:                    op("GetReferences"),
+		getImplementations:               op("GetImplementations"),
+		getHover:                         op("GetHover"),
+		getDefinitions:                   op("GetDefinitions"),
+		getDiagnostics:                   op("GetDiagnostics"),
+		getRanges:                        op("GetRanges"),
+		getStencil:                       op("GetStencil"),
+		getExists:                        op("GetExists"),
+		getMonikersByPosition:            op("GetMonikersByPosition"),
+		getImportMonikersByPositions:     op("GetImportMonikersByPositions"),
+		getExportedMonikers:              op("GetExportedMonikers"),
+		getBulkMonikerReferenceCount:     op("GetBulkMonikerReferenceCount"),
+		getPackageInformation:            op("GetPackageInformation"),
+		getBulkMonikerResults:            op("GetBulkMonikerResults"),
+		getBulkMonikerLocationsByMoniker: op("GetBulkMonikerLocationsByMoniker"),
+		getLocationsWithinFile:           op("GetLocationsWithinFile"),
+		getFunctionDefinitions:           op("GetFunctionDefinitions"),
 
 		locations: subOp("locations"),
 	}
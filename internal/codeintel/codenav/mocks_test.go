@@ -180,12 +180,26 @@ type MockLsifStore struct {
 	// GetBulkMonikerLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetBulkMonikerLocations.
 	GetBulkMonikerLocationsFunc *LsifStoreGetBulkMonikerLocationsFunc
+	// GetBulkMonikerLocationsByMonikerFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetBulkMonikerLocationsByMoniker.
+	GetBulkMonikerLocationsByMonikerFunc *LsifStoreGetBulkMonikerLocationsByMonikerFunc
+	// GetBulkMonikerReferenceCountFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetBulkMonikerReferenceCount.
+	GetBulkMonikerReferenceCountFunc *LsifStoreGetBulkMonikerReferenceCountFunc
 	// GetDefinitionLocationsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDefinitionLocations.
 	GetDefinitionLocationsFunc *LsifStoreGetDefinitionLocationsFunc
 	// GetDiagnosticsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDiagnostics.
 	GetDiagnosticsFunc *LsifStoreGetDiagnosticsFunc
+	// GetExportedMonikersFunc is an instance of a mock function object
+	// controlling the behavior of the method GetExportedMonikers.
+	GetExportedMonikersFunc *LsifStoreGetExportedMonikersFunc
+	// GetFunctionDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetFunctionDefinitions.
+	GetFunctionDefinitionsFunc *LsifStoreGetFunctionDefinitionsFunc
 	// GetHoverFunc is an instance of a mock function object controlling the
 	// behavior of the method GetHover.
 	GetHoverFunc *LsifStoreGetHoverFunc
@@ -193,6 +207,10 @@ type MockLsifStore struct {
 	// object controlling the behavior of the method
 	// GetImplementationLocations.
 	GetImplementationLocationsFunc *LsifStoreGetImplementationLocationsFunc
+	// GetImportMonikersByPositionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetImportMonikersByPositions.
+	GetImportMonikersByPositionsFunc *LsifStoreGetImportMonikersByPositionsFunc
 	// GetMonikersByPositionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetMonikersByPosition.
 	GetMonikersByPositionFunc *LsifStoreGetMonikersByPositionFunc
@@ -222,6 +240,16 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetBulkMonikerLocationsByMonikerFunc: &LsifStoreGetBulkMonikerLocationsByMonikerFunc{
+			defaultHook: func(context.Context, string, []int, []precise.MonikerData, int) (r0 [][]shared.Location, r1 error) {
+				return
+			},
+		},
+		GetBulkMonikerReferenceCountFunc: &LsifStoreGetBulkMonikerReferenceCountFunc{
+			defaultHook: func(context.Context, []int, []precise.MonikerData) (r0 int, r1 error) {
+				return
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) (r0 []shared.Location, r1 int, r2 error) {
 				return
@@ -232,6 +260,16 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetExportedMonikersFunc: &LsifStoreGetExportedMonikersFunc{
+			defaultHook: func(context.Context, int, string) (r0 []precise.QualifiedMonikerData, r1 error) {
+				return
+			},
+		},
+		GetFunctionDefinitionsFunc: &LsifStoreGetFunctionDefinitionsFunc{
+			defaultHook: func(context.Context, int, string) (r0 []shared.FunctionDefinition, r1 error) {
+				return
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 string, r1 shared.Range, r2 bool, r3 error) {
 				return
@@ -242,6 +280,11 @@ func NewMockLsifStore() *MockLsifStore {
 				return
 			},
 		},
+		GetImportMonikersByPositionsFunc: &LsifStoreGetImportMonikersByPositionsFunc{
+			defaultHook: func(context.Context, int, string, []shared.Position) (r0 [][]precise.QualifiedMonikerData, r1 error) {
+				return
+			},
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) (r0 [][]precise.MonikerData, r1 error) {
 				return
@@ -284,6 +327,16 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetBulkMonikerLocations")
 			},
 		},
+		GetBulkMonikerLocationsByMonikerFunc: &LsifStoreGetBulkMonikerLocationsByMonikerFunc{
+			defaultHook: func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error) {
+				panic("unexpected invocation of MockLsifStore.GetBulkMonikerLocationsByMoniker")
+			},
+		},
+		GetBulkMonikerReferenceCountFunc: &LsifStoreGetBulkMonikerReferenceCountFunc{
+			defaultHook: func(context.Context, []int, []precise.MonikerData) (int, error) {
+				panic("unexpected invocation of MockLsifStore.GetBulkMonikerReferenceCount")
+			},
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]shared.Location, int, error) {
 				panic("unexpected invocation of MockLsifStore.GetDefinitionLocations")
@@ -294,6 +347,16 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetDiagnostics")
 			},
 		},
+		GetExportedMonikersFunc: &LsifStoreGetExportedMonikersFunc{
+			defaultHook: func(context.Context, int, string) ([]precise.QualifiedMonikerData, error) {
+				panic("unexpected invocation of MockLsifStore.GetExportedMonikers")
+			},
+		},
+		GetFunctionDefinitionsFunc: &LsifStoreGetFunctionDefinitionsFunc{
+			defaultHook: func(context.Context, int, string) ([]shared.FunctionDefinition, error) {
+				panic("unexpected invocation of MockLsifStore.GetFunctionDefinitions")
+			},
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
 				panic("unexpected invocation of MockLsifStore.GetHover")
@@ -304,6 +367,11 @@ func NewStrictMockLsifStore() *MockLsifStore {
 				panic("unexpected invocation of MockLsifStore.GetImplementationLocations")
 			},
 		},
+		GetImportMonikersByPositionsFunc: &LsifStoreGetImportMonikersByPositionsFunc{
+			defaultHook: func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+				panic("unexpected invocation of MockLsifStore.GetImportMonikersByPositions")
+			},
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				panic("unexpected invocation of MockLsifStore.GetMonikersByPosition")
@@ -344,18 +412,33 @@ func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
 		GetBulkMonikerLocationsFunc: &LsifStoreGetBulkMonikerLocationsFunc{
 			defaultHook: i.GetBulkMonikerLocations,
 		},
+		GetBulkMonikerLocationsByMonikerFunc: &LsifStoreGetBulkMonikerLocationsByMonikerFunc{
+			defaultHook: i.GetBulkMonikerLocationsByMoniker,
+		},
+		GetBulkMonikerReferenceCountFunc: &LsifStoreGetBulkMonikerReferenceCountFunc{
+			defaultHook: i.GetBulkMonikerReferenceCount,
+		},
 		GetDefinitionLocationsFunc: &LsifStoreGetDefinitionLocationsFunc{
 			defaultHook: i.GetDefinitionLocations,
 		},
 		GetDiagnosticsFunc: &LsifStoreGetDiagnosticsFunc{
 			defaultHook: i.GetDiagnostics,
 		},
+		GetExportedMonikersFunc: &LsifStoreGetExportedMonikersFunc{
+			defaultHook: i.GetExportedMonikers,
+		},
+		GetFunctionDefinitionsFunc: &LsifStoreGetFunctionDefinitionsFunc{
+			defaultHook: i.GetFunctionDefinitions,
+		},
 		GetHoverFunc: &LsifStoreGetHoverFunc{
 			defaultHook: i.GetHover,
 		},
 		GetImplementationLocationsFunc: &LsifStoreGetImplementationLocationsFunc{
 			defaultHook: i.GetImplementationLocations,
 		},
+		GetImportMonikersByPositionsFunc: &LsifStoreGetImportMonikersByPositionsFunc{
+			defaultHook: i.GetImportMonikersByPositions,
+		},
 		GetMonikersByPositionFunc: &LsifStoreGetMonikersByPositionFunc{
 			defaultHook: i.GetMonikersByPosition,
 		},
@@ -503,6 +586,242 @@ func (c LsifStoreGetBulkMonikerLocationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LsifStoreGetBulkMonikerLocationsByMonikerFunc describes the behavior when
+// the GetBulkMonikerLocationsByMoniker method of the parent MockLsifStore
+// instance is invoked.
+type LsifStoreGetBulkMonikerLocationsByMonikerFunc struct {
+	defaultHook func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error)
+	hooks       []func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error)
+	history     []LsifStoreGetBulkMonikerLocationsByMonikerFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBulkMonikerLocationsByMoniker delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetBulkMonikerLocationsByMoniker(v0 context.Context, v1 string, v2 []int, v3 []precise.MonikerData, v4 int) ([][]shared.Location, error) {
+	r0, r1 := m.GetBulkMonikerLocationsByMonikerFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetBulkMonikerLocationsByMonikerFunc.appendCall(LsifStoreGetBulkMonikerLocationsByMonikerFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetBulkMonikerLocationsByMoniker method of the parent MockLsifStore
+// instance is invoked and the hook queue is empty.
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) SetDefaultHook(hook func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBulkMonikerLocationsByMoniker method of the parent MockLsifStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) PushHook(hook func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) SetDefaultReturn(r0 [][]shared.Location, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) PushReturn(r0 [][]shared.Location, r1 error) {
+	f.PushHook(func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) nextHook() func(context.Context, string, []int, []precise.MonikerData, int) ([][]shared.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) appendCall(r0 LsifStoreGetBulkMonikerLocationsByMonikerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LsifStoreGetBulkMonikerLocationsByMonikerFuncCall objects describing the
+// invocations of this function.
+func (f *LsifStoreGetBulkMonikerLocationsByMonikerFunc) History() []LsifStoreGetBulkMonikerLocationsByMonikerFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetBulkMonikerLocationsByMonikerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetBulkMonikerLocationsByMonikerFuncCall is an object that
+// describes an invocation of method GetBulkMonikerLocationsByMoniker on an
+// instance of MockLsifStore.
+type LsifStoreGetBulkMonikerLocationsByMonikerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []precise.MonikerData
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 [][]shared.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetBulkMonikerLocationsByMonikerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetBulkMonikerLocationsByMonikerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetBulkMonikerReferenceCountFunc describes the behavior when the
+// GetBulkMonikerReferenceCount method of the parent MockLsifStore instance
+// is invoked.
+type LsifStoreGetBulkMonikerReferenceCountFunc struct {
+	defaultHook func(context.Context, []int, []precise.MonikerData) (int, error)
+	hooks       []func(context.Context, []int, []precise.MonikerData) (int, error)
+	history     []LsifStoreGetBulkMonikerReferenceCountFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBulkMonikerReferenceCount delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetBulkMonikerReferenceCount(v0 context.Context, v1 []int, v2 []precise.MonikerData) (int, error) {
+	r0, r1 := m.GetBulkMonikerReferenceCountFunc.nextHook()(v0, v1, v2)
+	m.GetBulkMonikerReferenceCountFunc.appendCall(LsifStoreGetBulkMonikerReferenceCountFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetBulkMonikerReferenceCount method of the parent MockLsifStore instance
+// is invoked and the hook queue is empty.
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) SetDefaultHook(hook func(context.Context, []int, []precise.MonikerData) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBulkMonikerReferenceCount method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) PushHook(hook func(context.Context, []int, []precise.MonikerData) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int, []precise.MonikerData) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, []int, []precise.MonikerData) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) nextHook() func(context.Context, []int, []precise.MonikerData) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) appendCall(r0 LsifStoreGetBulkMonikerReferenceCountFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LsifStoreGetBulkMonikerReferenceCountFuncCall objects describing the
+// invocations of this function.
+func (f *LsifStoreGetBulkMonikerReferenceCountFunc) History() []LsifStoreGetBulkMonikerReferenceCountFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetBulkMonikerReferenceCountFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetBulkMonikerReferenceCountFuncCall is an object that describes
+// an invocation of method GetBulkMonikerReferenceCount on an instance of
+// MockLsifStore.
+type LsifStoreGetBulkMonikerReferenceCountFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []precise.MonikerData
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetBulkMonikerReferenceCountFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetBulkMonikerReferenceCountFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LsifStoreGetDefinitionLocationsFunc describes the behavior when the
 // GetDefinitionLocations method of the parent MockLsifStore instance is
 // invoked.
@@ -752,34 +1071,36 @@ func (c LsifStoreGetDiagnosticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// LsifStoreGetHoverFunc describes the behavior when the GetHover method of
-// the parent MockLsifStore instance is invoked.
-type LsifStoreGetHoverFunc struct {
-	defaultHook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)
-	hooks       []func(context.Context, int, string, int, int) (string, shared.Range, bool, error)
-	history     []LsifStoreGetHoverFuncCall
+// LsifStoreGetExportedMonikersFunc describes the behavior when the
+// GetExportedMonikers method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetExportedMonikersFunc struct {
+	defaultHook func(context.Context, int, string) ([]precise.QualifiedMonikerData, error)
+	hooks       []func(context.Context, int, string) ([]precise.QualifiedMonikerData, error)
+	history     []LsifStoreGetExportedMonikersFuncCall
 	mutex       sync.Mutex
 }
 
-// GetHover delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockLsifStore) GetHover(v0 context.Context, v1 int, v2 string, v3 int, v4 int) (string, shared.Range, bool, error) {
-	r0, r1, r2, r3 := m.GetHoverFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.GetHoverFunc.appendCall(LsifStoreGetHoverFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
-	return r0, r1, r2, r3
+// GetExportedMonikers delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetExportedMonikers(v0 context.Context, v1 int, v2 string) ([]precise.QualifiedMonikerData, error) {
+	r0, r1 := m.GetExportedMonikersFunc.nextHook()(v0, v1, v2)
+	m.GetExportedMonikersFunc.appendCall(LsifStoreGetExportedMonikersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetHover method of
-// the parent MockLsifStore instance is invoked and the hook queue is empty.
-func (f *LsifStoreGetHoverFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)) {
+// SetDefaultHook sets function that is called when the GetExportedMonikers
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetExportedMonikersFunc) SetDefaultHook(hook func(context.Context, int, string) ([]precise.QualifiedMonikerData, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetHover method of the parent MockLsifStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *LsifStoreGetHoverFunc) PushHook(hook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)) {
+// GetExportedMonikers method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetExportedMonikersFunc) PushHook(hook func(context.Context, int, string) ([]precise.QualifiedMonikerData, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -787,20 +1108,20 @@ func (f *LsifStoreGetHoverFunc) PushHook(hook func(context.Context, int, string,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LsifStoreGetHoverFunc) SetDefaultReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *LsifStoreGetExportedMonikersFunc) SetDefaultReturn(r0 []precise.QualifiedMonikerData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]precise.QualifiedMonikerData, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LsifStoreGetHoverFunc) PushReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
-		return r0, r1, r2, r3
+func (f *LsifStoreGetExportedMonikersFunc) PushReturn(r0 []precise.QualifiedMonikerData, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]precise.QualifiedMonikerData, error) {
+		return r0, r1
 	})
 }
 
-func (f *LsifStoreGetHoverFunc) nextHook() func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
+func (f *LsifStoreGetExportedMonikersFunc) nextHook() func(context.Context, int, string) ([]precise.QualifiedMonikerData, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -813,26 +1134,26 @@ func (f *LsifStoreGetHoverFunc) nextHook() func(context.Context, int, string, in
 	return hook
 }
 
-func (f *LsifStoreGetHoverFunc) appendCall(r0 LsifStoreGetHoverFuncCall) {
+func (f *LsifStoreGetExportedMonikersFunc) appendCall(r0 LsifStoreGetExportedMonikersFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LsifStoreGetHoverFuncCall objects
-// describing the invocations of this function.
-func (f *LsifStoreGetHoverFunc) History() []LsifStoreGetHoverFuncCall {
+// History returns a sequence of LsifStoreGetExportedMonikersFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetExportedMonikersFunc) History() []LsifStoreGetExportedMonikersFuncCall {
 	f.mutex.Lock()
-	history := make([]LsifStoreGetHoverFuncCall, len(f.history))
+	history := make([]LsifStoreGetExportedMonikersFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LsifStoreGetHoverFuncCall is an object that describes an invocation of
-// method GetHover on an instance of MockLsifStore.
-type LsifStoreGetHoverFuncCall struct {
+// LsifStoreGetExportedMonikersFuncCall is an object that describes an
+// invocation of method GetExportedMonikers on an instance of MockLsifStore.
+type LsifStoreGetExportedMonikersFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -842,10 +1163,234 @@ type LsifStoreGetHoverFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Arg3 int
-	// Arg4 is the value of the 5th argument passed to this method
+	Result0 []precise.QualifiedMonikerData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetExportedMonikersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetExportedMonikersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetFunctionDefinitionsFunc describes the behavior when the
+// GetFunctionDefinitions method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetFunctionDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string) ([]shared.FunctionDefinition, error)
+	hooks       []func(context.Context, int, string) ([]shared.FunctionDefinition, error)
+	history     []LsifStoreGetFunctionDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetFunctionDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetFunctionDefinitions(v0 context.Context, v1 int, v2 string) ([]shared.FunctionDefinition, error) {
+	r0, r1 := m.GetFunctionDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.GetFunctionDefinitionsFunc.appendCall(LsifStoreGetFunctionDefinitionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetFunctionDefinitions method of the parent MockLsifStore instance is
+// invoked and the hook queue is empty.
+func (f *LsifStoreGetFunctionDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string) ([]shared.FunctionDefinition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetFunctionDefinitions method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetFunctionDefinitionsFunc) PushHook(hook func(context.Context, int, string) ([]shared.FunctionDefinition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetFunctionDefinitionsFunc) SetDefaultReturn(r0 []shared.FunctionDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]shared.FunctionDefinition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetFunctionDefinitionsFunc) PushReturn(r0 []shared.FunctionDefinition, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]shared.FunctionDefinition, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetFunctionDefinitionsFunc) nextHook() func(context.Context, int, string) ([]shared.FunctionDefinition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetFunctionDefinitionsFunc) appendCall(r0 LsifStoreGetFunctionDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetFunctionDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetFunctionDefinitionsFunc) History() []LsifStoreGetFunctionDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetFunctionDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetFunctionDefinitionsFuncCall is an object that describes an
+// invocation of method GetFunctionDefinitions on an instance of
+// MockLsifStore.
+type LsifStoreGetFunctionDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.FunctionDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetFunctionDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetFunctionDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LsifStoreGetHoverFunc describes the behavior when the GetHover method of
+// the parent MockLsifStore instance is invoked.
+type LsifStoreGetHoverFunc struct {
+	defaultHook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)
+	hooks       []func(context.Context, int, string, int, int) (string, shared.Range, bool, error)
+	history     []LsifStoreGetHoverFuncCall
+	mutex       sync.Mutex
+}
+
+// GetHover delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLsifStore) GetHover(v0 context.Context, v1 int, v2 string, v3 int, v4 int) (string, shared.Range, bool, error) {
+	r0, r1, r2, r3 := m.GetHoverFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetHoverFunc.appendCall(LsifStoreGetHoverFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the GetHover method of
+// the parent MockLsifStore instance is invoked and the hook queue is empty.
+func (f *LsifStoreGetHoverFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetHover method of the parent MockLsifStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LsifStoreGetHoverFunc) PushHook(hook func(context.Context, int, string, int, int) (string, shared.Range, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetHoverFunc) SetDefaultReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetHoverFunc) PushReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *LsifStoreGetHoverFunc) nextHook() func(context.Context, int, string, int, int) (string, shared.Range, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetHoverFunc) appendCall(r0 LsifStoreGetHoverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetHoverFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetHoverFunc) History() []LsifStoreGetHoverFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetHoverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetHoverFuncCall is an object that describes an invocation of
+// method GetHover on an instance of MockLsifStore.
+type LsifStoreGetHoverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int
 	// Result0 is the value of the 1st result returned from this method
@@ -970,37 +1515,155 @@ type LsifStoreGetImplementationLocationsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 int
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 int
-	// Arg5 is the value of the 6th argument passed to this method
-	// invocation.
-	Arg5 int
-	// Arg6 is the value of the 7th argument passed to this method
-	// invocation.
-	Arg6 int
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetImplementationLocationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetImplementationLocationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// LsifStoreGetImportMonikersByPositionsFunc describes the behavior when the
+// GetImportMonikersByPositions method of the parent MockLsifStore instance
+// is invoked.
+type LsifStoreGetImportMonikersByPositionsFunc struct {
+	defaultHook func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error)
+	hooks       []func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error)
+	history     []LsifStoreGetImportMonikersByPositionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetImportMonikersByPositions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetImportMonikersByPositions(v0 context.Context, v1 int, v2 string, v3 []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+	r0, r1 := m.GetImportMonikersByPositionsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetImportMonikersByPositionsFunc.appendCall(LsifStoreGetImportMonikersByPositionsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetImportMonikersByPositions method of the parent MockLsifStore instance
+// is invoked and the hook queue is empty.
+func (f *LsifStoreGetImportMonikersByPositionsFunc) SetDefaultHook(hook func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetImportMonikersByPositions method of the parent MockLsifStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LsifStoreGetImportMonikersByPositionsFunc) PushHook(hook func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetImportMonikersByPositionsFunc) SetDefaultReturn(r0 [][]precise.QualifiedMonikerData, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetImportMonikersByPositionsFunc) PushReturn(r0 [][]precise.QualifiedMonikerData, r1 error) {
+	f.PushHook(func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+		return r0, r1
+	})
+}
+
+func (f *LsifStoreGetImportMonikersByPositionsFunc) nextHook() func(context.Context, int, string, []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetImportMonikersByPositionsFunc) appendCall(r0 LsifStoreGetImportMonikersByPositionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LsifStoreGetImportMonikersByPositionsFuncCall objects describing the
+// invocations of this function.
+func (f *LsifStoreGetImportMonikersByPositionsFunc) History() []LsifStoreGetImportMonikersByPositionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetImportMonikersByPositionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetImportMonikersByPositionsFuncCall is an object that describes
+// an invocation of method GetImportMonikersByPositions on an instance of
+// MockLsifStore.
+type LsifStoreGetImportMonikersByPositionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []shared.Position
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared.Location
+	Result0 [][]precise.QualifiedMonikerData
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c LsifStoreGetImplementationLocationsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+func (c LsifStoreGetImportMonikersByPositionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c LsifStoreGetImplementationLocationsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c LsifStoreGetImportMonikersByPositionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // LsifStoreGetMonikersByPositionFunc describes the behavior when the
@@ -1991,6 +2654,10 @@ func (c DBStoreRepoNamesFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/internal/codeintel/codenav) used for
 // unit testing.
 type MockGitTreeTranslator struct {
+	// ApproximateTargetCommitRangeFromSourceRangeFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// ApproximateTargetCommitRangeFromSourceRange.
+	ApproximateTargetCommitRangeFromSourceRangeFunc *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc
 	// GetTargetCommitPathFromSourcePathFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetTargetCommitPathFromSourcePath.
@@ -2003,6 +2670,10 @@ type MockGitTreeTranslator struct {
 	// function object controlling the behavior of the method
 	// GetTargetCommitRangeFromSourceRange.
 	GetTargetCommitRangeFromSourceRangeFunc *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc
+	// GetTargetCommitRangesFromSourceRangesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetTargetCommitRangesFromSourceRanges.
+	GetTargetCommitRangesFromSourceRangesFunc *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc
 }
 
 // NewMockGitTreeTranslator creates a new mock of the GitTreeTranslator
@@ -2010,6 +2681,11 @@ type MockGitTreeTranslator struct {
 // overwritten.
 func NewMockGitTreeTranslator() *MockGitTreeTranslator {
 	return &MockGitTreeTranslator{
+		ApproximateTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: func(context.Context, string, string, shared.Range, bool) (r0 string, r1 shared.Range, r2 float64, r3 bool, r4 error) {
+				return
+			},
+		},
 		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
 			defaultHook: func(context.Context, string, string, bool) (r0 string, r1 bool, r2 error) {
 				return
@@ -2025,6 +2701,11 @@ func NewMockGitTreeTranslator() *MockGitTreeTranslator {
 				return
 			},
 		},
+		GetTargetCommitRangesFromSourceRangesFunc: &GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc{
+			defaultHook: func(context.Context, string, string, []shared.Range, bool) (r0 string, r1 []shared.Range, r2 []bool, r3 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -2033,6 +2714,11 @@ func NewMockGitTreeTranslator() *MockGitTreeTranslator {
 // overwritten.
 func NewStrictMockGitTreeTranslator() *MockGitTreeTranslator {
 	return &MockGitTreeTranslator{
+		ApproximateTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error) {
+				panic("unexpected invocation of MockGitTreeTranslator.ApproximateTargetCommitRangeFromSourceRange")
+			},
+		},
 		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
 			defaultHook: func(context.Context, string, string, bool) (string, bool, error) {
 				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitPathFromSourcePath")
@@ -2048,6 +2734,11 @@ func NewStrictMockGitTreeTranslator() *MockGitTreeTranslator {
 				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitRangeFromSourceRange")
 			},
 		},
+		GetTargetCommitRangesFromSourceRangesFunc: &GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc{
+			defaultHook: func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error) {
+				panic("unexpected invocation of MockGitTreeTranslator.GetTargetCommitRangesFromSourceRanges")
+			},
+		},
 	}
 }
 
@@ -2056,6 +2747,9 @@ func NewStrictMockGitTreeTranslator() *MockGitTreeTranslator {
 // implementation, unless overwritten.
 func NewMockGitTreeTranslatorFrom(i GitTreeTranslator) *MockGitTreeTranslator {
 	return &MockGitTreeTranslator{
+		ApproximateTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc{
+			defaultHook: i.ApproximateTargetCommitRangeFromSourceRange,
+		},
 		GetTargetCommitPathFromSourcePathFunc: &GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc{
 			defaultHook: i.GetTargetCommitPathFromSourcePath,
 		},
@@ -2065,7 +2759,143 @@ func NewMockGitTreeTranslatorFrom(i GitTreeTranslator) *MockGitTreeTranslator {
 		GetTargetCommitRangeFromSourceRangeFunc: &GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc{
 			defaultHook: i.GetTargetCommitRangeFromSourceRange,
 		},
+		GetTargetCommitRangesFromSourceRangesFunc: &GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc{
+			defaultHook: i.GetTargetCommitRangesFromSourceRanges,
+		},
+	}
+}
+
+// GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc
+// describes the behavior when the
+// ApproximateTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance is invoked.
+type GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc struct {
+	defaultHook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error)
+	hooks       []func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error)
+	history     []GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall
+	mutex       sync.Mutex
+}
+
+// ApproximateTargetCommitRangeFromSourceRange delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockGitTreeTranslator) ApproximateTargetCommitRangeFromSourceRange(v0 context.Context, v1 string, v2 string, v3 shared.Range, v4 bool) (string, shared.Range, float64, bool, error) {
+	r0, r1, r2, r3, r4 := m.ApproximateTargetCommitRangeFromSourceRangeFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.ApproximateTargetCommitRangeFromSourceRangeFunc.appendCall(GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3, r4})
+	return r0, r1, r2, r3, r4
+}
+
+// SetDefaultHook sets function that is called when the
+// ApproximateTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance is invoked and the hook queue is empty.
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) SetDefaultHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ApproximateTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) PushHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) SetDefaultReturn(r0 string, r1 shared.Range, r2 float64, r3 bool, r4 error) {
+	f.SetDefaultHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error) {
+		return r0, r1, r2, r3, r4
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) PushReturn(r0 string, r1 shared.Range, r2 float64, r3 bool, r4 error) {
+	f.PushHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error) {
+		return r0, r1, r2, r3, r4
+	})
+}
+
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) nextHook() func(context.Context, string, string, shared.Range, bool) (string, shared.Range, float64, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) appendCall(r0 GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall
+// objects describing the invocations of this function.
+func (f *GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFunc) History() []GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall is
+// an object that describes an invocation of method
+// ApproximateTargetCommitRangeFromSourceRange on an instance of
+// MockGitTreeTranslator.
+type GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 shared.Range
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 shared.Range
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 float64
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 bool
+	// Result4 is the value of the 5th result returned from this method
+	// invocation.
+	Result4 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitTreeTranslatorApproximateTargetCommitRangeFromSourceRangeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3, c.Result4}
 }
 
 // GitTreeTranslatorGetTargetCommitPathFromSourcePathFunc describes the
@@ -2272,11 +3102,137 @@ func (f *GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFunc) History
 	return history
 }
 
-// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall is an
-// object that describes an invocation of method
-// GetTargetCommitPositionFromSourcePosition on an instance of
+// GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall is an
+// object that describes an invocation of method
+// GetTargetCommitPositionFromSourcePosition on an instance of
+// MockGitTreeTranslator.
+type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.Position
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 shared.Position
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc describes the
+// behavior when the GetTargetCommitRangeFromSourceRange method of the
+// parent MockGitTreeTranslator instance is invoked.
+type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc struct {
+	defaultHook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)
+	hooks       []func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)
+	history     []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall
+	mutex       sync.Mutex
+}
+
+// GetTargetCommitRangeFromSourceRange delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockGitTreeTranslator) GetTargetCommitRangeFromSourceRange(v0 context.Context, v1 string, v2 string, v3 shared.Range, v4 bool) (string, shared.Range, bool, error) {
+	r0, r1, r2, r3 := m.GetTargetCommitRangeFromSourceRangeFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetTargetCommitRangeFromSourceRangeFunc.appendCall(GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// GetTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance is invoked and the hook queue is empty.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetTargetCommitRangeFromSourceRange method of the parent
+// MockGitTreeTranslator instance invokes the hook at the front of the queue
+// and discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
+	f.PushHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall objects
+// describing the invocations of this function.
+func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) History() []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall is an object
+// that describes an invocation of method
+// GetTargetCommitRangeFromSourceRange on an instance of
 // MockGitTreeTranslator.
-type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
+type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2285,16 +3241,19 @@ type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 shared.Position
+	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 bool
+	Arg3 shared.Range
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 shared.Position
+	Result1 shared.Range
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
 	Result2 bool
@@ -2305,48 +3264,48 @@ type GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitPositionFromSourcePositionFuncCall) Results() []interface{} {
+func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc describes the
-// behavior when the GetTargetCommitRangeFromSourceRange method of the
+// GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc describes the
+// behavior when the GetTargetCommitRangesFromSourceRanges method of the
 // parent MockGitTreeTranslator instance is invoked.
-type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc struct {
-	defaultHook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)
-	hooks       []func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)
-	history     []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall
+type GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc struct {
+	defaultHook func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error)
+	hooks       []func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error)
+	history     []GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetTargetCommitRangeFromSourceRange delegates to the next hook function
+// GetTargetCommitRangesFromSourceRanges delegates to the next hook function
 // in the queue and stores the parameter and result values of this
 // invocation.
-func (m *MockGitTreeTranslator) GetTargetCommitRangeFromSourceRange(v0 context.Context, v1 string, v2 string, v3 shared.Range, v4 bool) (string, shared.Range, bool, error) {
-	r0, r1, r2, r3 := m.GetTargetCommitRangeFromSourceRangeFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.GetTargetCommitRangeFromSourceRangeFunc.appendCall(GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
+func (m *MockGitTreeTranslator) GetTargetCommitRangesFromSourceRanges(v0 context.Context, v1 string, v2 string, v3 []shared.Range, v4 bool) (string, []shared.Range, []bool, error) {
+	r0, r1, r2, r3 := m.GetTargetCommitRangesFromSourceRangesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.GetTargetCommitRangesFromSourceRangesFunc.appendCall(GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall{v0, v1, v2, v3, v4, r0, r1, r2, r3})
 	return r0, r1, r2, r3
 }
 
 // SetDefaultHook sets function that is called when the
-// GetTargetCommitRangeFromSourceRange method of the parent
+// GetTargetCommitRangesFromSourceRanges method of the parent
 // MockGitTreeTranslator instance is invoked and the hook queue is empty.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) SetDefaultHook(hook func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetTargetCommitRangeFromSourceRange method of the parent
+// GetTargetCommitRangesFromSourceRanges method of the parent
 // MockGitTreeTranslator instance invokes the hook at the front of the queue
 // and discards it. After the queue is empty, the default hook function is
 // invoked for any future action.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error)) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) PushHook(hook func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2354,20 +3313,20 @@ func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushHook(hook
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) SetDefaultReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
-	f.SetDefaultHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) SetDefaultReturn(r0 string, r1 []shared.Range, r2 []bool, r3 error) {
+	f.SetDefaultHook(func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error) {
 		return r0, r1, r2, r3
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) PushReturn(r0 string, r1 shared.Range, r2 bool, r3 error) {
-	f.PushHook(func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) PushReturn(r0 string, r1 []shared.Range, r2 []bool, r3 error) {
+	f.PushHook(func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error) {
 		return r0, r1, r2, r3
 	})
 }
 
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() func(context.Context, string, string, shared.Range, bool) (string, shared.Range, bool, error) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) nextHook() func(context.Context, string, string, []shared.Range, bool) (string, []shared.Range, []bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2380,29 +3339,29 @@ func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) nextHook() fu
 	return hook
 }
 
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) appendCall(r0 GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall objects
+// GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall objects
 // describing the invocations of this function.
-func (f *GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFunc) History() []GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall {
+func (f *GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFunc) History() []GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall {
 	f.mutex.Lock()
-	history := make([]GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall, len(f.history))
+	history := make([]GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall is an object
-// that describes an invocation of method
-// GetTargetCommitRangeFromSourceRange on an instance of
+// GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall is an
+// object that describes an invocation of method
+// GetTargetCommitRangesFromSourceRanges on an instance of
 // MockGitTreeTranslator.
-type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
+type GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2414,7 +3373,7 @@ type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 shared.Range
+	Arg3 []shared.Range
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 bool
@@ -2423,10 +3382,10 @@ type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
 	Result0 string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 shared.Range
+	Result1 []shared.Range
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
-	Result2 bool
+	Result2 []bool
 	// Result3 is the value of the 4th result returned from this method
 	// invocation.
 	Result3 error
@@ -2434,13 +3393,13 @@ type GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Args() []interface{} {
+func (c GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c GitTreeTranslatorGetTargetCommitRangeFromSourceRangeFuncCall) Results() []interface{} {
+func (c GitTreeTranslatorGetTargetCommitRangesFromSourceRangesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
@@ -2452,9 +3411,15 @@ type MockGitserverClient struct {
 	// CommitsExistFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitsExist.
 	CommitsExistFunc *GitserverClientCommitsExistFunc
+	// DiffFileRevisionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiffFileRevisions.
+	DiffFileRevisionsFunc *GitserverClientDiffFileRevisionsFunc
 	// DiffPathFunc is an instance of a mock function object controlling the
 	// behavior of the method DiffPath.
 	DiffPathFunc *GitserverClientDiffPathFunc
+	// RenamedFilesFunc is an instance of a mock function object controlling
+	// the behavior of the method RenamedFiles.
+	RenamedFilesFunc *GitserverClientRenamedFilesFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -2467,11 +3432,21 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return
 			},
 		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) (r0 []*diff.Hunk, r1 error) {
+				return
+			},
+		},
 		DiffPathFunc: &GitserverClientDiffPathFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []*diff.Hunk, r1 error) {
 				return
 			},
 		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []gitserver.RenamedFile, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -2484,11 +3459,21 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.CommitsExist")
 			},
 		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+				panic("unexpected invocation of MockGitserverClient.DiffFileRevisions")
+			},
+		},
 		DiffPathFunc: &GitserverClientDiffPathFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
 				panic("unexpected invocation of MockGitserverClient.DiffPath")
 			},
 		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+				panic("unexpected invocation of MockGitserverClient.RenamedFiles")
+			},
+		},
 	}
 }
 
@@ -2500,9 +3485,15 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 		CommitsExistFunc: &GitserverClientCommitsExistFunc{
 			defaultHook: i.CommitsExist,
 		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: i.DiffFileRevisions,
+		},
 		DiffPathFunc: &GitserverClientDiffPathFunc{
 			defaultHook: i.DiffPath,
 		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: i.RenamedFiles,
+		},
 	}
 }
 
@@ -2615,6 +3606,132 @@ func (c GitserverClientCommitsExistFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientDiffFileRevisionsFunc describes the behavior when the
+// DiffFileRevisions method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientDiffFileRevisionsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	history     []GitserverClientDiffFileRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffFileRevisions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) DiffFileRevisions(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string, v6 string) ([]*diff.Hunk, error) {
+	r0, r1 := m.DiffFileRevisionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.DiffFileRevisionsFunc.appendCall(GitserverClientDiffFileRevisionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffFileRevisions
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientDiffFileRevisionsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffFileRevisions method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientDiffFileRevisionsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientDiffFileRevisionsFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientDiffFileRevisionsFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientDiffFileRevisionsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientDiffFileRevisionsFunc) appendCall(r0 GitserverClientDiffFileRevisionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientDiffFileRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientDiffFileRevisionsFunc) History() []GitserverClientDiffFileRevisionsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientDiffFileRevisionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientDiffFileRevisionsFuncCall is an object that describes an
+// invocation of method DiffFileRevisions on an instance of
+// MockGitserverClient.
+type GitserverClientDiffFileRevisionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*diff.Hunk
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientDiffFileRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientDiffFileRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverClientDiffPathFunc describes the behavior when the DiffPath
 // method of the parent MockGitserverClient instance is invoked.
 type GitserverClientDiffPathFunc struct {
@@ -2735,6 +3852,127 @@ func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientRenamedFilesFunc describes the behavior when the
+// RenamedFiles method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientRenamedFilesFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)
+	history     []GitserverClientRenamedFilesFuncCall
+	mutex       sync.Mutex
+}
+
+// RenamedFiles delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) RenamedFiles(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]gitserver.RenamedFile, error) {
+	r0, r1 := m.RenamedFilesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.RenamedFilesFunc.appendCall(GitserverClientRenamedFilesFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RenamedFiles method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientRenamedFilesFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RenamedFiles method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientRenamedFilesFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRenamedFilesFunc) SetDefaultReturn(r0 []gitserver.RenamedFile, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRenamedFilesFunc) PushReturn(r0 []gitserver.RenamedFile, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRenamedFilesFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRenamedFilesFunc) appendCall(r0 GitserverClientRenamedFilesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRenamedFilesFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientRenamedFilesFunc) History() []GitserverClientRenamedFilesFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRenamedFilesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRenamedFilesFuncCall is an object that describes an
+// invocation of method RenamedFiles on an instance of MockGitserverClient.
+type GitserverClientRenamedFilesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gitserver.RenamedFile
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRenamedFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRenamedFilesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockUploadService is a mock implementation of the UploadService interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/internal/codeintel/codenav) used for
@@ -22,6 +22,8 @@ type operations struct {
 	getDefinitions                       *observation.Operation
 	getRanges                            *observation.Operation
 	getStencil                           *observation.Operation
+	getIncomingCalls                     *observation.Operation
+	getOutgoingCalls                     *observation.Operation
 	getMonikersByPosition                *observation.Operation
 	getBulkMonikerLocations              *observation.Operation
 	getPackageInformation                *observation.Operation
@@ -29,6 +31,7 @@ type operations struct {
 	getUploadIDsWithReferences           *observation.Operation
 	getDumpsByIDs                        *observation.Operation
 	getClosestDumpsForBlob               *observation.Operation
+	getInboundReferenceCounts            *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -58,6 +61,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		getDefinitions:                       op("getDefinitions"),
 		getRanges:                            op("getRanges"),
 		getStencil:                           op("getStencil"),
+		getIncomingCalls:                     op("getIncomingCalls"),
+		getOutgoingCalls:                     op("getOutgoingCalls"),
 		getMonikersByPosition:                op("GetMonikersByPosition"),
 		getBulkMonikerLocations:              op("GetBulkMonikerLocations"),
 		getPackageInformation:                op("GetPackageInformation"),
@@ -65,6 +70,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		getUploadIDsWithReferences:           op("GetUploadIDsWithReferences"),
 		getDumpsByIDs:                        op("GetDumpsByIDs"),
 		getClosestDumpsForBlob:               op("GetClosestDumpsForBlob"),
+		getInboundReferenceCounts:            op("GetInboundReferenceCounts"),
 	}
 }
 
@@ -29,11 +29,14 @@ type service interface {
 	GetRanges(ctx context.Context, args shared.RequestArgs, requestState RequestState, startLine, endLine int) (adjustedRanges []shared.AdjustedCodeIntelligenceRange, err error)
 	GetReferences(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.ReferencesCursor) (_ []shared.UploadLocation, nextCursor shared.ReferencesCursor, err error)
 	GetStencil(ctx context.Context, args shared.RequestArgs, requestState RequestState) (adjustedRanges []shared.Range, err error)
+	GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.ReferencesCursor) (_ []shared.CallHierarchyCall, nextCursor shared.ReferencesCursor, err error)
+	GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.OutgoingCallsCursor) (_ []shared.CallHierarchyCall, nextCursor shared.OutgoingCallsCursor, err error)
 
 	GetMonikersByPosition(ctx context.Context, bundleID int, path string, line, character int) (_ [][]precise.MonikerData, err error)
 	GetBulkMonikerLocations(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit, offset int) (_ []shared.Location, _ int, err error)
 	GetPackageInformation(ctx context.Context, bundleID int, path, packageInformationID string) (_ precise.PackageInformationData, _ bool, err error)
 	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []shared.Dump, err error)
+	GetInboundReferenceCounts(ctx context.Context, repositoryID int, commit string, paths []string) (_ []int, err error)
 
 	// Uploads Service
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error)
@@ -87,7 +90,6 @@ func (s *Service) GetHover(ctx context.Context, args shared.RequestArgs, request
 	// as a hint to highlight a range in the current document.
 	adjustedRanges := make([]shared.Range, 0, len(adjustedUploads))
 
-	cachedUploads := requestState.GetCacheUploads()
 	for i := range adjustedUploads {
 		adjustedUpload := adjustedUploads[i]
 		trace.Log(traceLog.Int("uploadID", adjustedUpload.Upload.ID))
@@ -108,7 +110,7 @@ func (s *Service) GetHover(ctx context.Context, args shared.RequestArgs, request
 		}
 
 		// Adjust the highlighted range back to the appropriate range in the target commit
-		_, adjustedRange, _, err := s.getSourceRange(ctx, args, requestState, cachedUploads[i].RepositoryID, cachedUploads[i].Commit, args.Path, rn)
+		_, adjustedRange, _, err := s.getSourceRange(ctx, args, requestState, adjustedUpload.Upload.RepositoryID, adjustedUpload.Upload.Commit, adjustedUpload.TargetPath, rn)
 		if err != nil {
 			return "", shared.Range{}, false, err
 		}
@@ -202,6 +204,26 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	})
 	defer endObservation()
 
+	locations, cursor, err := s.getReferenceLocations(ctx, args, requestState, cursor, trace)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all references
+	// are occurring at the same commit they are looking at.
+	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.Log(traceLog.Int("numReferenceLocations", len(referenceLocations)))
+
+	return referenceLocations, cursor, nil
+}
+
+// getReferenceLocations returns a page of the locations (relative to their indexed commit) that
+// reference the symbol at the given position, and the cursor to use for the next page.
+func (s *Service) getReferenceLocations(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.ReferencesCursor, trace observation.TraceLogger) ([]shared.Location, shared.ReferencesCursor, error) {
 	// Adjust the path and position for each visible upload based on its git difference to
 	// the target commit. This data may already be stashed in the cursor decoded above, in
 	// which case we don't need to hit the database.
@@ -258,23 +280,9 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	// more local results remaining, just as we did above.
 	if cursor.Phase == "remote" {
 		if cursor.RemoteCursor.UploadBatchIDs == nil {
-			cursor.RemoteCursor.UploadBatchIDs = []int{}
-			definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, cursor.OrderedMonikers, requestState)
-			if err != nil {
+			if cursor.RemoteCursor.UploadBatchIDs, err = s.getRemoteDefinitionUploadIDs(ctx, adjustedUploads, cursor.OrderedMonikers, requestState); err != nil {
 				return nil, cursor, err
 			}
-			for i := range definitionUploads {
-				found := false
-				for j := range adjustedUploads {
-					if definitionUploads[i].ID == adjustedUploads[j].Upload.ID {
-						found = true
-						break
-					}
-				}
-				if !found {
-					cursor.RemoteCursor.UploadBatchIDs = append(cursor.RemoteCursor.UploadBatchIDs, definitionUploads[i].ID)
-				}
-			}
 		}
 
 		for len(locations) < args.Limit {
@@ -293,16 +301,7 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 
 	trace.Log(traceLog.Int("numLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all references
-	// are occurring at the same commit they are looking at.
-	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations)
-	if err != nil {
-		return nil, cursor, err
-	}
-	trace.Log(traceLog.Int("numReferenceLocations", len(referenceLocations)))
-
-	return referenceLocations, cursor, nil
+	return locations, cursor, nil
 }
 
 // getUploadsWithDefinitionsForMonikers returns the set of uploads that provide any of the given monikers.
@@ -323,6 +322,32 @@ func (s *Service) getUploadsWithDefinitionsForMonikers(ctx context.Context, orde
 	return uploadsWithResolvableCommits, nil
 }
 
+// getRemoteDefinitionUploadIDs returns the identifiers of the uploads that provide any of the given monikers
+// and are not one of the given visible uploads. These uploads are searched first in the remote phase, as
+// the uploads with references to the monikers do not include the uploads defining them.
+func (s *Service) getRemoteDefinitionUploadIDs(ctx context.Context, visibleUploads []visibleUpload, orderedMonikers []precise.QualifiedMonikerData, requestState RequestState) ([]int, error) {
+	definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, orderedMonikers, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for i := range definitionUploads {
+		found := false
+		for j := range visibleUploads {
+			if definitionUploads[i].ID == visibleUploads[j].Upload.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, definitionUploads[i].ID)
+		}
+	}
+
+	return ids, nil
+}
+
 // monikerLimit is the maximum number of monikers that can be returned from orderedMonikers.
 const monikerLimit = 10
 
@@ -546,20 +571,46 @@ func (s *Service) getUploadLocations(ctx context.Context, args shared.RequestArg
 }
 
 // getUploadLocation translates a location (relative to the indexed commit) into an equivalent location in
-// the requested commit. If the translation fails, then the original commit and range are used as the
-// commit and range of the adjusted location.
+// the requested commit. Ranges on lines edited since the indexed commit are approximated, which lowers the
+// confidence of the adjusted location. If the translation fails, then the original commit, path, and range
+// are used as the commit, path, and range of the adjusted location.
 func (s *Service) getUploadLocation(ctx context.Context, args shared.RequestArgs, requestState RequestState, dump shared.Dump, location shared.Location) (shared.UploadLocation, error) {
-	adjustedCommit, adjustedRange, _, err := s.getSourceRange(ctx, args, requestState, dump.RepositoryID, dump.Commit, dump.Root+location.Path, location.Range)
+	adjustedCommit, adjustedPath, adjustedRange, confidence, err := s.getApproximateSourceRange(ctx, args, requestState, dump.RepositoryID, dump.Commit, dump.Root+location.Path, location.Range)
 	if err != nil {
 		return shared.UploadLocation{}, err
 	}
 
-	return shared.UploadLocation{
+	uploadLocation := shared.UploadLocation{
 		Dump:         dump,
-		Path:         dump.Root + location.Path,
+		Path:         adjustedPath,
 		TargetCommit: adjustedCommit,
 		TargetRange:  adjustedRange,
-	}, nil
+	}
+	if confidence < 1 {
+		uploadLocation.Confidence = &confidence
+	}
+
+	return uploadLocation, nil
+}
+
+// getApproximateSourceRange translates a range (relative to the indexed commit) into an equivalent range
+// in the requested commit like getSourceRange, following renames of the file and approximating ranges on
+// lines edited since the indexed commit. The adjusted path and the confidence of the translation are
+// returned along with the adjusted commit and range. If the translation fails, then the original commit,
+// path, and range are returned.
+func (s *Service) getApproximateSourceRange(ctx context.Context, args shared.RequestArgs, requestState RequestState, repositoryID int, commit, path string, rng shared.Range) (string, string, shared.Range, float64, error) {
+	if repositoryID != args.RepositoryID {
+		// No diffs between distinct repositories
+		return commit, path, rng, 1, nil
+	}
+
+	if sourcePath, sourceRange, confidence, ok, err := requestState.GitTreeTranslator.ApproximateTargetCommitRangeFromSourceRange(ctx, commit, path, rng, true); err != nil {
+		return "", "", shared.Range{}, 0, errors.Wrap(err, "gitTreeTranslator.ApproximateTargetCommitRangeFromSourceRange")
+	} else if ok {
+		return args.Commit, sourcePath, sourceRange, confidence, nil
+	}
+
+	return commit, path, rng, 1, nil
 }
 
 // getSourceRange translates a range (relative to the indexed commit) into an equivalent range in the requested
@@ -698,14 +749,17 @@ func (s *Service) GetImplementations(ctx context.Context, args shared.RequestArg
 		traceLog.String("implementationMonikers", monikersToString(cursor.OrderedImplementationMonikers)),
 	)
 
-	if cursor.OrderedExportMonikers == nil {
-		if cursor.OrderedExportMonikers, err = s.getOrderedMonikers(ctx, visibleUploads, "export"); err != nil {
+	// Implementations in other repositories are found through the package monikers of the symbol. The
+	// symbol may be exported from one of the visible uploads, or it may be imported from a dependency,
+	// in which case we also want the implementations in the dependency and its other dependents.
+	if cursor.OrderedMonikers == nil {
+		if cursor.OrderedMonikers, err = s.getOrderedMonikers(ctx, visibleUploads, "import", "export"); err != nil {
 			return nil, cursor, err
 		}
 	}
 	trace.Log(
-		traceLog.Int("numExportMonikers", len(cursor.OrderedExportMonikers)),
-		traceLog.String("exportMonikers", monikersToString(cursor.OrderedExportMonikers)),
+		traceLog.Int("numMonikers", len(cursor.OrderedMonikers)),
+		traceLog.String("monikers", monikersToString(cursor.OrderedMonikers)),
 	)
 
 	// Phase 1: Gather all "local" locations via LSIF graph traversal. We'll continue to request additional
@@ -749,10 +803,17 @@ func (s *Service) GetImplementations(ctx context.Context, args shared.RequestArg
 		cursor.Phase = "dependents"
 	}
 
-	// Phase 3: Gather all "remote" locations in dependents via moniker search.
+	// Phase 3: Gather all "remote" locations in dependents via moniker search. The uploads defining the
+	// monikers are searched before the uploads referencing them, just as for references.
 	if cursor.Phase == "dependents" {
+		if cursor.RemoteCursor.UploadBatchIDs == nil {
+			if cursor.RemoteCursor.UploadBatchIDs, err = s.getRemoteDefinitionUploadIDs(ctx, visibleUploads, cursor.OrderedMonikers, requestState); err != nil {
+				return nil, cursor, err
+			}
+		}
+
 		for len(locations) < args.Limit {
-			remoteLocations, hasMore, err := s.getPageRemoteLocations(ctx, "implementations", visibleUploads, cursor.OrderedExportMonikers, &cursor.RemoteCursor, args.Limit-len(locations), trace, args, requestState)
+			remoteLocations, hasMore, err := s.getPageRemoteLocations(ctx, "implementations", visibleUploads, cursor.OrderedMonikers, &cursor.RemoteCursor, args.Limit-len(locations), trace, args, requestState)
 			if err != nil {
 				return nil, cursor, err
 			}
@@ -780,6 +841,393 @@ func (s *Service) GetImplementations(ctx context.Context, args shared.RequestArg
 	return implementationLocations, cursor, nil
 }
 
+// GetIncomingCalls returns the calls to the symbol at the given position, grouped by the calling
+// function. A call is a reference to the symbol within the extent of a function, method, or
+// constructor definition. References outside of any function, such as those in functions indexed
+// without the extent of their definition, are not part of the call hierarchy. Incoming calls are
+// paginated like references, so a page has up to the given limit of call sites and calls from the
+// same function may be split over several pages.
+func (s *Service) GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.ReferencesCursor) (_ []shared.CallHierarchyCall, _ shared.ReferencesCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	locations, cursor, err := s.getReferenceLocations(ctx, args, requestState, cursor, trace)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Group each reference under the innermost function enclosing it. The definition of the
+	// symbol itself is one of its references, but it is not a call.
+	functions := functionDefinitionsCache{}
+	edges := newCallHierarchyEdges()
+	for _, location := range locations {
+		definitions, err := s.getFunctionDefinitions(ctx, functions, location.DumpID, location.Path)
+		if err != nil {
+			return nil, cursor, err
+		}
+
+		if caller, ok := enclosingFunction(definitions, location.Range); ok {
+			edges.add(caller, location)
+		}
+	}
+	trace.Log(traceLog.Int("numCallers", len(edges.functions)))
+
+	calls, err := s.getCallHierarchyCalls(ctx, args, requestState, edges)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.Log(traceLog.Int("numCalls", len(calls)))
+
+	return calls, cursor, nil
+}
+
+// GetOutgoingCalls returns the calls made by the function, method, or constructor at the given position,
+// grouped by the called function. A call is a range within the extent of the function that is defined
+// by another function, either in the same index or, through its import monikers, in a dependency. The
+// function itself must be defined in one of the visible uploads. A page has up to the given limit of
+// call sites.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, cursor shared.OutgoingCallsCursor) (_ []shared.CallHierarchyCall, _ shared.OutgoingCallsCursor, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit. This data may already be stashed in the cursor decoded above, in
+	// which case we don't need to hit the database.
+	visibleUploads, cursorsToVisibleUploads, err := s.getVisibleUploadsFromCursor(ctx, args.Line, args.Character, &cursor.CursorsToVisibleUploads, requestState)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Update the cursors with the updated visible uploads.
+	cursor.CursorsToVisibleUploads = cursorsToVisibleUploads
+
+	// Walk the call sites of the function in each visible upload in order. The cursor stores the
+	// upload and the call site at which the next page starts.
+	functions := functionDefinitionsCache{}
+	edges := newCallHierarchyEdges()
+	numCallSites := 0
+	for cursor.Phase == "local" && numCallSites < args.Limit {
+		if cursor.UploadOffset >= len(visibleUploads) {
+			cursor.Phase = "done"
+			break
+		}
+		visibleUpload := visibleUploads[cursor.UploadOffset]
+
+		caller, ok, err := s.getFunctionAtPosition(ctx, functions, visibleUpload)
+		if err != nil {
+			return nil, cursor, err
+		}
+		if ok {
+			callSites, err := s.getCallSites(ctx, caller)
+			if err != nil {
+				return nil, cursor, err
+			}
+
+			// Resolve the callees of a batch of call sites at a time, and stop at the call site
+			// which fills the page. Call sites without callees do not count towards the limit.
+			for cursor.CallSiteOffset < len(callSites) && numCallSites < args.Limit {
+				batchSize := args.Limit - numCallSites
+				if batchSize < callSiteBatchSize {
+					batchSize = callSiteBatchSize
+				}
+				batch := callSites[cursor.CallSiteOffset:]
+				if len(batch) > batchSize {
+					batch = batch[:batchSize]
+				}
+
+				callees, err := s.getCallees(ctx, functions, requestState, visibleUpload.Upload, caller.Path, batch)
+				if err != nil {
+					return nil, cursor, err
+				}
+				for i, callSite := range batch {
+					if numCallSites >= args.Limit {
+						break
+					}
+					cursor.CallSiteOffset++
+
+					for _, callee := range callees[i] {
+						edges.add(callee, shared.Location{DumpID: caller.DumpID, Path: caller.Path, Range: callSite.Range})
+					}
+					if len(callees[i]) > 0 {
+						numCallSites++
+					}
+				}
+			}
+
+			if cursor.CallSiteOffset < len(callSites) {
+				// The page is full
+				break
+			}
+		}
+
+		cursor.UploadOffset++
+		cursor.CallSiteOffset = 0
+	}
+	trace.Log(
+		traceLog.Int("numCallSites", numCallSites),
+		traceLog.Int("numCallees", len(edges.functions)),
+	)
+
+	calls, err := s.getCallHierarchyCalls(ctx, args, requestState, edges)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.Log(traceLog.Int("numCalls", len(calls)))
+
+	return calls, cursor, nil
+}
+
+// getFunctionDefinitions returns the functions defined in the given document. The functions of each
+// document are read once per request.
+func (s *Service) getFunctionDefinitions(ctx context.Context, cache functionDefinitionsCache, dumpID int, path string) ([]shared.FunctionDefinition, error) {
+	key := documentKey{dumpID: dumpID, path: path}
+	if functions, ok := cache[key]; ok {
+		return functions, nil
+	}
+
+	functions, err := s.lsifstore.GetFunctionDefinitions(ctx, dumpID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetFunctionDefinitions")
+	}
+	cache[key] = functions
+
+	return functions, nil
+}
+
+// getFunctionAtPosition returns the function defined by the symbol at the position in the given visible
+// upload. If the symbol is not a function defined within the upload, a false-valued flag is returned.
+func (s *Service) getFunctionAtPosition(ctx context.Context, cache functionDefinitionsCache, visibleUpload visibleUpload) (shared.FunctionDefinition, bool, error) {
+	locations, _, err := s.lsifstore.GetDefinitionLocations(
+		ctx,
+		visibleUpload.Upload.ID,
+		visibleUpload.TargetPathWithoutRoot,
+		visibleUpload.TargetPosition.Line,
+		visibleUpload.TargetPosition.Character,
+		DefinitionsLimit,
+		0,
+	)
+	if err != nil {
+		return shared.FunctionDefinition{}, false, errors.Wrap(err, "lsifStore.Definitions")
+	}
+
+	for _, location := range locations {
+		if function, ok, err := s.getFunctionDefinedAt(ctx, cache, location); err != nil || ok {
+			return function, ok, err
+		}
+	}
+
+	return shared.FunctionDefinition{}, false, nil
+}
+
+// getFunctionDefinedAt returns the function whose name is at the given definition location. If the
+// location does not define a function, a false-valued flag is returned.
+func (s *Service) getFunctionDefinedAt(ctx context.Context, cache functionDefinitionsCache, location shared.Location) (shared.FunctionDefinition, bool, error) {
+	functions, err := s.getFunctionDefinitions(ctx, cache, location.DumpID, location.Path)
+	if err != nil {
+		return shared.FunctionDefinition{}, false, err
+	}
+
+	for _, function := range functions {
+		if function.Range == location.Range {
+			return function, true, nil
+		}
+	}
+
+	return shared.FunctionDefinition{}, false, nil
+}
+
+// getCallSites returns the ranges within the extent of the given function, excluding its name, ordered
+// by their position. Each of these ranges may be a call.
+func (s *Service) getCallSites(ctx context.Context, function shared.FunctionDefinition) ([]shared.CodeIntelligenceRange, error) {
+	ranges, err := s.lsifstore.GetRanges(ctx, function.DumpID, function.Path, function.Extent.Start.Line, function.Extent.End.Line)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.Ranges")
+	}
+
+	callSites := make([]shared.CodeIntelligenceRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Range != function.Range && rangeContainsRange(function.Extent, r.Range) {
+			callSites = append(callSites, r)
+		}
+	}
+
+	return callSites, nil
+}
+
+// callSiteBatchSize is the minimum number of call sites whose callees are resolved at once by
+// GetOutgoingCalls.
+const callSiteBatchSize = 50
+
+// getCallees returns the functions called at each of the given call sites of a function in the given
+// upload. The call sites without a definition within the upload are resolved together with a moniker
+// search over the uploads defining the import monikers of any of them.
+func (s *Service) getCallees(ctx context.Context, cache functionDefinitionsCache, requestState RequestState, upload shared.Dump, path string, callSites []shared.CodeIntelligenceRange) ([][]shared.FunctionDefinition, error) {
+	definitions := make([][]shared.Location, len(callSites))
+	var remoteIndexes []int
+	var remotePositions []shared.Position
+	for i, callSite := range callSites {
+		if len(callSite.Definitions) > 0 {
+			definitions[i] = callSite.Definitions
+		} else {
+			remoteIndexes = append(remoteIndexes, i)
+			remotePositions = append(remotePositions, callSite.Range.Start)
+		}
+	}
+
+	if len(remoteIndexes) > 0 {
+		remoteDefinitions, err := s.getRemoteDefinitionsByPositions(ctx, requestState, upload, path, remotePositions)
+		if err != nil {
+			return nil, err
+		}
+		for i, index := range remoteIndexes {
+			definitions[index] = remoteDefinitions[i]
+		}
+	}
+
+	callees := make([][]shared.FunctionDefinition, len(callSites))
+	for i := range callSites {
+		for _, definition := range definitions[i] {
+			callee, ok, err := s.getFunctionDefinedAt(ctx, cache, definition)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				callees[i] = append(callees[i], callee)
+			}
+		}
+	}
+
+	return callees, nil
+}
+
+// getRemoteDefinitionsByPositions returns the definitions of the import monikers at each of the given
+// positions within a document of the given upload. The monikers of all positions are read, and their
+// definitions are searched, with a single query each.
+func (s *Service) getRemoteDefinitionsByPositions(ctx context.Context, requestState RequestState, upload shared.Dump, path string, positions []shared.Position) ([][]shared.Location, error) {
+	positionMonikers, err := s.lsifstore.GetImportMonikersByPositions(ctx, upload.ID, path, positions)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetImportMonikersByPositions")
+	}
+
+	// Each position keeps up to monikerLimit of its monikers, as for a single position. The monikers of
+	// all positions are searched at once, and each position takes the locations of its own monikers.
+	monikerSet := newQualifiedMonikerSet()
+	monikers := []precise.MonikerData{}
+	monikerIndexes := map[string]int{}
+	positionIndexes := make([][]int, len(positions))
+	for i, qualifiedMonikers := range positionMonikers {
+		positionSet := newQualifiedMonikerSet()
+		for _, moniker := range qualifiedMonikers {
+			if len(positionSet.monikers) >= monikerLimit {
+				break
+			}
+			positionSet.add(moniker)
+		}
+
+		for _, moniker := range positionSet.monikers {
+			monikerSet.add(moniker)
+
+			key := moniker.Scheme + ":" + moniker.Identifier
+			index, ok := monikerIndexes[key]
+			if !ok {
+				index = len(monikers)
+				monikerIndexes[key] = index
+				monikers = append(monikers, moniker.MonikerData)
+			}
+			if !containsInt(positionIndexes[i], index) {
+				positionIndexes[i] = append(positionIndexes[i], index)
+			}
+		}
+	}
+
+	definitions := make([][]shared.Location, len(positions))
+	if len(monikers) == 0 {
+		return definitions, nil
+	}
+
+	uploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, monikerSet.monikers, requestState)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(uploads))
+	for i := range uploads {
+		ids = append(ids, uploads[i].ID)
+	}
+
+	monikerLocations, err := s.lsifstore.GetBulkMonikerLocationsByMoniker(ctx, "definitions", ids, monikers, DefinitionsLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.GetBulkMonikerLocationsByMoniker")
+	}
+
+	for i, indexes := range positionIndexes {
+		for _, j := range indexes {
+			for _, location := range monikerLocations[j] {
+				if len(definitions[i]) >= DefinitionsLimit {
+					break
+				}
+				definitions[i] = append(definitions[i], location)
+			}
+		}
+	}
+
+	return definitions, nil
+}
+
+// getCallHierarchyCalls translates the given call hierarchy edges into calls in the requested commit.
+// Functions and call sites which the user cannot see are omitted.
+func (s *Service) getCallHierarchyCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState, edges *callHierarchyEdges) ([]shared.CallHierarchyCall, error) {
+	calls := make([]shared.CallHierarchyCall, 0, len(edges.functions))
+	for _, function := range edges.functions {
+		location := shared.Location{DumpID: function.DumpID, Path: function.Path, Range: function.Range}
+
+		functionLocations, err := s.getUploadLocations(ctx, args, requestState, []shared.Location{location})
+		if err != nil {
+			return nil, err
+		}
+		if len(functionLocations) == 0 {
+			continue
+		}
+
+		callSites, err := s.getUploadLocations(ctx, args, requestState, edges.callSites[location])
+		if err != nil {
+			return nil, err
+		}
+		if len(callSites) == 0 {
+			continue
+		}
+
+		calls = append(calls, shared.CallHierarchyCall{
+			Item: shared.CallHierarchyItem{
+				Name:     function.Name,
+				Location: functionLocations[0],
+			},
+			CallSites: callSites,
+		})
+	}
+
+	return calls, nil
+}
+
 // GetDefinitions returns the set of locations defining the symbol at the given position.
 func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []shared.UploadLocation, err error) {
 	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getDefinitions, serviceObserverThreshold, observation.Args{
@@ -1116,11 +1564,9 @@ func (s *Service) GetStencil(ctx context.Context, args shared.RequestArgs, reque
 			return nil, errors.Wrap(err, "lsifStore.Stencil")
 		}
 
-		for i, rn := range ranges {
-			// FIXME: change this at it expects an empty shared.Dump{}
-			cu := requestState.GetCacheUploadsAtIndex(i)
+		for _, rn := range ranges {
 			// Adjust the highlighted range back to the appropriate range in the target commit
-			_, adjustedRange, _, err := s.getSourceRange(ctx, args, requestState, cu.RepositoryID, cu.Commit, args.Path, rn)
+			_, adjustedRange, _, err := s.getSourceRange(ctx, args, requestState, adjustedUploads[i].Upload.RepositoryID, adjustedUploads[i].Upload.Commit, adjustedUploads[i].TargetPath, rn)
 			if err != nil {
 				return nil, err
 			}
@@ -1195,6 +1641,132 @@ func (s *Service) GetPackageInformation(ctx context.Context, bundleID int, path,
 	return s.lsifstore.GetPackageInformation(ctx, bundleID, path, packageInformationID)
 }
 
+// inboundReferenceUploadBatchSize is the maximum number of referencing uploads whose reference counts
+// are summed in a single query by GetInboundReferenceCounts.
+const inboundReferenceUploadBatchSize = 500
+
+// GetInboundReferenceCounts returns the number of references from other uploads to the symbols exported
+// from each of the given files by the closest upload covering the file. The uploads visible from the
+// given commit are resolved once for all files. References are matched to the exported monikers by
+// scheme and identifier; references within the exporting upload are not counted. The count of a file
+// not covered by any upload is zero.
+func (s *Service) GetInboundReferenceCounts(ctx context.Context, repositoryID int, commit string, paths []string) (_ []int, err error) {
+	ctx, trace, endObservation := s.operations.getInboundReferenceCounts.With(ctx, &err, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", repositoryID),
+			traceLog.String("commit", commit),
+			traceLog.Int("numPaths", len(paths)),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	// An empty path that is not exact matches the uploads of every root
+	uploads, err := s.GetClosestDumpsForBlob(ctx, repositoryID, commit, "", false, "")
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(traceLog.Int("numUploads", len(uploads)))
+
+	counts := make([]int, len(paths))
+	if len(uploads) == 0 {
+		return counts, nil
+	}
+
+	// Group the exported monikers of each file by the closest upload covering it, so that the
+	// uploads referencing any of them are scanned once per upload rather than once per file.
+	type fileMonikers struct {
+		index    int
+		monikers []precise.MonikerData
+	}
+	var uploadIDs []int
+	monikersByUpload := map[int][]precise.QualifiedMonikerData{}
+	filesByUpload := map[int][]fileMonikers{}
+
+	for i, path := range paths {
+		upload, ok, err := s.closestUploadForPath(ctx, uploads, path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		monikers, err := s.lsifstore.GetExportedMonikers(ctx, upload.ID, strings.TrimPrefix(path, upload.Root))
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetExportedMonikers")
+		}
+		if len(monikers) == 0 {
+			continue
+		}
+
+		monikerData := make([]precise.MonikerData, 0, len(monikers))
+		for _, moniker := range monikers {
+			monikerData = append(monikerData, moniker.MonikerData)
+		}
+
+		if _, ok := filesByUpload[upload.ID]; !ok {
+			uploadIDs = append(uploadIDs, upload.ID)
+		}
+		monikersByUpload[upload.ID] = append(monikersByUpload[upload.ID], monikers...)
+		filesByUpload[upload.ID] = append(filesByUpload[upload.ID], fileMonikers{index: i, monikers: monikerData})
+	}
+	trace.Log(traceLog.Int("numCoveringUploads", len(uploadIDs)))
+
+	for _, uploadID := range uploadIDs {
+		for offset := 0; ; {
+			referencingIDs, recordsScanned, totalCount, err := s.GetUploadIDsWithReferences(
+				ctx,
+				monikersByUpload[uploadID],
+				[]int{uploadID},
+				repositoryID,
+				commit,
+				inboundReferenceUploadBatchSize,
+				offset,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(referencingIDs) > 0 {
+				for _, file := range filesByUpload[uploadID] {
+					count, err := s.lsifstore.GetBulkMonikerReferenceCount(ctx, referencingIDs, file.monikers)
+					if err != nil {
+						return nil, errors.Wrap(err, "lsifStore.GetBulkMonikerReferenceCount")
+					}
+					counts[file.index] += count
+				}
+			}
+
+			offset += recordsScanned
+			if recordsScanned == 0 || offset >= totalCount {
+				break
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// closestUploadForPath returns the first of the given uploads, ordered by distance from the target
+// commit, whose root encloses the given path and which contains the path.
+func (s *Service) closestUploadForPath(ctx context.Context, uploads []shared.Dump, path string) (shared.Dump, bool, error) {
+	for _, upload := range uploads {
+		if !strings.HasPrefix(path, upload.Root) {
+			continue
+		}
+
+		exists, err := s.lsifstore.GetPathExists(ctx, upload.ID, strings.TrimPrefix(path, upload.Root))
+		if err != nil {
+			return shared.Dump{}, false, errors.Wrap(err, "lsifStore.GetPathExists")
+		}
+		if exists {
+			return upload, true, nil
+		}
+	}
+
+	return shared.Dump{}, false, nil
+}
+
 func (s *Service) GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []shared.Dump, err error) {
 	ctx, trace, endObservation := s.operations.getClosestDumpsForBlob.With(ctx, &err, observation.Args{
 		LogFields: []traceLog.Field{
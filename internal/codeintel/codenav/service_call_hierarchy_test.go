@@ -0,0 +1,303 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
+	uploadsShared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func newTestRange(startLine, startCharacter, endLine, endCharacter int) shared.Range {
+	return shared.Range{
+		Start: shared.Position{Line: startLine, Character: startCharacter},
+		End:   shared.Position{Line: endLine, Character: endCharacter},
+	}
+}
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockDBStore := NewMockDBStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), mockDBStore, &observation.TestContext)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &types.Repo{}, mockCommit, mockPath, 50)
+	uploads := []shared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, Commit: "deadbeef", Root: "sub3/"},
+		{ID: 53, Commit: "deadbeef", Root: "sub4/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{}, 0, 0, nil)
+
+	functions := []shared.FunctionDefinition{
+		{DumpID: 51, Path: "a.go", Name: "target", Range: newTestRange(1, 5, 1, 11), Extent: newTestRange(1, 0, 2, 1)},
+		{DumpID: 51, Path: "a.go", Name: "caller", Range: newTestRange(3, 5, 3, 11), Extent: newTestRange(3, 0, 8, 1)},
+		{DumpID: 51, Path: "a.go", Name: "outer", Range: newTestRange(15, 5, 15, 10), Extent: newTestRange(15, 0, 30, 1)},
+		{DumpID: 51, Path: "a.go", Name: "inner", Range: newTestRange(18, 1, 18, 6), Extent: newTestRange(18, 0, 25, 1)},
+	}
+	mockLsifStore.GetFunctionDefinitionsFunc.SetDefaultHook(func(_ context.Context, bundleID int, path string) ([]shared.FunctionDefinition, error) {
+		if bundleID == 51 && path == "a.go" {
+			return functions, nil
+		}
+		return nil, nil
+	})
+
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: newTestRange(1, 5, 1, 11)},  // definition of target
+		{DumpID: 51, Path: "a.go", Range: newTestRange(5, 1, 5, 7)},   // in caller
+		{DumpID: 51, Path: "a.go", Range: newTestRange(20, 1, 20, 7)}, // in inner
+		{DumpID: 51, Path: "a.go", Range: newTestRange(6, 1, 6, 7)},   // in caller
+		{DumpID: 51, Path: "a.go", Range: newTestRange(27, 1, 27, 7)}, // in outer
+		{DumpID: 51, Path: "b.go", Range: newTestRange(2, 0, 2, 6)},   // outside of any function
+	}
+	mockLsifStore.GetReferenceLocationsFunc.SetDefaultHook(func(_ context.Context, uploadID int, _ string, _, _, _, _ int) ([]shared.Location, int, error) {
+		if uploadID == 51 {
+			return locations, len(locations), nil
+		}
+		return nil, 0, nil
+	})
+
+	mockCursor := shared.ReferencesCursor{Phase: "local"}
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         1,
+		Character:    7,
+		Limit:        50,
+	}
+	calls, cursor, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState, mockCursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+	if cursor.Phase != "done" {
+		t.Errorf("unexpected phase. want=%q have=%q", "done", cursor.Phase)
+	}
+
+	uploadLocation := func(r shared.Range) shared.UploadLocation {
+		return shared.UploadLocation{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: "deadbeef", TargetRange: r}
+	}
+	expectedCalls := []shared.CallHierarchyCall{
+		{
+			Item: shared.CallHierarchyItem{Name: "caller", Location: uploadLocation(functions[1].Range)},
+			CallSites: []shared.UploadLocation{
+				uploadLocation(newTestRange(5, 1, 5, 7)),
+				uploadLocation(newTestRange(6, 1, 6, 7)),
+			},
+		},
+		{
+			Item:      shared.CallHierarchyItem{Name: "inner", Location: uploadLocation(functions[3].Range)},
+			CallSites: []shared.UploadLocation{uploadLocation(newTestRange(20, 1, 20, 7))},
+		},
+		{
+			Item:      shared.CallHierarchyItem{Name: "outer", Location: uploadLocation(functions[2].Range)},
+			CallSites: []shared.UploadLocation{uploadLocation(newTestRange(27, 1, 27, 7))},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	// The functions of each document are read once
+	if history := mockLsifStore.GetFunctionDefinitionsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected call count for lsifstore.GetFunctionDefinitions. want=%d have=%d", 2, len(history))
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockDBStore := NewMockDBStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(database.NewMockDB(), mockDBStore, &observation.TestContext)
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &types.Repo{ID: 42}, mockCommit, mockPath, 50)
+	uploads := []shared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, Commit: "deadbeef", Root: "sub3/"},
+		{ID: 53, Commit: "deadbeef", Root: "sub4/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(_ context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	caller := shared.FunctionDefinition{DumpID: 51, Path: "a.go", Name: "caller", Range: newTestRange(3, 5, 3, 11), Extent: newTestRange(3, 0, 8, 1)}
+	helper := shared.FunctionDefinition{DumpID: 51, Path: "a.go", Name: "helper", Range: newTestRange(10, 5, 10, 11), Extent: newTestRange(10, 0, 12, 1)}
+	util := shared.FunctionDefinition{DumpID: 51, Path: "b.go", Name: "util", Range: newTestRange(2, 5, 2, 9), Extent: newTestRange(2, 0, 4, 1)}
+	do := shared.FunctionDefinition{DumpID: 60, Path: "do.go", Name: "Do", Range: newTestRange(7, 5, 7, 7), Extent: newTestRange(7, 0, 9, 1)}
+	mockLsifStore.GetFunctionDefinitionsFunc.SetDefaultHook(func(_ context.Context, bundleID int, path string) ([]shared.FunctionDefinition, error) {
+		switch {
+		case bundleID == 51 && path == "a.go":
+			return []shared.FunctionDefinition{caller, helper}, nil
+		case bundleID == 51 && path == "b.go":
+			return []shared.FunctionDefinition{util}, nil
+		case bundleID == 60 && path == "do.go":
+			return []shared.FunctionDefinition{do}, nil
+		}
+		return nil, nil
+	})
+
+	// The requested position is the name of caller
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(_ context.Context, uploadID int, _ string, _, _, _, _ int) ([]shared.Location, int, error) {
+		if uploadID == 51 {
+			return []shared.Location{{DumpID: 51, Path: "a.go", Range: caller.Range}}, 1, nil
+		}
+		return nil, 0, nil
+	})
+
+	mockLsifStore.GetRangesFunc.SetDefaultReturn([]shared.CodeIntelligenceRange{
+		{Range: caller.Range, Definitions: []shared.Location{{DumpID: 51, Path: "a.go", Range: caller.Range}}},
+		{Range: newTestRange(4, 1, 4, 7), Definitions: []shared.Location{{DumpID: 51, Path: "a.go", Range: helper.Range}}},
+		{Range: newTestRange(5, 1, 5, 4), Definitions: []shared.Location{{DumpID: 51, Path: "a.go", Range: newTestRange(4, 9, 4, 12)}}}, // not a function
+		{Range: newTestRange(6, 1, 6, 7), Definitions: []shared.Location{{DumpID: 51, Path: "a.go", Range: helper.Range}}},
+		{Range: newTestRange(7, 1, 7, 5), Definitions: []shared.Location{{DumpID: 51, Path: "b.go", Range: util.Range}}},
+		{Range: newTestRange(7, 10, 7, 12)}, // defined in a dependency
+		{Range: newTestRange(8, 3, 8, 6), Definitions: []shared.Location{{DumpID: 51, Path: "a.go", Range: helper.Range}}}, // outside of caller
+	}, nil)
+
+	moniker := precise.QualifiedMonikerData{
+		MonikerData:            precise.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "lib.Do", PackageInformationID: "1"},
+		PackageInformationData: precise.PackageInformationData{Name: "lib", Version: "v1.0.0"},
+	}
+	mockLsifStore.GetImportMonikersByPositionsFunc.SetDefaultHook(func(_ context.Context, _ int, _ string, positions []shared.Position) ([][]precise.QualifiedMonikerData, error) {
+		monikers := make([][]precise.QualifiedMonikerData, len(positions))
+		for i, position := range positions {
+			if position == (shared.Position{Line: 7, Character: 10}) {
+				monikers[i] = []precise.QualifiedMonikerData{moniker}
+			}
+		}
+		return monikers, nil
+	})
+	libUpload := uploadsShared.Dump{ID: 60, Commit: "cafebabe", Root: "lib/", RepositoryID: 43}
+	mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.SetDefaultReturn([]uploadsShared.Dump{libUpload}, nil)
+	mockLsifStore.GetBulkMonikerLocationsByMonikerFunc.SetDefaultReturn([][]shared.Location{{{DumpID: 60, Path: "do.go", Range: do.Range}}}, nil)
+
+	uploadLocation := func(path string, r shared.Range) shared.UploadLocation {
+		return shared.UploadLocation{Dump: uploads[1], Path: "sub2/" + path, TargetCommit: "deadbeef", TargetRange: r}
+	}
+	helperCall := shared.CallHierarchyCall{
+		Item: shared.CallHierarchyItem{Name: "helper", Location: uploadLocation("a.go", helper.Range)},
+		CallSites: []shared.UploadLocation{
+			uploadLocation("a.go", newTestRange(4, 1, 4, 7)),
+			uploadLocation("a.go", newTestRange(6, 1, 6, 7)),
+		},
+	}
+	utilCall := shared.CallHierarchyCall{
+		Item:      shared.CallHierarchyItem{Name: "util", Location: uploadLocation("b.go", util.Range)},
+		CallSites: []shared.UploadLocation{uploadLocation("a.go", newTestRange(7, 1, 7, 5))},
+	}
+	doCall := shared.CallHierarchyCall{
+		Item: shared.CallHierarchyItem{Name: "Do", Location: shared.UploadLocation{
+			Dump:         shared.Dump{ID: 60, Commit: "cafebabe", Root: "lib/", RepositoryID: 43},
+			Path:         "lib/do.go",
+			TargetCommit: "cafebabe",
+			TargetRange:  do.Range,
+		}},
+		CallSites: []shared.UploadLocation{uploadLocation("a.go", newTestRange(7, 10, 7, 12))},
+	}
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         3,
+		Character:    7,
+		Limit:        50,
+	}
+
+	t.Run("single page", func(t *testing.T) {
+		calls, cursor, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState, shared.OutgoingCallsCursor{Phase: "local"})
+		if err != nil {
+			t.Fatalf("unexpected error querying outgoing calls: %s", err)
+		}
+		if cursor.Phase != "done" {
+			t.Errorf("unexpected phase. want=%q have=%q", "done", cursor.Phase)
+		}
+
+		if diff := cmp.Diff([]shared.CallHierarchyCall{helperCall, utilCall, doCall}, calls); diff != "" {
+			t.Errorf("unexpected calls (-want +got):\n%s", diff)
+		}
+
+		// The call sites without a local definition are resolved together
+		history := mockLsifStore.GetImportMonikersByPositionsFunc.History()
+		if len(history) != 1 {
+			t.Fatalf("unexpected call count for lsifstore.GetImportMonikersByPositions. want=%d have=%d", 1, len(history))
+		}
+		if diff := cmp.Diff([]shared.Position{{Line: 7, Character: 10}}, history[0].Arg3); diff != "" {
+			t.Errorf("unexpected positions (-want +got):\n%s", diff)
+		}
+		if history := mockLsifStore.GetBulkMonikerLocationsByMonikerFunc.History(); len(history) != 1 {
+			t.Errorf("unexpected call count for lsifstore.GetBulkMonikerLocationsByMoniker. want=%d have=%d", 1, len(history))
+		}
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		paginatedRequest := mockRequest
+		paginatedRequest.Limit = 2
+
+		calls, cursor, err := svc.GetOutgoingCalls(context.Background(), paginatedRequest, mockRequestState, shared.OutgoingCallsCursor{Phase: "local"})
+		if err != nil {
+			t.Fatalf("unexpected error querying outgoing calls: %s", err)
+		}
+		if diff := cmp.Diff([]shared.CallHierarchyCall{helperCall}, calls); diff != "" {
+			t.Errorf("unexpected calls (-want +got):\n%s", diff)
+		}
+		if cursor.Phase != "local" || cursor.UploadOffset != 1 || cursor.CallSiteOffset != 3 {
+			t.Errorf("unexpected cursor. want=(%q, %d, %d) have=(%q, %d, %d)", "local", 1, 3, cursor.Phase, cursor.UploadOffset, cursor.CallSiteOffset)
+		}
+
+		calls, cursor, err = svc.GetOutgoingCalls(context.Background(), paginatedRequest, mockRequestState, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error querying outgoing calls: %s", err)
+		}
+		if diff := cmp.Diff([]shared.CallHierarchyCall{utilCall, doCall}, calls); diff != "" {
+			t.Errorf("unexpected calls (-want +got):\n%s", diff)
+		}
+
+		// The remaining uploads are searched for the next page
+		calls, cursor, err = svc.GetOutgoingCalls(context.Background(), paginatedRequest, mockRequestState, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error querying outgoing calls: %s", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("unexpected calls. want=%d have=%d", 0, len(calls))
+		}
+		if cursor.Phase != "done" {
+			t.Errorf("unexpected phase. want=%q have=%q", "done", cursor.Phase)
+		}
+	})
+}
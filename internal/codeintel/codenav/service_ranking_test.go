@@ -0,0 +1,124 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
+	uploads "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestGetInboundReferenceCounts(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploads.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 60, Commit: "deadbeef", Root: "sub2/"},
+	}, nil)
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(_ context.Context, commits []gitserver.RepositoryCommit) ([]bool, error) {
+		exists := make([]bool, len(commits))
+		for i := range commits {
+			exists[i] = true
+		}
+		return exists, nil
+	})
+	mockLsifStore.GetPathExistsFunc.SetDefaultHook(func(_ context.Context, _ int, path string) (bool, error) {
+		return path != "missing.go", nil
+	})
+
+	fooMoniker := precise.QualifiedMonikerData{
+		MonikerData:            precise.MonikerData{Kind: "export", Scheme: "gomod", Identifier: "pkg.Foo", PackageInformationID: "1"},
+		PackageInformationData: precise.PackageInformationData{Name: "pkg", Version: "v1.0.0"},
+	}
+	barMoniker := precise.QualifiedMonikerData{
+		MonikerData:            precise.MonikerData{Kind: "export", Scheme: "gomod", Identifier: "pkg.Bar", PackageInformationID: "1"},
+		PackageInformationData: precise.PackageInformationData{Name: "pkg", Version: "v1.0.0"},
+	}
+	mockLsifStore.GetExportedMonikersFunc.SetDefaultHook(func(_ context.Context, _ int, path string) ([]precise.QualifiedMonikerData, error) {
+		switch path {
+		case "foo.go":
+			return []precise.QualifiedMonikerData{fooMoniker}, nil
+		case "bar.go":
+			return []precise.QualifiedMonikerData{barMoniker}, nil
+		}
+		return nil, nil
+	})
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{51, 52}, 2, 3, nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{53}, 1, 3, nil)
+	mockLsifStore.GetBulkMonikerReferenceCountFunc.SetDefaultHook(func(_ context.Context, uploadIDs []int, monikers []precise.MonikerData) (int, error) {
+		if monikers[0].Identifier == "pkg.Bar" {
+			return len(uploadIDs), nil
+		}
+		return 10 * len(uploadIDs), nil
+	})
+
+	paths := []string{"sub1/foo.go", "sub1/bar.go", "sub1/missing.go", "sub2/empty.go", "sub3/uncovered.go"}
+	counts, err := svc.GetInboundReferenceCounts(context.Background(), 42, mockCommit, paths)
+	if err != nil {
+		t.Fatalf("unexpected error getting inbound reference counts: %s", err)
+	}
+	if diff := cmp.Diff([]int{30, 3, 0, 0, 0}, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+
+	// The closest uploads are resolved once for all files
+	if history := mockUploadSvc.InferClosestUploadsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to InferClosestUploads. want=%d have=%d", 1, len(history))
+	}
+
+	if history := mockLsifStore.GetExportedMonikersFunc.History(); len(history) != 3 {
+		t.Fatalf("unexpected number of calls to GetExportedMonikers. want=%d have=%d", 3, len(history))
+	} else if history[0].Arg1 != 50 || history[0].Arg2 != "foo.go" {
+		t.Errorf("unexpected arguments to GetExportedMonikers. want=(%d, %q) have=(%d, %q)", 50, "foo.go", history[0].Arg1, history[0].Arg2)
+	}
+
+	// The referencing uploads are scanned once for the files of an upload
+	if history := mockUploadSvc.GetUploadIDsWithReferencesFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected number of calls to GetUploadIDsWithReferences. want=%d have=%d", 2, len(history))
+	} else {
+		for i, call := range history {
+			if diff := cmp.Diff([]precise.QualifiedMonikerData{fooMoniker, barMoniker}, call.Arg1); diff != "" {
+				t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]int{50}, call.Arg2); diff != "" {
+				t.Errorf("unexpected ignored upload ids (-want +got):\n%s", diff)
+			}
+			if expectedOffset := []int{0, 2}[i]; call.Arg6 != expectedOffset {
+				t.Errorf("unexpected offset. want=%d have=%d", expectedOffset, call.Arg6)
+			}
+		}
+	}
+
+	if history := mockLsifStore.GetBulkMonikerReferenceCountFunc.History(); len(history) != 4 {
+		t.Fatalf("unexpected number of calls to GetBulkMonikerReferenceCount. want=%d have=%d", 4, len(history))
+	} else {
+		if diff := cmp.Diff([]int{51, 52}, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]int{53}, history[2].Arg1); diff != "" {
+			t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]precise.MonikerData{fooMoniker.MonikerData}, history[0].Arg2); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+
+	// No covering uploads
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn(nil, nil)
+	if counts, err := svc.GetInboundReferenceCounts(context.Background(), 42, mockCommit, paths); err != nil {
+		t.Fatalf("unexpected error getting inbound reference counts: %s", err)
+	} else if diff := cmp.Diff([]int{0, 0, 0, 0, 0}, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
@@ -13,4 +13,6 @@ import (
 type GitserverClient interface {
 	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error)
+	RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]gitserver.RenamedFile, error)
 }
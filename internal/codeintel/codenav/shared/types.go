@@ -46,6 +46,11 @@ type UploadLocation struct {
 	Path         string
 	TargetCommit string
 	TargetRange  Range
+
+	// Confidence is the confidence, between 0 and 1, that the target range is equivalent to the
+	// indexed range when it was approximated across edits between the indexed commit and the
+	// target commit. It is nil if the target range is exact.
+	Confidence *float64
 }
 
 // DiagnosticAtUpload is a diagnostic from within a particular upload. The adjusted commit denotes
@@ -107,6 +112,31 @@ type CodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// FunctionDefinition is a function, method, or constructor defined in a document of a dump. The
+// range is that of the name of the function, and the extent encloses its entire definition.
+type FunctionDefinition struct {
+	DumpID int
+	Path   string
+	Name   string
+	Range  Range
+	Extent Range
+}
+
+// CallHierarchyItem is a function, method, or constructor in a call hierarchy. The location is
+// that of the name of the function, adjusted to the target commit.
+type CallHierarchyItem struct {
+	Name     string
+	Location UploadLocation
+}
+
+// CallHierarchyCall is an edge of a call hierarchy. The item is the calling function of an incoming
+// call, or the called function of an outgoing call. In both cases, the call sites are the ranges of
+// the calls within the calling function.
+type CallHierarchyCall struct {
+	Item      CallHierarchyItem
+	CallSites []UploadLocation
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
@@ -122,12 +152,22 @@ type ReferencesCursor struct {
 type ImplementationsCursor struct {
 	CursorsToVisibleUploads       []CursorToVisibleUpload        `json:"visibleUploads"`
 	OrderedImplementationMonikers []precise.QualifiedMonikerData `json:"orderedImplementationMonikers"`
-	OrderedExportMonikers         []precise.QualifiedMonikerData `json:"orderedExportMonikers"`
+	OrderedMonikers               []precise.QualifiedMonikerData `json:"orderedExportMonikers"`
 	Phase                         string                         `json:"phase"`
 	LocalCursor                   LocalCursor                    `json:"localCursor"`
 	RemoteCursor                  RemoteCursor                   `json:"remoteCursor"`
 }
 
+// OutgoingCallsCursor stores (enough of) the state of a previous OutgoingCalls request used to
+// calculate the offset into the result set to be returned by the current request. Incoming calls
+// are read from the references of a symbol, and are paginated with a ReferencesCursor.
+type OutgoingCallsCursor struct {
+	CursorsToVisibleUploads []CursorToVisibleUpload `json:"visibleUploads"`
+	Phase                   string                  `json:"phase"`
+	UploadOffset            int                     `json:"uploadOffset"`
+	CallSiteOffset          int                     `json:"callSiteOffset"`
+}
+
 // cursorAdjustedUpload
 type CursorToVisibleUpload struct {
 	DumpID                int      `json:"dumpID"`
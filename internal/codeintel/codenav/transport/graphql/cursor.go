@@ -52,3 +52,26 @@ func encodeReferencesCursor(cursor shared.ReferencesCursor) string {
 	rawEncoded, _ := json.Marshal(cursor)
 	return base64.RawURLEncoding.EncodeToString(rawEncoded)
 }
+
+// decodeOutgoingCallsCursor is the inverse of encodeOutgoingCallsCursor. If the given encoded string
+// is empty, then a fresh cursor is returned.
+func decodeOutgoingCallsCursor(rawEncoded string) (shared.OutgoingCallsCursor, error) {
+	if rawEncoded == "" {
+		return shared.OutgoingCallsCursor{Phase: "local"}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
+	if err != nil {
+		return shared.OutgoingCallsCursor{}, err
+	}
+
+	var cursor shared.OutgoingCallsCursor
+	err = json.Unmarshal(raw, &cursor)
+	return cursor, err
+}
+
+// encodeOutgoingCallsCursor returns an encoding of the given cursor suitable for a URL or a GraphQL token.
+func encodeOutgoingCallsCursor(cursor shared.OutgoingCallsCursor) string {
+	rawEncoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawEncoded)
+}
@@ -26,6 +26,8 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]shared.UploadLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]shared.UploadLocation, string, error)
 	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]shared.UploadLocation, string, error)
+	IncomingCalls(ctx context.Context, line, character, limit int, rawCursor string) ([]shared.CallHierarchyCall, string, error)
+	OutgoingCalls(ctx context.Context, line, character, limit int, rawCursor string) ([]shared.CallHierarchyCall, string, error)
 }
 
 type gitBlobLSIFDataResolver struct {
@@ -191,6 +193,54 @@ func (r *gitBlobLSIFDataResolver) References(ctx context.Context, line, characte
 	return refs, nextCursor, nil
 }
 
+// IncomingCalls returns the calls to the symbol at the given position, grouped by the calling function.
+func (r *gitBlobLSIFDataResolver) IncomingCalls(ctx context.Context, line, character, limit int, rawCursor string) (_ []shared.CallHierarchyCall, nextCursor string, err error) {
+	args := shared.RequestArgs{RepositoryID: r.repositoryID, Commit: r.commit, Path: r.path, Line: line, Character: character, Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, time.Second, getObservationArgs(args))
+	defer endObservation()
+
+	// Incoming calls are read from the references of the symbol, so they share the cursor of
+	// a references request.
+	cursor, err := decodeReferencesCursor(args.RawCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", args.RawCursor))
+	}
+
+	calls, callsCursor, err := r.svc.GetIncomingCalls(ctx, args, r.requestState, cursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "svc.GetIncomingCalls")
+	}
+
+	if callsCursor.Phase != "done" {
+		nextCursor = encodeReferencesCursor(callsCursor)
+	}
+
+	return calls, nextCursor, nil
+}
+
+// OutgoingCalls returns the calls made by the function at the given position, grouped by the called function.
+func (r *gitBlobLSIFDataResolver) OutgoingCalls(ctx context.Context, line, character, limit int, rawCursor string) (_ []shared.CallHierarchyCall, nextCursor string, err error) {
+	args := shared.RequestArgs{RepositoryID: r.repositoryID, Commit: r.commit, Path: r.path, Line: line, Character: character, Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, time.Second, getObservationArgs(args))
+	defer endObservation()
+
+	cursor, err := decodeOutgoingCallsCursor(args.RawCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", args.RawCursor))
+	}
+
+	calls, callsCursor, err := r.svc.GetOutgoingCalls(ctx, args, r.requestState, cursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "svc.GetOutgoingCalls")
+	}
+
+	if callsCursor.Phase != "done" {
+		nextCursor = encodeOutgoingCallsCursor(callsCursor)
+	}
+
+	return calls, nextCursor, nil
+}
+
 // Stencil returns all ranges within a single document.
 func (r *gitBlobLSIFDataResolver) Stencil(ctx context.Context) (adjustedRanges []shared.Range, err error) {
 	args := shared.RequestArgs{RepositoryID: r.repositoryID, Commit: r.commit, Path: r.path}
@@ -20,6 +20,8 @@ type Service interface {
 	GetDiagnostics(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState) (diagnosticsAtUploads []shared.DiagnosticAtUpload, _ int, err error)
 	GetRanges(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState, startLine, endLine int) (adjustedRanges []shared.AdjustedCodeIntelligenceRange, err error)
 	GetStencil(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState) (adjustedRanges []shared.Range, err error)
+	GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState, cursor shared.ReferencesCursor) (_ []shared.CallHierarchyCall, nextCursor shared.ReferencesCursor, err error)
+	GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState codenav.RequestState, cursor shared.OutgoingCallsCursor) (_ []shared.CallHierarchyCall, nextCursor shared.OutgoingCallsCursor, err error)
 
 	// Uploads Service
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error)
@@ -29,4 +31,6 @@ type Service interface {
 type GitserverClient interface {
 	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error)
+	RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]gitserver.RenamedFile, error)
 }
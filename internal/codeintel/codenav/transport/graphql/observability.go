@@ -22,6 +22,8 @@ type operations struct {
 	diagnostics     *observation.Operation
 	stencil         *observation.Operation
 	ranges          *observation.Operation
+	incomingCalls   *observation.Operation
+	outgoingCalls   *observation.Operation
 
 	getGitBlobLSIFDataResolver *observation.Operation
 }
@@ -52,6 +54,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		diagnostics:     op("Diagnostics"),
 		stencil:         op("Stencil"),
 		ranges:          op("Ranges"),
+		incomingCalls:   op("IncomingCalls"),
+		outgoingCalls:   op("OutgoingCalls"),
 
 		getGitBlobLSIFDataResolver: op("GetGitBlobLSIFDataResolver"),
 	}
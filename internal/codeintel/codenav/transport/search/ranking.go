@@ -0,0 +1,12 @@
+package search
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *client) InboundReferenceCounts(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, paths []string) ([]int, error) {
+	return s.svc.GetInboundReferenceCounts(ctx, int(repo.ID), string(commit), paths)
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type client struct {
+	svc                            *codenav.Service
+	gitserver                      shared.GitserverClient
+	maximumIndexesPerMonikerSearch int
+	hunkCacheSize                  int
+}
+
+// NewClient returns a client that answers the precise code intelligence queries
+// of search jobs with the code navigation service.
+func NewClient(svc *codenav.Service, gitserver shared.GitserverClient, maxIndexSearch, hunkCacheSize int) precise.Client {
+	return &client{
+		svc:                            svc,
+		gitserver:                      gitserver,
+		maximumIndexesPerMonikerSearch: maxIndexSearch,
+		hunkCacheSize:                  hunkCacheSize,
+	}
+}
+
+func (s *client) References(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, path string, line, character, limit int) ([]precise.Location, bool, error) {
+	uploads, err := s.svc.GetClosestDumpsForBlob(ctx, int(repo.ID), string(commit), path, true, "")
+	if err != nil {
+		return nil, false, err
+	}
+	if len(uploads) == 0 {
+		return nil, false, nil
+	}
+
+	requestState := codenav.NewRequestState(
+		uploads,
+		authz.DefaultSubRepoPermsChecker,
+		s.gitserver,
+		&types.Repo{ID: repo.ID, Name: repo.Name},
+		string(commit),
+		path,
+		s.maximumIndexesPerMonikerSearch,
+		s.hunkCacheSize,
+	)
+
+	args := shared.RequestArgs{
+		RepositoryID: int(repo.ID),
+		Commit:       string(commit),
+		Path:         path,
+		Line:         line,
+		Character:    character,
+		Limit:        limit,
+	}
+
+	var locations []precise.Location
+	cursor := shared.ReferencesCursor{Phase: "local"}
+	for len(locations) < limit {
+		uploadLocations, nextCursor, err := s.svc.GetReferences(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, location := range uploadLocations {
+			locations = append(locations, precise.Location{
+				Repo: types.MinimalRepo{
+					ID:   api.RepoID(location.Dump.RepositoryID),
+					Name: api.RepoName(location.Dump.RepositoryName),
+				},
+				Commit: api.CommitID(location.TargetCommit),
+				Path:   location.Path,
+				Range:  toRange(location.TargetRange),
+			})
+		}
+
+		if nextCursor.Phase == "done" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	if len(locations) > limit {
+		locations = locations[:limit]
+	}
+
+	return locations, true, nil
+}
+
+func toRange(r shared.Range) result.Range {
+	return result.Range{
+		Start: result.Location{Line: r.Start.Line, Column: r.Start.Character},
+		End:   result.Location{Line: r.End.Line, Column: r.End.Character},
+	}
+}
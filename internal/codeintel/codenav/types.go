@@ -44,3 +44,36 @@ func (s *qualifiedMonikerSet) add(qualifiedMoniker precise.QualifiedMonikerData)
 	s.monikerHashMap[monikerHash] = struct{}{}
 	s.monikers = append(s.monikers, qualifiedMoniker)
 }
+
+// documentKey identifies a document within an upload.
+type documentKey struct {
+	dumpID int
+	path   string
+}
+
+// functionDefinitionsCache stores the functions defined in each document read by a single call
+// hierarchy request, as many references and call sites are within the same documents.
+type functionDefinitionsCache map[documentKey][]shared.FunctionDefinition
+
+// callHierarchyEdges groups the call sites of a call hierarchy request by the function at the other
+// end of the call. Functions are kept in the order in which they were first added.
+type callHierarchyEdges struct {
+	functions []shared.FunctionDefinition
+	callSites map[shared.Location][]shared.Location
+}
+
+func newCallHierarchyEdges() *callHierarchyEdges {
+	return &callHierarchyEdges{
+		callSites: map[shared.Location][]shared.Location{},
+	}
+}
+
+// add the given call site to the calls of the given function.
+func (e *callHierarchyEdges) add(function shared.FunctionDefinition, callSite shared.Location) {
+	key := shared.Location{DumpID: function.DumpID, Path: function.Path, Range: function.Range}
+	if _, ok := e.callSites[key]; !ok {
+		e.functions = append(e.functions, function)
+	}
+
+	e.callSites[key] = append(e.callSites[key], callSite)
+}
@@ -28,6 +28,15 @@ func sliceContains(slice []string, str string) bool {
 	return false
 }
 
+func containsInt(slice []int, value int) bool {
+	for _, el := range slice {
+		if el == value {
+			return true
+		}
+	}
+	return false
+}
+
 func uploadIDsToString(vs []shared.Dump) string {
 	ids := make([]string, 0, len(vs))
 	for _, v := range vs {
@@ -71,6 +80,33 @@ func rangeContainsPosition(r shared.Range, pos shared.Position) bool {
 	return true
 }
 
+// rangeContainsRange returns true if the outer range encloses the inner range.
+func rangeContainsRange(outer, inner shared.Range) bool {
+	return rangeContainsPosition(outer, inner.Start) && rangeContainsPosition(outer, inner.End)
+}
+
+// enclosingFunction returns the innermost of the given functions, ordered by the start of their
+// extent, whose extent encloses the given range. A range which is the name of one of the functions
+// is its definition rather than a call, and no function is returned for it.
+func enclosingFunction(functions []shared.FunctionDefinition, r shared.Range) (shared.FunctionDefinition, bool) {
+	var enclosing shared.FunctionDefinition
+	found := false
+	for _, function := range functions {
+		if function.Range == r {
+			return shared.FunctionDefinition{}, false
+		}
+
+		if rangeContainsRange(function.Extent, r) {
+			// Later functions start after earlier ones, so an enclosing function found later is
+			// nested within the one found previously
+			enclosing = function
+			found = true
+		}
+	}
+
+	return enclosing, found
+}
+
 func sortRanges(ranges []shared.Range) []shared.Range {
 	sort.Slice(ranges, func(i, j int) bool {
 		iStart := ranges[i].Start
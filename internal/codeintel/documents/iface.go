@@ -0,0 +1,24 @@
+package documents
+
+import (
+	"context"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
+)
+
+type CodeNavService interface {
+	GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error)
+	GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []shared.Dump, err error)
+}
+
+type GitserverClient interface {
+	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
+	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error)
+	RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]gitserver.RenamedFile, error)
+}
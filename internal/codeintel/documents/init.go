@@ -8,7 +8,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
-	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -20,22 +20,22 @@ var (
 )
 
 // GetService creates or returns an already-initialized documents service. If the service is
-// new, it will use the given database handle.
-func GetService(db database.DB) *Service {
+// new, it will use the given codeintel database handle.
+func GetService(codeIntelDB database.DB, codenavSvc CodeNavService, gitserver GitserverClient, hunkCacheSize int) *Service {
 	svcOnce.Do(func() {
-		storeObservationCtx := &observation.Context{
-			Logger:     log.Scoped("documents.store", "codeintel documents store"),
+		lsifstoreObservationCtx := &observation.Context{
+			Logger:     log.Scoped("documents.lsifstore", "codeintel documents lsifstore"),
 			Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
 			Registerer: prometheus.DefaultRegisterer,
 		}
-		store := store.New(db, storeObservationCtx)
+		lsifstore := lsifstore.New(codeIntelDB, lsifstoreObservationCtx)
 
 		observationContext := &observation.Context{
 			Logger:     log.Scoped("documents.service", "codeintel documents service"),
 			Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
 			Registerer: prometheus.DefaultRegisterer,
 		}
-		svc = newService(store, observationContext)
+		svc = newService(lsifstore, codenavSvc, gitserver, hunkCacheSize, observationContext)
 	})
 
 	return svc
@@ -0,0 +1,33 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// LsifStore provides the interface for reading documents from the codeintel database.
+type LsifStore interface {
+	GetDocument(ctx context.Context, bundleID int, path string) (_ shared.Document, _ bool, err error)
+	GetDefinitionSymbols(ctx context.Context, bundleID int, after *shared.SymbolKey, limit int) (_ []shared.Symbol, next *shared.SymbolKey, err error)
+	GetReferenceSymbols(ctx context.Context, bundleID int, after *shared.SymbolKey, limit int) (_ []shared.SymbolReference, next *shared.SymbolKey, err error)
+}
+
+type store struct {
+	db         *basestore.Store
+	serializer *lsifstore.Serializer
+	operations *operations
+}
+
+// New returns a new documents lsifstore backed by the given codeintel database.
+func New(codeIntelDB database.DB, observationContext *observation.Context) LsifStore {
+	return &store{
+		db:         basestore.NewWithHandle(codeIntelDB.Handle()),
+		serializer: lsifstore.NewSerializer(),
+		operations: newOperations(observationContext),
+	}
+}
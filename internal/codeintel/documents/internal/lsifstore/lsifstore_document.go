@@ -0,0 +1,196 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNoMetadata occurs if we can't determine the number of result chunks for an index.
+var ErrNoMetadata = errors.New("no rows in meta table")
+
+// GetDocument returns all symbol occurrences of the given path within the given bundle, along
+// with the hover text they refer to. The ranges of the occurrences are relative to the indexed
+// commit. If the bundle does not contain the path, a false-valued flag is returned.
+func (s *store) GetDocument(ctx context.Context, bundleID int, path string) (_ shared.Document, _ bool, err error) {
+	ctx, trace, endObservation := s.operations.getDocument.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(documentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return shared.Document{}, false, err
+	}
+	trace.Log(log.Int("numRanges", len(documentData.Ranges)))
+
+	definitionRangeIDs, err := s.getDefinitionRangeIDs(ctx, bundleID, path, documentData)
+	if err != nil {
+		return shared.Document{}, false, err
+	}
+
+	occurrences := make([]shared.Occurrence, 0, len(documentData.Ranges))
+	hovers := map[string]string{}
+	for id, r := range documentData.Ranges {
+		roles := shared.SymbolRoleReference
+		if _, ok := definitionRangeIDs[id]; ok {
+			roles = shared.SymbolRoleDefinition
+		}
+
+		monikers := make([]shared.Moniker, 0, len(r.MonikerIDs))
+		for _, monikerID := range r.MonikerIDs {
+			if moniker, ok := documentData.Monikers[monikerID]; ok {
+				monikers = append(monikers, shared.Moniker{
+					Kind:       moniker.Kind,
+					Scheme:     moniker.Scheme,
+					Identifier: moniker.Identifier,
+				})
+			}
+		}
+
+		hoverID := ""
+		if text, ok := documentData.HoverResults[r.HoverResultID]; ok && text != "" {
+			hoverID = string(r.HoverResultID)
+			hovers[hoverID] = text
+		}
+
+		occurrences = append(occurrences, shared.Occurrence{
+			Range: shared.Range{
+				Start: shared.Position{Line: r.StartLine, Character: r.StartCharacter},
+				End:   shared.Position{Line: r.EndLine, Character: r.EndCharacter},
+			},
+			SymbolRoles: roles,
+			Monikers:    monikers,
+			HoverID:     hoverID,
+		})
+	}
+	sort.Slice(occurrences, func(i, j int) bool {
+		return compareRanges(occurrences[i].Range, occurrences[j].Range)
+	})
+
+	return shared.Document{
+		UploadID:    bundleID,
+		Path:        path,
+		Occurrences: occurrences,
+		Hovers:      hovers,
+	}, true, nil
+}
+
+const documentQuery = `
+-- source: internal/codeintel/documents/internal/lsifstore/lsifstore_document.go:GetDocument
+SELECT
+	data,
+	ranges,
+	hovers,
+	monikers
+FROM
+	lsif_data_all_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// getDefinitionRangeIDs returns the set of ranges of the given document which define their
+// symbol, i.e. the ranges which are part of their own definition result.
+func (s *store) getDefinitionRangeIDs(ctx context.Context, bundleID int, path string, documentData precise.DocumentData) (map[precise.ID]struct{}, error) {
+	idSet := map[precise.ID]struct{}{}
+	for _, r := range documentData.Ranges {
+		if r.DefinitionResultID != "" {
+			idSet[r.DefinitionResultID] = struct{}{}
+		}
+	}
+	if len(idSet) == 0 {
+		return nil, nil
+	}
+
+	// Mapping ids to result chunk indexes relies on the number of total result chunks written during
+	// processing so that we can hash identifiers to their parent result chunk in the same deterministic
+	// way.
+	numResultChunks, exists, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(numResultChunksQuery, bundleID)))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNoMetadata
+	}
+
+	indexSet := map[int]struct{}{}
+	for id := range idSet {
+		indexSet[precise.HashKey(id, numResultChunks)] = struct{}{}
+	}
+	indexes := make([]int, 0, len(indexSet))
+	for index := range indexSet {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	definitionRangeIDs := map[precise.ID]struct{}{}
+
+	// In order to limit the number of parameters we send to Postgres in the result chunk
+	// fetch query, we process the indexes in batches of maximum size.
+	for len(indexes) > 0 {
+		var batch []int
+		if len(indexes) <= resultChunkBatchSize {
+			batch, indexes = indexes, nil
+		} else {
+			batch, indexes = indexes[:resultChunkBatchSize], indexes[resultChunkBatchSize:]
+		}
+
+		indexQueries := make([]*sqlf.Query, 0, len(batch))
+		for _, index := range batch {
+			indexQueries = append(indexQueries, sqlf.Sprintf("%s", index))
+		}
+		visitResultChunks := s.makeResultChunkVisitor(s.db.Query(ctx, sqlf.Sprintf(
+			resultChunksQuery,
+			bundleID,
+			sqlf.Join(indexQueries, ","),
+		)))
+
+		if err := visitResultChunks(func(resultChunkData precise.ResultChunkData) {
+			for id := range idSet {
+				for _, documentIDRangeID := range resultChunkData.DocumentIDRangeIDs[id] {
+					if resultChunkData.DocumentPaths[documentIDRangeID.DocumentID] == path {
+						definitionRangeIDs[documentIDRangeID.RangeID] = struct{}{}
+					}
+				}
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return definitionRangeIDs, nil
+}
+
+// resultChunkBatchSize is the maximum number of result chunks we will query at once to resolve
+// the definitions of a single document.
+const resultChunkBatchSize = 50
+
+const numResultChunksQuery = `
+-- source: internal/codeintel/documents/internal/lsifstore/lsifstore_document.go:getDefinitionRangeIDs
+SELECT num_result_chunks FROM lsif_data_metadata WHERE dump_id = %s
+`
+
+const resultChunksQuery = `
+-- source: internal/codeintel/documents/internal/lsifstore/lsifstore_document.go:getDefinitionRangeIDs
+SELECT data FROM lsif_data_result_chunks WHERE dump_id = %s AND idx IN (%s)
+`
+
+// compareRanges returns true if r1's start position occurs before r2's start position.
+func compareRanges(r1, r2 shared.Range) bool {
+	if r1.Start.Line != r2.Start.Line {
+		return r1.Start.Line < r2.Start.Line
+	}
+
+	return r1.Start.Character < r2.Start.Character
+}
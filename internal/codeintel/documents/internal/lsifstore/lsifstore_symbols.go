@@ -0,0 +1,215 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// GetDefinitionSymbols returns the symbols defined by export monikers within the given bundle,
+// along with the hover text of their first definition. The symbols are ordered by scheme and
+// identifier. At most limit monikers ordered after the given key, if any, are read. Monikers
+// without locations are skipped, so fewer symbols may be returned. If limit monikers were read,
+// the key of the last one is returned as the key from which the next page continues.
+func (s *store) GetDefinitionSymbols(ctx context.Context, bundleID int, after *shared.SymbolKey, limit int) (_ []shared.Symbol, next *shared.SymbolKey, err error) {
+	ctx, trace, endObservation := s.operations.getDefinitionSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	monikerLocations, err := s.scanMonikerLocations(s.db.Query(ctx, makeSymbolsQuery("lsif_data_definitions", bundleID, after, limit)))
+	if err != nil {
+		return nil, nil, err
+	}
+	trace.Log(log.Int("numMonikers", len(monikerLocations)))
+
+	symbols := make([]shared.Symbol, 0, len(monikerLocations))
+	paths := map[string]struct{}{}
+	for _, monikerLocation := range monikerLocations {
+		if len(monikerLocation.Locations) == 0 {
+			continue
+		}
+
+		location := firstLocation(monikerLocation.Locations)
+		paths[location.URI] = struct{}{}
+		symbols = append(symbols, shared.Symbol{
+			Scheme:     monikerLocation.Scheme,
+			Identifier: monikerLocation.Identifier,
+			Location:   toLocation(location),
+		})
+	}
+
+	hoversByPath, err := s.getHoversByRange(ctx, bundleID, paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, symbol := range symbols {
+		symbols[i].Hover = hoversByPath[symbol.Location.Path][symbol.Location.Range]
+	}
+
+	return symbols, nextSymbolKey(monikerLocations, limit), nil
+}
+
+// GetReferenceSymbols returns the references to import and export monikers within the given
+// bundle. The references are ordered by scheme, identifier and location. The references to at
+// most limit monikers ordered after the given key, if any, are returned. If limit monikers were
+// read, the key of the last one is returned as the key from which the next page continues.
+func (s *store) GetReferenceSymbols(ctx context.Context, bundleID int, after *shared.SymbolKey, limit int) (_ []shared.SymbolReference, next *shared.SymbolKey, err error) {
+	ctx, trace, endObservation := s.operations.getReferenceSymbols.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	monikerLocations, err := s.scanMonikerLocations(s.db.Query(ctx, makeSymbolsQuery("lsif_data_references", bundleID, after, limit)))
+	if err != nil {
+		return nil, nil, err
+	}
+	trace.Log(log.Int("numMonikers", len(monikerLocations)))
+
+	var references []shared.SymbolReference
+	for _, monikerLocation := range monikerLocations {
+		locations := monikerLocation.Locations
+		sort.Slice(locations, func(i, j int) bool { return compareLocations(locations[i], locations[j]) })
+
+		for _, location := range locations {
+			references = append(references, shared.SymbolReference{
+				Scheme:     monikerLocation.Scheme,
+				Identifier: monikerLocation.Identifier,
+				Location:   toLocation(location),
+			})
+		}
+	}
+
+	return references, nextSymbolKey(monikerLocations, limit), nil
+}
+
+// nextSymbolKey returns the key of the last of the given monikers if the symbols query read a
+// full page of them, and nil otherwise.
+func nextSymbolKey(monikerLocations []precise.MonikerLocations, limit int) *shared.SymbolKey {
+	if len(monikerLocations) < limit || len(monikerLocations) == 0 {
+		return nil
+	}
+
+	last := monikerLocations[len(monikerLocations)-1]
+	return &shared.SymbolKey{Scheme: last.Scheme, Identifier: last.Identifier}
+}
+
+// makeSymbolsQuery returns a query selecting the monikers of the given table ordered after the given
+// key. Both the cursor comparison and the ordering use the "C" collation so that they agree with the
+// byte-wise ordering of shared.SymbolKey rather than the collation of the database.
+func makeSymbolsQuery(tableName string, bundleID int, after *shared.SymbolKey, limit int) *sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("dump_id = %s", bundleID)}
+	if after != nil {
+		conds = append(conds, sqlf.Sprintf(`(scheme COLLATE "C", identifier COLLATE "C") > (%s COLLATE "C", %s COLLATE "C")`, after.Scheme, after.Identifier))
+	}
+
+	return sqlf.Sprintf(symbolsQuery, sqlf.Sprintf(tableName), sqlf.Join(conds, " AND "), limit)
+}
+
+const symbolsQuery = `
+-- source: internal/codeintel/documents/internal/lsifstore/lsifstore_symbols.go:makeSymbolsQuery
+SELECT scheme, identifier, data FROM %s WHERE %s ORDER BY scheme COLLATE "C", identifier COLLATE "C" LIMIT %s
+`
+
+// getHoversByRange returns the hover text of the ranges of the given documents, keyed by path
+// and range.
+func (s *store) getHoversByRange(ctx context.Context, bundleID int, pathSet map[string]struct{}) (map[string]map[shared.Range]string, error) {
+	paths := make([]string, 0, len(pathSet))
+	for path := range pathSet {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	hoversByPath := make(map[string]map[shared.Range]string, len(paths))
+
+	// In order to limit the number of parameters we send to Postgres in the document
+	// fetch query, we process the paths in batches of maximum size.
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= documentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:documentBatchSize], paths[documentBatchSize:]
+		}
+
+		pathQueries := make([]*sqlf.Query, 0, len(batch))
+		for _, path := range batch {
+			pathQueries = append(pathQueries, sqlf.Sprintf("%s", path))
+		}
+
+		if err := s.visitDocumentData(s.db.Query(ctx, sqlf.Sprintf(documentsQuery, bundleID, sqlf.Join(pathQueries, ","))))(func(path string, document precise.DocumentData) {
+			hovers := map[shared.Range]string{}
+			for _, r := range document.Ranges {
+				if text, ok := document.HoverResults[r.HoverResultID]; ok && text != "" {
+					hovers[shared.Range{
+						Start: shared.Position{Line: r.StartLine, Character: r.StartCharacter},
+						End:   shared.Position{Line: r.EndLine, Character: r.EndCharacter},
+					}] = text
+				}
+			}
+			hoversByPath[path] = hovers
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return hoversByPath, nil
+}
+
+// documentBatchSize is the maximum number of documents we will query at once to resolve the
+// hover text of the definitions of a bundle.
+const documentBatchSize = 100
+
+const documentsQuery = `
+-- source: internal/codeintel/documents/internal/lsifstore/lsifstore_symbols.go:getHoversByRange
+SELECT
+	path,
+	data,
+	ranges,
+	hovers,
+	monikers
+FROM
+	lsif_data_all_documents
+WHERE
+	dump_id = %s AND
+	path IN (%s)
+`
+
+// firstLocation returns the location of the given set which occurs first.
+func firstLocation(locations []precise.LocationData) precise.LocationData {
+	first := locations[0]
+	for _, location := range locations[1:] {
+		if compareLocations(location, first) {
+			first = location
+		}
+	}
+
+	return first
+}
+
+// compareLocations returns true if l1 occurs before l2.
+func compareLocations(l1, l2 precise.LocationData) bool {
+	if l1.URI != l2.URI {
+		return l1.URI < l2.URI
+	}
+
+	return compareRanges(toLocation(l1).Range, toLocation(l2).Range)
+}
+
+func toLocation(location precise.LocationData) shared.Location {
+	return shared.Location{
+		Path: location.URI,
+		Range: shared.Range{
+			Start: shared.Position{Line: location.StartLine, Character: location.StartCharacter},
+			End:   shared.Position{Line: location.EndLine, Character: location.EndCharacter},
+		},
+	}
+}
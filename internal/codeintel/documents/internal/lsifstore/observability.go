@@ -1,4 +1,4 @@
-package store
+package lsifstore
 
 import (
 	"fmt"
@@ -8,26 +8,30 @@ import (
 )
 
 type operations struct {
-	list *observation.Operation
+	getDocument          *observation.Operation
+	getDefinitionSymbols *observation.Operation
+	getReferenceSymbols  *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
 	metrics := metrics.NewREDMetrics(
 		observationContext.Registerer,
-		"codeintel_documents_store",
+		"codeintel_documents_lsifstore",
 		metrics.WithLabels("op"),
 		metrics.WithCountHelp("Total number of method invocations."),
 	)
 
 	op := func(name string) *observation.Operation {
 		return observationContext.Operation(observation.Op{
-			Name:              fmt.Sprintf("codeintel.documents.store.%s", name),
+			Name:              fmt.Sprintf("codeintel.documents.lsifstore.%s", name),
 			MetricLabelValues: []string{name},
 			Metrics:           metrics,
 		})
 	}
 
 	return &operations{
-		list: op("List"),
+		getDocument:          op("GetDocument"),
+		getDefinitionSymbols: op("GetDefinitionSymbols"),
+		getReferenceSymbols:  op("GetReferenceSymbols"),
 	}
 }
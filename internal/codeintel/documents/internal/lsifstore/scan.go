@@ -0,0 +1,137 @@
+package lsifstore
+
+import (
+	"database/sql"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// scanFirstDocumentData reads document data from its given row object and returns the
+// first one. If no rows match the query, a false-valued flag is returned.
+func (s *store) scanFirstDocumentData(rows *sql.Rows, queryErr error) (_ precise.DocumentData, _ bool, err error) {
+	if queryErr != nil {
+		return precise.DocumentData{}, false, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return precise.DocumentData{}, false, nil
+	}
+
+	var rawData []byte
+	var encoded lsifstore.MarshalledDocumentData
+	if err := rows.Scan(
+		&rawData,
+		&encoded.Ranges,
+		&encoded.HoverResults,
+		&encoded.Monikers,
+	); err != nil {
+		return precise.DocumentData{}, false, err
+	}
+
+	if len(rawData) != 0 {
+		data, err := s.serializer.UnmarshalLegacyDocumentData(rawData)
+		return data, true, err
+	}
+
+	data, err := s.serializer.UnmarshalDocumentData(encoded)
+	return data, true, err
+}
+
+// makeResultChunkVisitor returns a function that accepts a mapping function, reads
+// result chunk values from the given row object and calls the mapping function on
+// each decoded result chunk.
+func (s *store) makeResultChunkVisitor(rows *sql.Rows, queryErr error) func(func(precise.ResultChunkData)) error {
+	return func(f func(precise.ResultChunkData)) (err error) {
+		if queryErr != nil {
+			return queryErr
+		}
+		defer func() { err = basestore.CloseRows(rows, err) }()
+
+		var rawData []byte
+		for rows.Next() {
+			if err := rows.Scan(&rawData); err != nil {
+				return err
+			}
+
+			data, err := s.serializer.UnmarshalResultChunkData(rawData)
+			if err != nil {
+				return err
+			}
+
+			f(data)
+		}
+
+		return nil
+	}
+}
+
+// scanMonikerLocations reads moniker locations values from the given row object.
+func (s *store) scanMonikerLocations(rows *sql.Rows, queryErr error) (_ []precise.MonikerLocations, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var values []precise.MonikerLocations
+	for rows.Next() {
+		var rawData []byte
+		var record precise.MonikerLocations
+		if err := rows.Scan(&record.Scheme, &record.Identifier, &rawData); err != nil {
+			return nil, err
+		}
+
+		data, err := s.serializer.UnmarshalLocations(rawData)
+		if err != nil {
+			return nil, err
+		}
+		record.Locations = data
+
+		values = append(values, record)
+	}
+
+	return values, nil
+}
+
+// visitDocumentData returns a function that accepts a mapping function, reads keyed
+// document data values from the given row object and calls the mapping function on
+// each decoded document.
+func (s *store) visitDocumentData(rows *sql.Rows, queryErr error) func(func(string, precise.DocumentData)) error {
+	return func(f func(string, precise.DocumentData)) (err error) {
+		if queryErr != nil {
+			return queryErr
+		}
+		defer func() { err = basestore.CloseRows(rows, err) }()
+
+		for rows.Next() {
+			var path string
+			var rawData []byte
+			var encoded lsifstore.MarshalledDocumentData
+			if err := rows.Scan(
+				&path,
+				&rawData,
+				&encoded.Ranges,
+				&encoded.HoverResults,
+				&encoded.Monikers,
+			); err != nil {
+				return err
+			}
+
+			var data precise.DocumentData
+			if len(rawData) != 0 {
+				data, err = s.serializer.UnmarshalLegacyDocumentData(rawData)
+			} else {
+				data, err = s.serializer.UnmarshalDocumentData(encoded)
+			}
+			if err != nil {
+				return err
+			}
+
+			f(path, data)
+		}
+
+		return nil
+	}
+}
@@ -10,82 +10,860 @@ import (
 	"context"
 	"sync"
 
-	store "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/store"
+	diff "github.com/sourcegraph/go-diff/diff"
+	api "github.com/sourcegraph/sourcegraph/internal/api"
+	authz "github.com/sourcegraph/sourcegraph/internal/authz"
+	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	lsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/lsifstore"
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	gitserver "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/gitserver"
 )
 
-// MockStore is a mock implementation of the Store interface (from the
-// package
-// github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/store)
+// MockLsifStore is a mock implementation of the LsifStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/lsifstore)
 // used for unit testing.
-type MockStore struct {
-	// ListFunc is an instance of a mock function object controlling the
-	// behavior of the method List.
-	ListFunc *StoreListFunc
-}
-
-// NewMockStore creates a new mock of the Store interface. All methods
-// return zero values for all results, unless overwritten.
-func NewMockStore() *MockStore {
-	return &MockStore{
-		ListFunc: &StoreListFunc{
-			defaultHook: func(context.Context, store.ListOpts) (r0 []shared.Document, r1 error) {
+type MockLsifStore struct {
+	// GetDefinitionSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDefinitionSymbols.
+	GetDefinitionSymbolsFunc *LsifStoreGetDefinitionSymbolsFunc
+	// GetDocumentFunc is an instance of a mock function object controlling
+	// the behavior of the method GetDocument.
+	GetDocumentFunc *LsifStoreGetDocumentFunc
+	// GetReferenceSymbolsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetReferenceSymbols.
+	GetReferenceSymbolsFunc *LsifStoreGetReferenceSymbolsFunc
+}
+
+// NewMockLsifStore creates a new mock of the LsifStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		GetDefinitionSymbolsFunc: &LsifStoreGetDefinitionSymbolsFunc{
+			defaultHook: func(context.Context, int, *shared.SymbolKey, int) (r0 []shared.Symbol, r1 *shared.SymbolKey, r2 error) {
+				return
+			},
+		},
+		GetDocumentFunc: &LsifStoreGetDocumentFunc{
+			defaultHook: func(context.Context, int, string) (r0 shared.Document, r1 bool, r2 error) {
+				return
+			},
+		},
+		GetReferenceSymbolsFunc: &LsifStoreGetReferenceSymbolsFunc{
+			defaultHook: func(context.Context, int, *shared.SymbolKey, int) (r0 []shared.SymbolReference, r1 *shared.SymbolKey, r2 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockLsifStore creates a new mock of the LsifStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		GetDefinitionSymbolsFunc: &LsifStoreGetDefinitionSymbolsFunc{
+			defaultHook: func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error) {
+				panic("unexpected invocation of MockLsifStore.GetDefinitionSymbols")
+			},
+		},
+		GetDocumentFunc: &LsifStoreGetDocumentFunc{
+			defaultHook: func(context.Context, int, string) (shared.Document, bool, error) {
+				panic("unexpected invocation of MockLsifStore.GetDocument")
+			},
+		},
+		GetReferenceSymbolsFunc: &LsifStoreGetReferenceSymbolsFunc{
+			defaultHook: func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error) {
+				panic("unexpected invocation of MockLsifStore.GetReferenceSymbols")
+			},
+		},
+	}
+}
+
+// NewMockLsifStoreFrom creates a new mock of the MockLsifStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
+	return &MockLsifStore{
+		GetDefinitionSymbolsFunc: &LsifStoreGetDefinitionSymbolsFunc{
+			defaultHook: i.GetDefinitionSymbols,
+		},
+		GetDocumentFunc: &LsifStoreGetDocumentFunc{
+			defaultHook: i.GetDocument,
+		},
+		GetReferenceSymbolsFunc: &LsifStoreGetReferenceSymbolsFunc{
+			defaultHook: i.GetReferenceSymbols,
+		},
+	}
+}
+
+// LsifStoreGetDefinitionSymbolsFunc describes the behavior when the
+// GetDefinitionSymbols method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetDefinitionSymbolsFunc struct {
+	defaultHook func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error)
+	hooks       []func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error)
+	history     []LsifStoreGetDefinitionSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDefinitionSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetDefinitionSymbols(v0 context.Context, v1 int, v2 *shared.SymbolKey, v3 int) ([]shared.Symbol, *shared.SymbolKey, error) {
+	r0, r1, r2 := m.GetDefinitionSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetDefinitionSymbolsFunc.appendCall(LsifStoreGetDefinitionSymbolsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetDefinitionSymbols
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetDefinitionSymbolsFunc) SetDefaultHook(hook func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDefinitionSymbols method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetDefinitionSymbolsFunc) PushHook(hook func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetDefinitionSymbolsFunc) SetDefaultReturn(r0 []shared.Symbol, r1 *shared.SymbolKey, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetDefinitionSymbolsFunc) PushReturn(r0 []shared.Symbol, r1 *shared.SymbolKey, r2 error) {
+	f.PushHook(func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetDefinitionSymbolsFunc) nextHook() func(context.Context, int, *shared.SymbolKey, int) ([]shared.Symbol, *shared.SymbolKey, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetDefinitionSymbolsFunc) appendCall(r0 LsifStoreGetDefinitionSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetDefinitionSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetDefinitionSymbolsFunc) History() []LsifStoreGetDefinitionSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetDefinitionSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetDefinitionSymbolsFuncCall is an object that describes an
+// invocation of method GetDefinitionSymbols on an instance of
+// MockLsifStore.
+type LsifStoreGetDefinitionSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *shared.SymbolKey
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Symbol
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 *shared.SymbolKey
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetDefinitionSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetDefinitionSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// LsifStoreGetDocumentFunc describes the behavior when the GetDocument
+// method of the parent MockLsifStore instance is invoked.
+type LsifStoreGetDocumentFunc struct {
+	defaultHook func(context.Context, int, string) (shared.Document, bool, error)
+	hooks       []func(context.Context, int, string) (shared.Document, bool, error)
+	history     []LsifStoreGetDocumentFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocument delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLsifStore) GetDocument(v0 context.Context, v1 int, v2 string) (shared.Document, bool, error) {
+	r0, r1, r2 := m.GetDocumentFunc.nextHook()(v0, v1, v2)
+	m.GetDocumentFunc.appendCall(LsifStoreGetDocumentFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetDocument method
+// of the parent MockLsifStore instance is invoked and the hook queue is
+// empty.
+func (f *LsifStoreGetDocumentFunc) SetDefaultHook(hook func(context.Context, int, string) (shared.Document, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocument method of the parent MockLsifStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LsifStoreGetDocumentFunc) PushHook(hook func(context.Context, int, string) (shared.Document, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetDocumentFunc) SetDefaultReturn(r0 shared.Document, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string) (shared.Document, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetDocumentFunc) PushReturn(r0 shared.Document, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, string) (shared.Document, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetDocumentFunc) nextHook() func(context.Context, int, string) (shared.Document, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetDocumentFunc) appendCall(r0 LsifStoreGetDocumentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetDocumentFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreGetDocumentFunc) History() []LsifStoreGetDocumentFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetDocumentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetDocumentFuncCall is an object that describes an invocation of
+// method GetDocument on an instance of MockLsifStore.
+type LsifStoreGetDocumentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.Document
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetDocumentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetDocumentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// LsifStoreGetReferenceSymbolsFunc describes the behavior when the
+// GetReferenceSymbols method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreGetReferenceSymbolsFunc struct {
+	defaultHook func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error)
+	hooks       []func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error)
+	history     []LsifStoreGetReferenceSymbolsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReferenceSymbols delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) GetReferenceSymbols(v0 context.Context, v1 int, v2 *shared.SymbolKey, v3 int) ([]shared.SymbolReference, *shared.SymbolKey, error) {
+	r0, r1, r2 := m.GetReferenceSymbolsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetReferenceSymbolsFunc.appendCall(LsifStoreGetReferenceSymbolsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetReferenceSymbols
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreGetReferenceSymbolsFunc) SetDefaultHook(hook func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReferenceSymbols method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreGetReferenceSymbolsFunc) PushHook(hook func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreGetReferenceSymbolsFunc) SetDefaultReturn(r0 []shared.SymbolReference, r1 *shared.SymbolKey, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreGetReferenceSymbolsFunc) PushReturn(r0 []shared.SymbolReference, r1 *shared.SymbolKey, r2 error) {
+	f.PushHook(func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LsifStoreGetReferenceSymbolsFunc) nextHook() func(context.Context, int, *shared.SymbolKey, int) ([]shared.SymbolReference, *shared.SymbolKey, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreGetReferenceSymbolsFunc) appendCall(r0 LsifStoreGetReferenceSymbolsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreGetReferenceSymbolsFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreGetReferenceSymbolsFunc) History() []LsifStoreGetReferenceSymbolsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreGetReferenceSymbolsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreGetReferenceSymbolsFuncCall is an object that describes an
+// invocation of method GetReferenceSymbols on an instance of MockLsifStore.
+type LsifStoreGetReferenceSymbolsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *shared.SymbolKey
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolReference
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 *shared.SymbolKey
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreGetReferenceSymbolsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreGetReferenceSymbolsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// MockCodeNavService is a mock implementation of the CodeNavService
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/documents) used for
+// unit testing.
+type MockCodeNavService struct {
+	// GetClosestDumpsForBlobFunc is an instance of a mock function object
+	// controlling the behavior of the method GetClosestDumpsForBlob.
+	GetClosestDumpsForBlobFunc *CodeNavServiceGetClosestDumpsForBlobFunc
+	// GetDumpsByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDumpsByIDs.
+	GetDumpsByIDsFunc *CodeNavServiceGetDumpsByIDsFunc
+}
+
+// NewMockCodeNavService creates a new mock of the CodeNavService interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockCodeNavService() *MockCodeNavService {
+	return &MockCodeNavService{
+		GetClosestDumpsForBlobFunc: &CodeNavServiceGetClosestDumpsForBlobFunc{
+			defaultHook: func(context.Context, int, string, string, bool, string) (r0 []shared1.Dump, r1 error) {
+				return
+			},
+		},
+		GetDumpsByIDsFunc: &CodeNavServiceGetDumpsByIDsFunc{
+			defaultHook: func(context.Context, []int) (r0 []shared1.Dump, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockCodeNavService creates a new mock of the CodeNavService
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockCodeNavService() *MockCodeNavService {
+	return &MockCodeNavService{
+		GetClosestDumpsForBlobFunc: &CodeNavServiceGetClosestDumpsForBlobFunc{
+			defaultHook: func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error) {
+				panic("unexpected invocation of MockCodeNavService.GetClosestDumpsForBlob")
+			},
+		},
+		GetDumpsByIDsFunc: &CodeNavServiceGetDumpsByIDsFunc{
+			defaultHook: func(context.Context, []int) ([]shared1.Dump, error) {
+				panic("unexpected invocation of MockCodeNavService.GetDumpsByIDs")
+			},
+		},
+	}
+}
+
+// NewMockCodeNavServiceFrom creates a new mock of the MockCodeNavService
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockCodeNavServiceFrom(i CodeNavService) *MockCodeNavService {
+	return &MockCodeNavService{
+		GetClosestDumpsForBlobFunc: &CodeNavServiceGetClosestDumpsForBlobFunc{
+			defaultHook: i.GetClosestDumpsForBlob,
+		},
+		GetDumpsByIDsFunc: &CodeNavServiceGetDumpsByIDsFunc{
+			defaultHook: i.GetDumpsByIDs,
+		},
+	}
+}
+
+// CodeNavServiceGetClosestDumpsForBlobFunc describes the behavior when the
+// GetClosestDumpsForBlob method of the parent MockCodeNavService instance
+// is invoked.
+type CodeNavServiceGetClosestDumpsForBlobFunc struct {
+	defaultHook func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error)
+	hooks       []func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error)
+	history     []CodeNavServiceGetClosestDumpsForBlobFuncCall
+	mutex       sync.Mutex
+}
+
+// GetClosestDumpsForBlob delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetClosestDumpsForBlob(v0 context.Context, v1 int, v2 string, v3 string, v4 bool, v5 string) ([]shared1.Dump, error) {
+	r0, r1 := m.GetClosestDumpsForBlobFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.GetClosestDumpsForBlobFunc.appendCall(CodeNavServiceGetClosestDumpsForBlobFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetClosestDumpsForBlob method of the parent MockCodeNavService instance
+// is invoked and the hook queue is empty.
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) SetDefaultHook(hook func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetClosestDumpsForBlob method of the parent MockCodeNavService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) PushHook(hook func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) SetDefaultReturn(r0 []shared1.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) PushReturn(r0 []shared1.Dump, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) nextHook() func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) appendCall(r0 CodeNavServiceGetClosestDumpsForBlobFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeNavServiceGetClosestDumpsForBlobFuncCall objects describing the
+// invocations of this function.
+func (f *CodeNavServiceGetClosestDumpsForBlobFunc) History() []CodeNavServiceGetClosestDumpsForBlobFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetClosestDumpsForBlobFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetClosestDumpsForBlobFuncCall is an object that describes
+// an invocation of method GetClosestDumpsForBlob on an instance of
+// MockCodeNavService.
+type CodeNavServiceGetClosestDumpsForBlobFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetClosestDumpsForBlobFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetClosestDumpsForBlobFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeNavServiceGetDumpsByIDsFunc describes the behavior when the
+// GetDumpsByIDs method of the parent MockCodeNavService instance is
+// invoked.
+type CodeNavServiceGetDumpsByIDsFunc struct {
+	defaultHook func(context.Context, []int) ([]shared1.Dump, error)
+	hooks       []func(context.Context, []int) ([]shared1.Dump, error)
+	history     []CodeNavServiceGetDumpsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDumpsByIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeNavService) GetDumpsByIDs(v0 context.Context, v1 []int) ([]shared1.Dump, error) {
+	r0, r1 := m.GetDumpsByIDsFunc.nextHook()(v0, v1)
+	m.GetDumpsByIDsFunc.appendCall(CodeNavServiceGetDumpsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDumpsByIDs method
+// of the parent MockCodeNavService instance is invoked and the hook queue
+// is empty.
+func (f *CodeNavServiceGetDumpsByIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]shared1.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDumpsByIDs method of the parent MockCodeNavService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeNavServiceGetDumpsByIDsFunc) PushHook(hook func(context.Context, []int) ([]shared1.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeNavServiceGetDumpsByIDsFunc) SetDefaultReturn(r0 []shared1.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) ([]shared1.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeNavServiceGetDumpsByIDsFunc) PushReturn(r0 []shared1.Dump, r1 error) {
+	f.PushHook(func(context.Context, []int) ([]shared1.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeNavServiceGetDumpsByIDsFunc) nextHook() func(context.Context, []int) ([]shared1.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeNavServiceGetDumpsByIDsFunc) appendCall(r0 CodeNavServiceGetDumpsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeNavServiceGetDumpsByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeNavServiceGetDumpsByIDsFunc) History() []CodeNavServiceGetDumpsByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeNavServiceGetDumpsByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeNavServiceGetDumpsByIDsFuncCall is an object that describes an
+// invocation of method GetDumpsByIDs on an instance of MockCodeNavService.
+type CodeNavServiceGetDumpsByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeNavServiceGetDumpsByIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeNavServiceGetDumpsByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/documents) used for
+// unit testing.
+type MockGitserverClient struct {
+	// CommitsExistFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitsExist.
+	CommitsExistFunc *GitserverClientCommitsExistFunc
+	// DiffFileRevisionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiffFileRevisions.
+	DiffFileRevisionsFunc *GitserverClientDiffFileRevisionsFunc
+	// DiffPathFunc is an instance of a mock function object controlling the
+	// behavior of the method DiffPath.
+	DiffPathFunc *GitserverClientDiffPathFunc
+	// RenamedFilesFunc is an instance of a mock function object controlling
+	// the behavior of the method RenamedFiles.
+	RenamedFilesFunc *GitserverClientRenamedFilesFunc
+}
+
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: func(context.Context, []gitserver.RepositoryCommit) (r0 []bool, r1 error) {
+				return
+			},
+		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) (r0 []*diff.Hunk, r1 error) {
+				return
+			},
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []*diff.Hunk, r1 error) {
+				return
+			},
+		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []gitserver.RenamedFile, r1 error) {
 				return
 			},
 		},
 	}
 }
 
-// NewStrictMockStore creates a new mock of the Store interface. All methods
-// panic on invocation, unless overwritten.
-func NewStrictMockStore() *MockStore {
-	return &MockStore{
-		ListFunc: &StoreListFunc{
-			defaultHook: func(context.Context, store.ListOpts) ([]shared.Document, error) {
-				panic("unexpected invocation of MockStore.List")
+// NewStrictMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
+				panic("unexpected invocation of MockGitserverClient.CommitsExist")
+			},
+		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+				panic("unexpected invocation of MockGitserverClient.DiffFileRevisions")
+			},
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+				panic("unexpected invocation of MockGitserverClient.DiffPath")
+			},
+		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+				panic("unexpected invocation of MockGitserverClient.RenamedFiles")
 			},
 		},
 	}
 }
 
-// NewMockStoreFrom creates a new mock of the MockStore interface. All
-// methods delegate to the given implementation, unless overwritten.
-func NewMockStoreFrom(i store.Store) *MockStore {
-	return &MockStore{
-		ListFunc: &StoreListFunc{
-			defaultHook: i.List,
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitsExistFunc: &GitserverClientCommitsExistFunc{
+			defaultHook: i.CommitsExist,
+		},
+		DiffFileRevisionsFunc: &GitserverClientDiffFileRevisionsFunc{
+			defaultHook: i.DiffFileRevisions,
+		},
+		DiffPathFunc: &GitserverClientDiffPathFunc{
+			defaultHook: i.DiffPath,
+		},
+		RenamedFilesFunc: &GitserverClientRenamedFilesFunc{
+			defaultHook: i.RenamedFiles,
 		},
 	}
 }
 
-// StoreListFunc describes the behavior when the List method of the parent
-// MockStore instance is invoked.
-type StoreListFunc struct {
-	defaultHook func(context.Context, store.ListOpts) ([]shared.Document, error)
-	hooks       []func(context.Context, store.ListOpts) ([]shared.Document, error)
-	history     []StoreListFuncCall
+// GitserverClientCommitsExistFunc describes the behavior when the
+// CommitsExist method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientCommitsExistFunc struct {
+	defaultHook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
+	hooks       []func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)
+	history     []GitserverClientCommitsExistFuncCall
 	mutex       sync.Mutex
 }
 
-// List delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockStore) List(v0 context.Context, v1 store.ListOpts) ([]shared.Document, error) {
-	r0, r1 := m.ListFunc.nextHook()(v0, v1)
-	m.ListFunc.appendCall(StoreListFuncCall{v0, v1, r0, r1})
+// CommitsExist delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) CommitsExist(v0 context.Context, v1 []gitserver.RepositoryCommit) ([]bool, error) {
+	r0, r1 := m.CommitsExistFunc.nextHook()(v0, v1)
+	m.CommitsExistFunc.appendCall(GitserverClientCommitsExistFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the List method of the
-// parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreListFunc) SetDefaultHook(hook func(context.Context, store.ListOpts) ([]shared.Document, error)) {
+// SetDefaultHook sets function that is called when the CommitsExist method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientCommitsExistFunc) SetDefaultHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// List method of the parent MockStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *StoreListFunc) PushHook(hook func(context.Context, store.ListOpts) ([]shared.Document, error)) {
+// CommitsExist method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientCommitsExistFunc) PushHook(hook func(context.Context, []gitserver.RepositoryCommit) ([]bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -93,20 +871,20 @@ func (f *StoreListFunc) PushHook(hook func(context.Context, store.ListOpts) ([]s
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreListFunc) SetDefaultReturn(r0 []shared.Document, r1 error) {
-	f.SetDefaultHook(func(context.Context, store.ListOpts) ([]shared.Document, error) {
+func (f *GitserverClientCommitsExistFunc) SetDefaultReturn(r0 []bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreListFunc) PushReturn(r0 []shared.Document, r1 error) {
-	f.PushHook(func(context.Context, store.ListOpts) ([]shared.Document, error) {
+func (f *GitserverClientCommitsExistFunc) PushReturn(r0 []bool, r1 error) {
+	f.PushHook(func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreListFunc) nextHook() func(context.Context, store.ListOpts) ([]shared.Document, error) {
+func (f *GitserverClientCommitsExistFunc) nextHook() func(context.Context, []gitserver.RepositoryCommit) ([]bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -119,35 +897,35 @@ func (f *StoreListFunc) nextHook() func(context.Context, store.ListOpts) ([]shar
 	return hook
 }
 
-func (f *StoreListFunc) appendCall(r0 StoreListFuncCall) {
+func (f *GitserverClientCommitsExistFunc) appendCall(r0 GitserverClientCommitsExistFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreListFuncCall objects describing the
-// invocations of this function.
-func (f *StoreListFunc) History() []StoreListFuncCall {
+// History returns a sequence of GitserverClientCommitsExistFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientCommitsExistFunc) History() []GitserverClientCommitsExistFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreListFuncCall, len(f.history))
+	history := make([]GitserverClientCommitsExistFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreListFuncCall is an object that describes an invocation of method
-// List on an instance of MockStore.
-type StoreListFuncCall struct {
+// GitserverClientCommitsExistFuncCall is an object that describes an
+// invocation of method CommitsExist on an instance of MockGitserverClient.
+type GitserverClientCommitsExistFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 store.ListOpts
+	Arg1 []gitserver.RepositoryCommit
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared.Document
+	Result0 []bool
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -155,12 +933,379 @@ type StoreListFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreListFuncCall) Args() []interface{} {
+func (c GitserverClientCommitsExistFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreListFuncCall) Results() []interface{} {
+func (c GitserverClientCommitsExistFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientDiffFileRevisionsFunc describes the behavior when the
+// DiffFileRevisions method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientDiffFileRevisionsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	history     []GitserverClientDiffFileRevisionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffFileRevisions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverClient) DiffFileRevisions(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string, v6 string) ([]*diff.Hunk, error) {
+	r0, r1 := m.DiffFileRevisionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.DiffFileRevisionsFunc.appendCall(GitserverClientDiffFileRevisionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffFileRevisions
+// method of the parent MockGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *GitserverClientDiffFileRevisionsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffFileRevisions method of the parent MockGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverClientDiffFileRevisionsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientDiffFileRevisionsFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientDiffFileRevisionsFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientDiffFileRevisionsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientDiffFileRevisionsFunc) appendCall(r0 GitserverClientDiffFileRevisionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientDiffFileRevisionsFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverClientDiffFileRevisionsFunc) History() []GitserverClientDiffFileRevisionsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientDiffFileRevisionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientDiffFileRevisionsFuncCall is an object that describes an
+// invocation of method DiffFileRevisions on an instance of
+// MockGitserverClient.
+type GitserverClientDiffFileRevisionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*diff.Hunk
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientDiffFileRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientDiffFileRevisionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientDiffPathFunc describes the behavior when the DiffPath
+// method of the parent MockGitserverClient instance is invoked.
+type GitserverClientDiffPathFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)
+	history     []GitserverClientDiffPathFuncCall
+	mutex       sync.Mutex
+}
+
+// DiffPath delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) DiffPath(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]*diff.Hunk, error) {
+	r0, r1 := m.DiffPathFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.DiffPathFunc.appendCall(GitserverClientDiffPathFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiffPath method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientDiffPathFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiffPath method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientDiffPathFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientDiffPathFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientDiffPathFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientDiffPathFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientDiffPathFunc) appendCall(r0 GitserverClientDiffPathFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientDiffPathFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientDiffPathFunc) History() []GitserverClientDiffPathFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientDiffPathFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientDiffPathFuncCall is an object that describes an invocation
+// of method DiffPath on an instance of MockGitserverClient.
+type GitserverClientDiffPathFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*diff.Hunk
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientDiffPathFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientDiffPathFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverClientRenamedFilesFunc describes the behavior when the
+// RenamedFiles method of the parent MockGitserverClient instance is
+// invoked.
+type GitserverClientRenamedFilesFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)
+	history     []GitserverClientRenamedFilesFuncCall
+	mutex       sync.Mutex
+}
+
+// RenamedFiles delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverClient) RenamedFiles(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]gitserver.RenamedFile, error) {
+	r0, r1 := m.RenamedFilesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.RenamedFilesFunc.appendCall(GitserverClientRenamedFilesFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RenamedFiles method
+// of the parent MockGitserverClient instance is invoked and the hook queue
+// is empty.
+func (f *GitserverClientRenamedFilesFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RenamedFiles method of the parent MockGitserverClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverClientRenamedFilesFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverClientRenamedFilesFunc) SetDefaultReturn(r0 []gitserver.RenamedFile, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverClientRenamedFilesFunc) PushReturn(r0 []gitserver.RenamedFile, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientRenamedFilesFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]gitserver.RenamedFile, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientRenamedFilesFunc) appendCall(r0 GitserverClientRenamedFilesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientRenamedFilesFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientRenamedFilesFunc) History() []GitserverClientRenamedFilesFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientRenamedFilesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientRenamedFilesFuncCall is an object that describes an
+// invocation of method RenamedFiles on an instance of MockGitserverClient.
+type GitserverClientRenamedFilesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gitserver.RenamedFile
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientRenamedFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientRenamedFilesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
@@ -2,34 +2,145 @@ package documents
 
 import (
 	"context"
+	"strings"
 
-	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/store"
+	traceLog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type Service struct {
-	documentsStore store.Store
-	operations     *operations
+	lsifstore     lsifstore.LsifStore
+	codenavSvc    CodeNavService
+	gitserver     GitserverClient
+	hunkCacheSize int
+	operations    *operations
 }
 
-func newService(documentsStore store.Store, observationContext *observation.Context) *Service {
+func newService(lsifstore lsifstore.LsifStore, codenavSvc CodeNavService, gitserver GitserverClient, hunkCacheSize int, observationContext *observation.Context) *Service {
 	return &Service{
-		documentsStore: documentsStore,
-		operations:     newOperations(observationContext),
+		lsifstore:     lsifstore,
+		codenavSvc:    codenavSvc,
+		gitserver:     gitserver,
+		hunkCacheSize: hunkCacheSize,
+		operations:    newOperations(observationContext),
 	}
 }
 
 type Document = shared.Document
 
-type DocumentOpts struct{}
+type DocumentOpts struct {
+	Repo   *types.Repo
+	Commit string
+	Path   string
+
+	// ExactPath and Indexer restrict the uploads used to serve the document,
+	// as for the other code navigation requests of a blob.
+	ExactPath bool
+	Indexer   string
+}
 
+// Document returns the symbol occurrences of the given file, as indexed by each of the uploads
+// that can serve code intelligence requests for it. The ranges of the occurrences are adjusted
+// to the requested commit, and occurrences that cannot be adjusted are omitted. No documents are
+// returned for a file the actor cannot read.
 func (s *Service) Document(ctx context.Context, opts DocumentOpts) (documents []Document, err error) {
-	ctx, _, endObservation := s.operations.document.With(ctx, &err, observation.Args{})
+	ctx, trace, endObservation := s.operations.document.With(ctx, &err, observation.Args{LogFields: []traceLog.Field{
+		traceLog.Int("repositoryID", int(opts.Repo.ID)),
+		traceLog.String("commit", opts.Commit),
+		traceLog.String("path", opts.Path),
+		traceLog.Bool("exactPath", opts.ExactPath),
+		traceLog.String("indexer", opts.Indexer),
+	}})
 	defer endObservation(1, observation.Args{})
 
-	// To be implemented in https://github.com/sourcegraph/sourcegraph/issues/33373
-	_, _ = s.documentsStore.List(ctx, store.ListOpts{})
-	return nil, errors.Newf("unimplemented: documents.Document")
+	checker := authz.DefaultSubRepoPermsChecker
+	if authz.SubRepoEnabled(checker) {
+		// Respond as if the file had no precise code intelligence rather than revealing that it exists
+		if include, err := authz.FilterActorPath(ctx, checker, actor.FromContext(ctx), opts.Repo.Name, opts.Path); err != nil {
+			return nil, err
+		} else if !include {
+			return nil, nil
+		}
+	}
+
+	uploads, err := s.codenavSvc.GetClosestDumpsForBlob(ctx, int(opts.Repo.ID), opts.Commit, opts.Path, opts.ExactPath, opts.Indexer)
+	if err != nil {
+		return nil, errors.Wrap(err, "codenav.GetClosestDumpsForBlob")
+	}
+	trace.Log(traceLog.Int("numUploads", len(uploads)))
+	if len(uploads) == 0 {
+		return nil, nil
+	}
+
+	requestState := codenav.NewRequestState(uploads, checker, s.gitserver, opts.Repo, opts.Commit, opts.Path, 0, s.hunkCacheSize)
+	translator := requestState.GitTreeTranslator
+
+	for _, upload := range uploads {
+		// The file may have been renamed since the upload's commit
+		uploadPath, ok, err := translator.GetTargetCommitPathFromSourcePath(ctx, upload.Commit, opts.Path, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitTreeTranslator.GetTargetCommitPathFromSourcePath")
+		}
+		if !ok {
+			continue
+		}
+
+		document, exists, err := s.lsifstore.GetDocument(ctx, upload.ID, strings.TrimPrefix(uploadPath, upload.Root))
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifstore.GetDocument")
+		}
+		if !exists {
+			continue
+		}
+
+		ranges := make([]codenavshared.Range, 0, len(document.Occurrences))
+		for _, occurrence := range document.Occurrences {
+			ranges = append(ranges, toCodeNavRange(occurrence.Range))
+		}
+		_, adjustedRanges, oks, err := translator.GetTargetCommitRangesFromSourceRanges(ctx, upload.Commit, uploadPath, ranges, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitTreeTranslator.GetTargetCommitRangesFromSourceRanges")
+		}
+
+		occurrences := document.Occurrences[:0]
+		for i, occurrence := range document.Occurrences {
+			if !oks[i] {
+				continue
+			}
+
+			occurrence.Range = fromCodeNavRange(adjustedRanges[i])
+			occurrences = append(occurrences, occurrence)
+		}
+
+		document.Commit = opts.Commit
+		document.Path = opts.Path
+		document.Occurrences = occurrences
+		documents = append(documents, document)
+	}
+	trace.Log(traceLog.Int("numDocuments", len(documents)))
+
+	return documents, nil
+}
+
+func toCodeNavRange(r shared.Range) codenavshared.Range {
+	return codenavshared.Range{
+		Start: codenavshared.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   codenavshared.Position{Line: r.End.Line, Character: r.End.Character},
+	}
+}
+
+func fromCodeNavRange(r codenavshared.Range) shared.Range {
+	return shared.Range{
+		Start: shared.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   shared.Position{Line: r.End.Line, Character: r.End.Character},
+	}
 }
@@ -0,0 +1,326 @@
+package documents
+
+import (
+	"context"
+	"sort"
+
+	traceLog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type SemanticDiff = shared.SemanticDiff
+
+type SemanticDiffOpts struct {
+	// Limit is the maximum number of symbols read from each upload. The symbols are compared
+	// in pages ordered by scheme and identifier.
+	Limit int
+
+	// After is the key of the last symbol compared by the previous page, if any.
+	After *shared.SymbolKey
+
+	// BaseUploadID and HeadUploadID restrict a comparison of commits to the given pair of
+	// uploads. Each pair of uploads is paged separately, so they must be set along with After
+	// when continuing a comparison of commits.
+	BaseUploadID int
+	HeadUploadID int
+}
+
+// SemanticDiff compares the symbols defined and referenced by the given uploads. Symbols are
+// matched by the scheme and identifier of their moniker, so only symbols with export monikers
+// take part in the comparison. If either upload does not exist, a false-valued flag is returned.
+func (s *Service) SemanticDiff(ctx context.Context, baseUploadID, headUploadID int, opts SemanticDiffOpts) (_ SemanticDiff, _ bool, err error) {
+	ctx, _, endObservation := s.operations.semanticDiff.With(ctx, &err, observation.Args{LogFields: []traceLog.Field{
+		traceLog.Int("baseUploadID", baseUploadID),
+		traceLog.Int("headUploadID", headUploadID),
+		traceLog.Int("limit", opts.Limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	uploads, err := s.codenavSvc.GetDumpsByIDs(ctx, []int{baseUploadID, headUploadID})
+	if err != nil {
+		return SemanticDiff{}, false, errors.Wrap(err, "codenav.GetDumpsByIDs")
+	}
+
+	uploadsByID := make(map[int]codenavshared.Dump, len(uploads))
+	for _, upload := range uploads {
+		uploadsByID[upload.ID] = upload
+	}
+	base, ok := uploadsByID[baseUploadID]
+	if !ok {
+		return SemanticDiff{}, false, nil
+	}
+	head, ok := uploadsByID[headUploadID]
+	if !ok {
+		return SemanticDiff{}, false, nil
+	}
+
+	diff, err := s.semanticDiff(ctx, base, head, opts)
+	if err != nil {
+		return SemanticDiff{}, false, err
+	}
+
+	return diff, true, nil
+}
+
+// CommitSemanticDiff compares the precise code intelligence visible from two commits of the
+// given repository. Each upload visible from the head commit is compared with the upload of
+// the same root and indexer visible from the base commit. Uploads without a counterpart are
+// not compared. Each pair of uploads is paged separately: the first page of every pair is
+// returned, and the following pages are requested for one pair at a time.
+func (s *Service) CommitSemanticDiff(ctx context.Context, repositoryID int, baseCommit, headCommit string, opts SemanticDiffOpts) (_ []SemanticDiff, err error) {
+	ctx, trace, endObservation := s.operations.commitSemanticDiff.With(ctx, &err, observation.Args{LogFields: []traceLog.Field{
+		traceLog.Int("repositoryID", repositoryID),
+		traceLog.String("baseCommit", baseCommit),
+		traceLog.String("headCommit", headCommit),
+		traceLog.Int("limit", opts.Limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if opts.After != nil && (opts.BaseUploadID == 0 || opts.HeadUploadID == 0) {
+		return nil, errors.New("continuing a semantic diff of commits requires the compared uploads")
+	}
+
+	baseUploads, err := s.codenavSvc.GetClosestDumpsForBlob(ctx, repositoryID, baseCommit, "", false, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "codenav.GetClosestDumpsForBlob")
+	}
+	headUploads, err := s.codenavSvc.GetClosestDumpsForBlob(ctx, repositoryID, headCommit, "", false, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "codenav.GetClosestDumpsForBlob")
+	}
+	trace.Log(
+		traceLog.Int("numBaseUploads", len(baseUploads)),
+		traceLog.Int("numHeadUploads", len(headUploads)),
+	)
+
+	type uploadKey struct{ root, indexer string }
+	baseUploadsByKey := make(map[uploadKey]codenavshared.Dump, len(baseUploads))
+	for _, upload := range baseUploads {
+		key := uploadKey{upload.Root, upload.Indexer}
+		// Prefer the first (closest) upload for each root and indexer
+		if _, ok := baseUploadsByKey[key]; !ok {
+			baseUploadsByKey[key] = upload
+		}
+	}
+
+	var diffs []SemanticDiff
+	seen := map[uploadKey]struct{}{}
+	for _, head := range headUploads {
+		key := uploadKey{head.Root, head.Indexer}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		base, ok := baseUploadsByKey[key]
+		if !ok {
+			continue
+		}
+		if opts.HeadUploadID != 0 && (base.ID != opts.BaseUploadID || head.ID != opts.HeadUploadID) {
+			continue
+		}
+
+		diff, err := s.semanticDiff(ctx, base, head, opts)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	trace.Log(traceLog.Int("numDiffs", len(diffs)))
+
+	return diffs, nil
+}
+
+// semanticDiff compares a page of the symbols of the given uploads. The paths of the reported
+// locations are relative to the repository root, and locations the actor cannot read are omitted.
+func (s *Service) semanticDiff(ctx context.Context, base, head codenavshared.Dump, opts SemanticDiffOpts) (SemanticDiff, error) {
+	baseDefinitions, baseNext, err := s.lsifstore.GetDefinitionSymbols(ctx, base.ID, opts.After, opts.Limit)
+	if err != nil {
+		return SemanticDiff{}, errors.Wrap(err, "lsifstore.GetDefinitionSymbols")
+	}
+	headDefinitions, headNext, err := s.lsifstore.GetDefinitionSymbols(ctx, head.ID, opts.After, opts.Limit)
+	if err != nil {
+		return SemanticDiff{}, errors.Wrap(err, "lsifstore.GetDefinitionSymbols")
+	}
+	headReferences, referencesNext, err := s.lsifstore.GetReferenceSymbols(ctx, head.ID, opts.After, opts.Limit)
+	if err != nil {
+		return SemanticDiff{}, errors.Wrap(err, "lsifstore.GetReferenceSymbols")
+	}
+
+	// Each of the lists may have been cut off by the limit at a different symbol. Only the
+	// symbols up to the first cut off can be compared, the next page continues from there.
+	var next *shared.SymbolKey
+	for _, key := range []*shared.SymbolKey{baseNext, headNext, referencesNext} {
+		if key != nil && (next == nil || key.Less(*next)) {
+			next = key
+		}
+	}
+	if next != nil {
+		baseDefinitions = truncateDefinitions(baseDefinitions, *next)
+		headDefinitions = truncateDefinitions(headDefinitions, *next)
+		headReferences = truncateReferences(headReferences, *next)
+	}
+
+	for i := range baseDefinitions {
+		baseDefinitions[i].Location.Path = base.Root + baseDefinitions[i].Location.Path
+	}
+	for i := range headDefinitions {
+		headDefinitions[i].Location.Path = head.Root + headDefinitions[i].Location.Path
+	}
+	for i := range headReferences {
+		headReferences[i].Location.Path = head.Root + headReferences[i].Location.Path
+	}
+
+	diff := diffSymbols(baseDefinitions, headDefinitions, headReferences)
+	diff.BaseUploadID = base.ID
+	diff.HeadUploadID = head.ID
+	diff.Next = next
+
+	if err := s.filterSemanticDiff(ctx, &diff, api.RepoName(base.RepositoryName), api.RepoName(head.RepositoryName)); err != nil {
+		return SemanticDiff{}, err
+	}
+
+	return diff, nil
+}
+
+// filterSemanticDiff removes the symbols and references whose paths the actor cannot read.
+func (s *Service) filterSemanticDiff(ctx context.Context, diff *SemanticDiff, baseRepo, headRepo api.RepoName) error {
+	checker := authz.DefaultSubRepoPermsChecker
+	if !authz.SubRepoEnabled(checker) {
+		return nil
+	}
+	a := actor.FromContext(ctx)
+
+	filterSymbols := func(repo api.RepoName, symbols []shared.Symbol) ([]shared.Symbol, error) {
+		filtered := symbols[:0]
+		for _, symbol := range symbols {
+			if include, err := authz.FilterActorPath(ctx, checker, a, repo, symbol.Location.Path); err != nil {
+				return nil, err
+			} else if include {
+				filtered = append(filtered, symbol)
+			}
+		}
+		return filtered, nil
+	}
+
+	var err error
+	if diff.AddedDefinitions, err = filterSymbols(headRepo, diff.AddedDefinitions); err != nil {
+		return err
+	}
+	if diff.RemovedDefinitions, err = filterSymbols(baseRepo, diff.RemovedDefinitions); err != nil {
+		return err
+	}
+
+	changes := diff.ChangedDefinitions[:0]
+	for _, change := range diff.ChangedDefinitions {
+		includeBase, err := authz.FilterActorPath(ctx, checker, a, baseRepo, change.Base.Location.Path)
+		if err != nil {
+			return err
+		}
+		includeHead, err := authz.FilterActorPath(ctx, checker, a, headRepo, change.Head.Location.Path)
+		if err != nil {
+			return err
+		}
+		if includeBase && includeHead {
+			changes = append(changes, change)
+		}
+	}
+	diff.ChangedDefinitions = changes
+
+	references := diff.BrokenReferences[:0]
+	for _, reference := range diff.BrokenReferences {
+		if include, err := authz.FilterActorPath(ctx, checker, a, headRepo, reference.Location.Path); err != nil {
+			return err
+		} else if include {
+			references = append(references, reference)
+		}
+	}
+	diff.BrokenReferences = references
+
+	return nil
+}
+
+// truncateDefinitions removes the symbols ordered after the given key. The symbols must be ordered
+// by SymbolKey.Less, as returned by the symbols queries ordering by the "C" collation.
+func truncateDefinitions(symbols []shared.Symbol, last shared.SymbolKey) []shared.Symbol {
+	n := sort.Search(len(symbols), func(i int) bool {
+		return last.Less(symbolKey(symbols[i]))
+	})
+	return symbols[:n]
+}
+
+// truncateReferences removes the references to symbols ordered after the given key.
+func truncateReferences(references []shared.SymbolReference, last shared.SymbolKey) []shared.SymbolReference {
+	n := sort.Search(len(references), func(i int) bool {
+		return last.Less(referenceKey(references[i]))
+	})
+	return references[:n]
+}
+
+// diffSymbols compares the definitions of two uploads and determines which references of the
+// head upload refer to definitions that have been removed.
+func diffSymbols(baseDefinitions, headDefinitions []shared.Symbol, headReferences []shared.SymbolReference) (diff SemanticDiff) {
+	baseByKey := make(map[shared.SymbolKey]shared.Symbol, len(baseDefinitions))
+	for _, symbol := range baseDefinitions {
+		baseByKey[symbolKey(symbol)] = symbol
+	}
+	headByKey := make(map[shared.SymbolKey]shared.Symbol, len(headDefinitions))
+	for _, symbol := range headDefinitions {
+		headByKey[symbolKey(symbol)] = symbol
+	}
+
+	for _, symbol := range headDefinitions {
+		baseSymbol, ok := baseByKey[symbolKey(symbol)]
+		if !ok {
+			diff.AddedDefinitions = append(diff.AddedDefinitions, symbol)
+		} else if baseSymbol.Hover != symbol.Hover {
+			diff.ChangedDefinitions = append(diff.ChangedDefinitions, shared.SymbolChange{Base: baseSymbol, Head: symbol})
+		}
+	}
+	for _, symbol := range baseDefinitions {
+		if _, ok := headByKey[symbolKey(symbol)]; !ok {
+			diff.RemovedDefinitions = append(diff.RemovedDefinitions, symbol)
+		}
+	}
+	for _, reference := range headReferences {
+		key := referenceKey(reference)
+		if _, ok := headByKey[key]; ok {
+			continue
+		}
+		if _, ok := baseByKey[key]; ok {
+			diff.BrokenReferences = append(diff.BrokenReferences, reference)
+		}
+	}
+
+	sortSymbols(diff.AddedDefinitions)
+	sortSymbols(diff.RemovedDefinitions)
+	sort.Slice(diff.ChangedDefinitions, func(i, j int) bool {
+		return compareSymbols(diff.ChangedDefinitions[i].Head, diff.ChangedDefinitions[j].Head)
+	})
+
+	return diff
+}
+
+func sortSymbols(symbols []shared.Symbol) {
+	sort.Slice(symbols, func(i, j int) bool { return compareSymbols(symbols[i], symbols[j]) })
+}
+
+// compareSymbols returns true if s1 is ordered before s2 by scheme and identifier.
+func compareSymbols(s1, s2 shared.Symbol) bool {
+	return symbolKey(s1).Less(symbolKey(s2))
+}
+
+func symbolKey(symbol shared.Symbol) shared.SymbolKey {
+	return shared.SymbolKey{Scheme: symbol.Scheme, Identifier: symbol.Identifier}
+}
+
+func referenceKey(reference shared.SymbolReference) shared.SymbolKey {
+	return shared.SymbolKey{Scheme: reference.Scheme, Identifier: reference.Identifier}
+}
@@ -0,0 +1,386 @@
+package documents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestSemanticDiff(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetDumpsByIDsFunc.SetDefaultReturn([]codenavshared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub/"},
+		{ID: 51, Commit: "c0ffee", Root: "sub/"},
+	}, nil)
+
+	location := func(path string, line int) shared.Location {
+		return shared.Location{Path: path, Range: shared.Range{
+			Start: shared.Position{Line: line, Character: 5},
+			End:   shared.Position{Line: line, Character: 8},
+		}}
+	}
+	symbol := func(identifier, path string, line int, hover string) shared.Symbol {
+		return shared.Symbol{Scheme: "gomod", Identifier: identifier, Location: location(path, line), Hover: hover}
+	}
+
+	mockLsifStore.GetDefinitionSymbolsFunc.SetDefaultHook(func(_ context.Context, bundleID int, _ *shared.SymbolKey, _ int) ([]shared.Symbol, *shared.SymbolKey, error) {
+		if bundleID == 50 {
+			return []shared.Symbol{
+				symbol("pkg:Bar", "bar.go", 3, "func Bar()"),
+				symbol("pkg:Baz", "baz.go", 4, "func Baz()"),
+				symbol("pkg:Foo", "foo.go", 10, "func Foo()"),
+			}, nil, nil
+		}
+
+		return []shared.Symbol{
+			symbol("pkg:Bar", "bar.go", 7, "func Bar()"),
+			symbol("pkg:Foo", "foo.go", 10, "func Foo(x int)"),
+			symbol("pkg:Quux", "quux.go", 1, "func Quux()"),
+		}, nil, nil
+	})
+	mockLsifStore.GetReferenceSymbolsFunc.SetDefaultReturn([]shared.SymbolReference{
+		{Scheme: "gomod", Identifier: "pkg:Bar", Location: location("main.go", 1)},
+		{Scheme: "gomod", Identifier: "pkg:Baz", Location: location("main.go", 2)},
+		{Scheme: "npm", Identifier: "left-pad", Location: location("main.go", 3)},
+	}, nil, nil)
+
+	diff, ok, err := svc.SemanticDiff(context.Background(), 50, 51, SemanticDiffOpts{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected uploads to exist")
+	}
+
+	expectedDiff := shared.SemanticDiff{
+		BaseUploadID:       50,
+		HeadUploadID:       51,
+		AddedDefinitions:   []shared.Symbol{symbol("pkg:Quux", "sub/quux.go", 1, "func Quux()")},
+		RemovedDefinitions: []shared.Symbol{symbol("pkg:Baz", "sub/baz.go", 4, "func Baz()")},
+		ChangedDefinitions: []shared.SymbolChange{
+			{
+				Base: symbol("pkg:Foo", "sub/foo.go", 10, "func Foo()"),
+				Head: symbol("pkg:Foo", "sub/foo.go", 10, "func Foo(x int)"),
+			},
+		},
+		BrokenReferences: []shared.SymbolReference{
+			{Scheme: "gomod", Identifier: "pkg:Baz", Location: location("sub/main.go", 2)},
+		},
+	}
+	if diff := cmp.Diff(expectedDiff, diff); diff != "" {
+		t.Errorf("unexpected semantic diff (-want +got):\n%s", diff)
+	}
+
+	if _, ok, err := svc.SemanticDiff(context.Background(), 50, 52, SemanticDiffOpts{Limit: 100}); err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	} else if ok {
+		t.Errorf("expected missing upload to be reported")
+	}
+}
+
+func TestCommitSemanticDiff(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetClosestDumpsForBlobFunc.SetDefaultHook(func(_ context.Context, _ int, commit, _ string, _ bool, _ string) ([]codenavshared.Dump, error) {
+		if commit == "base" {
+			return []codenavshared.Dump{
+				{ID: 50, Root: "", Indexer: "lsif-go"},
+				{ID: 51, Root: "web/", Indexer: "lsif-node"},
+			}, nil
+		}
+
+		return []codenavshared.Dump{
+			{ID: 60, Root: "", Indexer: "lsif-go"},
+			{ID: 61, Root: "web/", Indexer: "scip-typescript"},
+		}, nil
+	})
+
+	diffs, err := svc.CommitSemanticDiff(context.Background(), 42, "base", "head", SemanticDiffOpts{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+
+	var pairs [][2]int
+	for _, diff := range diffs {
+		pairs = append(pairs, [2]int{diff.BaseUploadID, diff.HeadUploadID})
+	}
+	if diff := cmp.Diff([][2]int{{50, 60}}, pairs); diff != "" {
+		t.Errorf("unexpected compared uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitSemanticDiffPages(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetClosestDumpsForBlobFunc.SetDefaultHook(func(_ context.Context, _ int, commit, _ string, _ bool, _ string) ([]codenavshared.Dump, error) {
+		if commit == "base" {
+			return []codenavshared.Dump{
+				{ID: 50, Root: "", Indexer: "lsif-go"},
+				{ID: 51, Root: "web/", Indexer: "scip-typescript"},
+			}, nil
+		}
+
+		return []codenavshared.Dump{
+			{ID: 60, Root: "", Indexer: "lsif-go"},
+			{ID: 61, Root: "web/", Indexer: "scip-typescript"},
+		}, nil
+	})
+
+	definitions := map[int][]string{
+		50: {"a", "b"},
+		51: {"a", "b", "c", "d"},
+		60: {"a", "b"},
+		61: {"a", "b", "c", "d"},
+	}
+	mockLsifStore.GetDefinitionSymbolsFunc.SetDefaultHook(func(_ context.Context, bundleID int, key *shared.SymbolKey, limit int) (page []shared.Symbol, next *shared.SymbolKey, _ error) {
+		for _, identifier := range definitions[bundleID] {
+			if key != nil && identifier <= key.Identifier {
+				continue
+			}
+			if len(page) == limit {
+				break
+			}
+			page = append(page, shared.Symbol{Scheme: "gomod", Identifier: identifier})
+			if len(page) == limit {
+				next = &shared.SymbolKey{Scheme: "gomod", Identifier: identifier}
+			}
+		}
+		return page, next, nil
+	})
+
+	diffs, err := svc.CommitSemanticDiff(context.Background(), 42, "base", "head", SemanticDiffOpts{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+	nexts := map[[2]int]*shared.SymbolKey{}
+	for _, diff := range diffs {
+		nexts[[2]int{diff.BaseUploadID, diff.HeadUploadID}] = diff.Next
+	}
+	expectedNexts := map[[2]int]*shared.SymbolKey{
+		{50, 60}: nil,
+		{51, 61}: {Scheme: "gomod", Identifier: "c"},
+	}
+	if diff := cmp.Diff(expectedNexts, nexts); diff != "" {
+		t.Errorf("unexpected cursors (-want +got):\n%s", diff)
+	}
+
+	diffs, err = svc.CommitSemanticDiff(context.Background(), 42, "base", "head", SemanticDiffOpts{
+		Limit:        3,
+		After:        nexts[[2]int{51, 61}],
+		BaseUploadID: 51,
+		HeadUploadID: 61,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+	if len(diffs) != 1 || diffs[0].BaseUploadID != 51 || diffs[0].HeadUploadID != 61 || diffs[0].Next != nil {
+		t.Errorf("expected the last page of a single pair of uploads, got %+v", diffs)
+	}
+
+	if _, err := svc.CommitSemanticDiff(context.Background(), 42, "base", "head", SemanticDiffOpts{Limit: 3, After: &shared.SymbolKey{}}); err == nil {
+		t.Errorf("expected an error continuing a comparison without the compared uploads")
+	}
+}
+
+func TestSemanticDiffPages(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetDumpsByIDsFunc.SetDefaultReturn([]codenavshared.Dump{{ID: 50}, {ID: 51}}, nil)
+
+	symbol := func(identifier, hover string) shared.Symbol {
+		return shared.Symbol{Scheme: "gomod", Identifier: identifier, Location: shared.Location{Path: identifier + ".go"}, Hover: hover}
+	}
+	reference := func(identifier string, line int) shared.SymbolReference {
+		return shared.SymbolReference{Scheme: "gomod", Identifier: identifier, Location: shared.Location{
+			Path:  "main.go",
+			Range: shared.Range{Start: shared.Position{Line: line}, End: shared.Position{Line: line}},
+		}}
+	}
+
+	definitions := map[int][]shared.Symbol{
+		50: {symbol("a", ""), symbol("b", ""), symbol("c", "func c()"), symbol("d", ""), symbol("f", "")},
+		51: {symbol("a", ""), symbol("c", "func c(x int)"), symbol("e", "")},
+	}
+	references := []shared.SymbolReference{
+		reference("b", 1), reference("b", 2), reference("d", 3), reference("e", 4), reference("f", 5), reference("f", 6),
+	}
+
+	// Definition monikers without locations are read by the symbols query but not returned
+	withoutLocations := map[string]struct{}{"b": {}, "c": {}}
+
+	after := func(key *shared.SymbolKey, scheme, identifier string) bool {
+		return key == nil || key.Less(shared.SymbolKey{Scheme: scheme, Identifier: identifier})
+	}
+	mockLsifStore.GetDefinitionSymbolsFunc.SetDefaultHook(func(_ context.Context, bundleID int, key *shared.SymbolKey, limit int) (page []shared.Symbol, next *shared.SymbolKey, _ error) {
+		read := 0
+		for _, symbol := range definitions[bundleID] {
+			if !after(key, symbol.Scheme, symbol.Identifier) || read == limit {
+				continue
+			}
+			if read++; read == limit {
+				next = &shared.SymbolKey{Scheme: symbol.Scheme, Identifier: symbol.Identifier}
+			}
+			if _, ok := withoutLocations[symbol.Identifier]; bundleID == 51 && ok {
+				continue
+			}
+			page = append(page, symbol)
+		}
+		return page, next, nil
+	})
+	mockLsifStore.GetReferenceSymbolsFunc.SetDefaultHook(func(_ context.Context, _ int, key *shared.SymbolKey, limit int) (page []shared.SymbolReference, next *shared.SymbolKey, _ error) {
+		monikers := map[string]struct{}{}
+		for _, reference := range references {
+			if !after(key, reference.Scheme, reference.Identifier) {
+				continue
+			}
+			if _, ok := monikers[reference.Identifier]; !ok {
+				if len(monikers) == limit {
+					break
+				}
+				monikers[reference.Identifier] = struct{}{}
+				if len(monikers) == limit {
+					next = &shared.SymbolKey{Scheme: reference.Scheme, Identifier: reference.Identifier}
+				}
+			}
+			page = append(page, reference)
+		}
+		return page, next, nil
+	})
+
+	expectedDiff, _, err := svc.SemanticDiff(context.Background(), 50, 51, SemanticDiffOpts{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+	if expectedDiff.Next != nil {
+		t.Fatalf("expected a single page")
+	}
+
+	for _, limit := range []int{1, 2, 3} {
+		var (
+			diff  = shared.SemanticDiff{BaseUploadID: 50, HeadUploadID: 51}
+			opts  = SemanticDiffOpts{Limit: limit}
+			pages = 0
+		)
+		for {
+			page, _, err := svc.SemanticDiff(context.Background(), 50, 51, opts)
+			if err != nil {
+				t.Fatalf("unexpected error computing semantic diff: %s", err)
+			}
+			diff.AddedDefinitions = append(diff.AddedDefinitions, page.AddedDefinitions...)
+			diff.RemovedDefinitions = append(diff.RemovedDefinitions, page.RemovedDefinitions...)
+			diff.ChangedDefinitions = append(diff.ChangedDefinitions, page.ChangedDefinitions...)
+			diff.BrokenReferences = append(diff.BrokenReferences, page.BrokenReferences...)
+
+			if pages++; page.Next == nil || pages > 10 {
+				break
+			}
+			opts.After = page.Next
+		}
+
+		if diff := cmp.Diff(expectedDiff, diff); diff != "" {
+			t.Errorf("unexpected semantic diff with limit %d (-want +got):\n%s", limit, diff)
+		}
+	}
+}
+
+func TestSemanticDiffSubRepoPermissions(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetDumpsByIDsFunc.SetDefaultReturn([]codenavshared.Dump{
+		{ID: 50, RepositoryName: "github.com/test/test"},
+		{ID: 51, RepositoryName: "github.com/test/test"},
+	}, nil)
+
+	symbol := func(identifier, path, hover string) shared.Symbol {
+		return shared.Symbol{Scheme: "gomod", Identifier: identifier, Location: shared.Location{Path: path}, Hover: hover}
+	}
+	mockLsifStore.GetDefinitionSymbolsFunc.SetDefaultHook(func(_ context.Context, bundleID int, _ *shared.SymbolKey, _ int) ([]shared.Symbol, *shared.SymbolKey, error) {
+		if bundleID == 50 {
+			return []shared.Symbol{
+				symbol("pkg:Bar", "secret/bar.go", "func Bar()"),
+				symbol("pkg:Baz", "baz.go", "func Baz()"),
+				symbol("pkg:Foo", "secret/foo.go", "func Foo()"),
+			}, nil, nil
+		}
+
+		return []shared.Symbol{
+			symbol("pkg:Foo", "secret/foo.go", "func Foo(x int)"),
+			symbol("pkg:Quux", "secret/quux.go", "func Quux()"),
+		}, nil, nil
+	})
+	mockLsifStore.GetReferenceSymbolsFunc.SetDefaultReturn([]shared.SymbolReference{
+		{Scheme: "gomod", Identifier: "pkg:Bar", Location: shared.Location{Path: "main.go"}},
+		{Scheme: "gomod", Identifier: "pkg:Baz", Location: shared.Location{Path: "secret/main.go"}},
+	}, nil, nil)
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if strings.HasPrefix(content.Path, "secret/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	authz.DefaultSubRepoPermsChecker = checker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = nil })
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	diff, _, err := svc.SemanticDiff(ctx, 50, 51, SemanticDiffOpts{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error computing semantic diff: %s", err)
+	}
+
+	expectedDiff := shared.SemanticDiff{
+		BaseUploadID:       50,
+		HeadUploadID:       51,
+		AddedDefinitions:   []shared.Symbol{},
+		RemovedDefinitions: []shared.Symbol{symbol("pkg:Baz", "baz.go", "func Baz()")},
+		ChangedDefinitions: []shared.SymbolChange{},
+		BrokenReferences: []shared.SymbolReference{
+			{Scheme: "gomod", Identifier: "pkg:Bar", Location: shared.Location{Path: "main.go"}},
+		},
+	}
+	if diff := cmp.Diff(expectedDiff, diff, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected semantic diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,158 @@
+package documents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	codenavshared "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/documents/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDocument(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetClosestDumpsForBlobFunc.PushReturn([]codenavshared.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub/"},
+		{ID: 51, Commit: "c0ffee", Root: ""},
+		{ID: 52, Commit: "deadbeef", Root: ""},
+	}, nil)
+
+	// Line 3 of the indexed commit was replaced by three lines in the requested commit
+	mockGitserverClient.DiffFileRevisionsFunc.SetDefaultReturn([]*diff.Hunk{
+		{OrigStartLine: 3, OrigLines: 1, NewStartLine: 3, NewLines: 3, Body: []byte("-b\n+x\n+y\n+z\n")},
+	}, nil)
+
+	newRange := func(line, startCharacter, endCharacter int) shared.Range {
+		return shared.Range{
+			Start: shared.Position{Line: line, Character: startCharacter},
+			End:   shared.Position{Line: line, Character: endCharacter},
+		}
+	}
+	definition := shared.Occurrence{
+		Range:       newRange(10, 5, 8),
+		SymbolRoles: shared.SymbolRoleDefinition,
+		Monikers:    []shared.Moniker{{Kind: "export", Scheme: "gomod", Identifier: "pkg:Foo"}},
+		HoverID:     "h1",
+	}
+	reference := shared.Occurrence{
+		Range:       newRange(2, 1, 4),
+		SymbolRoles: shared.SymbolRoleReference,
+		HoverID:     "h1",
+	}
+	mockLsifStore.GetDocumentFunc.SetDefaultHook(func(_ context.Context, bundleID int, path string) (shared.Document, bool, error) {
+		if bundleID == 52 {
+			return shared.Document{}, false, nil
+		}
+
+		return shared.Document{
+			UploadID:    bundleID,
+			Path:        path,
+			Occurrences: []shared.Occurrence{reference, definition},
+			Hovers:      map[string]string{"h1": "func Foo()"},
+		}, true, nil
+	})
+
+	documents, err := svc.Document(context.Background(), DocumentOpts{
+		Repo:   &types.Repo{ID: 42, Name: "github.com/test/test"},
+		Commit: "deadbeef",
+		Path:   "sub/main.go",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error querying document: %s", err)
+	}
+
+	adjustedDefinition := definition
+	adjustedDefinition.Range = newRange(12, 5, 8)
+
+	expectedDocuments := []shared.Document{
+		{
+			UploadID:    50,
+			Commit:      "deadbeef",
+			Path:        "sub/main.go",
+			Occurrences: []shared.Occurrence{reference, definition},
+			Hovers:      map[string]string{"h1": "func Foo()"},
+		},
+		{
+			UploadID:    51,
+			Commit:      "deadbeef",
+			Path:        "sub/main.go",
+			Occurrences: []shared.Occurrence{adjustedDefinition},
+			Hovers:      map[string]string{"h1": "func Foo()"},
+		},
+	}
+	if diff := cmp.Diff(expectedDocuments, documents); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetDocumentFunc.History(); len(history) != 3 {
+		t.Fatalf("unexpected number of document requests. want=%d have=%d", 3, len(history))
+	} else {
+		for i, expectedPath := range []string{"main.go", "sub/main.go", "sub/main.go"} {
+			if history[i].Arg2 != expectedPath {
+				t.Errorf("unexpected path for upload %d. want=%q have=%q", history[i].Arg1, expectedPath, history[i].Arg2)
+			}
+		}
+	}
+}
+
+func TestDocumentSubRepoPermissions(t *testing.T) {
+	// Set up mocks
+	mockLsifStore := NewMockLsifStore()
+	mockCodeNavSvc := NewMockCodeNavService()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Init service
+	svc := newService(mockLsifStore, mockCodeNavSvc, mockGitserverClient, 50, &observation.TestContext)
+
+	mockCodeNavSvc.GetClosestDumpsForBlobFunc.SetDefaultReturn([]codenavshared.Dump{{ID: 50, Commit: "deadbeef"}}, nil)
+	mockLsifStore.GetDocumentFunc.SetDefaultHook(func(_ context.Context, bundleID int, path string) (shared.Document, bool, error) {
+		return shared.Document{UploadID: bundleID, Path: path}, true, nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if strings.HasPrefix(content.Path, "secret/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	authz.DefaultSubRepoPermsChecker = checker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = nil })
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	repo := &types.Repo{ID: 42, Name: "github.com/test/test"}
+
+	documents, err := svc.Document(ctx, DocumentOpts{Repo: repo, Commit: "deadbeef", Path: "secret/main.go"})
+	if err != nil {
+		t.Fatalf("unexpected error querying document: %s", err)
+	}
+	if len(documents) != 0 {
+		t.Errorf("unexpected documents for an unreadable path. want=%d have=%d", 0, len(documents))
+	}
+	if history := mockCodeNavSvc.GetClosestDumpsForBlobFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected number of upload requests. want=%d have=%d", 0, len(history))
+	}
+
+	documents, err = svc.Document(ctx, DocumentOpts{Repo: repo, Commit: "deadbeef", Path: "main.go"})
+	if err != nil {
+		t.Fatalf("unexpected error querying document: %s", err)
+	}
+	if len(documents) != 1 {
+		t.Errorf("unexpected documents for a readable path. want=%d have=%d", 1, len(documents))
+	}
+}
@@ -1,5 +1,137 @@
 package shared
 
+// Document is the code intelligence data of a single file, as indexed by
+// a single upload.
 type Document struct {
-	Path string
+	// UploadID is the identifier of the upload that indexed the document.
+	UploadID int
+
+	// Commit and Path are the commit and path of the requested document. The
+	// ranges of occurrences are adjusted to this commit.
+	Commit string
+	Path   string
+
+	// Occurrences are the symbol occurrences of the document, ordered by
+	// their range.
+	Occurrences []Occurrence
+
+	// Hovers maps the hover identifiers of occurrences to their hover text.
+	Hovers map[string]string
+}
+
+// Occurrence is an occurrence of a symbol within a document.
+type Occurrence struct {
+	Range       Range
+	SymbolRoles SymbolRole
+	Monikers    []Moniker
+
+	// HoverID identifies the hover text of the symbol in the hovers of the
+	// containing document, or is empty if the symbol has no hover text.
+	HoverID string
+}
+
+// SymbolRole is a bitset of the roles of a symbol occurrence.
+type SymbolRole int
+
+const (
+	// SymbolRoleDefinition is set when the occurrence defines the symbol.
+	SymbolRoleDefinition SymbolRole = 1 << iota
+
+	// SymbolRoleReference is set when the occurrence refers to a symbol
+	// defined elsewhere.
+	SymbolRoleReference
+)
+
+// Moniker identifies a symbol across indexes.
+type Moniker struct {
+	Kind       string
+	Scheme     string
+	Identifier string
+}
+
+// Range is an inclusive bounds within a file.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// Position is a unique position within a file.
+type Position struct {
+	Line      int
+	Character int
+}
+
+// Location is a range within a file of an upload.
+type Location struct {
+	Path  string
+	Range Range
+}
+
+// Symbol is a symbol defined by an upload, identified by the scheme and
+// identifier of its moniker.
+type Symbol struct {
+	Scheme     string
+	Identifier string
+
+	// Location is the first definition of the symbol within the upload.
+	Location Location
+
+	// Hover is the hover text of the definition, or is empty if the symbol
+	// has no hover text.
+	Hover string
+}
+
+// SymbolKey identifies a symbol by the scheme and identifier of its moniker.
+type SymbolKey struct {
+	Scheme     string
+	Identifier string
+}
+
+// Less returns true if k is ordered before other by scheme and identifier. Strings are compared
+// byte-wise, as by the "C" collation in Postgres.
+func (k SymbolKey) Less(other SymbolKey) bool {
+	if k.Scheme != other.Scheme {
+		return k.Scheme < other.Scheme
+	}
+
+	return k.Identifier < other.Identifier
+}
+
+// SymbolReference is a reference to a moniker within an upload.
+type SymbolReference struct {
+	Scheme     string
+	Identifier string
+	Location   Location
+}
+
+// SemanticDiff is the difference between the symbols defined and referenced
+// by two uploads.
+type SemanticDiff struct {
+	BaseUploadID int
+	HeadUploadID int
+
+	// AddedDefinitions are the symbols defined by the head upload only.
+	AddedDefinitions []Symbol
+
+	// RemovedDefinitions are the symbols defined by the base upload only.
+	RemovedDefinitions []Symbol
+
+	// ChangedDefinitions are the symbols defined by both uploads whose hover
+	// text, and therefore usually their signature, differs.
+	ChangedDefinitions []SymbolChange
+
+	// BrokenReferences are the references of the head upload to symbols that
+	// were defined by the base upload but are no longer defined by the head
+	// upload.
+	BrokenReferences []SymbolReference
+
+	// Next is the key of the last symbol compared, from which the next page
+	// of the diff continues. It is nil if all symbols have been compared.
+	Next *SymbolKey
+}
+
+// SymbolChange pairs the base and head definitions of a changed symbol.
+type SymbolChange struct {
+	Base Symbol
+	Head Symbol
 }
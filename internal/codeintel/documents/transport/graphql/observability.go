@@ -8,7 +8,9 @@ import (
 )
 
 type operations struct {
-	document *observation.Operation
+	document           *observation.Operation
+	semanticDiff       *observation.Operation
+	commitSemanticDiff *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -28,6 +30,8 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		document: op("Document"),
+		document:           op("Document"),
+		semanticDiff:       op("SemanticDiff"),
+		commitSemanticDiff: op("CommitSemanticDiff"),
 	}
 }
@@ -3,9 +3,10 @@ package graphql
 import (
 	"context"
 
+	"github.com/opentracing/opentracing-go/log"
+
 	documents "github.com/sourcegraph/sourcegraph/internal/codeintel/documents"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
-	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type Resolver struct {
@@ -20,11 +21,39 @@ func newResolver(svc *documents.Service, observationContext *observation.Context
 	}
 }
 
-func (r *Resolver) Document(ctx context.Context, args struct{}) (_ any, err error) {
-	ctx, _, endObservation := r.operations.document.With(ctx, &err, observation.Args{})
+// Document returns the symbol occurrences of the requested file for each upload that can serve
+// code intelligence requests for it.
+func (r *Resolver) Document(ctx context.Context, opts documents.DocumentOpts) (_ []documents.Document, err error) {
+	ctx, _, endObservation := r.operations.document.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", int(opts.Repo.ID)),
+		log.String("commit", opts.Commit),
+		log.String("path", opts.Path),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return r.svc.Document(ctx, opts)
+}
+
+// SemanticDiff compares the symbols defined and referenced by the given uploads.
+func (r *Resolver) SemanticDiff(ctx context.Context, baseUploadID, headUploadID int, opts documents.SemanticDiffOpts) (_ documents.SemanticDiff, _ bool, err error) {
+	ctx, _, endObservation := r.operations.semanticDiff.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("baseUploadID", baseUploadID),
+		log.Int("headUploadID", headUploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return r.svc.SemanticDiff(ctx, baseUploadID, headUploadID, opts)
+}
+
+// CommitSemanticDiff compares the precise code intelligence visible from two commits of the
+// given repository.
+func (r *Resolver) CommitSemanticDiff(ctx context.Context, repositoryID int, baseCommit, headCommit string, opts documents.SemanticDiffOpts) (_ []documents.SemanticDiff, err error) {
+	ctx, _, endObservation := r.operations.commitSemanticDiff.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("baseCommit", baseCommit),
+		log.String("headCommit", headCommit),
+	}})
 	defer endObservation(1, observation.Args{})
 
-	// To be implemented in https://github.com/sourcegraph/sourcegraph/issues/33373
-	_, _ = ctx, args
-	return nil, errors.New("unimplemented: Document")
+	return r.svc.CommitSemanticDiff(ctx, repositoryID, baseCommit, headCommit, opts)
 }
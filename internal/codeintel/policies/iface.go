@@ -4,11 +4,14 @@ import (
 	"context"
 	"time"
 
+	uploadsShared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 )
 
 type UploadService interface {
 	GetCommitsVisibleToUpload(ctx context.Context, uploadID, limit int, token *string) (_ []string, nextToken *string, err error)
+	GetUploads(ctx context.Context, opts uploadsShared.GetUploadsOptions) (uploads []uploadsShared.Upload, totalCount int, err error)
+	GetUploadDataSizes(ctx context.Context, ids []int) (map[int]int64, error)
 }
 
 type GitserverClient interface {
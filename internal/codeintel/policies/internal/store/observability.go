@@ -17,6 +17,7 @@ type operations struct {
 
 	// Repositories
 	getRepoIDsByGlobPatterns    *observation.Operation
+	getRepoIDsWithUploads       *observation.Operation
 	updateReposMatchingPatterns *observation.Operation
 }
 
@@ -47,5 +48,6 @@ func newOperations(observationContext *observation.Context) *operations {
 		// Repositories
 		updateReposMatchingPatterns: op("UpdateReposMatchingPatterns"),
 		getRepoIDsByGlobPatterns:    op("GetRepoIDsByGlobPatterns"),
+		getRepoIDsWithUploads:       op("GetRepoIDsWithUploads"),
 	}
 }
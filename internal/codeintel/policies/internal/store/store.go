@@ -22,6 +22,7 @@ type Store interface {
 
 	// Repositories
 	GetRepoIDsByGlobPatterns(ctx context.Context, patterns []string, limit, offset int) (_ []int, _ int, err error)
+	GetRepoIDsWithUploads(ctx context.Context, repositoryIDs []int, patterns []string, limit, offset int) (_ []int, _ int, err error)
 	UpdateReposMatchingPatterns(ctx context.Context, patterns []string, policyID int, repositoryMatchLimit *int) (err error)
 }
 
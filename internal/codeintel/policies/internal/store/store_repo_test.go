@@ -90,6 +90,69 @@ func TestRepoIDsByGlobPatterns(t *testing.T) {
 	})
 }
 
+func TestGetRepoIDsWithUploads(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	insertRepo(t, db, 50, "Darth Vader")
+	insertRepo(t, db, 51, "Darth Venamis")
+	insertRepo(t, db, 52, "Darth Maul")
+	insertRepo(t, db, 53, "Anakin Skywalker")
+	insertRepo(t, db, 54, "Luke Skywalker")
+
+	for i, upload := range []struct {
+		repositoryID int
+		state        string
+		expired      bool
+	}{
+		{50, "completed", false},
+		{51, "completed", true},  // expired
+		{52, "errored", false},   // not completed
+		{53, "completed", false}, //
+		{54, "completed", false}, //
+		{54, "completed", false}, // duplicate repository
+	} {
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO lsif_uploads (id, commit, repository_id, state, expired, indexer, num_parts, uploaded_parts)
+			VALUES ($1, $2, $3, $4, $5, 'lsif-go', 0, '{}')
+		`, 100+i, fmt.Sprintf("%040d", i), upload.repositoryID, upload.state, upload.expired); err != nil {
+			t.Fatalf("unexpected error inserting upload: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		repositoryIDs         []int
+		patterns              []string
+		expectedRepositoryIDs []int
+	}{
+		{repositoryIDs: nil, patterns: nil, expectedRepositoryIDs: nil},                               // No scope
+		{repositoryIDs: nil, patterns: []string{"*"}, expectedRepositoryIDs: []int{50, 53, 54}},       // Wildcard
+		{repositoryIDs: nil, patterns: []string{"Darth*"}, expectedRepositoryIDs: []int{50}},          // Pattern
+		{repositoryIDs: []int{51, 53}, patterns: nil, expectedRepositoryIDs: []int{53}},               // Identifiers
+		{repositoryIDs: []int{50}, patterns: []string{"Luke*"}, expectedRepositoryIDs: []int{50, 54}}, // Both
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("repositoryIDs=%v patterns=%v", testCase.repositoryIDs, testCase.patterns)
+
+		t.Run(name, func(t *testing.T) {
+			repositoryIDs, totalCount, err := store.GetRepoIDsWithUploads(ctx, testCase.repositoryIDs, testCase.patterns, 10, 0)
+			if err != nil {
+				t.Fatalf("unexpected error fetching repository ids with uploads: %s", err)
+			}
+			if totalCount != len(testCase.expectedRepositoryIDs) {
+				t.Errorf("unexpected total count. want=%d have=%d", len(testCase.expectedRepositoryIDs), totalCount)
+			}
+
+			if diff := cmp.Diff(testCase.expectedRepositoryIDs, repositoryIDs); diff != "" {
+				t.Errorf("unexpected repository ids (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpdateReposMatchingPatterns(t *testing.T) {
 	ctx := context.Background()
 	logger := logtest.Scoped(t)
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -170,3 +171,77 @@ WHERE
 ORDER BY stars DESC NULLS LAST, id
 LIMIT %s OFFSET %s
 `
+
+// GetRepoIDsWithUploads returns a page of repository identifiers and a total count of repositories that
+// have a completed and unexpired upload and that are either in the given list of repository identifiers
+// or have a name matching one of the given patterns.
+func (s *store) GetRepoIDsWithUploads(ctx context.Context, repositoryIDs []int, patterns []string, limit, offset int) (_ []int, _ int, err error) {
+	ctx, _, endObservation := s.operations.getRepoIDsWithUploads.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numRepositoryIDs", len(repositoryIDs)),
+		log.String("patterns", strings.Join(patterns, ", ")),
+		log.Int("limit", limit),
+		log.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(repositoryIDs) == 0 && len(patterns) == 0 {
+		return nil, 0, nil
+	}
+
+	conds := make([]*sqlf.Query, 0, len(patterns)+1)
+	if len(repositoryIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("repo.id = ANY(%s)", pq.Array(repositoryIDs)))
+	}
+	for _, pattern := range patterns {
+		conds = append(conds, sqlf.Sprintf("lower(repo.name) LIKE %s", makeWildcardPattern(pattern)))
+	}
+
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, tx))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(repoIDsWithUploadsCountQuery, sqlf.Join(conds, "OR"), authzConds)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, err := basestore.ScanInts(tx.Query(ctx, sqlf.Sprintf(repoIDsWithUploadsQuery, sqlf.Join(conds, "OR"), authzConds, limit, offset)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ids, totalCount, nil
+}
+
+const repoIDsWithUploadsCountQuery = `
+-- source: internal/codeintel/policies/internal/store/store_repos.go:GetRepoIDsWithUploads
+SELECT COUNT(*)
+FROM repo
+WHERE
+	(%s) AND
+	repo.deleted_at IS NULL AND
+	repo.blocked IS NULL AND
+	EXISTS (SELECT 1 FROM lsif_uploads u WHERE u.repository_id = repo.id AND u.state = 'completed' AND NOT u.expired) AND
+	(%s)
+`
+
+const repoIDsWithUploadsQuery = `
+-- source: internal/codeintel/policies/internal/store/store_repos.go:GetRepoIDsWithUploads
+SELECT repo.id
+FROM repo
+WHERE
+	(%s) AND
+	repo.deleted_at IS NULL AND
+	repo.blocked IS NULL AND
+	EXISTS (SELECT 1 FROM lsif_uploads u WHERE u.repository_id = repo.id AND u.state = 'completed' AND NOT u.expired) AND
+	(%s)
+ORDER BY repo.id
+LIMIT %s OFFSET %s
+`
@@ -30,6 +30,7 @@ type service interface {
 
 	// Retention Policy
 	GetRetentionPolicyOverview(ctx context.Context, upload shared.Upload, matchesOnly bool, first int, after int64, query string, now time.Time) (matches []shared.RetentionPolicyMatchCandidate, totalCount int, err error)
+	PreviewRetentionPolicyImpact(ctx context.Context, policy shared.ConfigurationPolicy, limit, offset int, now time.Time) (_ []shared.RetentionPolicyImpact, totalCount int, err error)
 
 	// Repository
 	GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, _ error)
@@ -0,0 +1,255 @@
+package policies
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/opentracing/opentracing-go/log"
+
+	policies "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/enterprise"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	uploadsShared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	retentionImpactPolicyBatchSize = 100
+	retentionImpactUploadBatchSize = 100
+)
+
+// PreviewRetentionPolicyImpact determines, without saving anything, how the given proposed data
+// retention policy would change the set of protected uploads. If the policy has a non-zero identifier
+// it is treated as an edit of the existing policy with that identifier; otherwise it is treated as a
+// new policy. The result contains one entry per repository with unexpired uploads to which either the
+// current or the proposed version of the policy applies, paginated by the given limit and offset.
+func (s *Service) PreviewRetentionPolicyImpact(ctx context.Context, policy shared.ConfigurationPolicy, limit, offset int, now time.Time) (_ []shared.RetentionPolicyImpact, totalCount int, err error) {
+	ctx, _, endObservation := s.operations.previewRetentionPolicyImpact.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("policyID", policy.ID),
+		log.Int("limit", limit),
+		log.Int("offset", offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	scopes := []shared.ConfigurationPolicy{policy}
+	if policy.ID != 0 {
+		currentPolicy, ok, err := s.store.GetConfigurationPolicyByID(ctx, policy.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			return nil, 0, errors.Newf("unknown configuration policy %d", policy.ID)
+		}
+
+		// Mirror the behavior of UpdateConfigurationPolicy, which never changes the
+		// repository or the protected status of an existing policy.
+		policy.RepositoryID = currentPolicy.RepositoryID
+		policy.Protected = currentPolicy.Protected
+		scopes = append(scopes, currentPolicy)
+	}
+
+	var (
+		repositoryIDs []int
+		patterns      []string
+	)
+	for _, scope := range scopes {
+		if scope.RepositoryID != nil {
+			repositoryIDs = append(repositoryIDs, *scope.RepositoryID)
+		} else if scope.RepositoryPatterns != nil && len(*scope.RepositoryPatterns) > 0 {
+			patterns = append(patterns, *scope.RepositoryPatterns...)
+		} else {
+			patterns = append(patterns, "*")
+		}
+	}
+
+	ids, totalCount, err := s.store.GetRepoIDsWithUploads(ctx, repositoryIDs, patterns, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	policyMatcher := s.getPolicyMatcherFromFactory(s.gitserver, policies.RetentionExtractor, true, false)
+
+	impacts := make([]shared.RetentionPolicyImpact, 0, len(ids))
+	for _, repositoryID := range ids {
+		impact, err := s.previewRetentionPolicyImpactForRepository(ctx, policyMatcher, repositoryID, policy, now)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		impacts = append(impacts, impact)
+	}
+
+	var uploadIDs []int
+	for _, impact := range impacts {
+		uploadIDs = append(uploadIDs, impact.ExpiredUploadIDs...)
+		uploadIDs = append(uploadIDs, impact.ProtectedUploadIDs...)
+	}
+	if len(uploadIDs) == 0 {
+		return impacts, totalCount, nil
+	}
+
+	sizes, err := s.uploadSvc.GetUploadDataSizes(ctx, uploadIDs)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "uploadSvc.GetUploadDataSizes")
+	}
+
+	for i := range impacts {
+		for _, id := range impacts[i].ExpiredUploadIDs {
+			if size, ok := sizes[id]; ok {
+				impacts[i].ReclaimedBytes += size
+			} else {
+				impacts[i].UnsizedUploads++
+			}
+		}
+		for _, id := range impacts[i].ProtectedUploadIDs {
+			if size, ok := sizes[id]; ok {
+				impacts[i].RetainedBytes += size
+			} else {
+				impacts[i].UnsizedUploads++
+			}
+		}
+	}
+
+	return impacts, totalCount, nil
+}
+
+// previewRetentionPolicyImpactForRepository compares the protection status of each unexpired upload of
+// the given repository under the current set of data retention policies and under the same set with the
+// proposed policy substituted in.
+func (s *Service) previewRetentionPolicyImpactForRepository(ctx context.Context, policyMatcher *policies.Matcher, repositoryID int, policy shared.ConfigurationPolicy, now time.Time) (shared.RetentionPolicyImpact, error) {
+	impact := shared.RetentionPolicyImpact{RepositoryID: repositoryID}
+
+	uploads, err := s.getUnexpiredUploads(ctx, repositoryID)
+	if err != nil || len(uploads) == 0 {
+		return impact, err
+	}
+
+	currentPolicies, err := s.getRetentionPolicies(ctx, repositoryID)
+	if err != nil {
+		return impact, err
+	}
+
+	proposedPolicies := make([]shared.ConfigurationPolicy, 0, len(currentPolicies)+1)
+	for _, currentPolicy := range currentPolicies {
+		if currentPolicy.ID != policy.ID {
+			proposedPolicies = append(proposedPolicies, currentPolicy)
+		}
+	}
+	if policy.RetentionEnabled && policyAppliesToRepository(policy, repositoryID, uploads[0].RepositoryName) {
+		proposedPolicies = append(proposedPolicies, policy)
+	}
+
+	currentCommitMap, err := policyMatcher.CommitsDescribedByPolicyInternal(ctx, repositoryID, currentPolicies, now)
+	if err != nil {
+		return impact, errors.Wrap(err, "policyMatcher.CommitsDescribedByPolicyInternal")
+	}
+	proposedCommitMap, err := policyMatcher.CommitsDescribedByPolicyInternal(ctx, repositoryID, proposedPolicies, now)
+	if err != nil {
+		return impact, errors.Wrap(err, "policyMatcher.CommitsDescribedByPolicyInternal")
+	}
+
+	for _, upload := range uploads {
+		visibleCommits, err := s.getCommitsVisibleToUpload(ctx, shared.Upload{ID: upload.ID})
+		if err != nil {
+			return impact, err
+		}
+
+		protectedNow := isUploadProtected(currentCommitMap, visibleCommits, upload.UploadedAt, now)
+		protectedLater := isUploadProtected(proposedCommitMap, visibleCommits, upload.UploadedAt, now)
+
+		if protectedNow && !protectedLater {
+			impact.ExpiredUploadIDs = append(impact.ExpiredUploadIDs, upload.ID)
+		} else if !protectedNow && protectedLater {
+			impact.ProtectedUploadIDs = append(impact.ProtectedUploadIDs, upload.ID)
+		}
+	}
+
+	return impact, nil
+}
+
+// getUnexpiredUploads returns all completed and unexpired uploads of the given repository that
+// would be considered by the upload expirer.
+func (s *Service) getUnexpiredUploads(ctx context.Context, repositoryID int) (uploads []uploadsShared.Upload, _ error) {
+	for {
+		uploadBatch, totalCount, err := s.uploadSvc.GetUploads(ctx, uploadsShared.GetUploadsOptions{
+			State:         "completed",
+			RepositoryID:  repositoryID,
+			AllowExpired:  false,
+			InCommitGraph: true,
+			Limit:         retentionImpactUploadBatchSize,
+			Offset:        len(uploads),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "uploadSvc.GetUploads")
+		}
+
+		uploads = append(uploads, uploadBatch...)
+
+		if len(uploadBatch) == 0 || len(uploads) >= totalCount {
+			return uploads, nil
+		}
+	}
+}
+
+// getRetentionPolicies returns the complete set of configuration policies that affect data
+// retention for the given repository.
+func (s *Service) getRetentionPolicies(ctx context.Context, repositoryID int) (configPolicies []shared.ConfigurationPolicy, _ error) {
+	for {
+		policyBatch, totalCount, err := s.store.GetConfigurationPolicies(ctx, shared.GetConfigurationPoliciesOptions{
+			RepositoryID:     repositoryID,
+			ForDataRetention: true,
+			Limit:            retentionImpactPolicyBatchSize,
+			Offset:           len(configPolicies),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		configPolicies = append(configPolicies, policyBatch...)
+
+		if len(policyBatch) == 0 || len(configPolicies) >= totalCount {
+			return configPolicies, nil
+		}
+	}
+}
+
+// isUploadProtected returns true if one of the commits visible to an upload is described by a
+// policy whose retention duration has not yet elapsed since the upload was uploaded. This is the
+// same check performed by the upload expirer.
+func isUploadProtected(commitMap map[string][]policies.PolicyMatch, visibleCommits []string, uploadedAt, now time.Time) bool {
+	for _, commit := range visibleCommits {
+		for _, policyMatch := range commitMap[commit] {
+			if policyMatch.PolicyDuration == nil || now.Sub(uploadedAt) < *policyMatch.PolicyDuration {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// policyAppliesToRepository returns true if the given policy targets the given repository, either
+// directly, via one of its repository patterns, or by being a global policy.
+func policyAppliesToRepository(policy shared.ConfigurationPolicy, repositoryID int, repositoryName string) bool {
+	if policy.RepositoryID != nil {
+		return *policy.RepositoryID == repositoryID
+	}
+	if policy.RepositoryPatterns == nil || len(*policy.RepositoryPatterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range *policy.RepositoryPatterns {
+		g, err := glob.Compile(strings.ToLower(pattern))
+		if err != nil {
+			continue
+		}
+
+		if g.Match(strings.ToLower(repositoryName)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package policies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
+	uploadsShared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestPreviewRetentionPolicyImpact(t *testing.T) {
+	mockStore := NewMockStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	svc := newService(mockStore, mockUploadSvc, mockGitserverClient, &observation.TestContext)
+
+	now := time.Unix(1587396557, 0).UTC()
+	currentPolicy := shared.ConfigurationPolicy{
+		ID:               1,
+		Name:             "releases",
+		Type:             shared.GitObjectTypeTag,
+		Pattern:          "v1.*",
+		RetentionEnabled: true,
+	}
+
+	mockStore.GetConfigurationPolicyByIDFunc.SetDefaultReturn(currentPolicy, true, nil)
+	mockStore.GetRepoIDsWithUploadsFunc.SetDefaultReturn([]int{42}, 1, nil)
+	mockStore.GetConfigurationPoliciesFunc.SetDefaultReturn([]shared.ConfigurationPolicy{currentPolicy}, 1, nil)
+	mockUploadSvc.GetUploadsFunc.SetDefaultReturn([]uploadsShared.Upload{
+		{ID: 1, RepositoryID: 42, RepositoryName: "github.com/foo/bar", Commit: "deadbeef1", UploadedAt: now.Add(-time.Hour)},
+		{ID: 2, RepositoryID: 42, RepositoryName: "github.com/foo/bar", Commit: "deadbeef2", UploadedAt: now.Add(-time.Hour)},
+		{ID: 3, RepositoryID: 42, RepositoryName: "github.com/foo/bar", Commit: "deadbeef3", UploadedAt: now.Add(-time.Hour)},
+	}, 3, nil)
+	mockUploadSvc.GetCommitsVisibleToUploadFunc.SetDefaultHook(func(ctx context.Context, uploadID, limit int, token *string) ([]string, *string, error) {
+		return map[int][]string{1: {"deadbeef1"}, 2: {"deadbeef2"}, 3: {"deadbeef3"}}[uploadID], nil, nil
+	})
+	mockUploadSvc.GetUploadDataSizesFunc.SetDefaultReturn(map[int]int64{1: 100, 3: 1000}, nil)
+	mockGitserverClient.RefDescriptionsFunc.SetDefaultReturn(map[string][]gitdomain.RefDescription{
+		"deadbeef1": {{Name: "v1.0.0", Type: gitdomain.RefTypeTag}},
+		"deadbeef2": {{Name: "v2.0.0", Type: gitdomain.RefTypeTag}},
+		"deadbeef3": {{Name: "main", Type: gitdomain.RefTypeBranch, IsDefaultBranch: true}},
+	}, nil)
+
+	proposedPolicy := currentPolicy
+	proposedPolicy.Pattern = "v2.*"
+
+	impacts, totalCount, err := svc.PreviewRetentionPolicyImpact(context.Background(), proposedPolicy, 10, 0, now)
+	if err != nil {
+		t.Fatalf("unexpected error previewing retention policy impact: %s", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+
+	expectedImpacts := []shared.RetentionPolicyImpact{
+		{
+			RepositoryID:       42,
+			ExpiredUploadIDs:   []int{1},
+			ProtectedUploadIDs: []int{2},
+			ReclaimedBytes:     100,
+			RetainedBytes:      0,
+			UnsizedUploads:     1,
+		},
+	}
+	if diff := cmp.Diff(expectedImpacts, impacts); diff != "" {
+		t.Errorf("unexpected impacts (-want +got):\n%s", diff)
+	}
+
+	if history := mockStore.GetRepoIDsWithUploadsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetRepoIDsWithUploads. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff([]string{"*", "*"}, history[0].Arg2); diff != "" {
+		t.Errorf("unexpected repository patterns (-want +got):\n%s", diff)
+	}
+}
+
+func TestPolicyAppliesToRepository(t *testing.T) {
+	repositoryID := 42
+	otherRepositoryID := 43
+	patterns := []string{"github.com/foo/*", "gitlab.com/*/baz"}
+
+	testCases := []struct {
+		policy   shared.ConfigurationPolicy
+		expected bool
+	}{
+		{shared.ConfigurationPolicy{}, true},
+		{shared.ConfigurationPolicy{RepositoryID: &repositoryID}, true},
+		{shared.ConfigurationPolicy{RepositoryID: &otherRepositoryID}, false},
+		{shared.ConfigurationPolicy{RepositoryPatterns: &patterns}, true},
+		{shared.ConfigurationPolicy{RepositoryPatterns: &[]string{"github.com/bar/*"}}, false},
+	}
+
+	for _, testCase := range testCases {
+		if applies := policyAppliesToRepository(testCase.policy, repositoryID, "github.com/Foo/bar"); applies != testCase.expected {
+			t.Errorf("unexpected result for policy %+v. want=%v have=%v", testCase.policy, testCase.expected, applies)
+		}
+	}
+}
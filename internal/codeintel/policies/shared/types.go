@@ -85,3 +85,27 @@ type RetentionPolicyMatchCandidate struct {
 	Matched           bool
 	ProtectingCommits []string
 }
+
+// RetentionPolicyImpact describes how a proposed change to a data retention policy would
+// affect the completed uploads of a single repository.
+type RetentionPolicyImpact struct {
+	RepositoryID int
+
+	// ExpiredUploadIDs are the uploads that are protected by the current set of policies
+	// but would no longer be protected once the proposed policy is saved.
+	ExpiredUploadIDs []int
+
+	// ProtectedUploadIDs are the uploads that are not protected by the current set of
+	// policies but would be protected once the proposed policy is saved.
+	ProtectedUploadIDs []int
+
+	// ReclaimedBytes is the size of the code intelligence data belonging to the uploads
+	// in ExpiredUploadIDs, and RetainedBytes the size of the data belonging to the uploads
+	// in ProtectedUploadIDs. Both exclude the uploads counted by UnsizedUploads.
+	ReclaimedBytes int64
+	RetainedBytes  int64
+
+	// UnsizedUploads is the number of uploads in ExpiredUploadIDs and ProtectedUploadIDs
+	// whose size was not recorded when they were processed.
+	UnsizedUploads int
+}
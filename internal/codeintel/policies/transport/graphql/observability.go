@@ -20,7 +20,8 @@ type operations struct {
 	deleteConfigurationPolicyByID *observation.Operation
 
 	// Retention Policy
-	getRetentionPolicyOverview *observation.Operation
+	getRetentionPolicyOverview   *observation.Operation
+	previewRetentionPolicyImpact *observation.Operation
 
 	// Repository
 	getPreviewRepositoryFilter *observation.Operation
@@ -55,7 +56,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		deleteConfigurationPolicyByID: op("DeleteConfigurationPolicyByID"),
 
 		// Retention
-		getRetentionPolicyOverview: op("GetRetentionPolicyOverview"),
+		getRetentionPolicyOverview:   op("GetRetentionPolicyOverview"),
+		previewRetentionPolicyImpact: op("PreviewRetentionPolicyImpact"),
 
 		// Repository
 		getPreviewRepositoryFilter: op("PreviewRepositoryFilter"),
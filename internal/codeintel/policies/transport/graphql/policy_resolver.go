@@ -19,6 +19,7 @@ type PolicyResolver interface {
 
 	// Retention
 	GetRetentionPolicyOverview(ctx context.Context, upload shared.Upload, matchesOnly bool, first int, after int64, query string, now time.Time) (matches []shared.RetentionPolicyMatchCandidate, totalCount int, err error)
+	PreviewRetentionPolicyImpact(ctx context.Context, policy shared.ConfigurationPolicy, limit, offset int, now time.Time) (_ []shared.RetentionPolicyImpact, totalCount int, err error)
 
 	// Previews
 	GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, _ error)
@@ -79,6 +80,13 @@ func (p *policyResolver) GetRetentionPolicyOverview(ctx context.Context, upload
 	return p.svc.GetRetentionPolicyOverview(ctx, upload, matchesOnly, first, after, query, now)
 }
 
+func (p *policyResolver) PreviewRetentionPolicyImpact(ctx context.Context, policy shared.ConfigurationPolicy, limit, offset int, now time.Time) (_ []shared.RetentionPolicyImpact, totalCount int, err error) {
+	ctx, _, endObservation := p.operations.previewRetentionPolicyImpact.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return p.svc.PreviewRetentionPolicyImpact(ctx, policy, limit, offset, now)
+}
+
 func (p *policyResolver) GetPreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, err error) {
 	ctx, _, endObservation := p.operations.getPreviewRepositoryFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -12,6 +12,8 @@ type operations struct {
 	calculateVisibleUploads                     *observation.Operation
 	commitGraphMetadata                         *observation.Operation
 	commitsVisibleToUpload                      *observation.Operation
+	copyPackageReferences                       *observation.Operation
+	copyPackages                                *observation.Operation
 	createConfigurationPolicy                   *observation.Operation
 	definitionDumps                             *observation.Operation
 	deleteConfigurationPolicyByID               *observation.Operation
@@ -111,6 +113,8 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		calculateVisibleUploads:              op("CalculateVisibleUploads"),
 		commitGraphMetadata:                  op("CommitGraphMetadata"),
 		commitsVisibleToUpload:               op("CommitsVisibleToUpload"),
+		copyPackageReferences:                op("CopyPackageReferences"),
+		copyPackages:                         op("CopyPackages"),
 		createConfigurationPolicy:            op("CreateConfigurationPolicy"),
 		definitionDumps:                      op("DefinitionDumps"),
 		deleteConfigurationPolicyByID:        op("DeleteConfigurationPolicyByID"),
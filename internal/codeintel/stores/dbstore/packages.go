@@ -66,6 +66,59 @@ SELECT %s, source.scheme, source.name, source.version
 FROM t_lsif_packages source
 `
 
+// CopyPackages inserts the package data tied to the source upload for the target upload. Packages
+// already provided by the target upload and the given excluded packages are not copied. This is used
+// to carry over the packages of a base upload into an upload that only contains the documents that
+// changed since the base, excluding the packages that were only provided by the replaced documents.
+func (s *Store) CopyPackages(ctx context.Context, sourceDumpID, targetDumpID int, excluded []precise.Package) (err error) {
+	ctx, _, endObservation := s.operations.copyPackages.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceDumpID", sourceDumpID),
+		log.Int("targetDumpID", targetDumpID),
+		log.Int("numExcluded", len(excluded)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(copyPackagesQuery, targetDumpID, sourceDumpID, makeExcludedPackagesCondition("p", excluded), targetDumpID))
+}
+
+const copyPackagesQuery = `
+-- source: internal/codeintel/stores/dbstore/packages.go:CopyPackages
+INSERT INTO lsif_packages (dump_id, scheme, name, version)
+SELECT %s, p.scheme, p.name, p.version
+FROM lsif_packages p
+WHERE
+	p.dump_id = %s AND
+	%s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_packages e
+		WHERE
+			e.dump_id = %s AND
+			e.scheme = p.scheme AND
+			e.name = p.name AND
+			e.version IS NOT DISTINCT FROM p.version
+	)
+`
+
+// makeExcludedPackagesCondition returns a condition matching rows of the given table alias with
+// a scheme, name, and version other than the ones of the given packages.
+func makeExcludedPackagesCondition(alias string, excluded []precise.Package) *sqlf.Query {
+	if len(excluded) == 0 {
+		return sqlf.Sprintf("TRUE")
+	}
+
+	values := make([]*sqlf.Query, 0, len(excluded))
+	for _, pkg := range excluded {
+		values = append(values, sqlf.Sprintf("(%s, %s, %s)", pkg.Scheme, pkg.Name, pkg.Version))
+	}
+
+	return sqlf.Sprintf(
+		"(%s.scheme, %s.name, %s.version) NOT IN (%s)",
+		sqlf.Sprintf(alias), sqlf.Sprintf(alias), sqlf.Sprintf(alias),
+		sqlf.Join(values, ", "),
+	)
+}
+
 func loadPackagesChannel(packages []precise.Package) <-chan []any {
 	ch := make(chan []any, len(packages))
 
@@ -61,3 +61,38 @@ func TestUpdatePackagesEmpty(t *testing.T) {
 		t.Errorf("unexpected package count. want=%d have=%d", 0, count)
 	}
 }
+
+func TestCopyPackages(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := testStore(db)
+
+	// for foreign key relation
+	insertUploads(t, db, Upload{ID: 42}, Upload{ID: 43})
+
+	if err := store.UpdatePackages(context.Background(), 42, []precise.Package{
+		{Scheme: "s0", Name: "n0", Version: "v0"},
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+		{Scheme: "s3", Name: "n3", Version: "v3"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackages(context.Background(), 43, []precise.Package{
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+		{Scheme: "s2", Name: "n2", Version: "v2"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+
+	if err := store.CopyPackages(context.Background(), 42, 43, []precise.Package{{Scheme: "s3", Name: "n3", Version: "v3"}}); err != nil {
+		t.Fatalf("unexpected error copying packages: %s", err)
+	}
+
+	count, _, err := basestore.ScanFirstInt(db.QueryContext(context.Background(), "SELECT COUNT(*) FROM lsif_packages WHERE dump_id = 43"))
+	if err != nil {
+		t.Fatalf("unexpected error checking package count: %s", err)
+	}
+	if count != 3 {
+		t.Errorf("unexpected package count. want=%d have=%d", 3, count)
+	}
+}
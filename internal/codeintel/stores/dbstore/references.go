@@ -66,6 +66,58 @@ SELECT %s, source.scheme, source.name, source.version
 FROM t_lsif_references source
 `
 
+// CopyPackageReferences inserts the reference data tied to the source upload for the target upload.
+// References already made by the target upload and references to the given excluded packages are not
+// copied. This is used to carry over the references of a base upload into an upload that only contains
+// the documents that changed since the base, excluding the references that were only made by the replaced
+// documents. As with a full upload, the target upload is left without references to the packages it
+// provides itself, so this must be called after the packages of the target upload have been written.
+func (s *Store) CopyPackageReferences(ctx context.Context, sourceDumpID, targetDumpID int, excluded []precise.Package) (err error) {
+	ctx, _, endObservation := s.operations.copyPackageReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceDumpID", sourceDumpID),
+		log.Int("targetDumpID", targetDumpID),
+		log.Int("numExcluded", len(excluded)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := s.Exec(ctx, sqlf.Sprintf(copyPackageReferencesQuery, targetDumpID, sourceDumpID, makeExcludedPackagesCondition("r", excluded), targetDumpID)); err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteSelfReferencesQuery, targetDumpID))
+}
+
+const copyPackageReferencesQuery = `
+-- source: internal/codeintel/stores/dbstore/references.go:CopyPackageReferences
+INSERT INTO lsif_references (dump_id, scheme, name, version, filter)
+SELECT %s, r.scheme, r.name, r.version, r.filter
+FROM lsif_references r
+WHERE
+	r.dump_id = %s AND
+	%s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_references e
+		WHERE
+			e.dump_id = %s AND
+			e.scheme = r.scheme AND
+			e.name = r.name AND
+			e.version IS NOT DISTINCT FROM r.version
+	)
+`
+
+const deleteSelfReferencesQuery = `
+-- source: internal/codeintel/stores/dbstore/references.go:CopyPackageReferences
+DELETE FROM lsif_references r
+USING lsif_packages p
+WHERE
+	r.dump_id = %s AND
+	p.dump_id = r.dump_id AND
+	p.scheme = r.scheme AND
+	p.name = r.name AND
+	p.version IS NOT DISTINCT FROM r.version
+`
+
 func loadReferencesChannel(references []precise.PackageReference) <-chan []any {
 	ch := make(chan []any, len(references))
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -100,3 +102,45 @@ func TestUpdatePackageReferencesWithDuplicates(t *testing.T) {
 		t.Errorf("unexpected reference count. want=%d have=%d", 12, count)
 	}
 }
+
+func TestCopyPackageReferences(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := testStore(db)
+
+	// for foreign key relation
+	insertUploads(t, db, Upload{ID: 42}, Upload{ID: 43})
+
+	if err := store.UpdatePackageReferences(context.Background(), 42, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "s0", Name: "n0", Version: "v0"}},
+		{Package: precise.Package{Scheme: "s1", Name: "n1", Version: "v1"}},
+		{Package: precise.Package{Scheme: "s2", Name: "n2", Version: "v2"}},
+		{Package: precise.Package{Scheme: "s3", Name: "n3", Version: "v3"}},
+	}); err != nil {
+		t.Fatalf("unexpected error updating references: %s", err)
+	}
+	if err := store.UpdatePackageReferences(context.Background(), 43, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "s1", Name: "n1", Version: "v1"}},
+		{Package: precise.Package{Scheme: "s4", Name: "n4", Version: "v4"}},
+	}); err != nil {
+		t.Fatalf("unexpected error updating references: %s", err)
+	}
+	if err := store.UpdatePackages(context.Background(), 43, []precise.Package{
+		{Scheme: "s2", Name: "n2", Version: "v2"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+
+	// s0 is copied, s1 is already present, s2 is provided by the target, and s3 is excluded
+	if err := store.CopyPackageReferences(context.Background(), 42, 43, []precise.Package{{Scheme: "s3", Name: "n3", Version: "v3"}}); err != nil {
+		t.Fatalf("unexpected error copying references: %s", err)
+	}
+
+	schemes, err := basestore.ScanStrings(db.QueryContext(context.Background(), "SELECT scheme FROM lsif_references WHERE dump_id = 43 ORDER BY scheme"))
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	if diff := cmp.Diff([]string{"s0", "s1", "s4"}, schemes); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+}
@@ -45,6 +45,7 @@ type Upload struct {
 	UncompressedSize  *int64
 	Rank              *int
 	AssociatedIndexID *int
+	BaseUploadID      *int
 }
 
 func (u Upload) RecordID() int {
@@ -76,6 +77,7 @@ func scanUpload(s dbutil.Scanner) (upload Upload, _ error) {
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.BaseUploadID,
 	); err != nil {
 		return upload, err
 	}
@@ -113,6 +115,7 @@ func scanUploadWithCount(s dbutil.Scanner) (upload Upload, count int, _ error) {
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.BaseUploadID,
 		&count,
 	); err != nil {
 		return upload, 0, err
@@ -181,7 +184,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.base_upload_id
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -240,7 +244,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.base_upload_id
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -381,7 +386,8 @@ func (s *Store) GetUploads(ctx context.Context, opts GetUploadsOptions) (_ []Upl
 				upload_size,
 				associated_index_id,
 				expired,
-				uncompressed_size
+				uncompressed_size,
+				base_upload_id
 			FROM lsif_uploads
 			UNION ALL
 			SELECT *
@@ -485,6 +491,7 @@ SELECT
 	u.associated_index_id,
 	s.rank,
 	u.uncompressed_size,
+	u.base_upload_id,
 	COUNT(*) OVER() AS count
 FROM %s
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -509,7 +516,8 @@ SELECT
 	NULL::integer[] as uploaded_parts,
 	au.upload_size, au.associated_index_id,
 	COALESCE((snapshot->'expired')::boolean, false) AS expired,
-	NULL::bigint AS uncompressed_size
+	NULL::bigint AS uncompressed_size,
+	NULL::integer AS base_upload_id
 FROM (
 	SELECT upload_id, snapshot_transition_columns(transition_columns ORDER BY sequence ASC) AS snapshot
 	FROM lsif_uploads_audit_logs
@@ -625,6 +633,7 @@ func (s *Store) InsertUpload(ctx context.Context, upload Upload) (id int, err er
 			upload.UploadSize,
 			upload.AssociatedIndexID,
 			upload.UncompressedSize,
+			upload.BaseUploadID,
 		),
 	))
 
@@ -644,8 +653,9 @@ INSERT INTO lsif_uploads (
 	uploaded_parts,
 	upload_size,
 	associated_index_id,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	base_upload_id
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -732,6 +742,7 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.associated_index_id"),
 	sqlf.Sprintf("NULL"),
 	sqlf.Sprintf("u.uncompressed_size"),
+	sqlf.Sprintf("u.base_upload_id"),
 }
 
 // DeleteUploadByID deletes an upload by its identifier. This method returns a true-valued flag if a record
@@ -1324,7 +1335,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.base_upload_id
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
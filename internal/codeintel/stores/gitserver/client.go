@@ -38,6 +38,40 @@ func (c *Client) DiffPath(ctx context.Context, checker authz.SubRepoPermissionCh
 	return gitserver.NewClient(c.db).DiffPath(ctx, checker, repo, sourceCommit, targetCommit, path)
 }
 
+func (c *Client) DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error) {
+	return gitserver.NewClient(c.db).DiffFileRevisions(ctx, checker, repo, sourceCommit, sourcePath, targetCommit, targetPath)
+}
+
+// RenamedFile is a file renamed by a commit. Left is true if the commit is reachable from the first of
+// the commits given to RenamedFiles, and false if it is reachable from the second.
+type RenamedFile struct {
+	Commit  string
+	OldPath string
+	NewPath string
+	Left    bool
+}
+
+// RenamedFiles returns the renames of the file with the given path in the first of the given commits made
+// by the commits that are reachable from exactly one of the given commits, oldest first.
+func (c *Client) RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]RenamedFile, error) {
+	renames, err := gitserver.NewClient(c.db).RenamedFiles(ctx, checker, repo, commitA, commitB, path)
+	if err != nil {
+		return nil, err
+	}
+
+	renamedFiles := make([]RenamedFile, 0, len(renames))
+	for _, rename := range renames {
+		renamedFiles = append(renamedFiles, RenamedFile{
+			Commit:  string(rename.Commit),
+			OldPath: rename.OldPath,
+			NewPath: rename.NewPath,
+			Left:    rename.Left,
+		})
+	}
+
+	return renamedFiles, nil
+}
+
 // CommitExists determines if the given commit exists in the given repository.
 func (c *Client) CommitExists(ctx context.Context, repositoryID int, commit string) (_ bool, err error) {
 	ctx, _, endObservation := c.operations.commitExists.With(ctx, &err, observation.Args{LogFields: []log.Field{
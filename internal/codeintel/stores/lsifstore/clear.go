@@ -23,6 +23,7 @@ var tableNames = []string{
 	"lsif_data_references_schema_versions",
 	"lsif_data_implementations",
 	"lsif_data_implementations_schema_versions",
+	"lsif_data_shared_documents",
 }
 
 func (s *Store) Clear(ctx context.Context, bundleIDs ...int) (err error) {
@@ -54,6 +55,11 @@ func (s *Store) Clear(ctx context.Context, bundleIDs ...int) (err error) {
 		err = tx.Done(err)
 	}()
 
+	// Documents of the deleted bundles may still be shared by delta uploads that are not deleted.
+	if err := MaterializeSharedDocuments(ctx, tx, bundleIDs...); err != nil {
+		return err
+	}
+
 	for _, tableName := range tableNames {
 		trace.Log(log.String("tableName", tableName))
 
@@ -42,3 +42,40 @@ func TestClear(t *testing.T) {
 		t.Errorf("unexpected dump identifiers (-want +got):\n%s", diff)
 	}
 }
+
+func TestClearSharedDocuments(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := stores.NewCodeIntelDB(dbtest.NewDB(logger, t))
+	store := NewStore(db, conf.DefaultClient(), &observation.TestContext)
+
+	for _, query := range []*sqlf.Query{
+		sqlf.Sprintf("INSERT INTO lsif_data_documents (dump_id, path, schema_version, num_diagnostics) VALUES (1, 'a.go', 3, 0), (1, 'b.go', 3, 0)"),
+		sqlf.Sprintf("INSERT INTO lsif_data_shared_documents (dump_id, path, source_dump_id) VALUES (2, 'a.go', 1), (3, 'a.go', 1), (3, 'b.go', 1)"),
+	} {
+		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting documents: %s", err)
+		}
+	}
+
+	if err := store.Clear(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error clearing bundle data: %s", err)
+	}
+
+	scanDocuments := func(query string) []string {
+		documents, err := basestore.ScanStrings(db.QueryContext(context.Background(), query))
+		if err != nil {
+			t.Fatalf("unexpected error querying documents: %s", err)
+		}
+		return documents
+	}
+
+	if diff := cmp.Diff([]string{"2:a.go", "3:a.go", "3:b.go"}, scanDocuments("SELECT dump_id || ':' || path FROM lsif_data_all_documents ORDER BY dump_id, path")); diff != "" {
+		t.Errorf("unexpected visible documents (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"2:a.go", "3:b.go"}, scanDocuments("SELECT dump_id || ':' || path FROM lsif_data_documents ORDER BY dump_id, path")); diff != "" {
+		t.Errorf("unexpected stored documents (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"3:a.go:2"}, scanDocuments("SELECT dump_id || ':' || path || ':' || source_dump_id FROM lsif_data_shared_documents")); diff != "" {
+		t.Errorf("unexpected shared documents (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,220 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// The methods in this file are called from the precise-code-intel-worker when synthesizing a complete
+// dump from a delta upload. Bundles can be arbitrarily large, so all but the smallest reads visit their
+// values one at a time rather than returning them all at once.
+
+// ReadMeta returns the metadata written by WriteMeta for the given bundle.
+func (s *Store) ReadMeta(ctx context.Context, bundleID int) (_ precise.MetaData, err error) {
+	ctx, _, endObservation := s.operations.readMeta.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	numResultChunks, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(readMetaQuery, bundleID)))
+	if err != nil {
+		return precise.MetaData{}, err
+	}
+	if !exists {
+		return precise.MetaData{}, ErrNoMetadata
+	}
+
+	return precise.MetaData{NumResultChunks: numResultChunks}, nil
+}
+
+const readMetaQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:ReadMeta
+SELECT num_result_chunks FROM lsif_data_metadata WHERE dump_id = %s
+`
+
+// AllDocumentPaths returns the paths of all documents of the given bundle, including the documents
+// it shares with an earlier bundle.
+func (s *Store) AllDocumentPaths(ctx context.Context, bundleID int) (_ []string, err error) {
+	ctx, trace, endObservation := s.operations.allDocumentPaths.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	paths, err := basestore.ScanStrings(s.Store.Query(ctx, sqlf.Sprintf(allDocumentPathsQuery, bundleID)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numPaths", len(paths)))
+
+	return paths, nil
+}
+
+const allDocumentPathsQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:AllDocumentPaths
+SELECT path FROM lsif_data_all_documents WHERE dump_id = %s ORDER BY path
+`
+
+// VisitDocuments calls the given function with each document of the given bundle with one of the
+// given paths. Paths that do not exist in the bundle are ignored.
+func (s *Store) VisitDocuments(ctx context.Context, bundleID int, paths []string, f func(path string, document precise.DocumentData)) (err error) {
+	ctx, trace, endObservation := s.operations.visitDocuments.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	count := 0
+	visitDocuments := s.makeDocumentVisitor(func(path string, document precise.DocumentData) {
+		count++
+		f(path, document)
+	})
+
+	// Limit the number of compressed document payloads Postgres needs to load to handle a single query
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= documentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:documentBatchSize], paths[documentBatchSize:]
+		}
+
+		if err := visitDocuments(s.Store.Query(ctx, sqlf.Sprintf(visitDocumentsQuery, bundleID, pq.Array(batch)))); err != nil {
+			return err
+		}
+	}
+	trace.Log(log.Int("numDocuments", count))
+
+	return nil
+}
+
+const visitDocumentsQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:VisitDocuments
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	hovers,
+	monikers,
+	packages,
+	diagnostics
+FROM
+	lsif_data_all_documents
+WHERE
+	dump_id = %s AND
+	path = ANY(%s)
+`
+
+// ReadResultChunks returns the result chunks of the given bundle with the given indexes.
+func (s *Store) ReadResultChunks(ctx context.Context, bundleID int, indexes []int) (_ map[int]precise.ResultChunkData, err error) {
+	ctx, trace, endObservation := s.operations.readResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("numIndexes", len(indexes)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	resultChunks := make(map[int]precise.ResultChunkData, len(indexes))
+	for len(indexes) > 0 {
+		var batch []int
+		if len(indexes) <= resultChunkBatchSize {
+			batch, indexes = indexes, nil
+		} else {
+			batch, indexes = indexes[:resultChunkBatchSize], indexes[resultChunkBatchSize:]
+		}
+
+		visitResultChunks := s.makeResultChunkVisitor(s.Store.Query(ctx, sqlf.Sprintf(readResultChunksQuery, bundleID, pq.Array(batch))))
+		if err := visitResultChunks(func(index int, resultChunkData precise.ResultChunkData) {
+			resultChunks[index] = resultChunkData
+		}); err != nil {
+			return nil, err
+		}
+	}
+	trace.Log(log.Int("numResultChunks", len(resultChunks)))
+
+	return resultChunks, nil
+}
+
+const readResultChunksQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:ReadResultChunks
+SELECT idx, data FROM lsif_data_result_chunks WHERE dump_id = %s AND idx = ANY(%s)
+`
+
+// VisitResultChunks calls the given function with each result chunk of the given bundle in order of
+// their indexes.
+func (s *Store) VisitResultChunks(ctx context.Context, bundleID int, f func(index int, resultChunk precise.ResultChunkData)) (err error) {
+	ctx, _, endObservation := s.operations.visitResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.makeResultChunkVisitor(s.Store.Query(ctx, sqlf.Sprintf(visitResultChunksQuery, bundleID)))(f)
+}
+
+const visitResultChunksQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:VisitResultChunks
+SELECT idx, data FROM lsif_data_result_chunks WHERE dump_id = %s ORDER BY idx
+`
+
+// VisitDefinitions calls the given function with each moniker location row written by WriteDefinitions
+// for the given bundle.
+func (s *Store) VisitDefinitions(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.visitDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.visitMonikers(ctx, bundleID, "lsif_data_definitions", f)
+}
+
+// VisitReferences calls the given function with each moniker location row written by WriteReferences
+// for the given bundle.
+func (s *Store) VisitReferences(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.visitReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.visitMonikers(ctx, bundleID, "lsif_data_references", f)
+}
+
+// VisitImplementations calls the given function with each moniker location row written by
+// WriteImplementations for the given bundle.
+func (s *Store) VisitImplementations(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.visitImplementations.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.visitMonikers(ctx, bundleID, "lsif_data_implementations", f)
+}
+
+func (s *Store) visitMonikers(ctx context.Context, bundleID int, tableName string, f func(precise.MonikerLocations)) (err error) {
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(visitMonikersQuery, sqlf.Sprintf(tableName), bundleID))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		record, err := s.scanSingleQualifiedMonikerLocationsObject(rows)
+		if err != nil {
+			return err
+		}
+
+		f(record.MonikerLocations)
+	}
+
+	return nil
+}
+
+const visitMonikersQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_read.go:visitMonikers
+SELECT dump_id, scheme, identifier, data FROM %s WHERE dump_id = %s
+`
@@ -5,8 +5,10 @@ import (
 	"sync/atomic"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -36,6 +38,39 @@ func (s *Store) WriteMeta(ctx context.Context, bundleID int, meta precise.MetaDa
 	return s.Exec(ctx, sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks) VALUES (%s, %s)", bundleID, meta.NumResultChunks))
 }
 
+// WriteDataSize records the number of bytes occupied by the payloads of the rows written for the given
+// bundle. This is called (transactionally) from the precise-code-intel-worker once all other data of the
+// bundle has been written, so that the size of a bundle never needs to be computed on demand. Documents the
+// bundle shares with another bundle are not counted, as they are not reclaimed when the bundle is deleted.
+func (s *Store) WriteDataSize(ctx context.Context, bundleID int) (err error) {
+	ctx, _, endObservation := s.operations.writeDataSize.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(writeDataSizeQuery, bundleID, bundleID, bundleID, bundleID, bundleID, bundleID))
+}
+
+const writeDataSizeQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_write.go:WriteDataSize
+UPDATE lsif_data_metadata SET data_size = (
+	SELECT COALESCE(SUM(size), 0)::bigint FROM (
+		SELECT
+			` + documentSizeExpression + ` AS size
+		FROM lsif_data_documents d WHERE d.dump_id = %s
+		UNION ALL
+		SELECT COALESCE(pg_column_size(data), 0) FROM lsif_data_result_chunks WHERE dump_id = %s
+		UNION ALL
+		SELECT pg_column_size(scheme) + pg_column_size(identifier) + COALESCE(pg_column_size(data), 0) FROM lsif_data_definitions WHERE dump_id = %s
+		UNION ALL
+		SELECT pg_column_size(scheme) + pg_column_size(identifier) + COALESCE(pg_column_size(data), 0) FROM lsif_data_references WHERE dump_id = %s
+		UNION ALL
+		SELECT pg_column_size(scheme) + pg_column_size(identifier) + COALESCE(pg_column_size(data), 0) FROM lsif_data_implementations WHERE dump_id = %s
+	) s
+)
+WHERE dump_id = %s
+`
+
 // WriteDocuments is called (transactionally) from the precise-code-intel-worker.
 func (s *Store) WriteDocuments(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (count uint32, err error) {
 	ctx, trace, endObservation := s.operations.writeDocuments.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -126,6 +161,66 @@ SELECT %s, %s, source.path, source.ranges, source.hovers, source.monikers, sourc
 FROM t_lsif_data_documents source
 `
 
+// ShareDocuments makes the documents with the given paths of the source bundle part of the target
+// bundle without duplicating their rows. This is called (transactionally) from the precise-code-intel-worker
+// to share the unchanged documents of a base upload with a delta upload. Documents the source bundle
+// itself shares with an earlier bundle are shared with that bundle directly, so that a document is only
+// ever a single hop away from the row that stores it.
+func (s *Store) ShareDocuments(ctx context.Context, sourceBundleID, targetBundleID int, paths []string) (count uint32, err error) {
+	ctx, trace, endObservation := s.operations.shareDocuments.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceBundleID", sourceBundleID),
+		log.Int("targetBundleID", targetBundleID),
+		log.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= shareDocumentsBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:shareDocumentsBatchSize], paths[shareDocumentsBatchSize:]
+		}
+
+		rowsAffected, _, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(
+			shareDocumentsQuery,
+			targetBundleID,
+			sourceBundleID,
+			pq.Array(batch),
+			targetBundleID,
+			sourceBundleID,
+			pq.Array(batch),
+		)))
+		if err != nil {
+			return 0, err
+		}
+
+		count += uint32(rowsAffected)
+	}
+	trace.Log(log.Uint32("numSharedDocumentRecords", count))
+
+	return count, nil
+}
+
+// shareDocumentsBatchSize is the maximum number of document paths we send to Postgres in a single share query.
+const shareDocumentsBatchSize = 10000
+
+const shareDocumentsQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_write.go:ShareDocuments
+WITH ins AS (
+	INSERT INTO lsif_data_shared_documents (dump_id, path, source_dump_id)
+	SELECT %s, d.path, d.dump_id
+	FROM lsif_data_documents d
+	WHERE d.dump_id = %s AND d.path = ANY(%s)
+	UNION ALL
+	SELECT %s, s.path, s.source_dump_id
+	FROM lsif_data_shared_documents s
+	WHERE s.dump_id = %s AND s.path = ANY(%s)
+	RETURNING 1
+)
+SELECT COUNT(*) FROM ins
+`
+
 // WriteResultChunks is called (transactionally) from the precise-code-intel-worker.
 func (s *Store) WriteResultChunks(ctx context.Context, bundleID int, resultChunks chan precise.IndexedResultChunkData) (count uint32, err error) {
 	ctx, trace, endObservation := s.operations.writeResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
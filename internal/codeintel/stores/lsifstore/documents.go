@@ -29,7 +29,7 @@ const documentsCountQuery = `
 SELECT
 	COUNT(*)
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path ILIKE %s
@@ -40,7 +40,7 @@ const documentsQuery = `
 SELECT
 	path
 FROM
-	lsif_data_documents
+	lsif_data_all_documents
 WHERE
 	dump_id = %s AND
 	path ILIKE %s
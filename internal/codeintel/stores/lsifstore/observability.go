@@ -8,6 +8,7 @@ import (
 )
 
 type operations struct {
+	allDocumentPaths       *observation.Operation
 	bulkMonikerResults     *observation.Operation
 	clear                  *observation.Operation
 	definitions            *observation.Operation
@@ -20,8 +21,17 @@ type operations struct {
 	monikersByPosition     *observation.Operation
 	packageInformation     *observation.Operation
 	ranges                 *observation.Operation
+	readMeta               *observation.Operation
+	readResultChunks       *observation.Operation
 	references             *observation.Operation
+	shareDocuments         *observation.Operation
 	stencil                *observation.Operation
+	visitDefinitions       *observation.Operation
+	visitDocuments         *observation.Operation
+	visitImplementations   *observation.Operation
+	visitReferences        *observation.Operation
+	visitResultChunks      *observation.Operation
+	writeDataSize          *observation.Operation
 	writeDefinitions       *observation.Operation
 	writeDocuments         *observation.Operation
 	writeImplementations   *observation.Operation
@@ -59,6 +69,7 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
+		allDocumentPaths:       op("AllDocumentPaths"),
 		bulkMonikerResults:     op("BulkMonikerResults"),
 		clear:                  op("Clear"),
 		definitions:            op("Definitions"),
@@ -71,8 +82,17 @@ func newOperations(observationContext *observation.Context) *operations {
 		monikersByPosition:     op("MonikersByPosition"),
 		packageInformation:     op("PackageInformation"),
 		ranges:                 op("Ranges"),
+		readMeta:               op("ReadMeta"),
+		readResultChunks:       op("ReadResultChunks"),
 		references:             op("References"),
+		shareDocuments:         op("ShareDocuments"),
 		stencil:                op("Stencil"),
+		visitDefinitions:       op("VisitDefinitions"),
+		visitDocuments:         op("VisitDocuments"),
+		visitImplementations:   op("VisitImplementations"),
+		visitReferences:        op("VisitReferences"),
+		visitResultChunks:      op("VisitResultChunks"),
+		writeDataSize:          op("WriteDataSize"),
 		writeDefinitions:       op("WriteDefinitions"),
 		writeDocuments:         op("WriteDocuments"),
 		writeImplementations:   op("WriteImplementations"),
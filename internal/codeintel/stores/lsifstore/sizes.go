@@ -0,0 +1,86 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// documentSizeExpression is the number of bytes occupied by the payload of a row of lsif_data_documents
+// aliased as d. The sizes recorded in lsif_data_metadata.data_size are computed with this expression when
+// a bundle is written and when documents move between bundles. pg_column_size reports the stored (possibly
+// compressed) size of a value without decompressing it.
+const documentSizeExpression = `pg_column_size(d.path) +
+			COALESCE(pg_column_size(d.data), 0) +
+			COALESCE(pg_column_size(d.ranges), 0) +
+			COALESCE(pg_column_size(d.hovers), 0) +
+			COALESCE(pg_column_size(d.monikers), 0) +
+			COALESCE(pg_column_size(d.packages), 0) +
+			COALESCE(pg_column_size(d.diagnostics), 0)`
+
+// MaterializeSharedDocuments hands each document of the given bundles that is still shared by a delta
+// bundle outside of the given set over to one of the bundles sharing it, and adds the size of the document
+// to the recorded size of its new owner. This must be called in the transaction that deletes the rows of
+// the given bundles, before any rows are deleted.
+func MaterializeSharedDocuments(ctx context.Context, tx *basestore.Store, bundleIDs ...int) error {
+	if len(bundleIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]*sqlf.Query, 0, len(bundleIDs))
+	for _, bundleID := range bundleIDs {
+		ids = append(ids, sqlf.Sprintf("%d", bundleID))
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(materializeSharedDocumentsQuery, sqlf.Join(ids, ","), sqlf.Join(ids, ",")))
+}
+
+const materializeSharedDocumentsQuery = `
+-- source: internal/codeintel/stores/lsifstore/sizes.go:MaterializeSharedDocuments
+WITH
+candidates AS (
+	SELECT s.source_dump_id, s.path, MIN(s.dump_id) AS dump_id
+	FROM lsif_data_shared_documents s
+	WHERE s.source_dump_id IN (%s) AND s.dump_id NOT IN (%s)
+	GROUP BY s.source_dump_id, s.path
+),
+moved AS (
+	UPDATE lsif_data_documents d
+	SET dump_id = c.dump_id
+	FROM candidates c
+	WHERE d.dump_id = c.source_dump_id AND d.path = c.path
+	RETURNING
+		d.dump_id,
+		d.schema_version,
+		` + documentSizeExpression + ` AS size
+),
+resized AS (
+	UPDATE lsif_data_metadata m
+	SET data_size = m.data_size + s.size
+	FROM (SELECT dump_id, SUM(size) AS size FROM moved GROUP BY dump_id) s
+	WHERE m.dump_id = s.dump_id AND m.data_size IS NOT NULL
+	RETURNING 1
+),
+repointed AS (
+	UPDATE lsif_data_shared_documents s
+	SET source_dump_id = c.dump_id
+	FROM candidates c
+	WHERE s.source_dump_id = c.source_dump_id AND s.path = c.path AND s.dump_id != c.dump_id
+	RETURNING 1
+),
+materialized AS (
+	DELETE FROM lsif_data_shared_documents s
+	USING candidates c
+	WHERE s.dump_id = c.dump_id AND s.path = c.path
+	RETURNING 1
+)
+INSERT INTO lsif_data_documents_schema_versions (dump_id, min_schema_version, max_schema_version)
+SELECT dump_id, MIN(schema_version), MAX(schema_version)
+FROM moved
+GROUP BY dump_id
+ON CONFLICT (dump_id) DO UPDATE SET
+	min_schema_version = LEAST(lsif_data_documents_schema_versions.min_schema_version, EXCLUDED.min_schema_version),
+	max_schema_version = GREATEST(lsif_data_documents_schema_versions.max_schema_version, EXCLUDED.max_schema_version)
+`
@@ -10,6 +10,8 @@ import (
 
 type LsifStore interface {
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
+	GetUploadDataSizes(ctx context.Context, bundleIDs ...int) (_ map[int]int64, err error)
+	GetUploadDocumentPaths(ctx context.Context, prefixes map[int]string) (_ map[int][]string, err error)
 }
 
 type store struct {
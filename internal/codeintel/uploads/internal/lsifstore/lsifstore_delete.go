@@ -9,6 +9,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
 
+	codeintellsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -23,6 +24,7 @@ var tableNames = []string{
 	"lsif_data_references_schema_versions",
 	"lsif_data_implementations",
 	"lsif_data_implementations_schema_versions",
+	"lsif_data_shared_documents",
 }
 
 // DeleteLsifDataByUploadIds deletes LSIF data by UploadIds from the lsif database.
@@ -55,6 +57,11 @@ func (s *store) DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int)
 		err = tx.Done(err)
 	}()
 
+	// Documents of the deleted bundles may still be shared by delta uploads that are not deleted.
+	if err := codeintellsifstore.MaterializeSharedDocuments(ctx, tx, bundleIDs...); err != nil {
+		return err
+	}
+
 	for _, tableName := range tableNames {
 		trace.Log(log.String("tableName", tableName))
 
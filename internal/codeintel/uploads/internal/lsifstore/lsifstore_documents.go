@@ -0,0 +1,65 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUploadDocumentPaths returns the root-relative paths of the documents of each of the given uploads
+// that begin with the prefix given for that upload. Uploads without matching documents are absent from
+// the returned map.
+func (s *store) GetUploadDocumentPaths(ctx context.Context, prefixes map[int]string) (_ map[int][]string, err error) {
+	bundleIDs := make([]int, 0, len(prefixes))
+	for bundleID := range prefixes {
+		bundleIDs = append(bundleIDs, bundleID)
+	}
+	sort.Ints(bundleIDs)
+
+	ctx, trace, endObservation := s.operations.getUploadDocumentPaths.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numBundleIDs", len(bundleIDs)),
+		log.String("bundleIDs", intsToString(bundleIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(bundleIDs) == 0 {
+		return nil, nil
+	}
+
+	patterns := make([]string, 0, len(bundleIDs))
+	for _, bundleID := range bundleIDs {
+		patterns = append(patterns, likeEscaper.Replace(prefixes[bundleID])+"%")
+	}
+
+	paths, err := scanDocumentPaths(s.db.Query(ctx, sqlf.Sprintf(uploadDocumentPathsQuery, pq.Array(bundleIDs), pq.Array(patterns))))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numUploads", len(paths)))
+
+	return paths, nil
+}
+
+// likeEscaper escapes the characters of a string that have a special meaning in a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+const uploadDocumentPathsQuery = `
+-- source: internal/codeintel/uploads/internal/lsifstore/lsifstore_documents.go:GetUploadDocumentPaths
+SELECT d.dump_id, d.path
+FROM unnest(%s::integer[], %s::text[]) AS p(dump_id, pattern)
+JOIN lsif_data_all_documents d ON d.dump_id = p.dump_id
+WHERE d.path LIKE p.pattern
+`
+
+var scanDocumentPaths = basestore.NewMapSliceScanner(func(s dbutil.Scanner) (id int, path string, err error) {
+	err = s.Scan(&id, &path)
+	return id, path, err
+})
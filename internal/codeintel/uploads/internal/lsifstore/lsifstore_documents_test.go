@@ -0,0 +1,57 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUploadDocumentPaths(t *testing.T) {
+	logger := logtest.ScopedWith(t, logtest.LoggerOptions{
+		Level: log.LevelError,
+	})
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	store := New(db, &observation.TestContext)
+
+	for _, document := range []struct {
+		dumpID int
+		path   string
+	}{
+		{1, "cmd/main.go"},
+		{1, "cmd/server/server.go"},
+		{1, "cmd_test/main.go"},
+		{1, "web/index.ts"},
+		{2, "100%/a.go"},
+		{2, "100x/b.go"},
+		{3, "main.go"},
+	} {
+		query := sqlf.Sprintf("INSERT INTO lsif_data_documents (dump_id, path, schema_version, num_diagnostics) VALUES (%s, %s, 3, 0)", document.dumpID, document.path)
+		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting document: %s", err)
+		}
+	}
+
+	paths, err := store.GetUploadDocumentPaths(context.Background(), map[int]string{1: "cmd/", 2: "100%/", 3: "", 4: ""})
+	if err != nil {
+		t.Fatalf("unexpected error getting upload document paths: %s", err)
+	}
+
+	expectedPaths := map[int][]string{
+		1: {"cmd/main.go", "cmd/server/server.go"},
+		2: {"100%/a.go"},
+		3: {"main.go"},
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,41 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUploadDataSizes returns the number of bytes occupied by the LSIF data of each of the given
+// uploads in the codeintel database, as recorded by the worker when the upload was processed.
+// Uploads without LSIF data, or processed before their size was recorded, are absent from the
+// returned map.
+func (s *store) GetUploadDataSizes(ctx context.Context, bundleIDs ...int) (_ map[int]int64, err error) {
+	ctx, _, endObservation := s.operations.getUploadDataSizes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numBundleIDs", len(bundleIDs)),
+		log.String("bundleIDs", intsToString(bundleIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(bundleIDs) == 0 {
+		return nil, nil
+	}
+
+	return scanSizes(s.db.Query(ctx, sqlf.Sprintf(uploadDataSizesQuery, pq.Array(bundleIDs))))
+}
+
+const uploadDataSizesQuery = `
+-- source: internal/codeintel/uploads/internal/lsifstore/lsifstore_sizes.go:GetUploadDataSizes
+SELECT dump_id, data_size FROM lsif_data_metadata WHERE dump_id = ANY(%s) AND data_size IS NOT NULL
+`
+
+var scanSizes = basestore.NewMapScanner(func(s dbutil.Scanner) (id int, size int64, err error) {
+	err = s.Scan(&id, &size)
+	return id, size, err
+})
@@ -0,0 +1,45 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUploadDataSizes(t *testing.T) {
+	logger := logtest.ScopedWith(t, logtest.LoggerOptions{
+		Level: log.LevelError,
+	})
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	store := New(db, &observation.TestContext)
+
+	for _, query := range []*sqlf.Query{
+		sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks, data_size) VALUES (1, 0, 100)"),
+		sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks, data_size) VALUES (2, 0, 250)"),
+		sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks, data_size) VALUES (3, 0, NULL)"),
+		sqlf.Sprintf("INSERT INTO lsif_data_metadata (dump_id, num_result_chunks, data_size) VALUES (4, 0, 1000)"),
+	} {
+		if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error inserting metadata: %s", err)
+		}
+	}
+
+	sizes, err := store.GetUploadDataSizes(context.Background(), 1, 2, 3, 5)
+	if err != nil {
+		t.Fatalf("unexpected error getting upload data sizes: %s", err)
+	}
+
+	if diff := cmp.Diff(map[int]int64{1: 100, 2: 250}, sizes); diff != "" {
+		t.Errorf("unexpected sizes (-want +got):\n%s", diff)
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 type operations struct {
 	deleteLsifDataByUploadIds *observation.Operation
+	getUploadDataSizes        *observation.Operation
+	getUploadDocumentPaths    *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -29,5 +31,7 @@ func newOperations(observationContext *observation.Context) *operations {
 
 	return &operations{
 		deleteLsifDataByUploadIds: op("DeleteLsifDataByUploadIds"),
+		getUploadDataSizes:        op("GetUploadDataSizes"),
+		getUploadDocumentPaths:    op("GetUploadDocumentPaths"),
 	}
 }
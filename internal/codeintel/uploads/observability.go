@@ -46,6 +46,8 @@ type operations struct {
 	hardDeleteUploads                 *observation.Operation
 	inferClosestUploads               *observation.Operation
 	backfillCommittedAtBatch          *observation.Operation
+	getUploadDataSizes                *observation.Operation
+	getCoverageReport                 *observation.Operation
 
 	// Dumps
 	findClosestDumps                   *observation.Operation
@@ -117,6 +119,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		hardDeleteUploads:                 op("HardDeleteUploads"),
 		inferClosestUploads:               op("InferClosestUploads"),
 		backfillCommittedAtBatch:          op("BackfillCommittedAtBatch"),
+		getUploadDataSizes:                op("GetUploadDataSizes"),
+		getCoverageReport:                 op("GetCoverageReport"),
 
 		// Dumps
 		findClosestDumps:                   op("FindClosestDumps"),
@@ -62,6 +62,8 @@ type service interface {
 	DeleteUploadsStuckUploading(ctx context.Context, uploadedBefore time.Time) (_ int, err error)
 	DeleteUploadsWithoutRepository(ctx context.Context, now time.Time) (_ map[int]int, err error)
 	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]shared.Dump, error)
+	GetUploadDataSizes(ctx context.Context, ids []int) (_ map[int]int64, err error)
+	GetCoverageReport(ctx context.Context, repositoryID int, commit, path string, limit int) (_ []shared.DirectoryCoverage, err error)
 
 	// Dumps
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) (_ []shared.Dump, err error)
@@ -420,6 +422,18 @@ func (s *Service) UpdateUploadRetention(ctx context.Context, protectedIDs, expir
 	return s.store.UpdateUploadRetention(ctx, protectedIDs, expiredIDs)
 }
 
+// GetUploadDataSizes returns the number of bytes occupied by the LSIF data of each of the given
+// uploads in the codeintel database. Uploads without LSIF data, or processed before their size was
+// recorded, are absent from the returned map.
+func (s *Service) GetUploadDataSizes(ctx context.Context, ids []int) (_ map[int]int64, err error) {
+	ctx, _, endObservation := s.operations.getUploadDataSizes.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{log.Int("total_ids", len(ids)), log.String("ids", fmt.Sprintf("%v", ids))},
+	})
+	defer endObservation(1, observation.Args{})
+
+	return s.lsifstore.GetUploadDataSizes(ctx, ids...)
+}
+
 func (s *Service) BackfillReferenceCountBatch(ctx context.Context, batchSize int) (err error) {
 	ctx, _, endObservation := s.operations.backfillReferenceCountBatch.With(ctx, &err, observation.Args{
 		LogFields: []log.Field{log.Int("batchSize", batchSize)},
@@ -0,0 +1,233 @@
+package uploads
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/grafana/regexp"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GetCoverageReport determines which files within the given directory of a repository have precise code
+// intelligence at the given commit. A file is covered by an upload visible from the commit if the upload
+// contains a document for that file. Files the current actor cannot read are not counted. The result
+// contains an entry for the given directory followed by an entry for at most limit of its immediate
+// subdirectories that contain code, ordered by path. Directory paths are relative to the repository root
+// and do not have a trailing slash; the root directory is the empty string.
+func (s *Service) GetCoverageReport(ctx context.Context, repositoryID int, commit, dir string, limit int) (_ []shared.DirectoryCoverage, err error) {
+	ctx, trace, endObservation := s.operations.getCoverageReport.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+		log.String("path", dir),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	dir = strings.Trim(dir, "/")
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	files, err := s.gitserverClient.ListFiles(ctx, repositoryID, commit, regexp.MustCompile("^"+regexp.QuoteMeta(prefix)))
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.ListFiles")
+	}
+	trace.Log(log.Int("numFiles", len(files)))
+
+	checker := authz.DefaultSubRepoPermsChecker
+	if authz.SubRepoEnabled(checker) {
+		repositoryName, err := s.store.RepoName(ctx, repositoryID)
+		if err != nil {
+			return nil, errors.Wrap(err, "store.RepoName")
+		}
+
+		a := actor.FromContext(ctx)
+		filtered := files[:0]
+		for _, file := range files {
+			if include, err := authz.FilterActorPath(ctx, checker, a, api.RepoName(repositoryName), file); err != nil {
+				return nil, err
+			} else if include {
+				filtered = append(filtered, file)
+			}
+		}
+		files = filtered
+		trace.Log(log.Int("numFilteredFiles", len(files)))
+	}
+
+	dumps, err := s.store.FindClosestDumps(ctx, repositoryID, commit, prefix, false, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "store.FindClosestDumps")
+	}
+	trace.Log(log.Int("numDumps", len(dumps)))
+
+	// Only request the documents of each dump that fall within the given directory. The
+	// document paths of a dump are relative to its root.
+	documentPrefixes := make(map[int]string, len(dumps))
+	for _, dump := range dumps {
+		if strings.HasPrefix(dump.Root, prefix) {
+			documentPrefixes[dump.ID] = ""
+		} else if strings.HasPrefix(prefix, dump.Root) {
+			documentPrefixes[dump.ID] = strings.TrimPrefix(prefix, dump.Root)
+		}
+	}
+
+	documentPaths, err := s.lsifstore.GetUploadDocumentPaths(ctx, documentPrefixes)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifstore.GetUploadDocumentPaths")
+	}
+
+	indexersByPath := map[string][]string{}
+	for _, dump := range dumps {
+		for _, documentPath := range documentPaths[dump.ID] {
+			filePath := dump.Root + documentPath
+			if containsString(indexersByPath[filePath], dump.Indexer) {
+				continue
+			}
+
+			indexersByPath[filePath] = append(indexersByPath[filePath], dump.Indexer)
+		}
+	}
+
+	return computeCoverage(dir, files, indexersByPath, limit), nil
+}
+
+// computeCoverage aggregates the given files within the given directory, and the indexers covering each
+// of them, into a coverage entry for the directory and for at most limit of its immediate subdirectories
+// containing code.
+func computeCoverage(dir string, files []string, indexersByPath map[string][]string, limit int) []shared.DirectoryCoverage {
+	type directoryCounts struct {
+		totalFiles        int
+		coveredFiles      int
+		coveredByIndexer  map[string]int
+		totalByLanguage   map[string]int
+		coveredByLanguage map[string]int
+	}
+
+	countsByDirectory := map[string]*directoryCounts{
+		dir: {coveredByIndexer: map[string]int{}, totalByLanguage: map[string]int{}, coveredByLanguage: map[string]int{}},
+	}
+
+	for _, file := range files {
+		language := coverageLanguage(file)
+		if language == "" {
+			continue
+		}
+		indexers := indexersByPath[file]
+
+		dirs := []string{dir}
+		if child, ok := childDirectory(dir, file); ok {
+			dirs = append(dirs, child)
+		}
+
+		for _, dir := range dirs {
+			counts, ok := countsByDirectory[dir]
+			if !ok {
+				counts = &directoryCounts{coveredByIndexer: map[string]int{}, totalByLanguage: map[string]int{}, coveredByLanguage: map[string]int{}}
+				countsByDirectory[dir] = counts
+			}
+
+			counts.totalFiles++
+			counts.totalByLanguage[language]++
+			if len(indexers) > 0 {
+				counts.coveredFiles++
+				counts.coveredByLanguage[language]++
+			}
+			for _, indexer := range indexers {
+				counts.coveredByIndexer[indexer]++
+			}
+		}
+	}
+
+	coverage := make([]shared.DirectoryCoverage, 0, len(countsByDirectory))
+	for dir, counts := range countsByDirectory {
+		indexers := make([]shared.IndexerCoverage, 0, len(counts.coveredByIndexer))
+		for indexer, coveredFiles := range counts.coveredByIndexer {
+			indexers = append(indexers, shared.IndexerCoverage{Indexer: indexer, CoveredFiles: coveredFiles})
+		}
+		sort.Slice(indexers, func(i, j int) bool { return indexers[i].Indexer < indexers[j].Indexer })
+
+		languages := make([]shared.LanguageCoverage, 0, len(counts.totalByLanguage))
+		for language, totalFiles := range counts.totalByLanguage {
+			languages = append(languages, shared.LanguageCoverage{
+				Language:     language,
+				TotalFiles:   totalFiles,
+				CoveredFiles: counts.coveredByLanguage[language],
+			})
+		}
+		sort.Slice(languages, func(i, j int) bool { return languages[i].Language < languages[j].Language })
+
+		coverage = append(coverage, shared.DirectoryCoverage{
+			Path:         dir,
+			TotalFiles:   counts.totalFiles,
+			CoveredFiles: counts.coveredFiles,
+			Indexers:     indexers,
+			Languages:    languages,
+		})
+	}
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Path < coverage[j].Path })
+
+	// The given directory sorts before all of its subdirectories
+	if limit < 0 {
+		limit = 0
+	}
+	if len(coverage) > limit+1 {
+		coverage = coverage[:limit+1]
+	}
+
+	return coverage
+}
+
+// childDirectory returns the immediate subdirectory of the given directory that contains the given
+// file. The second return value is false if the file is directly within the given directory.
+func childDirectory(dir, file string) (string, bool) {
+	relative := file
+	if dir != "" {
+		relative = strings.TrimPrefix(file, dir+"/")
+	}
+
+	i := strings.IndexByte(relative, '/')
+	if i < 0 {
+		return "", false
+	}
+
+	return path.Join(dir, relative[:i]), true
+}
+
+// coverageLanguage returns the programming language of the given file, or an empty string if the
+// file is vendored or is not written in a programming language recognized by enry.
+func coverageLanguage(file string) string {
+	if enry.IsVendor(file) {
+		return ""
+	}
+
+	language, _ := enry.GetLanguageByExtension(file)
+	if language == "" {
+		language, _ = enry.GetLanguageByFilename(file)
+	}
+	if language == "" || enry.GetLanguageType(language) != enry.Programming {
+		return ""
+	}
+
+	return language
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+package uploads
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetCoverageReport(t *testing.T) {
+	ctx := context.Background()
+	store := NewMockStore()
+	lsifStore := NewMockLsifStore()
+	gitserverClient := NewMockGitserverClient()
+	svc := newService(store, lsifStore, gitserverClient, nil, &observation.TestContext)
+
+	gitserverClient.ListFilesFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error) {
+		var files []string
+		for _, file := range []string{
+			"README.md",
+			"cmd/main.go",
+			"cmd/server/server.go",
+			"cmd/server/server_test.go",
+			"cmd/server/handler.ts",
+			"cmd/server/internal/util.go",
+			"cmd/server/vendor/dep/dep.go",
+			"web/index.ts",
+		} {
+			if pattern.MatchString(file) {
+				files = append(files, file)
+			}
+		}
+		return files, nil
+	})
+	store.FindClosestDumpsFunc.SetDefaultReturn([]shared.Dump{
+		{ID: 1, Root: "", Indexer: "lsif-go"},
+		{ID: 2, Root: "cmd/server/", Indexer: "scip-typescript"},
+		{ID: 3, Root: "cmd/", Indexer: "lsif-go"},
+	}, nil)
+	documentPaths := map[int][]string{
+		1: {"cmd/main.go", "cmd/server/server.go", "web/index.ts"},
+		2: {"handler.ts"},
+		3: {"main.go"},
+	}
+	lsifStore.GetUploadDocumentPathsFunc.SetDefaultHook(func(ctx context.Context, prefixes map[int]string) (map[int][]string, error) {
+		paths := map[int][]string{}
+		for id, prefix := range prefixes {
+			for _, path := range documentPaths[id] {
+				if strings.HasPrefix(path, prefix) {
+					paths[id] = append(paths[id], path)
+				}
+			}
+		}
+		return paths, nil
+	})
+
+	coverage, err := svc.GetCoverageReport(ctx, 42, "deadbeef", "cmd/", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCoverage := []shared.DirectoryCoverage{
+		{
+			Path:         "cmd",
+			TotalFiles:   5,
+			CoveredFiles: 3,
+			Indexers: []shared.IndexerCoverage{
+				{Indexer: "lsif-go", CoveredFiles: 2},
+				{Indexer: "scip-typescript", CoveredFiles: 1},
+			},
+			Languages: []shared.LanguageCoverage{
+				{Language: "Go", TotalFiles: 4, CoveredFiles: 2},
+				{Language: "TypeScript", TotalFiles: 1, CoveredFiles: 1},
+			},
+		},
+		{
+			Path:         "cmd/server",
+			TotalFiles:   4,
+			CoveredFiles: 2,
+			Indexers: []shared.IndexerCoverage{
+				{Indexer: "lsif-go", CoveredFiles: 1},
+				{Indexer: "scip-typescript", CoveredFiles: 1},
+			},
+			Languages: []shared.LanguageCoverage{
+				{Language: "Go", TotalFiles: 3, CoveredFiles: 1},
+				{Language: "TypeScript", TotalFiles: 1, CoveredFiles: 1},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCoverage, coverage); diff != "" {
+		t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+	}
+
+	if history := lsifStore.GetUploadDocumentPathsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetUploadDocumentPaths. want=%d have=%d", 1, len(history))
+	} else if diff := cmp.Diff(map[int]string{1: "cmd/", 2: "", 3: ""}, history[0].Arg1); diff != "" {
+		t.Errorf("unexpected document prefixes (-want +got):\n%s", diff)
+	}
+
+	if history := gitserverClient.ListFilesFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of ListFiles calls. want=%d have=%d", 1, len(history))
+	} else if pattern := history[0].Arg3.String(); pattern != "^cmd/" {
+		t.Errorf("unexpected pattern. want=%q have=%q", "^cmd/", pattern)
+	}
+}
+
+func TestGetCoverageReportEmptyDirectory(t *testing.T) {
+	svc := newService(NewMockStore(), NewMockLsifStore(), NewMockGitserverClient(), nil, &observation.TestContext)
+
+	coverage, err := svc.GetCoverageReport(context.Background(), 42, "deadbeef", "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCoverage := []shared.DirectoryCoverage{
+		{Path: "", Indexers: []shared.IndexerCoverage{}, Languages: []shared.LanguageCoverage{}},
+	}
+	if diff := cmp.Diff(expectedCoverage, coverage); diff != "" {
+		t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetCoverageReportLimit(t *testing.T) {
+	gitserverClient := NewMockGitserverClient()
+	gitserverClient.ListFilesFunc.SetDefaultReturn([]string{
+		"a/a.go",
+		"b/b.go",
+		"c/c.go",
+		"main.go",
+	}, nil)
+	svc := newService(NewMockStore(), NewMockLsifStore(), gitserverClient, nil, &observation.TestContext)
+
+	coverage, err := svc.GetCoverageReport(context.Background(), 42, "deadbeef", "", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var paths []string
+	for _, directory := range coverage {
+		paths = append(paths, directory.Path)
+	}
+	if diff := cmp.Diff([]string{"", "a", "b"}, paths); diff != "" {
+		t.Errorf("unexpected directories (-want +got):\n%s", diff)
+	}
+	if coverage[0].TotalFiles != 4 {
+		t.Errorf("unexpected total files. want=%d have=%d", 4, coverage[0].TotalFiles)
+	}
+}
+
+func TestGetCoverageReportSubRepoPermissions(t *testing.T) {
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if strings.HasPrefix(content.Path, "secret/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	authz.DefaultSubRepoPermsChecker = checker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = nil })
+
+	store := NewMockStore()
+	store.RepoNameFunc.SetDefaultReturn("github.com/test/test", nil)
+	gitserverClient := NewMockGitserverClient()
+	gitserverClient.ListFilesFunc.SetDefaultReturn([]string{
+		"public/main.go",
+		"secret/main.go",
+	}, nil)
+	svc := newService(store, NewMockLsifStore(), gitserverClient, nil, &observation.TestContext)
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	coverage, err := svc.GetCoverageReport(ctx, 42, "deadbeef", "", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedCoverage := []shared.DirectoryCoverage{
+		{
+			Path:       "",
+			TotalFiles: 1,
+			Indexers:   []shared.IndexerCoverage{},
+			Languages:  []shared.LanguageCoverage{{Language: "Go", TotalFiles: 1}},
+		},
+		{
+			Path:       "public",
+			TotalFiles: 1,
+			Indexers:   []shared.IndexerCoverage{},
+			Languages:  []shared.LanguageCoverage{{Language: "Go", TotalFiles: 1}},
+		},
+	}
+	if diff := cmp.Diff(expectedCoverage, coverage); diff != "" {
+		t.Errorf("unexpected coverage (-want +got):\n%s", diff)
+	}
+}
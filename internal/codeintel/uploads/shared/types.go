@@ -94,6 +94,32 @@ const (
 	DependencyReferenceCountUpdateTypeRemove
 )
 
+// DirectoryCoverage describes how many of the files within a directory (recursively) have precise
+// code intelligence at a particular commit. Only files written in a programming language recognized
+// by enry are counted.
+type DirectoryCoverage struct {
+	Path         string
+	TotalFiles   int
+	CoveredFiles int
+	Indexers     []IndexerCoverage
+	Languages    []LanguageCoverage
+}
+
+// IndexerCoverage counts the files within a directory that have precise code intelligence produced
+// by a particular indexer.
+type IndexerCoverage struct {
+	Indexer      string
+	CoveredFiles int
+}
+
+// LanguageCoverage counts the files within a directory that are written in a particular language,
+// and how many of those have precise code intelligence.
+type LanguageCoverage struct {
+	Language     string
+	TotalFiles   int
+	CoveredFiles int
+}
+
 type CursorAdjustedUpload struct {
 	DumpID               int      `json:"dumpID"`
 	AdjustedPath         string   `json:"adjustedPath"`
@@ -10,6 +10,7 @@ import (
 type operations struct {
 	commitGraph       *observation.Operation
 	deleteLSIFUpload  *observation.Operation
+	getCoverageReport *observation.Operation
 	lsifUploadByID    *observation.Operation
 	lsifUploads       *observation.Operation
 	lsifUploadsByRepo *observation.Operation
@@ -34,6 +35,7 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		commitGraph:       op("CommitGraph"),
 		deleteLSIFUpload:  op("DeleteLSIFUpload"),
+		getCoverageReport: op("GetCoverageReport"),
 		lsifUploadByID:    op("LSIFUploadByID"),
 		lsifUploads:       op("LSIFUploads"),
 		lsifUploadsByRepo: op("LSIFUploadsByRepo"),
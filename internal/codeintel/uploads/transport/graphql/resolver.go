@@ -5,9 +5,11 @@ import (
 	"errors"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/opentracing/opentracing-go/log"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	uploads "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -67,3 +69,17 @@ func (r *Resolver) CommitGraph(ctx context.Context, id graphql.ID) (_ gql.CodeIn
 	_, _ = ctx, id
 	return nil, errors.New("unimplemented: CommitGraph")
 }
+
+// GetCoverageReport returns the precise code intelligence coverage of the given directory and
+// at most limit of its immediate subdirectories at the given commit.
+func (r *Resolver) GetCoverageReport(ctx context.Context, repositoryID int, commit, path string, limit int) (_ []shared.DirectoryCoverage, err error) {
+	ctx, _, endObservation := r.operations.getCoverageReport.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+		log.String("path", path),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return r.svc.GetCoverageReport(ctx, repositoryID, commit, path, limit)
+}
@@ -209,6 +209,7 @@ var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindGitolite:        {CodeHost: true, JSONSchema: schema.GitoliteSchemaJSON},
 	extsvc.KindGoPackages:      {CodeHost: true, JSONSchema: schema.GoModulesSchemaJSON},
 	extsvc.KindJVMPackages:     {CodeHost: true, JSONSchema: schema.JVMPackagesSchemaJSON},
+	extsvc.KindMercurial:       {CodeHost: true, JSONSchema: schema.MercurialSchemaJSON},
 	extsvc.KindNpmPackages:     {CodeHost: true, JSONSchema: schema.NpmPackagesSchemaJSON},
 	extsvc.KindOther:           {CodeHost: true, JSONSchema: schema.OtherExternalServiceSchemaJSON},
 	extsvc.KindPagure:          {CodeHost: true, JSONSchema: schema.PagureSchemaJSON},
@@ -449,6 +450,13 @@ func MakeValidateExternalServiceConfigFunc(gitHubValidators []func(*types.GitHub
 				return nil, err
 			}
 			err = validateOtherExternalServiceConnection(&c)
+
+		case extsvc.KindMercurial:
+			var c schema.MercurialConnection
+			if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+				return nil, err
+			}
+			err = validateMercurialConnection(&c)
 		}
 
 		return normalized, errors.Append(errs, err)
@@ -484,6 +492,23 @@ func validateOtherExternalServiceConnection(c *schema.OtherExternalServiceConnec
 	return nil
 }
 
+// validateMercurialConnection validates that repo items are paths relative
+// to the base URL, so that the credentials in the base URL are never sent to
+// another host.
+func validateMercurialConnection(c *schema.MercurialConnection) error {
+	for i, repo := range c.Repos {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return errors.Errorf(`repos.%d: %s`, i, err)
+		}
+		if u.Scheme != "" || u.Host != "" {
+			return errors.Errorf("repos.%d: %q is not a path relative to url", i, repo)
+		}
+	}
+
+	return nil
+}
+
 func validateGitHubConnection(githubValidators []func(*types.GitHubConnection) error, id int64, c *schema.GitHubConnection) error {
 	var err error
 	for _, validate := range githubValidators {
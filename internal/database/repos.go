@@ -554,6 +554,8 @@ func scanRepo(logger log.Logger, rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(pagure.Project)
 	case extsvc.TypeOther:
 		r.Metadata = new(extsvc.OtherRepoMetadata)
+	case extsvc.TypeMercurial:
+		r.Metadata = new(extsvc.MercurialRepoMetadata)
 	case extsvc.TypeJVMPackages:
 		r.Metadata = new(reposource.MavenMetadata)
 	case extsvc.TypeNpmPackages:
@@ -123,6 +123,7 @@ const (
 	KindRustPackages    = "RUSTPACKAGES"
 	KindNpmPackages     = "NPMPACKAGES"
 	KindPagure          = "PAGURE"
+	KindMercurial       = "MERCURIAL"
 	KindOther           = "OTHER"
 )
 
@@ -183,6 +184,10 @@ const (
 	// TypeRustPackages is the (api.ExternalRepoSpec).ServiceType value for Python packages.
 	TypeRustPackages = "rustPackages"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the base URL of the repositories without credentials.
+	TypeMercurial = "mercurial"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 )
@@ -221,6 +226,8 @@ func KindToType(kind string) string {
 		return TypeGoModules
 	case KindPagure:
 		return TypePagure
+	case KindMercurial:
+		return TypeMercurial
 	case KindOther:
 		return TypeOther
 	default:
@@ -262,6 +269,8 @@ func TypeToKind(t string) string {
 		return KindGoPackages
 	case TypePagure:
 		return KindPagure
+	case TypeMercurial:
+		return KindMercurial
 	case TypeOther:
 		return KindOther
 	default:
@@ -314,6 +323,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeRustPackages, true
 	case TypePagure:
 		return TypePagure, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypeOther:
 		return TypeOther, true
 	default:
@@ -353,6 +364,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindRustPackages, true
 	case KindPagure:
 		return KindPagure, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -432,6 +445,8 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.PythonPackagesConnection{}, nil
 	case KindRustPackages:
 		return &schema.RustPackagesConnection{}, nil
+	case KindMercurial:
+		return &schema.MercurialConnection{}, nil
 	case KindOther:
 		return &schema.OtherExternalServiceConnection{}, nil
 	default:
@@ -666,6 +681,13 @@ type OtherRepoMetadata struct {
 	RelativePath string
 }
 
+type MercurialRepoMetadata struct {
+	// RelativePath is relative to the base URL of the connection. Unlike
+	// ServiceID, the base URL may contain credentials. Joining them gives you
+	// the clone url.
+	RelativePath string
+}
+
 func UniqueEncryptableCodeHostIdentifier(ctx context.Context, kind string, config *EncryptableConfig) (string, error) {
 	cfg, err := ParseEncryptableConfig(ctx, kind, config)
 	if err != nil {
@@ -713,6 +735,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
@@ -15,12 +15,25 @@ import (
 
 type Git struct {
 	ReposDir string // The root directory where repos are stored
+
+	// Env returns the environment of git commands in the given repo. If Env is nil or returns nil,
+	// git commands run in the environment of the current process.
+	Env func(ctx context.Context, repo api.RepoName) []string
+}
+
+// command returns a git command with the given arguments that runs in the given repo.
+func (g *Git) command(ctx context.Context, repo api.RepoName, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoDir(repo, g.ReposDir)
+	if g.Env != nil {
+		cmd.Env = g.Env(ctx, repo)
+	}
+	return cmd
 }
 
 // RevParse will run rev-parse on the given rev
 func (g *Git) RevParse(ctx context.Context, repo api.RepoName, rev string) (string, error) {
-	cmd := exec.CommandContext(ctx, "git", "rev-parse", rev)
-	cmd.Dir = repoDir(repo, g.ReposDir)
+	cmd := g.command(ctx, repo, "rev-parse", rev)
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -32,8 +45,7 @@ func (g *Git) RevParse(ctx context.Context, repo api.RepoName, rev string) (stri
 
 // GetObjectType returns the object type given an objectID
 func (g *Git) GetObjectType(ctx context.Context, repo api.RepoName, objectID string) (gitdomain.ObjectType, error) {
-	cmd := exec.CommandContext(ctx, "git", "cat-file", "-t", "--", objectID)
-	cmd.Dir = repoDir(repo, g.ReposDir)
+	cmd := g.command(ctx, repo, "cat-file", "-t", "--", objectID)
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -44,6 +56,54 @@ func (g *Git) GetObjectType(ctx context.Context, repo api.RepoName, objectID str
 	return objectType, nil
 }
 
+// FileHistory returns the commits that changed the given file, following it across renames and copies,
+// and whether there are more commits beyond those returned. If the commit does not exist, a
+// *gitdomain.RevisionNotFoundError is returned.
+func (g *Git) FileHistory(ctx context.Context, repo api.RepoName, opts gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+	if opts.N <= 0 {
+		return nil, false, errors.New("N must be positive")
+	}
+
+	commit := opts.Commit
+	if commit == "" {
+		commit = "HEAD"
+	}
+	if err := g.command(ctx, repo, "rev-parse", "--verify", "--quiet", commit+"^{commit}").Run(); err != nil {
+		var e *exec.ExitError
+		if errors.As(err, &e) && ctx.Err() == nil {
+			return nil, false, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: commit}
+		}
+		return nil, false, err
+	}
+
+	// git log --skip does not account for --follow, so we fetch all skipped entries and drop
+	// them here. We ask for one more entry than requested to tell whether there is a next page.
+	cmd := g.command(ctx, repo, gitdomain.FileHistoryArgs(commit, opts.Path, opts.Skip+opts.N+1)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, false, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, stderr.Bytes()))
+	}
+
+	entries, err := gitdomain.ParseFileHistory(out, opts.Path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if opts.Skip >= len(entries) {
+		return nil, false, nil
+	}
+	entries = entries[opts.Skip:]
+
+	if len(entries) > opts.N {
+		return entries[:opts.N], true, nil
+	}
+	return entries, false, nil
+}
+
 func repoDir(name api.RepoName, reposDir string) string {
 	path := string(protocol.NormalizeRepo(name))
 	return filepath.Join(reposDir, filepath.FromSlash(path), ".git")
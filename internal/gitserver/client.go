@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cespare/xxhash/v2"
@@ -33,6 +34,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/go-rendezvous"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -77,10 +79,11 @@ func NewClient(db database.DB) Client {
 		addrs: func() []string {
 			return conf.Get().ServiceConnections().GitServers
 		},
-		pinned:      pinnedReposFromConfig,
-		db:          db,
-		httpClient:  defaultDoer,
-		HTTPLimiter: defaultLimiter,
+		pinned:            pinnedReposFromConfig,
+		replicationFactor: replicationFactorFromConfig,
+		db:                db,
+		httpClient:        defaultDoer,
+		HTTPLimiter:       defaultLimiter,
 		// Use the binary name for userAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -97,9 +100,10 @@ func NewTestClient(cli httpcli.Doer, db database.DB, addrs []string) Client {
 		addrs: func() []string {
 			return addrs
 		},
-		pinned:      pinnedReposFromConfig,
-		httpClient:  cli,
-		HTTPLimiter: parallel.NewRun(500),
+		pinned:            pinnedReposFromConfig,
+		replicationFactor: replicationFactorFromConfig,
+		httpClient:        cli,
+		HTTPLimiter:       parallel.NewRun(500),
 		// Use the binary name for userAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -134,6 +138,10 @@ type clientImplementor struct {
 	// and sync the pinned map.
 	pinned func() map[string]string
 
+	// replicationFactor returns the number of gitserver instances each repository is stored on.
+	// Like pinned, it should read the current configuration on each call.
+	replicationFactor func() int
+
 	// db is a connection to the database
 	db database.DB
 
@@ -151,6 +159,10 @@ type Client interface {
 	// AddrForRepo returns the gitserver address to use for the given repo name.
 	AddrForRepo(context.Context, api.RepoName) (string, error)
 
+	// AddrsForRepo returns the addresses of the gitserver replicas holding the given repo
+	// name in order of preference. The first address is the one returned by AddrForRepo.
+	AddrsForRepo(context.Context, api.RepoName) ([]string, error)
+
 	// ArchiveReader streams back the file contents of an archived git repo.
 	ArchiveReader(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, options ArchiveOptions) (io.ReadCloser, error)
 
@@ -166,6 +178,12 @@ type Client interface {
 	// If possible, the error returned will be of type protocol.CreateCommitFromPatchError
 	CreateCommitFromPatch(context.Context, protocol.CreateCommitFromPatchRequest) (string, error)
 
+	// FileHistory returns the commits that changed the given file, following the file across
+	// renames and copies, and whether there are more commits beyond those returned. Commits
+	// touching a path the current actor cannot read are omitted and are not counted by the
+	// N and Skip options. N must be positive.
+	FileHistory(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, opts gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error)
+
 	// GetDefaultBranch returns the name of the default branch and the commit it's
 	// currently at from the given repository. If short is true, then `main` instead
 	// of `refs/heads/main` would be returned.
@@ -255,6 +273,16 @@ type Client interface {
 	// of the given path between the given source and target commits.
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
 
+	// DiffFileRevisions returns a position-ordered slice of changes (additions or deletions)
+	// between the given source path at the source commit and the given target path at the
+	// target commit. If either path does not exist in its commit, os.ErrNotExist is returned.
+	DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error)
+
+	// RenamedFiles returns the renames of the file with the given path in the first of the
+	// given commits made by the commits that are reachable from exactly one of the given
+	// commits, oldest first.
+	RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]RenamedFile, error)
+
 	// ReadDir reads the contents of the named directory at commit.
 	ReadDir(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string, recurse bool) ([]fs.FileInfo, error)
 
@@ -325,6 +353,26 @@ type Client interface {
 	// many commits will be returned.
 	CommitGraph(ctx context.Context, repo api.RepoName, opts CommitGraphOptions) (_ *gitdomain.CommitGraph, err error)
 
+	// CommitGraphIsAncestor returns true if the commit of the ancestor revision is an
+	// ancestor of (or equal to) the commit of the descendant revision. It is served
+	// from the commit graph index maintained by gitserver rather than running git.
+	//
+	// The CommitGraph* methods are not used by existing callers of git log and
+	// rev-list (e.g. HasCommitAfter and CommitsUniqueToBranch) yet, as they accept
+	// git date expressions and revision ranges that the index does not support.
+	CommitGraphIsAncestor(ctx context.Context, repo api.RepoName, ancestor, descendant string) (bool, error)
+
+	// CommitGraphMergeBase returns a best common ancestor of the commits of the given
+	// revisions, or an empty commit ID if they have no common ancestor. It is served
+	// from the commit graph index maintained by gitserver rather than running git.
+	CommitGraphMergeBase(ctx context.Context, repo api.RepoName, a, b string) (api.CommitID, error)
+
+	// CommitGraphCommitsBetween returns at most limit of the commits reachable from
+	// the given revision whose committer date is within [after, before), from the most
+	// recent to the oldest. A zero bound is ignored. It is served from the commit graph
+	// index maintained by gitserver rather than running git.
+	CommitGraphCommitsBetween(ctx context.Context, repo api.RepoName, rev string, after, before time.Time, limit int) ([]gitdomain.CommitGraphCommit, error)
+
 	// CommitsUniqueToBranch returns a map from commits that exist on a particular
 	// branch in the given repository to their committer date. This set of commits is
 	// determined by listing `{branchName} ^HEAD`, which is interpreted as: all
@@ -384,6 +432,18 @@ func (c *clientImplementor) AddrForRepo(ctx context.Context, repo api.RepoName)
 	})
 }
 
+func (c *clientImplementor) AddrsForRepo(ctx context.Context, repo api.RepoName) ([]string, error) {
+	addrs := c.Addrs()
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return AddrsForRepo(ctx, c.userAgent, c.db, repo, GitServerAddresses{
+		Addresses:         addrs,
+		PinnedServers:     c.pinned(),
+		ReplicationFactor: c.replicationFactor(),
+	})
+}
+
 func (c *clientImplementor) RendezvousAddrForRepo(repo api.RepoName) string {
 	addrs := c.Addrs()
 	if len(addrs) == 0 {
@@ -422,9 +482,48 @@ func AddrForRepo(ctx context.Context, userAgent string, db database.DB, repo api
 	return addrForKey(rs, addresses.Addresses), nil
 }
 
+// AddrsForRepo returns the addresses of the gitserver replicas holding the given repo name in
+// order of preference. The first address is the one returned by AddrForRepo; the remaining
+// replicas are the best of the other gitserver addresses for the repo according to the Rendezvous
+// hashing scheme. The returned addresses are distinct. It should never be called with a nil
+// addresses pointer.
+func AddrsForRepo(ctx context.Context, userAgent string, db database.DB, repo api.RepoName, addresses GitServerAddresses) ([]string, error) {
+	primary, err := AddrForRepo(ctx, userAgent, db, repo, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	n := addresses.ReplicationFactor
+	if n > len(addresses.Addresses) {
+		n = len(addresses.Addresses)
+	}
+	if n <= 1 {
+		return []string{primary}, nil
+	}
+
+	// Rank only the addresses other than the primary so that the replicas are the same
+	// whether the primary was chosen by Rendezvous hashing, by hashing the repo name,
+	// or by pinning.
+	others := make([]string, 0, len(addresses.Addresses))
+	for _, addr := range addresses.Addresses {
+		if addr != primary {
+			others = append(others, addr)
+		}
+	}
+	if len(others) == 0 {
+		return []string{primary}, nil
+	}
+
+	return append([]string{primary}, RendezvousAddrsForRepo(repo, others, n-1)...), nil
+}
+
 type GitServerAddresses struct {
 	Addresses     []string
 	PinnedServers map[string]string
+
+	// ReplicationFactor is the number of gitserver instances each repository is stored on.
+	// Values less than one are treated as one.
+	ReplicationFactor int
 }
 
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
@@ -436,6 +535,34 @@ func RendezvousAddrForRepo(repo api.RepoName, addrs []string) string {
 	return r.Lookup(string(protocol.NormalizeRepo(repo)))
 }
 
+// RendezvousAddrsForRepo returns the n best gitserver addresses for the given repo name using the
+// Rendezvous hashing scheme, in order of preference. The first address is the one returned by
+// RendezvousAddrForRepo.
+//
+// It should never be called with an empty slice.
+func RendezvousAddrsForRepo(repo api.RepoName, addrs []string, n int) []string {
+	key := string(protocol.NormalizeRepo(repo))
+	remaining := append([]string(nil), addrs...)
+
+	// The best remaining address is looked up repeatedly rather than using LookupN, which
+	// may return the same address more than once.
+	ranked := make([]string, 0, n)
+	for len(ranked) < n && len(remaining) > 0 {
+		addr := rendezvous.New(remaining, xxhash.Sum64String).Lookup(key)
+		ranked = append(ranked, addr)
+
+		filtered := remaining[:0]
+		for _, a := range remaining {
+			if a != addr {
+				filtered = append(filtered, a)
+			}
+		}
+		remaining = filtered
+	}
+
+	return ranked
+}
+
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
@@ -485,9 +612,9 @@ func (a *archiveReader) Close() error {
 	return a.base.Close()
 }
 
-// archiveURL returns a URL from which an archive of the given Git repository can
-// be downloaded from.
-func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, opt ArchiveOptions) (*url.URL, error) {
+// archiveURL returns a URL relative to a gitserver instance from which an archive of the
+// given Git repository can be downloaded from.
+func archiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -498,16 +625,10 @@ func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, o
 		q.Add("path", string(pathspec))
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repo)
-	if err != nil {
-		return nil, err
-	}
 	return &url.URL{
-		Scheme:   "http",
-		Host:     addrForRepo,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
-	}, nil
+	}
 }
 
 type badRequestError struct{ error }
@@ -588,13 +709,7 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repoName)
-	if err != nil {
-		return false, err
-	}
-
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	resp, err := c.doWithFailover(ctx, repoName, "POST", "/search", buf.Bytes())
 	if err != nil {
 		return false, err
 	}
@@ -844,7 +959,42 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		Repo:  repo,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo, "repo-update", req)
+
+	addrs, err := c.AddrsForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Updates are sent to every replica so that each of them holds an up-to-date clone of the
+	// repository. The response of the most preferred replica that handled the request is
+	// returned, and an error is only returned if no replica handled it.
+	infos := make([]*protocol.RepoUpdateResponse, len(addrs))
+	errs := make([]error, len(addrs))
+
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			infos[i], errs[i] = c.requestRepoUpdateFrom(ctx, repo, addr, req)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	var allErr error
+	for i := range addrs {
+		if errs[i] == nil {
+			return infos[i], nil
+		}
+		allErr = errors.Append(allErr, errs[i])
+	}
+
+	return nil, allErr
+}
+
+// requestRepoUpdateFrom sends the given update request to the given gitserver instance.
+func (c *clientImplementor) requestRepoUpdateFrom(ctx context.Context, repo api.RepoName, addr string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.httpPostWithURI(ctx, repo, "http://"+addr+"/repo-update", req)
 	if err != nil {
 		return nil, err
 	}
@@ -971,16 +1121,17 @@ func (c *clientImplementor) RepoCloneProgress(ctx context.Context, repos ...api.
 	}
 
 	type op struct {
-		req *protocol.RepoCloneProgressRequest
-		res *protocol.RepoCloneProgressResponse
-		err error
+		addr string
+		req  *protocol.RepoCloneProgressRequest
+		res  *protocol.RepoCloneProgressResponse
+		err  error
 	}
 
 	ch := make(chan op, len(shards))
-	for _, req := range shards {
+	for addr, req := range shards {
 		go func(o op) {
 			var resp *http.Response
-			resp, o.err = c.httpPost(ctx, o.req.Repos[0], "repo-clone-progress", o.req)
+			resp, o.err = c.httpPostWithURI(ctx, o.req.Repos[0], "http://"+o.addr+"/repo-clone-progress", o.req)
 			if o.err != nil {
 				ch <- o
 				return
@@ -1000,7 +1151,7 @@ func (c *clientImplementor) RepoCloneProgress(ctx context.Context, repos ...api.
 			o.res = new(protocol.RepoCloneProgressResponse)
 			o.err = json.NewDecoder(resp.Body).Decode(o.res)
 			ch <- o
-		}(op{req: req})
+		}(op{addr: addr, req: req})
 	}
 
 	var err error
@@ -1061,12 +1212,19 @@ func (c *clientImplementor) doReposStats(ctx context.Context, addr string) (*pro
 
 func (c *clientImplementor) Remove(ctx context.Context, repo api.RepoName) error {
 	// In case the repo has already been deleted from the database we need to pass
-	// the old name in order to land on the correct gitserver instance.
-	addr, err := c.AddrForRepo(ctx, api.UndeletedRepoName(repo))
+	// the old name in order to land on the correct gitserver instances.
+	addrs, err := c.AddrsForRepo(ctx, api.UndeletedRepoName(repo))
 	if err != nil {
 		return err
 	}
-	return c.RemoveFrom(ctx, repo, addr)
+
+	var allErr error
+	for _, addr := range addrs {
+		if err := c.RemoveFrom(ctx, repo, addr); err != nil {
+			allErr = errors.Append(allErr, err)
+		}
+	}
+	return allErr
 }
 
 func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, from string) error {
@@ -1095,14 +1253,19 @@ func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, f
 }
 
 // httpPost will apply the MD5 hashing scheme on the repo name to determine the gitserver instance
-// to which the HTTP POST request is sent. To use the rendezvous hashing scheme, see
-// httpPostWithURI.
+// to which the HTTP POST request is sent. If the repo is replicated and op only reads from it, the
+// request fails over to the other replicas as described by doWithFailover. To use the rendezvous
+// hashing scheme, see httpPostWithURI.
 func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
+	if isReadOp(op) {
+		return c.doWithFailover(ctx, repo, "POST", "/"+op, b)
+	}
+
 	addrForRepo, err := c.AddrForRepo(ctx, repo)
 	if err != nil {
 		return nil, err
@@ -1111,6 +1274,68 @@ func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
+// isReadOp returns true if the given gitserver operation only reads from a repository, so that
+// retrying it against another replica has no side effects. Operations that change a repository,
+// like create-commit-from-patch, must only be sent to the primary replica.
+func isReadOp(op string) bool {
+	switch op {
+	case "exec", "archive", "search":
+		return true
+	}
+	return strings.HasPrefix(op, "commands/") || strings.HasPrefix(op, "commit-graph/")
+}
+
+var replicaFailoverCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover_total",
+	Help: "Number of requests retried against another gitserver replica",
+}, []string{"path"})
+
+// doWithFailover performs a request to the replicas of the given repo in order of preference until
+// one of them is able to serve it. It must only be used for requests that do not change the repo. A replica is skipped if it cannot be reached, fails with a server
+// error, or does not have the repo cloned. The response of the last replica is returned as-is. The
+// given path may include a query string.
+func (c *clientImplementor) doWithFailover(ctx context.Context, repo api.RepoName, method, path string, payload []byte) (*http.Response, error) {
+	addrs, err := c.AddrsForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, addr := range addrs {
+		resp, err := c.do(ctx, repo, method, "http://"+addr+path, payload)
+		if i == len(addrs)-1 || !shouldFailover(ctx, resp, err) {
+			return resp, err
+		}
+
+		fields := []sglog.Field{
+			sglog.String("repo", string(repo)),
+			sglog.String("addr", addr),
+			sglog.String("next", addrs[i+1]),
+		}
+		if err != nil {
+			fields = append(fields, sglog.Error(err))
+		} else {
+			fields = append(fields, sglog.Int("statusCode", resp.StatusCode))
+			resp.Body.Close()
+		}
+
+		parsedURL, _ := url.Parse(path)
+		replicaFailoverCounter.WithLabelValues(parsedURL.Path).Inc()
+		c.logger.Warn("failing over to another gitserver replica", fields...)
+	}
+
+	return nil, errors.Newf("no gitserver replicas for repo %q", repo)
+}
+
+// shouldFailover returns true if the given outcome of a request to a gitserver replica indicates
+// that another replica may be able to serve the request.
+func shouldFailover(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+
+	return resp.StatusCode == http.StatusNotFound || resp.StatusCode >= http.StatusInternalServerError
+}
+
 // httpPostWithURI does not apply any transformations to the given URI. This allows the consumer to
 // use the predetermined hashing scheme (md5 or rendezvous) of their choice to derive the gitserver
 // instance to which the HTTP POST request is sent.
@@ -1233,6 +1458,119 @@ func (c *clientImplementor) GetObject(ctx context.Context, repo api.RepoName, ob
 	return &res.Object, nil
 }
 
+func (c *clientImplementor) FileHistory(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, opts gitdomain.FileHistoryOptions) (_ []gitdomain.FileHistoryEntry, _ bool, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Client.FileHistory")
+	span.SetTag("commit", opts.Commit)
+	span.SetTag("path", opts.Path)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	if err := checkSpecArgSafety(opts.Commit); err != nil {
+		return nil, false, err
+	}
+	if opts.N <= 0 {
+		return nil, false, errors.New("N must be positive")
+	}
+
+	if !authz.SubRepoEnabled(checker) {
+		return c.fileHistoryPage(ctx, repo, opts)
+	}
+
+	// Entries the actor cannot read are dropped here, after gitserver has paginated the
+	// history, so Skip and N refer to readable entries. Pages are requested from the start
+	// of the history until enough readable entries have been seen to fill the page.
+	a := actor.FromContext(ctx)
+	entries := make([]gitdomain.FileHistoryEntry, 0, opts.N)
+	skipped := 0
+	page := gitdomain.FileHistoryOptions{Commit: opts.Commit, Path: opts.Path, N: opts.Skip + opts.N + 1}
+	for {
+		pageEntries, hasNextPage, err := c.fileHistoryPage(ctx, repo, page)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, entry := range pageEntries {
+			hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, entry.Path)
+			if err != nil {
+				return nil, false, err
+			}
+			if hasAccess && entry.OldPath != "" {
+				if hasAccess, err = authz.FilterActorPath(ctx, checker, a, repo, entry.OldPath); err != nil {
+					return nil, false, err
+				}
+			}
+			if !hasAccess {
+				continue
+			}
+
+			if skipped < opts.Skip {
+				skipped++
+				continue
+			}
+			if len(entries) == opts.N {
+				return entries, true, nil
+			}
+			entries = append(entries, entry)
+		}
+
+		if !hasNextPage {
+			return entries, false, nil
+		}
+		page.Skip += len(pageEntries)
+	}
+}
+
+// fileHistoryPage requests a single page of the history of a file from gitserver.
+func (c *clientImplementor) fileHistoryPage(ctx context.Context, repo api.RepoName, opts gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+	req := protocol.FileHistoryRequest{
+		Repo:   repo,
+		Commit: opts.Commit,
+		Path:   opts.Path,
+		N:      opts.N,
+		Skip:   opts.Skip,
+	}
+	resp, err := c.httpPost(ctx, repo, "commands/file-history", req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		var payload protocol.NotFoundPayload
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			return nil, false, err
+		}
+		return nil, false, &gitdomain.RepoNotExistError{Repo: repo, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+	default:
+		return nil, false, &url.Error{
+			URL: resp.Request.URL.String(),
+			Op:  "FileHistory",
+			Err: errors.Errorf("FileHistory: http status %d, %s", resp.StatusCode, readResponseBody(resp.Body)),
+		}
+	}
+
+	var res protocol.FileHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, false, &url.Error{
+			URL: resp.Request.URL.String(),
+			Op:  "FileHistory",
+			Err: errors.Errorf("FileHistory: http status %d, failed to decode response body: %v", resp.StatusCode, err),
+		}
+	}
+	if res.UnknownRevision != "" {
+		return nil, false, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: res.UnknownRevision}
+	}
+
+	return res.Entries, res.HasNextPage, nil
+}
+
 var ambiguousArgPattern = lazyregexp.New(`ambiguous argument '([^']+)'`)
 
 func (c *clientImplementor) ResolveRevisions(ctx context.Context, repo api.RepoName, revs []protocol.RevisionSpecifier) ([]string, error) {
@@ -1322,6 +1660,14 @@ func readResponseBody(body io.Reader) string {
 	return strings.TrimSpace(string(content))
 }
 
+func replicationFactorFromConfig() int {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerReplicationFactor > 1 {
+		return cfg.ExperimentalFeatures.GitServerReplicationFactor
+	}
+	return 1
+}
+
 func pinnedReposFromConfig() map[string]string {
 	cfg := conf.Get()
 	if cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerPinnedRepos != nil {
@@ -120,6 +120,165 @@ func TestClient_Remove(t *testing.T) {
 	}
 }
 
+func TestClient_Remove_Replicated(t *testing.T) {
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080", "172.16.8.3:8080"}
+	setReplicationFactor(t, 2)
+
+	var mu sync.Mutex
+	removed := map[string]bool{}
+
+	cli := gitserver.NewTestClient(
+		httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/delete" {
+				return nil, errors.Newf("unexpected URL: %q", r.URL.String())
+			}
+
+			mu.Lock()
+			removed[r.URL.Host] = true
+			mu.Unlock()
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("{}")),
+			}, nil
+		}),
+		newMockDB(),
+		addrs,
+	)
+
+	if err := cli.Remove(context.Background(), repo); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedAddrs, err := cli.AddrsForRepo(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(expectedAddrs) != 2 {
+		t.Fatalf("unexpected number of replicas. want=%d have=%d", 2, len(expectedAddrs))
+	}
+
+	expected := map[string]bool{}
+	for _, addr := range expectedAddrs {
+		expected[addr] = true
+	}
+	if diff := cmp.Diff(expected, removed); diff != "" {
+		t.Fatalf("unexpected replicas removed (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_RequestRepoUpdate_Replicated(t *testing.T) {
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080"}
+	setReplicationFactor(t, 2)
+
+	var mu sync.Mutex
+	updated := map[string]bool{}
+
+	cli := gitserver.NewTestClient(
+		httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/repo-update" {
+				return nil, errors.Newf("unexpected URL: %q", r.URL.String())
+			}
+
+			mu.Lock()
+			updated[r.URL.Host] = true
+			mu.Unlock()
+
+			// The primary replica is down
+			if r.URL.Host == "172.16.8.1:8080" {
+				return nil, errors.New("connection refused")
+			}
+
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"lastFetched": null, "lastChanged": null}`)),
+			}, nil
+		}),
+		newMockDB(),
+		addrs,
+	)
+
+	if _, err := cli.RequestRepoUpdate(context.Background(), repo, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]bool{"172.16.8.1:8080": true, "172.16.8.2:8080": true}
+	if diff := cmp.Diff(expected, updated); diff != "" {
+		t.Fatalf("unexpected replicas updated (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_Failover(t *testing.T) {
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080"}
+
+	newClient := func(requested *[]string) gitserver.Client {
+		return gitserver.NewTestClient(
+			httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+				*requested = append(*requested, r.URL.Host)
+
+				switch r.URL.Host {
+				case "172.16.8.1:8080":
+					return &http.Response{
+						Request:    r,
+						StatusCode: http.StatusInternalServerError,
+						Body:       io.NopCloser(bytes.NewBufferString("internal error")),
+					}, nil
+				case "172.16.8.2:8080":
+					return &http.Response{
+						Request:    r,
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+					}, nil
+				default:
+					return nil, errors.Newf("unexpected URL: %q", r.URL.String())
+				}
+			}),
+			newMockDB(),
+			addrs,
+		)
+	}
+
+	t.Run("not replicated", func(t *testing.T) {
+		setReplicationFactor(t, 1)
+
+		var requested []string
+		if _, err := newClient(&requested).GetObject(context.Background(), repo, "HEAD"); err == nil {
+			t.Fatal("expected error")
+		}
+		if diff := cmp.Diff([]string{"172.16.8.1:8080"}, requested); diff != "" {
+			t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("replicated read", func(t *testing.T) {
+		setReplicationFactor(t, 2)
+
+		var requested []string
+		if _, err := newClient(&requested).GetObject(context.Background(), repo, "HEAD"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]string{"172.16.8.1:8080", "172.16.8.2:8080"}, requested); diff != "" {
+			t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("replicated write", func(t *testing.T) {
+		setReplicationFactor(t, 2)
+
+		var requested []string
+		_, err := newClient(&requested).CreateCommitFromPatch(context.Background(), protocol.CreateCommitFromPatchRequest{Repo: repo})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if diff := cmp.Diff([]string{"172.16.8.1:8080"}, requested); diff != "" {
+			t.Fatalf("unexpected requests (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestClient_ArchiveReader(t *testing.T) {
 	root := gitserver.CreateRepoDir(t)
 
@@ -364,6 +523,73 @@ func TestAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	pinned := map[string]string{
+		"repo2": "gitserver-1",
+	}
+
+	testCases := []struct {
+		name              string
+		repo              api.RepoName
+		replicationFactor int
+		want              []string
+	}{
+		{
+			name:              "not replicated",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 1,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "unset replication factor",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 0,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "replicated",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "replication factor capped",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 5,
+			want:              []string{"gitserver-3", "gitserver-1", "gitserver-2"},
+		},
+		{
+			name:              "primary ranked last by rendezvous hashing",
+			repo:              api.RepoName("repo4"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-2"},
+		},
+		{
+			name:              "pinned repo",
+			repo:              api.RepoName("repo2"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-1", "gitserver-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := gitserver.AddrsForRepo(context.Background(), "gitserver", newMockDB(), tc.repo, gitserver.GitServerAddresses{
+				Addresses:         addrs,
+				PinnedServers:     pinned,
+				ReplicationFactor: tc.replicationFactor,
+			})
+			if err != nil {
+				t.Fatal("Error during getting gitserver addresses")
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected addresses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRendezvousAddrForRepo(t *testing.T) {
 	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
 
@@ -585,6 +811,15 @@ func setPinnedRepos(pinned map[string]string) {
 	}})
 }
 
+func setReplicationFactor(t *testing.T, replicationFactor int) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			GitServerReplicationFactor: replicationFactor,
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
+
 func TestClient_AddrForRepo_Rendezvous(t *testing.T) {
 	ctx := context.Background()
 	client := gitserver.NewTestClient(&http.Client{}, newMockDB(), []string{"gitserver1", "gitserver2"})
@@ -325,6 +325,126 @@ func (c *clientImplementor) CommitGraph(ctx context.Context, repo api.RepoName,
 	return gitdomain.ParseCommitGraph(strings.Split(string(out), "\n")), nil
 }
 
+func (c *clientImplementor) CommitGraphIsAncestor(ctx context.Context, repo api.RepoName, ancestor, descendant string) (_ bool, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: CommitGraphIsAncestor")
+	span.SetTag("ancestor", ancestor)
+	span.SetTag("descendant", descendant)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	if err := checkSpecArgSafety(ancestor); err != nil {
+		return false, err
+	}
+	if err := checkSpecArgSafety(descendant); err != nil {
+		return false, err
+	}
+
+	req := protocol.CommitGraphIsAncestorRequest{Repo: repo, Ancestor: ancestor, Descendant: descendant}
+	var resp protocol.CommitGraphIsAncestorResponse
+	if err := c.commitGraphQuery(ctx, repo, "commit-graph/is-ancestor", req, &resp); err != nil {
+		return false, err
+	}
+	if resp.UnknownRevision != "" {
+		return false, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: resp.UnknownRevision}
+	}
+
+	return resp.IsAncestor, nil
+}
+
+func (c *clientImplementor) CommitGraphMergeBase(ctx context.Context, repo api.RepoName, a, b string) (_ api.CommitID, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: CommitGraphMergeBase")
+	span.SetTag("a", a)
+	span.SetTag("b", b)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	if err := checkSpecArgSafety(a); err != nil {
+		return "", err
+	}
+	if err := checkSpecArgSafety(b); err != nil {
+		return "", err
+	}
+
+	req := protocol.CommitGraphMergeBaseRequest{Repo: repo, A: a, B: b}
+	var resp protocol.CommitGraphMergeBaseResponse
+	if err := c.commitGraphQuery(ctx, repo, "commit-graph/merge-base", req, &resp); err != nil {
+		return "", err
+	}
+	if resp.UnknownRevision != "" {
+		return "", &gitdomain.RevisionNotFoundError{Repo: repo, Spec: resp.UnknownRevision}
+	}
+
+	return resp.MergeBase, nil
+}
+
+func (c *clientImplementor) CommitGraphCommitsBetween(ctx context.Context, repo api.RepoName, rev string, after, before time.Time, limit int) (_ []gitdomain.CommitGraphCommit, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: CommitGraphCommitsBetween")
+	span.SetTag("rev", rev)
+	span.SetTag("after", stableTimeRepr(after))
+	span.SetTag("before", stableTimeRepr(before))
+	span.SetTag("limit", limit)
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	if err := checkSpecArgSafety(rev); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, errors.Errorf("invalid limit %d: must be positive", limit)
+	}
+
+	req := protocol.CommitGraphCommitsBetweenRequest{Repo: repo, Revision: rev, After: after, Before: before, Limit: limit}
+	var resp protocol.CommitGraphCommitsBetweenResponse
+	if err := c.commitGraphQuery(ctx, repo, "commit-graph/commits-between", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.UnknownRevision != "" {
+		return nil, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: resp.UnknownRevision}
+	}
+
+	return resp.Commits, nil
+}
+
+// commitGraphQuery sends the given commit graph query to the gitserver instance of the
+// given repository and decodes its response into resp.
+func (c *clientImplementor) commitGraphQuery(ctx context.Context, repo api.RepoName, op string, req, resp any) error {
+	r, err := c.httpPost(ctx, repo, op, req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	switch r.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(r.Body).Decode(resp)
+
+	case http.StatusNotFound:
+		var payload protocol.NotFoundPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			return err
+		}
+		return &gitdomain.RepoNotExistError{Repo: repo, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+
+	default:
+		return errors.Errorf("%s: http status %d: %s", op, r.StatusCode, readResponseBody(r.Body))
+	}
+}
+
 // DevNullSHA 4b825dc642cb6eb9a060e54bf8d69288fbee4904 is `git hash-object -t
 // tree /dev/null`, which is used as the base when computing the `git diff` of
 // the root commit.
@@ -358,6 +478,254 @@ func (c *clientImplementor) DiffPath(ctx context.Context, checker authz.SubRepoP
 	return d.Hunks, nil
 }
 
+// DiffFileRevisions returns a position-ordered slice of changes (additions or deletions) between
+// the given source path at the source commit and the given target path at the target commit. Unlike
+// DiffPath, the paths may differ, e.g. when the file was renamed between the commits. If either path
+// does not exist in its commit, os.ErrNotExist is returned.
+func (c *clientImplementor) DiffFileRevisions(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, sourcePath, targetCommit, targetPath string) ([]*diff.Hunk, error) {
+	a := actor.FromContext(ctx)
+	for _, path := range []string{sourcePath, targetPath} {
+		if hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, path); err != nil {
+			return nil, err
+		} else if !hasAccess {
+			return nil, os.ErrNotExist
+		}
+	}
+	for _, commit := range []string{sourceCommit, targetCommit} {
+		if err := checkSpecArgSafety(commit); err != nil {
+			return nil, err
+		}
+	}
+
+	reader, err := c.execReader(ctx, repo, []string{"diff", sourceCommit + ":" + sourcePath, targetCommit + ":" + targetPath})
+	if err != nil {
+		return nil, convertDiffPathError(err)
+	}
+	defer reader.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, convertDiffPathError(err)
+	}
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	d, err := diff.NewFileDiffReader(bytes.NewReader(output)).Read()
+	if err != nil {
+		return nil, err
+	}
+	return d.Hunks, nil
+}
+
+// convertDiffPathError converts the error returned by a diff of a path that does not exist
+// in one of the diffed commits into os.ErrNotExist.
+func convertDiffPathError(err error) error {
+	if errors.Is(err, os.ErrNotExist) || strings.Contains(err.Error(), "does not exist in") || strings.Contains(err.Error(), "exists on disk, but not in") {
+		return os.ErrNotExist
+	}
+	return err
+}
+
+// A RenamedFile is a file renamed by a commit.
+type RenamedFile struct {
+	Commit  api.CommitID
+	OldPath string
+	NewPath string
+
+	// Left is true if the commit is reachable from the first of the commits given to
+	// RenamedFiles, and false if it is reachable from the second.
+	Left bool
+}
+
+// RenamedFiles returns the renames of the file with the given path in the first of the given commits
+// made by the commits that are reachable from exactly one of the given commits, oldest first. The file
+// is followed back from the first commit to the merge base of the commits, and from there forward into
+// the second commit. Renames of files that the actor may not read are omitted.
+func (c *clientImplementor) RenamedFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commitA, commitB, path string) ([]RenamedFile, error) {
+	for _, commit := range []string{commitA, commitB} {
+		if err := checkSpecArgSafety(commit); err != nil {
+			return nil, err
+		}
+	}
+
+	leftRenames, err := c.followRenames(ctx, repo, commitB+".."+commitA, path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range leftRenames {
+		leftRenames[i].Left = true
+	}
+
+	basePath := path
+	if len(leftRenames) > 0 {
+		basePath = leftRenames[0].OldPath
+	}
+
+	// Only the file's path in the second commit can be followed back with --follow, so find
+	// it by diffing the merge base of the commits against the second commit first.
+	targetPath, ok, err := c.renamedPath(ctx, repo, commitA+"..."+commitB, basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rightRenames []RenamedFile
+	if ok {
+		if rightRenames, err = c.followRenames(ctx, repo, commitA+".."+commitB, targetPath); err != nil {
+			return nil, err
+		}
+	}
+
+	a := actor.FromContext(ctx)
+	filtered := make([]RenamedFile, 0, len(leftRenames)+len(rightRenames))
+	for _, rename := range append(leftRenames, rightRenames...) {
+		hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, rename.OldPath)
+		if err != nil {
+			return nil, err
+		}
+		if hasAccess {
+			if hasAccess, err = authz.FilterActorPath(ctx, checker, a, repo, rename.NewPath); err != nil {
+				return nil, err
+			}
+		}
+		if hasAccess {
+			filtered = append(filtered, rename)
+		}
+	}
+
+	return filtered, nil
+}
+
+// followRenames returns the renames of the file with the given path in the newest commit of the given
+// range made by the commits of the range, oldest first.
+func (c *clientImplementor) followRenames(ctx context.Context, repo api.RepoName, revisionRange, path string) ([]RenamedFile, error) {
+	reader, err := c.execReader(ctx, repo, []string{
+		"log",
+		"--follow",
+		"--find-renames",
+		"--diff-filter=R",
+		"--name-status",
+		"-z",
+		"--format=format:%x1e%H",
+		revisionRange,
+		"--",
+		path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	renames, err := parseRenamedFiles(output)
+	if err != nil {
+		return nil, err
+	}
+
+	// git log lists the newest commit first, and does not support --reverse with --follow
+	for i, j := 0, len(renames)-1; i < j; i, j = i+1, j-1 {
+		renames[i], renames[j] = renames[j], renames[i]
+	}
+
+	return renames, nil
+}
+
+// renamedPath returns the path to which the file with the given path was renamed between the
+// commits of the given revision range.
+func (c *clientImplementor) renamedPath(ctx context.Context, repo api.RepoName, revisionRange, path string) (string, bool, error) {
+	reader, err := c.execReader(ctx, repo, []string{
+		"diff",
+		"--find-renames",
+		"--diff-filter=R",
+		"--name-status",
+		"-z",
+		revisionRange,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	defer reader.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", false, err
+	}
+
+	renames, err := parseRenamedPaths(output)
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, rename := range renames {
+		if rename.OldPath == path {
+			return rename.NewPath, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// parseRenamedFiles parses the output of followRenames' git log command. Each commit is
+// introduced by a record separator followed by its hash on a single line, followed by the
+// NUL-separated status, old path, and new path triples of its renames.
+func parseRenamedFiles(output []byte) ([]RenamedFile, error) {
+	var renames []RenamedFile
+	for _, record := range bytes.Split(output, []byte{'\x1e'}) {
+		if len(bytes.TrimSpace(record)) == 0 {
+			continue
+		}
+
+		header, fields, _ := bytes.Cut(record, []byte{'\n'})
+		if len(header) == 0 {
+			return nil, errors.Errorf("unexpected git log output %q", record)
+		}
+		commit := api.CommitID(header)
+
+		commitRenames, err := parseRenamedPaths(fields)
+		if err != nil {
+			return nil, err
+		}
+		for _, rename := range commitRenames {
+			rename.Commit = commit
+			renames = append(renames, rename)
+		}
+	}
+
+	return renames, nil
+}
+
+// parseRenamedPaths parses NUL-separated status, old path, and new path triples as output by
+// git's --name-status -z flags when only renames are selected.
+func parseRenamedPaths(output []byte) ([]RenamedFile, error) {
+	output = bytes.Trim(output, "\x00\n")
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	parts := bytes.Split(output, []byte{0})
+	if len(parts)%3 != 0 {
+		return nil, errors.Errorf("unexpected git output %q", output)
+	}
+
+	renames := make([]RenamedFile, 0, len(parts)/3)
+	for i := 0; i < len(parts); i += 3 {
+		if !bytes.HasPrefix(parts[i], []byte("R")) {
+			return nil, errors.Errorf("unexpected file status %q", parts[i])
+		}
+
+		renames = append(renames, RenamedFile{
+			OldPath: string(parts[i+1]),
+			NewPath: string(parts[i+2]),
+		})
+	}
+
+	return renames, nil
+}
+
 // DiffSymbols performs a diff command which is expected to be parsed by our symbols package
 func (c *clientImplementor) DiffSymbols(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) ([]byte, error) {
 	command := c.gitCommand(repo, "diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB))
@@ -2387,12 +2755,8 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
-	u, err := c.archiveURL(ctx, repo, options)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+	u := archiveURL(repo, options)
+	resp, err := c.doWithFailover(ctx, repo, "POST", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
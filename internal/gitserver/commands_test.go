@@ -413,6 +413,75 @@ index 51a59ef1c..493090958 100644
 	})
 }
 
+func TestRenamedFiles(t *testing.T) {
+	ResetMocks()
+	ClientMocks.LocalGitserver = true
+	defer ResetClientMocks()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	checker := authz.NewMockSubRepoPermissionChecker()
+
+	commit := "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	repo := MakeGitRepository(t,
+		"printf '1\\n2\\n3\\n4\\n5\\n6\\n7\\n8\\n' > a",
+		"echo x > b",
+		"git add a b",
+		commit,
+		"git tag base",
+		"git mv a c",
+		commit,
+		"sed -i.bak 's/4/four/' c && rm c.bak",
+		"git add c",
+		commit,
+		"git mv 'c' 'd e'",
+		commit,
+		"git mv b g",
+		commit,
+		"git tag feature",
+		"git checkout -q base",
+		"git mv a f",
+		commit,
+		"git mv b h",
+		commit,
+		"git tag other",
+	)
+
+	client := NewClient(database.NewMockDB())
+	renames, err := client.RenamedFiles(ctx, checker, repo, "other", "feature", "f")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var have []RenamedFile
+	for _, rename := range renames {
+		if rename.Commit == "" {
+			t.Errorf("missing commit for rename %v", rename)
+		}
+		rename.Commit = ""
+		have = append(have, rename)
+	}
+	want := []RenamedFile{
+		{OldPath: "a", NewPath: "f", Left: true},
+		{OldPath: "a", NewPath: "c", Left: false},
+		{OldPath: "c", NewPath: "d e", Left: false},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected renames (-want +have):\n%s", diff)
+	}
+
+	hunks, err := client.DiffFileRevisions(ctx, checker, repo, "base", "a", "feature", "d e")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(hunks) != 1 || hunks[0].OrigStartLine != 1 || hunks[0].NewStartLine != 1 {
+		t.Errorf("unexpected hunks: %v", hunks)
+	}
+
+	if _, err := client.DiffFileRevisions(ctx, checker, repo, "base", "a", "feature", "a"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("unexpected error diffing missing path. want=%v have=%v", os.ErrNotExist, err)
+	}
+}
+
 func TestRepository_BlameFile(t *testing.T) {
 	ClientMocks.LocalGitserver = true
 	defer ResetClientMocks()
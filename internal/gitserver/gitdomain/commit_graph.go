@@ -2,6 +2,9 @@ package gitdomain
 
 import (
 	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 type CommitGraph struct {
@@ -60,3 +63,12 @@ func ParseCommitGraph(lines []string) *CommitGraph {
 		order: append(prefix, order...),
 	}
 }
+
+// CommitGraphCommit is a commit of the commit graph index maintained by gitserver.
+type CommitGraphCommit struct {
+	ID api.CommitID
+	// Generation is one for root commits, otherwise one more than the largest
+	// generation of the commit's parents.
+	Generation    int
+	CommitterDate time.Time
+}
@@ -51,6 +51,7 @@ var (
 		"--find-copies",
 		"--find-renames",
 		"--first-parent",
+		"--diff-filter",
 		"--no-abbrev",
 		"--inter-hunk-context",
 		"--after",
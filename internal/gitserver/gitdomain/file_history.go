@@ -0,0 +1,224 @@
+package gitdomain
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// FileChangeStatus describes how a commit changed a file.
+type FileChangeStatus string
+
+const (
+	FileAdded       FileChangeStatus = "A"
+	FileModified    FileChangeStatus = "M"
+	FileDeleted     FileChangeStatus = "D"
+	FileRenamed     FileChangeStatus = "R"
+	FileCopied      FileChangeStatus = "C"
+	FileTypeChanged FileChangeStatus = "T"
+)
+
+// FileHistoryOptions configures the history of a file returned by a FileHistoryFunc.
+type FileHistoryOptions struct {
+	Commit string // the commit from which the history is walked; HEAD if empty
+	Path   string // the path of the file at Commit
+	N      int    // limit the number of returned entries to this many (must be positive)
+	Skip   int    // skip this many entries at the beginning
+}
+
+// FileHistoryEntry describes a commit that changed a file, following the file across renames
+// and copies.
+type FileHistoryEntry struct {
+	Commit Commit
+	// Path is the path of the file after the commit.
+	Path string
+	// OldPath is the path of the file before the commit if the commit renamed or copied it.
+	OldPath string `json:",omitempty"`
+	Status  FileChangeStatus
+	// Similarity is the percentage of the file that was unchanged by a rename or copy.
+	Similarity int `json:",omitempty"`
+	// Additions and Deletions are the number of lines added and deleted. They are zero for
+	// binary files.
+	Additions int
+	Deletions int
+	Binary    bool `json:",omitempty"`
+}
+
+// FileHistoryFunc returns the history of a file and whether there are more entries beyond those
+// returned.
+type FileHistoryFunc func(ctx context.Context, repo api.RepoName, opts FileHistoryOptions) ([]FileHistoryEntry, bool, error)
+
+const (
+	// fileHistoryRecordSeparator precedes each commit in the output of FileHistoryArgs.
+	fileHistoryRecordSeparator = '\x1e'
+
+	fileHistoryFormat         = "--format=format:%x1e%H%x00%aN%x00%aE%x00%at%x00%cN%x00%cE%x00%ct%x00%B%x00%P%x00"
+	fileHistoryFieldsPerEntry = 9
+)
+
+// FileHistoryArgs returns the arguments of a git command whose output can be parsed by
+// ParseFileHistory. The command lists at most n entries (n <= 0 means no limit).
+func FileHistoryArgs(commit, path string, n int) []string {
+	if commit == "" {
+		commit = "HEAD"
+	}
+
+	args := []string{
+		"log",
+		fileHistoryFormat,
+		"--follow",
+		"--find-renames",
+		"--find-copies",
+		"--raw",
+		"--numstat",
+		"--no-abbrev",
+		"-z",
+	}
+	if n > 0 {
+		args = append(args, "-n", strconv.Itoa(n))
+	}
+
+	return append(args, commit, "--", path)
+}
+
+// ParseFileHistory parses the output of the command built by FileHistoryArgs for the given path.
+func ParseFileHistory(output []byte, path string) ([]FileHistoryEntry, error) {
+	records := bytes.Split(output, []byte{fileHistoryRecordSeparator})
+
+	entries := make([]FileHistoryEntry, 0, len(records))
+	for _, record := range records {
+		if len(bytes.TrimSpace(record)) == 0 {
+			continue
+		}
+
+		entry, err := parseFileHistoryEntry(record, path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+
+		// Older commits refer to the file by its name before it was renamed or copied
+		if entry.OldPath != "" {
+			path = entry.OldPath
+		}
+	}
+
+	return entries, nil
+}
+
+// parseFileHistoryEntry parses a single commit of the output of the command built by FileHistoryArgs.
+// The given path is the path of the file at that commit, which is used if the commit has no changes
+// to report (e.g., a merge commit).
+func parseFileHistoryEntry(record []byte, path string) (FileHistoryEntry, error) {
+	parts := bytes.SplitN(record, []byte{'\x00'}, fileHistoryFieldsPerEntry+1)
+	if len(parts) < fileHistoryFieldsPerEntry {
+		return FileHistoryEntry{}, errors.Errorf("invalid file history entry: %q", record)
+	}
+
+	authorTime, err := strconv.ParseInt(string(parts[3]), 10, 64)
+	if err != nil {
+		return FileHistoryEntry{}, errors.Errorf("parsing git commit author time: %s", err)
+	}
+	committerTime, err := strconv.ParseInt(string(parts[6]), 10, 64)
+	if err != nil {
+		return FileHistoryEntry{}, errors.Errorf("parsing git commit committer time: %s", err)
+	}
+
+	var parents []api.CommitID
+	if parentPart := parts[8]; len(parentPart) > 0 {
+		for _, id := range bytes.Split(parentPart, []byte{' '}) {
+			parents = append(parents, api.CommitID(id))
+		}
+	}
+
+	entry := FileHistoryEntry{
+		Commit: Commit{
+			ID:        api.CommitID(parts[0]),
+			Author:    Signature{Name: string(parts[1]), Email: string(parts[2]), Date: time.Unix(authorTime, 0).UTC()},
+			Committer: &Signature{Name: string(parts[4]), Email: string(parts[5]), Date: time.Unix(committerTime, 0).UTC()},
+			Message:   Message(strings.TrimSuffix(string(parts[7]), "\n")),
+			Parents:   parents,
+		},
+		Path: path,
+	}
+
+	if len(parts) == fileHistoryFieldsPerEntry+1 {
+		if err := parseFileHistoryChanges(&entry, parts[fileHistoryFieldsPerEntry]); err != nil {
+			return FileHistoryEntry{}, err
+		}
+	}
+
+	return entry, nil
+}
+
+// parseFileHistoryChanges parses the NUL-separated --raw and --numstat output of a commit into the
+// given entry. Renames and copies are followed by both the source and destination paths.
+func parseFileHistoryChanges(entry *FileHistoryEntry, changes []byte) error {
+	tokens := strings.Split(strings.TrimPrefix(string(changes), "\n"), "\x00")
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "" {
+			continue
+		}
+
+		if strings.HasPrefix(token, ":") {
+			// :<old mode> <new mode> <old sha> <new sha> <status><score>
+			fields := strings.Fields(token)
+			if len(fields) != 5 || fields[4] == "" {
+				return errors.Errorf("invalid raw diff entry: %q", token)
+			}
+
+			entry.Status = FileChangeStatus(fields[4][:1])
+			if entry.Status == FileRenamed || entry.Status == FileCopied {
+				if i+2 >= len(tokens) {
+					return errors.Errorf("invalid raw diff entry: %q", token)
+				}
+				similarity, err := strconv.Atoi(fields[4][1:])
+				if err != nil {
+					return errors.Errorf("parsing similarity score: %s", err)
+				}
+
+				entry.Similarity = similarity
+				entry.OldPath, entry.Path = tokens[i+1], tokens[i+2]
+				i += 2
+			} else if i+1 < len(tokens) {
+				entry.Path = tokens[i+1]
+				i++
+			}
+
+			continue
+		}
+
+		// <additions>\t<deletions>\t<path>, where the path is empty for renames and copies
+		// and is followed by the source and destination paths instead
+		fields := strings.SplitN(token, "\t", 3)
+		if len(fields) != 3 {
+			return errors.Errorf("invalid numstat entry: %q", token)
+		}
+		if fields[2] == "" {
+			i += 2
+		}
+
+		if fields[0] == "-" && fields[1] == "-" {
+			entry.Binary = true
+			continue
+		}
+		additions, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return errors.Errorf("parsing added lines: %s", err)
+		}
+		deletions, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return errors.Errorf("parsing deleted lines: %s", err)
+		}
+		entry.Additions, entry.Deletions = additions, deletions
+	}
+
+	return nil
+}
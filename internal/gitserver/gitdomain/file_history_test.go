@@ -0,0 +1,79 @@
+package gitdomain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParseFileHistory(t *testing.T) {
+	output := "" +
+		"\x1e56b6d55de6f181120d77850dcc29b999f4447894\x00a\x00a@a\x001136214245\x00c\x00c@c\x001136214246\x00three\n\x0009e3dd65d95aad94206b8387e50723d1acd1fee2\x00" +
+		"\n:100644 100644 f9d9a0195c5b9c01ef64e2a69d8b9a624f42b8c8 71ac1b5791204c80666ab1a4f9886b79e982739c R087\x00f.txt\x00g.txt\x001\t0\t\x00f.txt\x00g.txt\x00\x00" +
+		"\x1e09e3dd65d95aad94206b8387e50723d1acd1fee2\x00a\x00a@a\x001136214245\x00a\x00a@a\x001136214245\x00two\n\x008135462bed1e4297881739de6b02b0d48dc5a9be\x00" +
+		"\n:100644 100644 0fdf397db08b5cecda1b6394d4fef7395c1933ba f9d9a0195c5b9c01ef64e2a69d8b9a624f42b8c8 M\x00f.txt\x00-\t-\tf.txt\x00\x00" +
+		"\x1e8135462bed1e4297881739de6b02b0d48dc5a9be\x00a\x00a@a\x001136214245\x00a\x00a@a\x001136214245\x00one\n\x00\x00" +
+		"\n:000000 100644 0000000000000000000000000000000000000000 0fdf397db08b5cecda1b6394d4fef7395c1933ba A\x00f.txt\x006\t0\tf.txt\x00"
+
+	entries, err := ParseFileHistory([]byte(output), "g.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	author := Signature{Name: "a", Email: "a@a", Date: time.Unix(1136214245, 0).UTC()}
+	expectedEntries := []FileHistoryEntry{
+		{
+			Commit: Commit{
+				ID:        "56b6d55de6f181120d77850dcc29b999f4447894",
+				Author:    author,
+				Committer: &Signature{Name: "c", Email: "c@c", Date: time.Unix(1136214246, 0).UTC()},
+				Message:   "three",
+				Parents:   []api.CommitID{"09e3dd65d95aad94206b8387e50723d1acd1fee2"},
+			},
+			Path:       "g.txt",
+			OldPath:    "f.txt",
+			Status:     FileRenamed,
+			Similarity: 87,
+			Additions:  1,
+		},
+		{
+			Commit: Commit{
+				ID:        "09e3dd65d95aad94206b8387e50723d1acd1fee2",
+				Author:    author,
+				Committer: &author,
+				Message:   "two",
+				Parents:   []api.CommitID{"8135462bed1e4297881739de6b02b0d48dc5a9be"},
+			},
+			Path:   "f.txt",
+			Status: FileModified,
+			Binary: true,
+		},
+		{
+			Commit: Commit{
+				ID:        "8135462bed1e4297881739de6b02b0d48dc5a9be",
+				Author:    author,
+				Committer: &author,
+				Message:   "one",
+			},
+			Path:      "f.txt",
+			Status:    FileAdded,
+			Additions: 6,
+		},
+	}
+	if diff := cmp.Diff(expectedEntries, entries); diff != "" {
+		t.Errorf("unexpected entries (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseFileHistoryEmpty(t *testing.T) {
+	entries, err := ParseFileHistory(nil, "g.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+}
@@ -0,0 +1,108 @@
+package inttests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCommitGraphQueries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// base - main
+	//      \
+	//       feature
+	repo := MakeGitRepository(t,
+		"git checkout -b main",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit --allow-empty -m base --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag base",
+		"git checkout -b feature",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-03T15:04:05Z git commit --allow-empty -m feature --author='a <a@a.com>' --date 2006-01-03T15:04:05Z",
+		"git checkout main",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-04T15:04:05Z git commit --allow-empty -m main --author='a <a@a.com>' --date 2006-01-04T15:04:05Z",
+	)
+	client := gitserver.NewTestClient(http.DefaultClient, database.NewMockDB(), gitserverAddresses)
+
+	resolve := func(rev string) api.CommitID {
+		t.Helper()
+		commitID, err := client.ResolveRevision(ctx, repo, rev, gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error resolving %s: %s", rev, err)
+		}
+		return commitID
+	}
+	base, feature, main := resolve("base"), resolve("feature"), resolve("main")
+
+	t.Run("is ancestor", func(t *testing.T) {
+		for _, test := range []struct {
+			ancestor, descendant string
+			want                 bool
+		}{
+			{"base", "main", true},
+			{"base", "feature", true},
+			{"feature", "main", false},
+			{string(main), "HEAD", true},
+		} {
+			isAncestor, err := client.CommitGraphIsAncestor(ctx, repo, test.ancestor, test.descendant)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if isAncestor != test.want {
+				t.Errorf("unexpected result for %s and %s. want=%v have=%v", test.ancestor, test.descendant, test.want, isAncestor)
+			}
+		}
+	})
+
+	t.Run("merge base", func(t *testing.T) {
+		mergeBase, err := client.CommitGraphMergeBase(ctx, repo, "main", "feature")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if mergeBase != base {
+			t.Errorf("unexpected merge base. want=%s have=%s", base, mergeBase)
+		}
+	})
+
+	t.Run("commits between", func(t *testing.T) {
+		commits, err := client.CommitGraphCommitsBetween(ctx, repo, "main", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2006, 1, 4, 0, 0, 0, 0, time.UTC), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expectedCommits := []gitdomain.CommitGraphCommit{
+			{ID: base, Generation: 1, CommitterDate: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		}
+		if diff := cmp.Diff(expectedCommits, commits); diff != "" {
+			t.Errorf("unexpected commits (-want +got):\n%s", diff)
+		}
+
+		commits, err = client.CommitGraphCommitsBetween(ctx, repo, "feature", time.Time{}, time.Time{}, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expectedCommits = []gitdomain.CommitGraphCommit{
+			{ID: feature, Generation: 2, CommitterDate: time.Date(2006, 1, 3, 15, 4, 5, 0, time.UTC)},
+			{ID: base, Generation: 1, CommitterDate: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		}
+		if diff := cmp.Diff(expectedCommits, commits); diff != "" {
+			t.Errorf("unexpected commits (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := client.CommitGraphMergeBase(ctx, repo, "main", "unknown")
+		var e *gitdomain.RevisionNotFoundError
+		if !errors.As(err, &e) || e.Spec != "unknown" {
+			t.Errorf("expected a revision not found error, have %v", err)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package inttests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFileHistory(t *testing.T) {
+	t.Parallel()
+	ctx := actor.WithActor(context.Background(), &actor.Actor{
+		UID: 1,
+	})
+
+	repo := MakeGitRepository(t,
+		"printf 'a\\nb\\nc\\nd\\ne\\nf\\n' > f.txt",
+		"git add f.txt",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m add --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"printf 'a\\nb\\nc\\nd\\ne\\nf\\ng\\n' > f.txt",
+		"echo x > other.txt",
+		"git add f.txt other.txt",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:06Z git commit -m modify --author='a <a@a.com>' --date 2006-01-02T15:04:06Z",
+		"mkdir dir",
+		"git mv f.txt dir/g.txt",
+		"printf 'a\\nb\\nc\\nd\\ne\\nf\\ng\\nh\\n' > dir/g.txt",
+		"git add dir/g.txt",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:07Z git commit -m rename --author='a <a@a.com>' --date 2006-01-02T15:04:07Z",
+	)
+
+	type summary struct {
+		Message    string
+		Path       string
+		OldPath    string
+		Status     gitdomain.FileChangeStatus
+		Similarity int
+		Additions  int
+		Deletions  int
+	}
+	summarize := func(entries []gitdomain.FileHistoryEntry) []summary {
+		summaries := make([]summary, 0, len(entries))
+		for _, entry := range entries {
+			summaries = append(summaries, summary{
+				Message:    string(entry.Commit.Message),
+				Path:       entry.Path,
+				OldPath:    entry.OldPath,
+				Status:     entry.Status,
+				Similarity: entry.Similarity,
+				Additions:  entry.Additions,
+				Deletions:  entry.Deletions,
+			})
+		}
+		return summaries
+	}
+
+	renamed := summary{Message: "rename", Path: "dir/g.txt", OldPath: "f.txt", Status: gitdomain.FileRenamed, Similarity: 87, Additions: 1}
+	modified := summary{Message: "modify", Path: "f.txt", Status: gitdomain.FileModified, Additions: 1}
+	added := summary{Message: "add", Path: "f.txt", Status: gitdomain.FileAdded, Additions: 6}
+
+	tests := map[string]struct {
+		opts            gitdomain.FileHistoryOptions
+		checker         authz.SubRepoPermissionChecker
+		wantEntries     []summary
+		wantHasNextPage bool
+	}{
+		"all": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 10},
+			wantEntries: []summary{renamed, modified, added},
+		},
+		"first page": {
+			opts:            gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 2},
+			wantEntries:     []summary{renamed, modified},
+			wantHasNextPage: true,
+		},
+		"last page": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 2, Skip: 2},
+			wantEntries: []summary{added},
+		},
+		"past the end": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 2, Skip: 3},
+			wantEntries: []summary{},
+		},
+		"older commit": {
+			opts:        gitdomain.FileHistoryOptions{Commit: "HEAD~1", Path: "f.txt", N: 10},
+			wantEntries: []summary{modified, added},
+		},
+		"sub-repo permissions": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 10},
+			checker:     getTestSubRepoPermsChecker("f.txt"),
+			wantEntries: []summary{},
+		},
+		"sub-repo permissions with access": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 10},
+			checker:     getTestSubRepoPermsChecker("other.txt"),
+			wantEntries: []summary{renamed, modified, added},
+		},
+		"sub-repo permissions first page": {
+			opts:            gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 1},
+			checker:         getTestSubRepoPermsChecker("dir/g.txt"),
+			wantEntries:     []summary{modified},
+			wantHasNextPage: true,
+		},
+		"sub-repo permissions last page": {
+			opts:        gitdomain.FileHistoryOptions{Path: "dir/g.txt", N: 1, Skip: 1},
+			checker:     getTestSubRepoPermsChecker("dir/g.txt"),
+			wantEntries: []summary{added},
+		},
+	}
+
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			entries, hasNextPage, err := gitserver.NewTestClient(http.DefaultClient, database.NewMockDB(), gitserverAddresses).FileHistory(ctx, test.checker, repo, test.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.wantEntries, summarize(entries)); diff != "" {
+				t.Errorf("unexpected entries (-want +got):\n%s", diff)
+			}
+			if hasNextPage != test.wantHasNextPage {
+				t.Errorf("unexpected hasNextPage. want=%v have=%v", test.wantHasNextPage, hasNextPage)
+			}
+		})
+	}
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, _, err := gitserver.NewTestClient(http.DefaultClient, database.NewMockDB(), gitserverAddresses).FileHistory(ctx, nil, repo, gitdomain.FileHistoryOptions{Commit: "missing", Path: "dir/g.txt", N: 10})
+		if !errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			t.Errorf("expected a RevisionNotFoundError, got %v", err)
+		}
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		if _, _, err := gitserver.NewTestClient(http.DefaultClient, database.NewMockDB(), gitserverAddresses).FileHistory(ctx, nil, repo, gitdomain.FileHistoryOptions{Path: "dir/g.txt"}); err == nil {
+			t.Error("expected an error requesting the history without a limit")
+		}
+	})
+}
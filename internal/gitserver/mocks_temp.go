@@ -32,6 +32,9 @@ type MockClient struct {
 	// AddrsFunc is an instance of a mock function object controlling the
 	// behavior of the method Addrs.
 	AddrsFunc *ClientAddrsFunc
+	// AddrsForRepoFunc is an instance of a mock function object controlling
+	// the behavior of the method AddrsForRepo.
+	AddrsForRepoFunc *ClientAddrsForRepoFunc
 	// ArchiveReaderFunc is an instance of a mock function object
 	// controlling the behavior of the method ArchiveReader.
 	ArchiveReaderFunc *ClientArchiveReaderFunc
@@ -53,6 +56,16 @@ type MockClient struct {
 	// CommitGraphFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitGraph.
 	CommitGraphFunc *ClientCommitGraphFunc
+	// CommitGraphCommitsBetweenFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CommitGraphCommitsBetween.
+	CommitGraphCommitsBetweenFunc *ClientCommitGraphCommitsBetweenFunc
+	// CommitGraphIsAncestorFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitGraphIsAncestor.
+	CommitGraphIsAncestorFunc *ClientCommitGraphIsAncestorFunc
+	// CommitGraphMergeBaseFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitGraphMergeBase.
+	CommitGraphMergeBaseFunc *ClientCommitGraphMergeBaseFunc
 	// CommitsFunc is an instance of a mock function object controlling the
 	// behavior of the method Commits.
 	CommitsFunc *ClientCommitsFunc
@@ -71,12 +84,18 @@ type MockClient struct {
 	// DiffFunc is an instance of a mock function object controlling the
 	// behavior of the method Diff.
 	DiffFunc *ClientDiffFunc
+	// DiffFileRevisionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiffFileRevisions.
+	DiffFileRevisionsFunc *ClientDiffFileRevisionsFunc
 	// DiffPathFunc is an instance of a mock function object controlling the
 	// behavior of the method DiffPath.
 	DiffPathFunc *ClientDiffPathFunc
 	// DiffSymbolsFunc is an instance of a mock function object controlling
 	// the behavior of the method DiffSymbols.
 	DiffSymbolsFunc *ClientDiffSymbolsFunc
+	// FileHistoryFunc is an instance of a mock function object controlling
+	// the behavior of the method FileHistory.
+	FileHistoryFunc *ClientFileHistoryFunc
 	// FirstEverCommitFunc is an instance of a mock function object
 	// controlling the behavior of the method FirstEverCommit.
 	FirstEverCommitFunc *ClientFirstEverCommitFunc
@@ -149,6 +168,9 @@ type MockClient struct {
 	// RemoveFromFunc is an instance of a mock function object controlling
 	// the behavior of the method RemoveFrom.
 	RemoveFromFunc *ClientRemoveFromFunc
+	// RenamedFilesFunc is an instance of a mock function object controlling
+	// the behavior of the method RenamedFiles.
+	RenamedFilesFunc *ClientRenamedFilesFunc
 	// RendezvousAddrForRepoFunc is an instance of a mock function object
 	// controlling the behavior of the method RendezvousAddrForRepo.
 	RendezvousAddrForRepoFunc *ClientRendezvousAddrForRepoFunc
@@ -195,6 +217,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		AddrsForRepoFunc: &ClientAddrsForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 []string, r1 error) {
+				return
+			},
+		},
 		ArchiveReaderFunc: &ClientArchiveReaderFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, ArchiveOptions) (r0 io.ReadCloser, r1 error) {
 				return
@@ -230,6 +257,21 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		CommitGraphCommitsBetweenFunc: &ClientCommitGraphCommitsBetweenFunc{
+			defaultHook: func(context.Context, api.RepoName, string, time.Time, time.Time, int) (r0 []gitdomain.CommitGraphCommit, r1 error) {
+				return
+			},
+		},
+		CommitGraphIsAncestorFunc: &ClientCommitGraphIsAncestorFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 bool, r1 error) {
+				return
+			},
+		},
+		CommitGraphMergeBaseFunc: &ClientCommitGraphMergeBaseFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (r0 api.CommitID, r1 error) {
+				return
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) (r0 []*gitdomain.Commit, r1 error) {
 				return
@@ -260,6 +302,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		DiffFileRevisionsFunc: &ClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) (r0 []*diff.Hunk, r1 error) {
+				return
+			},
+		},
 		DiffPathFunc: &ClientDiffPathFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []*diff.Hunk, r1 error) {
 				return
@@ -270,6 +317,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		FileHistoryFunc: &ClientFileHistoryFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) (r0 []gitdomain.FileHistoryEntry, r1 bool, r2 error) {
+				return
+			},
+		},
 		FirstEverCommitFunc: &ClientFirstEverCommitFunc{
 			defaultHook: func(context.Context, api.RepoName, authz.SubRepoPermissionChecker) (r0 *gitdomain.Commit, r1 error) {
 				return
@@ -390,6 +442,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		RenamedFilesFunc: &ClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) (r0 []RenamedFile, r1 error) {
+				return
+			},
+		},
 		RendezvousAddrForRepoFunc: &ClientRendezvousAddrForRepoFunc{
 			defaultHook: func(api.RepoName) (r0 string) {
 				return
@@ -457,6 +514,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.Addrs")
 			},
 		},
+		AddrsForRepoFunc: &ClientAddrsForRepoFunc{
+			defaultHook: func(context.Context, api.RepoName) ([]string, error) {
+				panic("unexpected invocation of MockClient.AddrsForRepo")
+			},
+		},
 		ArchiveReaderFunc: &ClientArchiveReaderFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, ArchiveOptions) (io.ReadCloser, error) {
 				panic("unexpected invocation of MockClient.ArchiveReader")
@@ -492,6 +554,21 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.CommitGraph")
 			},
 		},
+		CommitGraphCommitsBetweenFunc: &ClientCommitGraphCommitsBetweenFunc{
+			defaultHook: func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error) {
+				panic("unexpected invocation of MockClient.CommitGraphCommitsBetween")
+			},
+		},
+		CommitGraphIsAncestorFunc: &ClientCommitGraphIsAncestorFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (bool, error) {
+				panic("unexpected invocation of MockClient.CommitGraphIsAncestor")
+			},
+		},
+		CommitGraphMergeBaseFunc: &ClientCommitGraphMergeBaseFunc{
+			defaultHook: func(context.Context, api.RepoName, string, string) (api.CommitID, error) {
+				panic("unexpected invocation of MockClient.CommitGraphMergeBase")
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, api.RepoName, CommitsOptions, authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockClient.Commits")
@@ -522,6 +599,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.Diff")
 			},
 		},
+		DiffFileRevisionsFunc: &ClientDiffFileRevisionsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
+				panic("unexpected invocation of MockClient.DiffFileRevisions")
+			},
+		},
 		DiffPathFunc: &ClientDiffPathFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]*diff.Hunk, error) {
 				panic("unexpected invocation of MockClient.DiffPath")
@@ -532,6 +614,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.DiffSymbols")
 			},
 		},
+		FileHistoryFunc: &ClientFileHistoryFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+				panic("unexpected invocation of MockClient.FileHistory")
+			},
+		},
 		FirstEverCommitFunc: &ClientFirstEverCommitFunc{
 			defaultHook: func(context.Context, api.RepoName, authz.SubRepoPermissionChecker) (*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockClient.FirstEverCommit")
@@ -652,6 +739,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.RemoveFrom")
 			},
 		},
+		RenamedFilesFunc: &ClientRenamedFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error) {
+				panic("unexpected invocation of MockClient.RenamedFiles")
+			},
+		},
 		RendezvousAddrForRepoFunc: &ClientRendezvousAddrForRepoFunc{
 			defaultHook: func(api.RepoName) string {
 				panic("unexpected invocation of MockClient.RendezvousAddrForRepo")
@@ -715,6 +807,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		AddrsFunc: &ClientAddrsFunc{
 			defaultHook: i.Addrs,
 		},
+		AddrsForRepoFunc: &ClientAddrsForRepoFunc{
+			defaultHook: i.AddrsForRepo,
+		},
 		ArchiveReaderFunc: &ClientArchiveReaderFunc{
 			defaultHook: i.ArchiveReader,
 		},
@@ -736,6 +831,15 @@ func NewMockClientFrom(i Client) *MockClient {
 		CommitGraphFunc: &ClientCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
+		CommitGraphCommitsBetweenFunc: &ClientCommitGraphCommitsBetweenFunc{
+			defaultHook: i.CommitGraphCommitsBetween,
+		},
+		CommitGraphIsAncestorFunc: &ClientCommitGraphIsAncestorFunc{
+			defaultHook: i.CommitGraphIsAncestor,
+		},
+		CommitGraphMergeBaseFunc: &ClientCommitGraphMergeBaseFunc{
+			defaultHook: i.CommitGraphMergeBase,
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: i.Commits,
 		},
@@ -754,12 +858,18 @@ func NewMockClientFrom(i Client) *MockClient {
 		DiffFunc: &ClientDiffFunc{
 			defaultHook: i.Diff,
 		},
+		DiffFileRevisionsFunc: &ClientDiffFileRevisionsFunc{
+			defaultHook: i.DiffFileRevisions,
+		},
 		DiffPathFunc: &ClientDiffPathFunc{
 			defaultHook: i.DiffPath,
 		},
 		DiffSymbolsFunc: &ClientDiffSymbolsFunc{
 			defaultHook: i.DiffSymbols,
 		},
+		FileHistoryFunc: &ClientFileHistoryFunc{
+			defaultHook: i.FileHistory,
+		},
 		FirstEverCommitFunc: &ClientFirstEverCommitFunc{
 			defaultHook: i.FirstEverCommit,
 		},
@@ -832,6 +942,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		RemoveFromFunc: &ClientRemoveFromFunc{
 			defaultHook: i.RemoveFrom,
 		},
+		RenamedFilesFunc: &ClientRenamedFilesFunc{
+			defaultHook: i.RenamedFiles,
+		},
 		RendezvousAddrForRepoFunc: &ClientRendezvousAddrForRepoFunc{
 			defaultHook: i.RendezvousAddrForRepo,
 		},
@@ -1070,6 +1183,113 @@ func (c ClientAddrsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ClientAddrsForRepoFunc describes the behavior when the AddrsForRepo
+// method of the parent MockClient instance is invoked.
+type ClientAddrsForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoName) ([]string, error)
+	hooks       []func(context.Context, api.RepoName) ([]string, error)
+	history     []ClientAddrsForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// AddrsForRepo delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) AddrsForRepo(v0 context.Context, v1 api.RepoName) ([]string, error) {
+	r0, r1 := m.AddrsForRepoFunc.nextHook()(v0, v1)
+	m.AddrsForRepoFunc.appendCall(ClientAddrsForRepoFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddrsForRepo method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientAddrsForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoName) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddrsForRepo method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientAddrsForRepoFunc) PushHook(hook func(context.Context, api.RepoName) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientAddrsForRepoFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientAddrsForRepoFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientAddrsForRepoFunc) nextHook() func(context.Context, api.RepoName) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientAddrsForRepoFunc) appendCall(r0 ClientAddrsForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientAddrsForRepoFuncCall objects
+// describing the invocations of this function.
+func (f *ClientAddrsForRepoFunc) History() []ClientAddrsForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientAddrsForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientAddrsForRepoFuncCall is an object that describes an invocation of
+// method AddrsForRepo on an instance of MockClient.
+type ClientAddrsForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientAddrsForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientAddrsForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientArchiveReaderFunc describes the behavior when the ArchiveReader
 // method of the parent MockClient instance is invoked.
 type ClientArchiveReaderFunc struct {
@@ -1862,6 +2082,358 @@ func (c ClientCommitGraphFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientCommitGraphCommitsBetweenFunc describes the behavior when the
+// CommitGraphCommitsBetween method of the parent MockClient instance is
+// invoked.
+type ClientCommitGraphCommitsBetweenFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error)
+	hooks       []func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error)
+	history     []ClientCommitGraphCommitsBetweenFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitGraphCommitsBetween delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockClient) CommitGraphCommitsBetween(v0 context.Context, v1 api.RepoName, v2 string, v3 time.Time, v4 time.Time, v5 int) ([]gitdomain.CommitGraphCommit, error) {
+	r0, r1 := m.CommitGraphCommitsBetweenFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CommitGraphCommitsBetweenFunc.appendCall(ClientCommitGraphCommitsBetweenFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CommitGraphCommitsBetween method of the parent MockClient instance is
+// invoked and the hook queue is empty.
+func (f *ClientCommitGraphCommitsBetweenFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitGraphCommitsBetween method of the parent MockClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ClientCommitGraphCommitsBetweenFunc) PushHook(hook func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitGraphCommitsBetweenFunc) SetDefaultReturn(r0 []gitdomain.CommitGraphCommit, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitGraphCommitsBetweenFunc) PushReturn(r0 []gitdomain.CommitGraphCommit, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitGraphCommitsBetweenFunc) nextHook() func(context.Context, api.RepoName, string, time.Time, time.Time, int) ([]gitdomain.CommitGraphCommit, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitGraphCommitsBetweenFunc) appendCall(r0 ClientCommitGraphCommitsBetweenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitGraphCommitsBetweenFuncCall
+// objects describing the invocations of this function.
+func (f *ClientCommitGraphCommitsBetweenFunc) History() []ClientCommitGraphCommitsBetweenFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitGraphCommitsBetweenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitGraphCommitsBetweenFuncCall is an object that describes an
+// invocation of method CommitGraphCommitsBetween on an instance of
+// MockClient.
+type ClientCommitGraphCommitsBetweenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gitdomain.CommitGraphCommit
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitGraphCommitsBetweenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitGraphCommitsBetweenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ClientCommitGraphIsAncestorFunc describes the behavior when the
+// CommitGraphIsAncestor method of the parent MockClient instance is
+// invoked.
+type ClientCommitGraphIsAncestorFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) (bool, error)
+	hooks       []func(context.Context, api.RepoName, string, string) (bool, error)
+	history     []ClientCommitGraphIsAncestorFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitGraphIsAncestor delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockClient) CommitGraphIsAncestor(v0 context.Context, v1 api.RepoName, v2 string, v3 string) (bool, error) {
+	r0, r1 := m.CommitGraphIsAncestorFunc.nextHook()(v0, v1, v2, v3)
+	m.CommitGraphIsAncestorFunc.appendCall(ClientCommitGraphIsAncestorFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CommitGraphIsAncestor method of the parent MockClient instance is invoked
+// and the hook queue is empty.
+func (f *ClientCommitGraphIsAncestorFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitGraphIsAncestor method of the parent MockClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ClientCommitGraphIsAncestorFunc) PushHook(hook func(context.Context, api.RepoName, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitGraphIsAncestorFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitGraphIsAncestorFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitGraphIsAncestorFunc) nextHook() func(context.Context, api.RepoName, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitGraphIsAncestorFunc) appendCall(r0 ClientCommitGraphIsAncestorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitGraphIsAncestorFuncCall objects
+// describing the invocations of this function.
+func (f *ClientCommitGraphIsAncestorFunc) History() []ClientCommitGraphIsAncestorFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitGraphIsAncestorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitGraphIsAncestorFuncCall is an object that describes an
+// invocation of method CommitGraphIsAncestor on an instance of MockClient.
+type ClientCommitGraphIsAncestorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitGraphIsAncestorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitGraphIsAncestorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ClientCommitGraphMergeBaseFunc describes the behavior when the
+// CommitGraphMergeBase method of the parent MockClient instance is invoked.
+type ClientCommitGraphMergeBaseFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, string) (api.CommitID, error)
+	hooks       []func(context.Context, api.RepoName, string, string) (api.CommitID, error)
+	history     []ClientCommitGraphMergeBaseFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitGraphMergeBase delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockClient) CommitGraphMergeBase(v0 context.Context, v1 api.RepoName, v2 string, v3 string) (api.CommitID, error) {
+	r0, r1 := m.CommitGraphMergeBaseFunc.nextHook()(v0, v1, v2, v3)
+	m.CommitGraphMergeBaseFunc.appendCall(ClientCommitGraphMergeBaseFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitGraphMergeBase
+// method of the parent MockClient instance is invoked and the hook queue is
+// empty.
+func (f *ClientCommitGraphMergeBaseFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, string) (api.CommitID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitGraphMergeBase method of the parent MockClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ClientCommitGraphMergeBaseFunc) PushHook(hook func(context.Context, api.RepoName, string, string) (api.CommitID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitGraphMergeBaseFunc) SetDefaultReturn(r0 api.CommitID, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, string) (api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitGraphMergeBaseFunc) PushReturn(r0 api.CommitID, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, string) (api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitGraphMergeBaseFunc) nextHook() func(context.Context, api.RepoName, string, string) (api.CommitID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitGraphMergeBaseFunc) appendCall(r0 ClientCommitGraphMergeBaseFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitGraphMergeBaseFuncCall objects
+// describing the invocations of this function.
+func (f *ClientCommitGraphMergeBaseFunc) History() []ClientCommitGraphMergeBaseFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitGraphMergeBaseFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitGraphMergeBaseFuncCall is an object that describes an
+// invocation of method CommitGraphMergeBase on an instance of MockClient.
+type ClientCommitGraphMergeBaseFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 api.CommitID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitGraphMergeBaseFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitGraphMergeBaseFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientCommitsFunc describes the behavior when the Commits method of the
 // parent MockClient instance is invoked.
 type ClientCommitsFunc struct {
@@ -2390,25 +2962,135 @@ func (f *ClientCreateCommitFromPatchFunc) appendCall(r0 ClientCreateCommitFromPa
 // describing the invocations of this function.
 func (f *ClientCreateCommitFromPatchFunc) History() []ClientCreateCommitFromPatchFuncCall {
 	f.mutex.Lock()
-	history := make([]ClientCreateCommitFromPatchFuncCall, len(f.history))
+	history := make([]ClientCreateCommitFromPatchFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCreateCommitFromPatchFuncCall is an object that describes an
+// invocation of method CreateCommitFromPatch on an instance of MockClient.
+type ClientCreateCommitFromPatchFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 protocol.CreateCommitFromPatchRequest
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCreateCommitFromPatchFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCreateCommitFromPatchFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ClientDiffFunc describes the behavior when the Diff method of the parent
+// MockClient instance is invoked.
+type ClientDiffFunc struct {
+	defaultHook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)
+	hooks       []func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)
+	history     []ClientDiffFuncCall
+	mutex       sync.Mutex
+}
+
+// Diff delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) Diff(v0 context.Context, v1 DiffOptions, v2 authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+	r0, r1 := m.DiffFunc.nextHook()(v0, v1, v2)
+	m.DiffFunc.appendCall(ClientDiffFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Diff method of the
+// parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientDiffFunc) SetDefaultHook(hook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Diff method of the parent MockClient instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientDiffFunc) PushHook(hook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientDiffFunc) SetDefaultReturn(r0 *DiffFileIterator, r1 error) {
+	f.SetDefaultHook(func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientDiffFunc) PushReturn(r0 *DiffFileIterator, r1 error) {
+	f.PushHook(func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientDiffFunc) nextHook() func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientDiffFunc) appendCall(r0 ClientDiffFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientDiffFuncCall objects describing the
+// invocations of this function.
+func (f *ClientDiffFunc) History() []ClientDiffFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientDiffFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ClientCreateCommitFromPatchFuncCall is an object that describes an
-// invocation of method CreateCommitFromPatch on an instance of MockClient.
-type ClientCreateCommitFromPatchFuncCall struct {
+// ClientDiffFuncCall is an object that describes an invocation of method
+// Diff on an instance of MockClient.
+type ClientDiffFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 protocol.CreateCommitFromPatchRequest
+	Arg1 DiffOptions
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 authz.SubRepoPermissionChecker
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 string
+	Result0 *DiffFileIterator
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2416,44 +3098,45 @@ type ClientCreateCommitFromPatchFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ClientCreateCommitFromPatchFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c ClientDiffFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ClientCreateCommitFromPatchFuncCall) Results() []interface{} {
+func (c ClientDiffFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// ClientDiffFunc describes the behavior when the Diff method of the parent
-// MockClient instance is invoked.
-type ClientDiffFunc struct {
-	defaultHook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)
-	hooks       []func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)
-	history     []ClientDiffFuncCall
+// ClientDiffFileRevisionsFunc describes the behavior when the
+// DiffFileRevisions method of the parent MockClient instance is invoked.
+type ClientDiffFileRevisionsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)
+	history     []ClientDiffFileRevisionsFuncCall
 	mutex       sync.Mutex
 }
 
-// Diff delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockClient) Diff(v0 context.Context, v1 DiffOptions, v2 authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
-	r0, r1 := m.DiffFunc.nextHook()(v0, v1, v2)
-	m.DiffFunc.appendCall(ClientDiffFuncCall{v0, v1, v2, r0, r1})
+// DiffFileRevisions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockClient) DiffFileRevisions(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string, v6 string) ([]*diff.Hunk, error) {
+	r0, r1 := m.DiffFileRevisionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.DiffFileRevisionsFunc.appendCall(ClientDiffFileRevisionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Diff method of the
-// parent MockClient instance is invoked and the hook queue is empty.
-func (f *ClientDiffFunc) SetDefaultHook(hook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)) {
+// SetDefaultHook sets function that is called when the DiffFileRevisions
+// method of the parent MockClient instance is invoked and the hook queue is
+// empty.
+func (f *ClientDiffFileRevisionsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Diff method of the parent MockClient instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *ClientDiffFunc) PushHook(hook func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error)) {
+// DiffFileRevisions method of the parent MockClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ClientDiffFileRevisionsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2461,20 +3144,20 @@ func (f *ClientDiffFunc) PushHook(hook func(context.Context, DiffOptions, authz.
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *ClientDiffFunc) SetDefaultReturn(r0 *DiffFileIterator, r1 error) {
-	f.SetDefaultHook(func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+func (f *ClientDiffFileRevisionsFunc) SetDefaultReturn(r0 []*diff.Hunk, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *ClientDiffFunc) PushReturn(r0 *DiffFileIterator, r1 error) {
-	f.PushHook(func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+func (f *ClientDiffFileRevisionsFunc) PushReturn(r0 []*diff.Hunk, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
 		return r0, r1
 	})
 }
 
-func (f *ClientDiffFunc) nextHook() func(context.Context, DiffOptions, authz.SubRepoPermissionChecker) (*DiffFileIterator, error) {
+func (f *ClientDiffFileRevisionsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string, string) ([]*diff.Hunk, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2487,38 +3170,50 @@ func (f *ClientDiffFunc) nextHook() func(context.Context, DiffOptions, authz.Sub
 	return hook
 }
 
-func (f *ClientDiffFunc) appendCall(r0 ClientDiffFuncCall) {
+func (f *ClientDiffFileRevisionsFunc) appendCall(r0 ClientDiffFileRevisionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of ClientDiffFuncCall objects describing the
-// invocations of this function.
-func (f *ClientDiffFunc) History() []ClientDiffFuncCall {
+// History returns a sequence of ClientDiffFileRevisionsFuncCall objects
+// describing the invocations of this function.
+func (f *ClientDiffFileRevisionsFunc) History() []ClientDiffFileRevisionsFuncCall {
 	f.mutex.Lock()
-	history := make([]ClientDiffFuncCall, len(f.history))
+	history := make([]ClientDiffFileRevisionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// ClientDiffFuncCall is an object that describes an invocation of method
-// Diff on an instance of MockClient.
-type ClientDiffFuncCall struct {
+// ClientDiffFileRevisionsFuncCall is an object that describes an invocation
+// of method DiffFileRevisions on an instance of MockClient.
+type ClientDiffFileRevisionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 DiffOptions
+	Arg1 authz.SubRepoPermissionChecker
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 authz.SubRepoPermissionChecker
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *DiffFileIterator
+	Result0 []*diff.Hunk
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2526,13 +3221,13 @@ type ClientDiffFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c ClientDiffFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c ClientDiffFileRevisionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c ClientDiffFuncCall) Results() []interface{} {
+func (c ClientDiffFileRevisionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -2768,6 +3463,122 @@ func (c ClientDiffSymbolsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientFileHistoryFunc describes the behavior when the FileHistory method
+// of the parent MockClient instance is invoked.
+type ClientFileHistoryFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error)
+	history     []ClientFileHistoryFuncCall
+	mutex       sync.Mutex
+}
+
+// FileHistory delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) FileHistory(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+	r0, r1, r2 := m.FileHistoryFunc.nextHook()(v0, v1, v2, v3)
+	m.FileHistoryFunc.appendCall(ClientFileHistoryFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the FileHistory method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientFileHistoryFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FileHistory method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientFileHistoryFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientFileHistoryFunc) SetDefaultReturn(r0 []gitdomain.FileHistoryEntry, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientFileHistoryFunc) PushReturn(r0 []gitdomain.FileHistoryEntry, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ClientFileHistoryFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, gitdomain.FileHistoryOptions) ([]gitdomain.FileHistoryEntry, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientFileHistoryFunc) appendCall(r0 ClientFileHistoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientFileHistoryFuncCall objects
+// describing the invocations of this function.
+func (f *ClientFileHistoryFunc) History() []ClientFileHistoryFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientFileHistoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientFileHistoryFuncCall is an object that describes an invocation of
+// method FileHistory on an instance of MockClient.
+type ClientFileHistoryFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 gitdomain.FileHistoryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []gitdomain.FileHistoryEntry
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientFileHistoryFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientFileHistoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ClientFirstEverCommitFunc describes the behavior when the FirstEverCommit
 // method of the parent MockClient instance is invoked.
 type ClientFirstEverCommitFunc struct {
@@ -5508,6 +6319,125 @@ func (c ClientRemoveFromFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ClientRenamedFilesFunc describes the behavior when the RenamedFiles
+// method of the parent MockClient instance is invoked.
+type ClientRenamedFilesFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error)
+	history     []ClientRenamedFilesFuncCall
+	mutex       sync.Mutex
+}
+
+// RenamedFiles delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) RenamedFiles(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 string, v4 string, v5 string) ([]RenamedFile, error) {
+	r0, r1 := m.RenamedFilesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.RenamedFilesFunc.appendCall(ClientRenamedFilesFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RenamedFiles method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientRenamedFilesFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RenamedFiles method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientRenamedFilesFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientRenamedFilesFunc) SetDefaultReturn(r0 []RenamedFile, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientRenamedFilesFunc) PushReturn(r0 []RenamedFile, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientRenamedFilesFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, string, string, string) ([]RenamedFile, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientRenamedFilesFunc) appendCall(r0 ClientRenamedFilesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientRenamedFilesFuncCall objects
+// describing the invocations of this function.
+func (f *ClientRenamedFilesFunc) History() []ClientRenamedFilesFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientRenamedFilesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientRenamedFilesFuncCall is an object that describes an invocation of
+// method RenamedFiles on an instance of MockClient.
+type ClientRenamedFilesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RenamedFile
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientRenamedFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientRenamedFilesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientRendezvousAddrForRepoFunc describes the behavior when the
 // RendezvousAddrForRepo method of the parent MockClient instance is
 // invoked.
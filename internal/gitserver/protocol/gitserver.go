@@ -335,3 +335,67 @@ type GetObjectRequest struct {
 type GetObjectResponse struct {
 	Object gitdomain.GitObject
 }
+
+// FileHistoryRequest is a request for the history of a file, following the
+// file across renames and copies.
+type FileHistoryRequest struct {
+	Repo   api.RepoName
+	Commit string
+	Path   string
+	N      int
+	Skip   int
+}
+
+type FileHistoryResponse struct {
+	Entries     []gitdomain.FileHistoryEntry
+	HasNextPage bool
+	// UnknownRevision is the commit of the request if it does not resolve to a
+	// commit of the repository.
+	UnknownRevision string `json:",omitempty"`
+}
+
+// CommitGraphIsAncestorRequest is a request to determine whether a commit is an
+// ancestor of another using the commit graph index of a repository.
+type CommitGraphIsAncestorRequest struct {
+	Repo       api.RepoName
+	Ancestor   string
+	Descendant string
+}
+
+type CommitGraphIsAncestorResponse struct {
+	IsAncestor bool
+	// UnknownRevision is the revision of the request that does not resolve to a
+	// commit of the repository, if any.
+	UnknownRevision string `json:",omitempty"`
+}
+
+// CommitGraphMergeBaseRequest is a request for a best common ancestor of two
+// commits using the commit graph index of a repository.
+type CommitGraphMergeBaseRequest struct {
+	Repo api.RepoName
+	A    string
+	B    string
+}
+
+type CommitGraphMergeBaseResponse struct {
+	// MergeBase is empty if the commits have no common ancestor.
+	MergeBase       api.CommitID
+	UnknownRevision string `json:",omitempty"`
+}
+
+// CommitGraphCommitsBetweenRequest is a request for at most Limit of the commits
+// reachable from a revision whose committer date is within [After, Before) using
+// the commit graph index of a repository. A zero bound is ignored. Limit must be
+// positive.
+type CommitGraphCommitsBetweenRequest struct {
+	Repo     api.RepoName
+	Revision string
+	After    time.Time
+	Before   time.Time
+	Limit    int
+}
+
+type CommitGraphCommitsBetweenResponse struct {
+	Commits         []gitdomain.CommitGraphCommit
+	UnknownRevision string `json:",omitempty"`
+}
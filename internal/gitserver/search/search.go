@@ -85,6 +85,7 @@ const (
 type CommitSearcher struct {
 	Logger               log.Logger
 	RepoDir              string
+	Env                  []string // environment of the git commands, if not nil
 	Query                MatchTree
 	Revisions            []protocol.RevisionSpecifier
 	IncludeDiff          bool
@@ -143,6 +144,7 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	}
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = cs.RepoDir
+	cmd.Env = cs.Env
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -216,7 +218,7 @@ func getSubRepoFilterFunc(ctx context.Context, checker authz.SubRepoPermissionCh
 
 func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	// Create a new diff fetcher subprocess for each worker
-	diffFetcher, err := NewDiffFetcher(cs.RepoDir)
+	diffFetcher, err := NewDiffFetcher(cs.RepoDir, cs.Env)
 	if err != nil {
 		return err
 	}
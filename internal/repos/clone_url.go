@@ -92,6 +92,10 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		if r, ok := repo.Metadata.(*extsvc.OtherRepoMetadata); ok {
 			return otherCloneURL(repo, r), nil
 		}
+	case *schema.MercurialConnection:
+		if r, ok := repo.Metadata.(*extsvc.MercurialRepoMetadata); ok {
+			return mercurialCloneURL(r, t)
+		}
 	case *schema.GoModulesConnection:
 		return string(repo.Name), nil
 	case *schema.PythonPackagesConnection:
@@ -0,0 +1,125 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection
+// configured in Sourcegraph via the external services configuration.
+type MercurialSource struct {
+	svc    *types.ExternalService
+	config *schema.MercurialConnection
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external
+// service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+	return newMercurialSource(svc, &c)
+}
+
+func newMercurialSource(svc *types.ExternalService, c *schema.MercurialConnection) (*MercurialSource, error) {
+	return &MercurialSource{
+		svc:    svc,
+		config: c,
+	}, nil
+}
+
+// ListRepos returns all Mercurial repositories configured with this
+// MercurialSource's config.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	base, err := mercurialBaseURL(s.config.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+
+	urn := s.svc.URN()
+	for _, repo := range s.config.Repos {
+		r, err := s.makeRepo(urn, base, repo)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s MercurialSource) makeRepo(urn string, base *url.URL, repo string) (*types.Repo, error) {
+	relativePath := strings.Trim(repo, "/")
+	cloneURL, err := base.Parse(relativePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The base URL may contain credentials, which must not end up in the
+	// repo name or in the ServiceID.
+	serviceID := *base
+	serviceID.User = nil
+	name := url.URL{Host: base.Host, Path: base.Path}
+
+	return &types.Repo{
+		Name: api.RepoName(reposource.OtherRepoName(s.config.RepositoryPathPattern, name.String(), relativePath)),
+		URI:  reposource.OtherRepoName("", name.String(), relativePath),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          relativePath,
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   extsvc.NormalizeBaseURL(&serviceID).String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL.String(),
+			},
+		},
+		Metadata: &extsvc.MercurialRepoMetadata{
+			RelativePath: relativePath,
+		},
+	}, nil
+}
+
+// mercurialBaseURL parses the base URL of a Mercurial connection. Repository
+// paths are resolved relative to it, so its path always ends with a slash.
+func mercurialBaseURL(rawURL string) (*url.URL, error) {
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return extsvc.NormalizeBaseURL(base), nil
+}
+
+// mercurialCloneURL returns the clone URL of a Mercurial repository, including
+// the credentials of the connection.
+func mercurialCloneURL(m *extsvc.MercurialRepoMetadata, cfg *schema.MercurialConnection) (string, error) {
+	base, err := mercurialBaseURL(cfg.Url)
+	if err != nil {
+		return "", err
+	}
+	cloneURL, err := base.Parse(m.RelativePath)
+	if err != nil {
+		return "", err
+	}
+	return cloneURL.String(), nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -45,12 +46,15 @@ type SearchClient interface {
 	JobClients() job.RuntimeClients
 }
 
-func NewSearchClient(logger log.Logger, db database.DB, zoektStreamer zoekt.Streamer, searcherURLs *endpoint.Map) SearchClient {
+// NewSearchClient returns a client that plans and executes searches. The given
+// code intelligence client may be nil if precise code intelligence is not available.
+func NewSearchClient(logger log.Logger, db database.DB, zoektStreamer zoekt.Streamer, searcherURLs *endpoint.Map, codeIntel precise.Client) SearchClient {
 	return &searchClient{
 		logger:       logger,
 		db:           db,
 		zoekt:        zoektStreamer,
 		searcherURLs: searcherURLs,
+		codeIntel:    codeIntel,
 	}
 }
 
@@ -59,6 +63,7 @@ type searchClient struct {
 	db           database.DB
 	zoekt        zoekt.Streamer
 	searcherURLs *endpoint.Map
+	codeIntel    precise.Client
 }
 
 func (s *searchClient) Plan(
@@ -80,7 +85,14 @@ func (s *searchClient) Plan(
 	if err != nil {
 		return nil, err
 	}
-	searchType = overrideSearchType(searchQuery, searchType)
+
+	// Expand references to query fragments defined in settings, e.g. @nogen.
+	expandedQuery, err := query.ExpandFragments(searchQuery, settings.SearchQueryFragments, searchType)
+	if err != nil {
+		return nil, &QueryError{Query: searchQuery, Err: err}
+	}
+
+	searchType = overrideSearchType(expandedQuery, searchType)
 
 	if searchType == query.SearchTypeStructural && !conf.StructuralSearchEnabled() {
 		return nil, errors.New("Structural search is disabled in the site configuration.")
@@ -97,13 +109,23 @@ func (s *searchClient) Plan(
 		return sc.Query, nil
 	})
 
-	var plan query.Plan
-	plan, err = query.Pipeline(
-		query.Init(searchQuery, searchType),
-		query.With(searchContextsQueryEnabled, substituteContextsStep),
-	)
+	join, err := query.ParseJoin(expandedQuery, searchType, query.With(searchContextsQueryEnabled, substituteContextsStep))
 	if err != nil {
-		return nil, &QueryError{Query: searchQuery, Err: err}
+		return nil, &QueryError{Query: expandedQuery, Err: err}
+	}
+
+	var plan query.Plan
+	if join != nil {
+		tr.LazyPrintf("joining %d subqueries on %s", len(join.Operands), join.Key)
+		plan = join.Plan()
+	} else {
+		plan, err = query.Pipeline(
+			query.Init(expandedQuery, searchType),
+			query.With(searchContextsQueryEnabled, substituteContextsStep),
+		)
+		if err != nil {
+			return nil, &QueryError{Query: expandedQuery, Err: err}
+		}
 	}
 	tr.LazyPrintf("parsing done")
 
@@ -111,6 +133,7 @@ func (s *searchClient) Plan(
 		Plan:                plan,
 		Query:               plan.ToQ(),
 		OriginalQuery:       searchQuery,
+		Join:                join,
 		UserSettings:        settings,
 		OnSourcegraphDotCom: sourcegraphDotComMode,
 		Features:            toFeatures(featureflag.FromContext(ctx), s.logger),
@@ -118,6 +141,10 @@ func (s *searchClient) Plan(
 		Protocol:            protocol,
 	}
 
+	if expandedQuery != searchQuery {
+		inputs.ExpandedQuery = expandedQuery
+	}
+
 	tr.LazyPrintf("Parsed query: %s", inputs.Query)
 
 	return inputs, nil
@@ -134,7 +161,12 @@ func (s *searchClient) Execute(
 		tr.Finish()
 	}()
 
-	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	var planJob job.Job
+	if inputs.Join != nil {
+		planJob, err = jobutil.NewJoinPlanJob(inputs, inputs.Join)
+	} else {
+		planJob, err = jobutil.NewPlanJob(inputs, inputs.Plan)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +181,7 @@ func (s *searchClient) JobClients() job.RuntimeClients {
 		Zoekt:        s.zoekt,
 		SearcherURLs: s.searcherURLs,
 		Gitserver:    gitserver.NewClient(s.db),
+		CodeIntel:    s.codeIntel,
 	}
 }
 
@@ -242,6 +275,7 @@ func toFeatures(flagSet *featureflag.FlagSet, logger log.Logger) *search.Feature
 		HybridSearch:            flagSet.GetBoolOr("search-hybrid", false),
 		CodeOwnershipFilters:    flagSet.GetBoolOr("code-ownership", false),
 		AbLuckySearch:           flagSet.GetBoolOr("ab-lucky-search", false),
+		Ranking:                 flagSet.GetBoolOr("search-ranking", false),
 	}
 }
 
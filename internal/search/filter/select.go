@@ -1,12 +1,16 @@
 package filter
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/grafana/regexp"
+
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const (
+	Capture    = "capture"
 	Commit     = "commit"
 	Content    = "content"
 	File       = "file"
@@ -73,11 +77,45 @@ var validSelectors = object{
 		"event":          nil,
 		"operator":       nil,
 		"type-parameter": nil,
+		"references":     nil,
 	},
 }
 
+// CaptureGroup returns the index of the capture group of re selected by a
+// select:capture path. select:capture selects the first group of re, or the
+// whole match if re has no groups. select:capture.N selects the Nth group and
+// select:capture.name the group with the given name.
+func (sp SelectPath) CaptureGroup(re *regexp.Regexp) (int, error) {
+	if len(sp) < 2 {
+		if re.NumSubexp() > 0 {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	group := sp[1]
+	if n, err := strconv.Atoi(group); err == nil {
+		if n < 0 || n > re.NumSubexp() {
+			return 0, errors.Errorf("invalid capture group %d on select path %q, the pattern has %d capture groups", n, sp.String(), re.NumSubexp())
+		}
+		return n, nil
+	}
+	if i := re.SubexpIndex(group); i >= 0 {
+		return i, nil
+	}
+	return 0, errors.Errorf("invalid capture group %q on select path %q, the pattern has no capture group with that name", group, sp.String())
+}
+
 func SelectPathFromString(s string) (SelectPath, error) {
 	fields := strings.Split(s, ".")
+	if fields[0] == Capture {
+		// The capture group depends on the search pattern, see
+		// SelectPath.CaptureGroup.
+		if len(fields) > 2 || len(fields) == 2 && fields[1] == "" {
+			return SelectPath{}, errors.Errorf("invalid capture group on select path %q", s)
+		}
+		return SelectPath(fields), nil
+	}
 	cur := validSelectors
 	for _, field := range fields {
 		child, ok := cur[field]
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 )
 
@@ -72,4 +73,8 @@ type RuntimeClients struct {
 	Zoekt        zoekt.Streamer
 	SearcherURLs *endpoint.Map
 	Gitserver    gitserver.Client
+
+	// CodeIntel queries precise code intelligence. It is nil when precise code
+	// intelligence is not available, e.g. in OSS builds.
+	CodeIntel precise.Client
 }
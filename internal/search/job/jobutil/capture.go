@@ -0,0 +1,134 @@
+package jobutil
+
+import (
+	"context"
+	"sort"
+
+	"github.com/grafana/regexp"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewCaptureJob creates a job for select:capture. It replaces the content
+// matches streamed by child with the values of the given capture group of
+// pattern, counted per file. All other matches are dropped.
+func NewCaptureJob(pattern *regexp.Regexp, group int, child job.Job) job.Job {
+	return &captureJob{pattern: pattern, group: group, child: child}
+}
+
+type captureJob struct {
+	pattern *regexp.Regexp
+	group   int
+	child   job.Job
+}
+
+func (j *captureJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	capturingStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		captured := make(result.Matches, 0, len(event.Results))
+		for _, match := range event.Results {
+			fm, ok := match.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			if cm := j.capture(fm); cm != nil {
+				captured = append(captured, cm)
+			}
+		}
+		event.Results = captured
+		stream.Send(event)
+	})
+
+	return j.child.Run(ctx, clients, capturingStream)
+}
+
+// capture returns the values of the capture group in the matched ranges of
+// fm, or nil if there are none.
+func (j *captureJob) capture(fm *result.FileMatch) *result.CaptureMatch {
+	counts := make(map[string]int)
+	for _, cm := range fm.ChunkMatches {
+		for _, content := range cm.MatchedContent() {
+			for _, submatches := range j.pattern.FindAllStringSubmatchIndex(content, -1) {
+				start, end := submatches[2*j.group], submatches[2*j.group+1]
+				if start < 0 || end < 0 {
+					// The pattern matched, but the capture group did not.
+					continue
+				}
+				counts[content[start:end]]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	captures := make([]result.Capture, 0, len(counts))
+	for value, count := range counts {
+		captures = append(captures, result.Capture{Value: value, Count: count})
+	}
+	sort.Slice(captures, func(i, j int) bool { return captures[i].Value < captures[j].Value })
+
+	return &result.CaptureMatch{
+		File:     fm.File,
+		Captures: captures,
+	}
+}
+
+func (j *captureJob) Name() string {
+	return "CaptureJob"
+}
+
+func (j *captureJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Stringer("pattern", j.pattern),
+			otlog.Int("group", j.group),
+		)
+	}
+	return res
+}
+
+func (j *captureJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *captureJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// newCaptureJob creates the job for select:capture of b, which must have a
+// single regular expression pattern.
+func newCaptureJob(b query.Basic, sp filter.SelectPath, child job.Job) (job.Job, error) {
+	p, ok := b.Pattern.(query.Pattern)
+	if !ok {
+		return nil, errors.New("select:capture requires a single regular expression search pattern")
+	}
+	pattern := p.Value
+	if !b.IsCaseSensitive() {
+		pattern = "(?i:" + pattern + ")"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	group, err := sp.CaptureGroup(re)
+	if err != nil {
+		return nil, err
+	}
+	return NewCaptureJob(re, group, child), nil
+}
@@ -0,0 +1,146 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestCaptureJob(t *testing.T) {
+	cm := func(matchedStrings ...string) result.ChunkMatch {
+		ranges := make([]result.Range, 0, len(matchedStrings))
+		currOffset := 0
+		for _, matchedString := range matchedStrings {
+			ranges = append(ranges, result.Range{
+				Start: result.Location{Offset: currOffset},
+				End:   result.Location{Offset: currOffset + len(matchedString)},
+			})
+			currOffset += len(matchedString)
+		}
+		return result.ChunkMatch{
+			Content: strings.Join(matchedStrings, ""),
+			Ranges:  ranges,
+		}
+	}
+	fm := func(path string, cms ...result.ChunkMatch) *result.FileMatch {
+		return &result.FileMatch{
+			File:         result.File{Path: path},
+			ChunkMatches: cms,
+		}
+	}
+
+	cases := []struct {
+		name        string
+		query       string
+		inputEvent  streaming.SearchEvent
+		outputEvent streaming.SearchEvent
+	}{{
+		name:  "first group by default",
+		query: `go\s+(\d+)\.(\d+) select:capture`,
+		inputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				fm("a/go.mod", cm("go 1.18", "go 1.19")),
+				fm("b/go.mod", cm("go 2.0")),
+			},
+		},
+		outputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				&result.CaptureMatch{
+					File:     result.File{Path: "a/go.mod"},
+					Captures: []result.Capture{{Value: "1", Count: 2}},
+				},
+				&result.CaptureMatch{
+					File:     result.File{Path: "b/go.mod"},
+					Captures: []result.Capture{{Value: "2", Count: 1}},
+				},
+			},
+		},
+	}, {
+		name:  "numbered group",
+		query: `go\s+(\d+)\.(\d+) select:capture.2`,
+		inputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				fm("go.mod", cm("go 1.18", "go 1.19", "go 2.18")),
+			},
+		},
+		outputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				&result.CaptureMatch{
+					File: result.File{Path: "go.mod"},
+					Captures: []result.Capture{
+						{Value: "18", Count: 2},
+						{Value: "19", Count: 1},
+					},
+				},
+			},
+		},
+	}, {
+		name:  "named group is case insensitive",
+		query: `GO\s+(?P<version>[\d.]+) select:capture.version`,
+		inputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				fm("go.mod", cm("go 1.18")),
+			},
+		},
+		outputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				&result.CaptureMatch{
+					File:     result.File{Path: "go.mod"},
+					Captures: []result.Capture{{Value: "1.18", Count: 1}},
+				},
+			},
+		},
+	}, {
+		name:  "drops matches without captures",
+		query: `go\s+(\d+)?x? select:capture`,
+		inputEvent: streaming.SearchEvent{
+			Results: result.Matches{
+				fm("go.mod", cm("go x")),
+				fm("go.sum"),
+				&result.RepoMatch{Name: "go"},
+			},
+			Stats: streaming.Stats{IsLimitHit: true},
+		},
+		outputEvent: streaming.SearchEvent{
+			Results: result.Matches{},
+			Stats:   streaming.Stats{IsLimitHit: true},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(tc.query))
+			require.NoError(t, err)
+			b := plan[0]
+			sp, err := filter.SelectPathFromString(b.FindValue(query.FieldSelect))
+			require.NoError(t, err)
+
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(tc.inputEvent)
+				return nil, nil
+			})
+			var got streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				got = ev
+			})
+
+			j, err := newCaptureJob(b, sp, childJob)
+			require.NoError(t, err)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, got)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// NewFileHistoryFilterJob creates a filter job to post-filter results for the
+// file:has.commit.after() and file:has.contributor() predicates.
+//
+// Each file result streamed by the child is checked against the git log of
+// its path at the commit it was found in. A file is kept only if every
+// included time reference and contributor has a matching commit, and no
+// excluded time reference or contributor does. Results that are not files
+// are dropped since these predicates only apply to file history.
+func NewFileHistoryFilterJob(child job.Job, includeCommitAfter, excludeCommitAfter, includeContributors, excludeContributors []string) job.Job {
+	return &fileHistoryFilterJob{
+		child:               child,
+		includeCommitAfter:  includeCommitAfter,
+		excludeCommitAfter:  excludeCommitAfter,
+		includeContributors: includeContributors,
+		excludeContributors: excludeContributors,
+	}
+}
+
+type fileHistoryFilterJob struct {
+	child job.Job
+
+	includeCommitAfter  []string
+	excludeCommitAfter  []string
+	includeContributors []string
+	excludeContributors []string
+}
+
+func (j *fileHistoryFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filterMatches(ctx, clients.Gitserver, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// fileHistoryConcurrency bounds the number of files whose history is checked
+// concurrently for each batch of streamed results.
+const fileHistoryConcurrency = 8
+
+func (j *fileHistoryFilterJob) filterMatches(ctx context.Context, client gitserver.Client, matches []result.Match) ([]result.Match, error) {
+	keep := make([]bool, len(matches))
+	g := group.New().WithContext(ctx).WithMaxConcurrency(fileHistoryConcurrency)
+	for i, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		i, fm := i, fm
+		g.Go(func(ctx context.Context) (err error) {
+			keep[i], err = j.matchesHistory(ctx, client, &fm.File)
+			return err
+		})
+	}
+	errs := g.Wait()
+
+	filtered := matches[:0]
+	for i, m := range matches {
+		if keep[i] {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered, errs
+}
+
+// matchesHistory returns true if the history of the given file satisfies all
+// of the predicates of this job.
+func (j *fileHistoryFilterJob) matchesHistory(ctx context.Context, client gitserver.Client, file *result.File) (bool, error) {
+	type condition struct {
+		opts    gitserver.CommitsOptions
+		negated bool
+	}
+
+	var conditions []condition
+	for _, after := range j.includeCommitAfter {
+		conditions = append(conditions, condition{opts: gitserver.CommitsOptions{After: after}})
+	}
+	for _, after := range j.excludeCommitAfter {
+		conditions = append(conditions, condition{opts: gitserver.CommitsOptions{After: after}, negated: true})
+	}
+	for _, contributor := range j.includeContributors {
+		conditions = append(conditions, condition{opts: gitserver.CommitsOptions{Author: contributor}})
+	}
+	for _, contributor := range j.excludeContributors {
+		conditions = append(conditions, condition{opts: gitserver.CommitsOptions{Author: contributor}, negated: true})
+	}
+
+	for _, c := range conditions {
+		opts := c.opts
+		opts.Range = string(file.CommitID)
+		opts.Path = file.Path
+		opts.N = 1
+		opts.NoEnsureRevision = true
+
+		commits, err := client.Commits(ctx, file.Repo.Name, opts, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			return false, err
+		}
+		if hasCommit := len(commits) > 0; hasCommit == c.negated {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (j *fileHistoryFilterJob) Name() string {
+	return "FileHistoryFilterJob"
+}
+
+func (j *fileHistoryFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		if len(j.includeCommitAfter) > 0 {
+			res = append(res, trace.Strings("includeCommitAfter", j.includeCommitAfter))
+		}
+		if len(j.excludeCommitAfter) > 0 {
+			res = append(res, trace.Strings("excludeCommitAfter", j.excludeCommitAfter))
+		}
+		if len(j.includeContributors) > 0 {
+			res = append(res, trace.Strings("includeContributors", j.includeContributors))
+		}
+		if len(j.excludeContributors) > 0 {
+			res = append(res, trace.Strings("excludeContributors", j.excludeContributors))
+		}
+	}
+	return res
+}
+
+func (j *fileHistoryFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHistoryFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
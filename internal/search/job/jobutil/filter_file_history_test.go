@@ -0,0 +1,107 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestFileHistoryFilterJob(t *testing.T) {
+	// Recently changed: a.go (by alice), b.go (by bob). Stale: c.go (by alice).
+	type commit struct {
+		author string
+		recent bool
+	}
+	history := map[string][]commit{
+		"a.go": {{author: "alice", recent: true}},
+		"b.go": {{author: "bob", recent: true}, {author: "alice"}},
+		"c.go": {{author: "alice"}},
+	}
+
+	gsClient := gitserver.NewMockClient()
+	gsClient.CommitsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opts gitserver.CommitsOptions, _ authz.SubRepoPermissionChecker) ([]*gitdomain.Commit, error) {
+		var commits []*gitdomain.Commit
+		for _, c := range history[opts.Path] {
+			if opts.After != "" && !c.recent {
+				continue
+			}
+			if opts.Author != "" && opts.Author != c.author {
+				continue
+			}
+			commits = append(commits, &gitdomain.Commit{Author: gitdomain.Signature{Name: c.author}})
+		}
+		return commits, nil
+	})
+
+	fm := func(path string) result.Match {
+		return &result.FileMatch{File: result.File{Path: path}}
+	}
+
+	cases := []struct {
+		name                string
+		includeCommitAfter  []string
+		excludeCommitAfter  []string
+		includeContributors []string
+		excludeContributors []string
+		input               result.Matches
+		output              result.Matches
+	}{{
+		name:               "recently changed files",
+		includeCommitAfter: []string{"2 weeks ago"},
+		input:              result.Matches{fm("a.go"), fm("b.go"), fm("c.go")},
+		output:             result.Matches{fm("a.go"), fm("b.go")},
+	}, {
+		name:               "stale files",
+		excludeCommitAfter: []string{"2 weeks ago"},
+		input:              result.Matches{fm("a.go"), fm("b.go"), fm("c.go")},
+		output:             result.Matches{fm("c.go")},
+	}, {
+		name:                "files with contributor",
+		includeContributors: []string{"alice"},
+		input:               result.Matches{fm("a.go"), fm("b.go"), fm("c.go")},
+		output:              result.Matches{fm("a.go"), fm("b.go"), fm("c.go")},
+	}, {
+		name:                "recently changed files without contributor",
+		includeCommitAfter:  []string{"2 weeks ago"},
+		excludeContributors: []string{"bob"},
+		input:               result.Matches{fm("a.go"), fm("b.go"), fm("c.go")},
+		output:              result.Matches{fm("a.go")},
+	}, {
+		name:                "non-file matches are dropped",
+		includeContributors: []string{"alice"},
+		input:               result.Matches{&result.RepoMatch{Name: "repo"}, fm("a.go")},
+		output:              result.Matches{fm("a.go")},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: append(result.Matches(nil), tc.input...)})
+				return nil, nil
+			})
+
+			var got result.Matches
+			stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+				got = append(got, e.Results...)
+			})
+
+			j := NewFileHistoryFilterJob(childJob, tc.includeCommitAfter, tc.excludeCommitAfter, tc.includeContributors, tc.excludeContributors)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gsClient}, stream)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, got)
+		})
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/lucky"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/ranking"
+	"github.com/sourcegraph/sourcegraph/internal/search/references"
 	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
@@ -64,6 +66,11 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		children = append(children, j)
 	}
 
+	// Modify the input query if the user specified `type:reference` or
+	// `select:symbol.references`. Both run a symbol search for definitions
+	// whose results are resolved into references below.
+	b, findReferences := toSymbolDefinitionsQuery(b)
+
 	// Modify the input query if the user specified `file:contains.content()`
 	fileContainsPatterns := b.FileContainsContent()
 	originalQuery := b
@@ -86,7 +93,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		resultTypes := computeResultTypes(types, b, inputs.PatternType)
 		fileMatchLimit := int32(computeFileMatchLimit(b, inputs.Protocol))
 		selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
-		repoOptions := toRepoOptions(b, inputs.UserSettings)
+		repoOptions := ToRepoOptions(b, inputs.UserSettings)
 		repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos := jobMode(b, repoOptions, resultTypes, inputs.PatternType, inputs.OnSourcegraphDotCom)
 
 		builder := &jobBuilder{
@@ -188,10 +195,35 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply symbol references search
+		// File predicates below filter the files containing references,
+		// so they must wrap the references job rather than the definitions
+		// search it runs.
+		if findReferences {
+			basicJob = references.NewSearchJob(basicJob, toTextPatternInfo(b, result.TypeFile, inputs.Features, inputs.Protocol), computeFileMatchLimit(b, inputs.Protocol))
+		}
+	}
+
+	{ // Apply file history post-search filter
+		includeCommitAfter, excludeCommitAfter := b.FileHasCommitAfter()
+		includeContributors, excludeContributors := b.FileHasContributor()
+		if len(includeCommitAfter) > 0 || len(excludeCommitAfter) > 0 || len(includeContributors) > 0 || len(excludeContributors) > 0 {
+			basicJob = NewFileHistoryFilterJob(basicJob, includeCommitAfter, excludeCommitAfter, includeContributors, excludeContributors)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
+			if sp.Root() == filter.Capture {
+				captureJob, err := newCaptureJob(originalQuery, sp, basicJob)
+				if err != nil {
+					return nil, err
+				}
+				basicJob = captureJob
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
 		}
 	}
 
@@ -202,6 +234,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply ranking to searches bounded by count:
+		if count := b.Count(); inputs.Features.Ranking && count != nil && *count <= ranking.MaxBufferedResults {
+			basicJob = ranking.NewRankingJob(basicJob)
+		}
+	}
+
 	{ // Apply limit
 		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
 		basicJob = NewLimitJob(maxResults, basicJob)
@@ -233,6 +271,38 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	return basicJob, nil
 }
 
+// toSymbolDefinitionsQuery rewrites a query containing `type:reference` or
+// `select:symbol.references` into a symbol search for the definitions whose
+// references should be found. The returned boolean is true if the query was
+// rewritten.
+func toSymbolDefinitionsQuery(b query.Basic) (query.Basic, bool) {
+	findReferences := false
+	hasType := false
+	parameters := make([]query.Parameter, 0, len(b.Parameters))
+	for _, p := range b.Parameters {
+		switch {
+		case p.Field == query.FieldType && p.Value == "reference":
+			findReferences = true
+			p.Value = "symbol"
+		case p.Field == query.FieldSelect && p.Value == filter.Symbol+".references":
+			findReferences = true
+			continue
+		}
+		if p.Field == query.FieldType {
+			hasType = true
+		}
+		parameters = append(parameters, p)
+	}
+
+	if !findReferences {
+		return b, false
+	}
+	if !hasType {
+		parameters = append(parameters, query.Parameter{Field: query.FieldType, Value: "symbol"})
+	}
+	return b.MapParameters(parameters), true
+}
+
 // orderSearcherJob ensures that, if a searcher job exists, then it is only ever
 // run sequentially after a Zoekt search has returned all its results.
 func orderSearcherJob(j job.Job) job.Job {
@@ -286,7 +356,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	maxResults := f.MaxResults(searchInputs.DefaultLimit())
 	types, _ := f.IncludeExcludeValues(query.FieldType)
 	resultTypes := computeResultTypes(types, f.ToBasic(), searchInputs.PatternType)
-	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Protocol)
+	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Features, searchInputs.Protocol)
 
 	// searcher to use full deadline if timeout: set or we are streaming.
 	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming
@@ -294,7 +364,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	fileMatchLimit := int32(computeFileMatchLimit(f.ToBasic(), searchInputs.Protocol))
 	selector, _ := filter.SelectPathFromString(f.FindValue(query.FieldSelect)) // Invariant: select is validated
 
-	repoOptions := toRepoOptions(f.ToBasic(), searchInputs.UserSettings)
+	repoOptions := ToRepoOptions(f.ToBasic(), searchInputs.UserSettings)
 
 	_, skipRepoSubsetSearch, _ := jobMode(f.ToBasic(), repoOptions, resultTypes, searchInputs.PatternType, searchInputs.OnSourcegraphDotCom)
 
@@ -524,13 +594,19 @@ func count(b query.Basic, p search.Protocol) int {
 // text search. An atomic query is a Basic query where the Pattern is either
 // nil, or comprises only one Pattern node (hence, an atom, and not an
 // expression). See TextPatternInfo for the values it computes and populates.
-func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protocol) *search.TextPatternInfo {
+func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Features, p search.Protocol) *search.TextPatternInfo {
 	// Handle file: and -file: filters.
 	filesInclude, filesExclude := b.IncludeExcludeValues(query.FieldFile)
-	// Handle lang: and -lang: filters.
+	// Handle lang: and -lang: filters. Structural search relies on the
+	// file extension patterns to select files.
 	langInclude, langExclude := b.IncludeExcludeValues(query.FieldLang)
-	filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
-	filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	var includeLangs, excludeLangs []string
+	if feat != nil && feat.ContentBasedLangFilters && !b.IsStructural() {
+		includeLangs, excludeLangs = langInclude, langExclude
+	} else {
+		filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
+		filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	}
 	selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
 	count := count(b, p)
 
@@ -565,6 +641,8 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		PatternMatchesPath:           resultTypes.Has(result.TypePath),
 		PatternMatchesContent:        resultTypes.Has(result.TypeFile),
 		Languages:                    langInclude,
+		IncludeLangs:                 includeLangs,
+		ExcludeLangs:                 excludeLangs,
 		PathPatternsAreCaseSensitive: b.IsCaseSensitive(),
 		CombyRule:                    b.FindValue(query.FieldCombyRule),
 		Index:                        b.Index(),
@@ -590,7 +668,10 @@ func computeResultTypes(types []string, b query.Basic, searchType query.SearchTy
 	return rts
 }
 
-func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
+// ToRepoOptions returns the options to resolve the repositories searched by
+// b, taking the defaults for forks and archived repositories from
+// userSettings.
+func ToRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
 	repoFilters, minusRepoFilters := b.Repositories()
 
 	var settingForks, settingArchived bool
@@ -0,0 +1,299 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/opentracing/opentracing-go/log"
+	"go.uber.org/atomic"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewJoinPlanJob converts a query.Join into its job tree representation.
+func NewJoinPlanJob(inputs *search.Inputs, join *query.Join) (job.Job, error) {
+	maxResults := 0
+	children := make([]job.Job, 0, len(join.Operands))
+	for _, operand := range join.Operands {
+		operandChildren := make([]job.Job, 0, len(operand))
+		for _, q := range operand {
+			child, err := NewBasicJob(inputs, q)
+			if err != nil {
+				return nil, err
+			}
+			operandChildren = append(operandChildren, child)
+
+			if limit := q.ToParseTree().MaxResults(inputs.DefaultLimit()); limit > maxResults {
+				maxResults = limit
+			}
+		}
+		children = append(children, NewOrJob(operandChildren...))
+	}
+
+	// The joined results are limited by the count: of the query like the
+	// results of any other plan.
+	jobTree := NewJoinJob(join.Key, maxResults, children...)
+	jobTree = NewLimitJob(maxResults, jobTree)
+	return NewAlertJob(inputs, jobTree), nil
+}
+
+// NewJoinJob creates a job that will run each of its child jobs and only
+// stream matches whose join key was found by all of the child jobs. Unlike
+// AndJob, the children may return different result types.
+//
+// Matches are held back until their join key was found by all child jobs. At
+// most maxPending matches are held back; further matches whose key was not
+// found by all child jobs yet are dropped, and the search reports that it hit
+// a limit.
+func NewJoinJob(key query.JoinKey, maxPending int, children ...job.Job) job.Job {
+	if len(children) == 0 {
+		return NewNoopJob()
+	}
+	return &JoinJob{key: key, maxPending: maxPending, children: children}
+}
+
+type JoinJob struct {
+	key        query.JoinKey
+	maxPending int
+	children   []job.Job
+}
+
+func (j *JoinJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		g           errors.Group
+		maxAlerter  search.MaxAlerter
+		limitHit    atomic.Bool
+		sentResults atomic.Bool
+		sem         = semaphore.NewWeighted(16)
+		joiner      = newJoiner(j.key, len(j.children), j.maxPending)
+	)
+	for childNum, child := range j.children {
+		childNum, child := childNum, child
+		g.Go(func() error {
+			if err := sem.Acquire(ctx, 1); err != nil {
+				return err
+			}
+			defer sem.Release(1)
+
+			joiningStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+				var dropped bool
+				event.Results, dropped = joiner.AddMatches(event.Results, childNum)
+				if dropped {
+					event.Stats.IsLimitHit = true
+				}
+				if event.Stats.IsLimitHit {
+					limitHit.Store(true)
+				}
+				if len(event.Results) > 0 {
+					sentResults.Store(true)
+				}
+				if len(event.Results) > 0 || !event.Stats.Zero() {
+					stream.Send(event)
+				}
+			})
+
+			alert, err := child.Run(ctx, clients, joiningStream)
+			maxAlerter.Add(alert)
+			return err
+		})
+	}
+
+	err = g.Wait()
+
+	if !sentResults.Load() && limitHit.Load() {
+		maxAlerter.Add(search.AlertForCappedAndExpression())
+	}
+	return maxAlerter.Alert, err
+}
+
+func (j *JoinJob) Name() string {
+	return "JoinJob"
+}
+
+func (j *JoinJob) Fields(v job.Verbosity) (res []log.Field) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			log.Int("maxPending", j.maxPending),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			log.String("key", string(j.key)),
+		)
+	}
+	return res
+}
+
+func (j *JoinJob) Children() []job.Describer {
+	res := make([]job.Describer, len(j.children))
+	for i := range j.children {
+		res[i] = j.children[i]
+	}
+	return res
+}
+
+func (j *JoinJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.children = make([]job.Job, len(j.children))
+	for i := range j.children {
+		cp.children[i] = job.Map(j.children[i], fn)
+	}
+	return &cp
+}
+
+// joinKey identifies a repository, or a file in a repository if path is set.
+type joinKey struct {
+	repo api.RepoID
+	path string
+}
+
+// joiner tracks the join keys seen by each source and holds back matches
+// until one of their keys has been seen by all sources.
+type joiner struct {
+	key        query.JoinKey
+	maxPending int
+
+	mu      sync.Mutex
+	seen    []map[joinKey]struct{}
+	pending map[joinKey][]result.Match
+	// pendingKeys holds the keys of each match that is held back. A match
+	// is held back under each of its keys, but only counts once towards
+	// maxPending.
+	pendingKeys map[result.Match][]joinKey
+}
+
+func newJoiner(key query.JoinKey, numSources, maxPending int) *joiner {
+	seen := make([]map[joinKey]struct{}, numSources)
+	for i := range seen {
+		seen[i] = make(map[joinKey]struct{})
+	}
+	return &joiner{
+		key:         key,
+		maxPending:  maxPending,
+		seen:        seen,
+		pending:     make(map[joinKey][]result.Match),
+		pendingKeys: make(map[result.Match][]joinKey),
+	}
+}
+
+// AddMatches records the matches found by the given source and returns all
+// matches, from any source, that can be sent because their key has now been
+// seen by all sources. It also returns whether matches were dropped because
+// maxPending matches are already held back.
+func (j *joiner) AddMatches(matches []result.Match, source int) (ready []result.Match, dropped bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, match := range matches {
+		keys := joinKeys(j.key, match)
+
+		var joined []joinKey
+		for _, key := range keys {
+			j.seen[source][key] = struct{}{}
+			if j.seenByAll(key) {
+				joined = append(joined, key)
+			}
+		}
+
+		if len(joined) == 0 {
+			if len(keys) == 0 {
+				continue
+			}
+			if len(j.pendingKeys) >= j.maxPending {
+				dropped = true
+				continue
+			}
+			j.pendingKeys[match] = keys
+			for _, key := range keys {
+				j.pending[key] = append(j.pending[key], match)
+			}
+			continue
+		}
+
+		ready = append(ready, match)
+		for _, key := range joined {
+			for _, m := range j.pending[key] {
+				ready = append(ready, m)
+				j.removePending(m, key)
+			}
+			delete(j.pending, key)
+		}
+	}
+	return ready, dropped
+}
+
+// removePending stops holding back the given match under any of its keys
+// other than the given one, which the caller removes.
+func (j *joiner) removePending(match result.Match, except joinKey) {
+	for _, key := range j.pendingKeys[match] {
+		if key == except {
+			continue
+		}
+		matches := j.pending[key][:0]
+		for _, m := range j.pending[key] {
+			if m != match {
+				matches = append(matches, m)
+			}
+		}
+		if len(matches) == 0 {
+			delete(j.pending, key)
+		} else {
+			j.pending[key] = matches
+		}
+	}
+	delete(j.pendingKeys, match)
+}
+
+func (j *joiner) seenByAll(key joinKey) bool {
+	for _, seen := range j.seen {
+		if _, ok := seen[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// joinKeys returns the keys a match can be joined on. Commit matches are in
+// every file modified by the commit, while repository matches are not in any
+// file.
+func joinKeys(key query.JoinKey, match result.Match) []joinKey {
+	repo := match.RepoName().ID
+	if key != query.JoinKeyFile {
+		return []joinKey{{repo: repo}}
+	}
+
+	switch v := match.(type) {
+	case *result.FileMatch:
+		return []joinKey{{repo: repo, path: v.Path}}
+	case *result.CommitMatch:
+		paths := v.ModifiedFiles
+		if len(paths) == 0 {
+			for _, diff := range v.Diff {
+				if diff.NewName != "/dev/null" {
+					paths = append(paths, diff.NewName)
+				}
+				if diff.OrigName != "/dev/null" && diff.OrigName != diff.NewName {
+					paths = append(paths, diff.OrigName)
+				}
+			}
+		}
+		keys := make([]joinKey, 0, len(paths))
+		for _, path := range paths {
+			keys = append(keys, joinKey{repo: repo, path: path})
+		}
+		return keys
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestJoinJob(t *testing.T) {
+	repoA := types.MinimalRepo{ID: 1, Name: "a"}
+	repoB := types.MinimalRepo{ID: 2, Name: "b"}
+
+	fileMatch := func(repo types.MinimalRepo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: repo, Path: path}}
+	}
+	diffMatch := func(repo types.MinimalRepo, paths ...string) *result.CommitMatch {
+		diff := make([]result.DiffFile, 0, len(paths))
+		for _, path := range paths {
+			diff = append(diff, result.DiffFile{OrigName: path, NewName: path})
+		}
+		return &result.CommitMatch{Repo: repo, Diff: diff}
+	}
+
+	newJob := func(matches ...result.Match) job.Job {
+		mj := mockjob.NewMockJob()
+		mj.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			for _, match := range matches {
+				s.Send(streaming.SearchEvent{Results: []result.Match{match}})
+			}
+			return nil, nil
+		})
+		return mj
+	}
+
+	symbolA := fileMatch(repoA, "foo.go")
+	symbolB := fileMatch(repoB, "bar.go")
+	diffA := diffMatch(repoA, "foo.go", "baz.go")
+	diffA2 := diffMatch(repoA, "baz.go")
+	diffB := diffMatch(repoB, "baz.go")
+
+	runWithLimit := func(key query.JoinKey, maxPending int, children ...job.Job) ([]result.Match, bool) {
+		var (
+			mu       sync.Mutex
+			matches  []result.Match
+			limitHit bool
+		)
+		stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+			mu.Lock()
+			matches = append(matches, event.Results...)
+			limitHit = limitHit || event.Stats.IsLimitHit
+			mu.Unlock()
+		})
+		_, err := NewJoinJob(key, maxPending, children...).Run(context.Background(), job.RuntimeClients{}, stream)
+		require.NoError(t, err)
+		return matches, limitHit
+	}
+
+	run := func(key query.JoinKey, children ...job.Job) []result.Match {
+		matches, limitHit := runWithLimit(key, 100, children...)
+		require.False(t, limitHit)
+		return matches
+	}
+
+	t.Run("repo", func(t *testing.T) {
+		matches := run(query.JoinKeyRepo,
+			newJob(symbolA),
+			newJob(diffA, diffA2),
+		)
+		require.ElementsMatch(t, []result.Match{symbolA, diffA, diffA2}, matches)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		matches := run(query.JoinKeyFile,
+			newJob(symbolA, symbolB),
+			newJob(diffA, diffA2, diffB),
+		)
+		require.ElementsMatch(t, []result.Match{symbolA, diffA}, matches)
+	})
+
+	t.Run("repo match has no file", func(t *testing.T) {
+		matches := run(query.JoinKeyFile,
+			newJob(&result.RepoMatch{Name: repoA.Name, ID: repoA.ID}),
+			newJob(diffA),
+		)
+		require.Empty(t, matches)
+	})
+
+	t.Run("sends each match once", func(t *testing.T) {
+		matches := run(query.JoinKeyFile,
+			newJob(diffA),
+			newJob(fileMatch(repoA, "baz.go"), symbolA),
+		)
+		require.ElementsMatch(t, []result.Match{diffA, fileMatch(repoA, "baz.go"), symbolA}, matches)
+	})
+
+	t.Run("counts matches with several keys once", func(t *testing.T) {
+		j := newJoiner(query.JoinKeyFile, 2, 1)
+
+		// diffA is held back for both of its files
+		ready, dropped := j.AddMatches([]result.Match{diffA}, 0)
+		require.Empty(t, ready)
+		require.False(t, dropped)
+
+		baz := fileMatch(repoA, "baz.go")
+		ready, dropped = j.AddMatches([]result.Match{baz, symbolA}, 1)
+		require.Equal(t, []result.Match{baz, diffA, symbolA}, ready)
+		require.False(t, dropped)
+		require.Empty(t, j.pending)
+		require.Empty(t, j.pendingKeys)
+
+		// Sending diffA freed the pending slot
+		ready, dropped = j.AddMatches([]result.Match{diffB}, 0)
+		require.Empty(t, ready)
+		require.False(t, dropped)
+	})
+
+	t.Run("caps pending matches", func(t *testing.T) {
+		j := newJoiner(query.JoinKeyRepo, 2, 1)
+
+		ready, dropped := j.AddMatches([]result.Match{symbolA}, 0)
+		require.Empty(t, ready)
+		require.False(t, dropped)
+
+		ready, dropped = j.AddMatches([]result.Match{symbolB}, 0)
+		require.Empty(t, ready)
+		require.True(t, dropped)
+
+		// symbolB was dropped, but repo b still counts as found by the
+		// first child.
+		ready, dropped = j.AddMatches([]result.Match{diffA, diffB}, 1)
+		require.ElementsMatch(t, []result.Match{diffA, symbolA, diffB}, ready)
+		require.False(t, dropped)
+
+		// Flushing repo a freed the pending slot.
+		ready, dropped = j.AddMatches([]result.Match{&result.RepoMatch{Name: "c", ID: 3}}, 1)
+		require.Empty(t, ready)
+		require.False(t, dropped)
+	})
+}
+
+func TestNewJoinPlanJob(t *testing.T) {
+	join, err := query.ParseJoin(`repo:^github\.com/acme/ (type:symbol Foo) AND (type:diff author:bob) join:file`, query.SearchTypeLiteral)
+	require.NoError(t, err)
+
+	inputs := &search.Inputs{
+		UserSettings:        &schema.Settings{},
+		PatternType:         query.SearchTypeLiteral,
+		Protocol:            search.Streaming,
+		Features:            &search.Features{},
+		OnSourcegraphDotCom: true,
+	}
+
+	j, err := NewJoinPlanJob(inputs, join)
+	require.NoError(t, err)
+
+	autogold.Want("join plan", `
+(ALERT
+  (query . )
+  (originalQuery . )
+  (patternType . literal)
+  (LIMIT
+    (limit . 500)
+    (JOIN
+      (key . file)
+      (TIMEOUT
+        (timeout . 20s)
+        (LIMIT
+          (limit . 500)
+          (PARALLEL
+            (REPOPAGER
+              (repoOpts.repoFilters.0 . ^github\.com/acme/)
+              (PARTIALREPOS
+                (ZOEKTSYMBOLSEARCH
+                  (query . sym:substr:"Foo"))))
+            (REPOSCOMPUTEEXCLUDED
+              (repoOpts.repoFilters.0 . ^github\.com/acme/))
+            (REPOPAGER
+              (repoOpts.repoFilters.0 . ^github\.com/acme/)
+              (PARTIALREPOS
+                (SEARCHERSYMBOLSEARCH
+                  (patternInfo.pattern . Foo)(patternInfo.isRegexp . true)(patternInfo.fileMatchLimit . 500)
+                  (numRepos . 0)
+                  (limit . 500)))))))
+      (TIMEOUT
+        (timeout . 20s)
+        (LIMIT
+          (limit . 500)
+          (PARALLEL
+            (DIFFSEARCH
+              (query . *protocol.AuthorMatches(bob))
+              (repoOpts.repoFilters.0 . ^github\.com/acme/)(repoOpts.onlyCloned . true)
+              (diff . true)
+              (limit . 500))
+            (REPOSCOMPUTEEXCLUDED
+              (repoOpts.repoFilters.0 . ^github\.com/acme/))
+            NoopJob))))))`).Equal(t, "\n"+printer.SexpPretty(j))
+}
@@ -48,11 +48,13 @@ func TestWithSelect(t *testing.T) {
   {
     "Path": "pokeman/",
     "ChunkMatches": null,
+    "Language": "",
     "LimitHit": false
   },
   {
     "Path": "digiman/",
     "ChunkMatches": null,
+    "Language": "",
     "LimitHit": false
   }
 ]`).Equal(t, test("file.directory"))
@@ -61,16 +63,19 @@ func TestWithSelect(t *testing.T) {
   {
     "Path": "pokeman/charmandar",
     "ChunkMatches": null,
+    "Language": "",
     "LimitHit": false
   },
   {
     "Path": "pokeman/bulbosaur",
     "ChunkMatches": null,
+    "Language": "",
     "LimitHit": false
   },
   {
     "Path": "digiman/ummm",
     "ChunkMatches": null,
+    "Language": "",
     "LimitHit": false
   }
 ]`).Equal(t, test("file"))
@@ -124,6 +129,7 @@ func TestWithSelect(t *testing.T) {
         ]
       }
     ],
+    "Language": "",
     "LimitHit": false
   },
   {
@@ -152,6 +158,7 @@ func TestWithSelect(t *testing.T) {
         ]
       }
     ],
+    "Language": "",
     "LimitHit": false
   },
   {
@@ -180,6 +187,7 @@ func TestWithSelect(t *testing.T) {
         ]
       }
     ],
+    "Language": "",
     "LimitHit": false
   },
   {
@@ -208,6 +216,7 @@ func TestWithSelect(t *testing.T) {
         ]
       }
     ],
+    "Language": "",
     "LimitHit": false
   }
 ]`).Equal(t, test("content"))
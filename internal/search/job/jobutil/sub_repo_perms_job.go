@@ -100,6 +100,20 @@ func applySubRepoFiltering(ctx context.Context, logger log.Logger, checker authz
 				continue
 			}
 
+			if perms.Include(authz.Read) {
+				filtered = append(filtered, m)
+			}
+		case *result.CaptureMatch:
+			content := authz.RepoContent{
+				Repo: mm.Repo.Name,
+				Path: mm.Path,
+			}
+			perms, err := authz.ActorPermissions(ctx, checker, a, content)
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
 			if perms.Include(authz.Read) {
 				filtered = append(filtered, m)
 			}
@@ -0,0 +1,34 @@
+// Package precise defines how search jobs query precise code intelligence. It is
+// implemented by the code intelligence services of enterprise builds, which pass
+// it to search jobs through job.RuntimeClients.
+package precise
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Location is a range within a file at a particular commit. Lines and columns
+// are zero-based.
+type Location struct {
+	Repo   types.MinimalRepo
+	Commit api.CommitID
+	Path   string
+	Range  result.Range
+}
+
+// Client queries precise code intelligence on behalf of search jobs.
+type Client interface {
+	// References returns up to limit locations referencing the symbol defined at
+	// the given zero-based position. The returned boolean is false if there is no
+	// precise code intelligence data covering the given file.
+	References(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, path string, line, character, limit int) ([]Location, bool, error)
+
+	// InboundReferenceCounts returns the number of references to the symbols
+	// exported from each of the given files of a commit. The count of a file
+	// without precise code intelligence data covering it is zero.
+	InboundReferenceCounts(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, paths []string) ([]int, error)
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxExpandedQueryLength is the maximum length in bytes of a query or query
+// fragment after the fragments it references are expanded. Fragments may
+// reference other fragments several times, so without a limit the expanded
+// query grows exponentially with the depth of the references.
+const maxExpandedQueryLength = 64 * 1024
+
+var errExpandedQueryTooLong = errors.Errorf("expanded query exceeds the maximum length of %d bytes", maxExpandedQueryLength)
+
+// ExpandFragments substitutes references of the form `@name` in the input
+// query with the query fragment defined for `name`. Fragments may reference
+// other fragments. A reference is only recognized as a standalone term, so
+// values like `repo:foo@rev` are left untouched, as are references to names
+// that are not defined. Each fragment is validated for the given search type
+// so that errors point into the fragment rather than the expanded query. An
+// error is returned if the expanded query exceeds maxExpandedQueryLength.
+func ExpandFragments(in string, fragments map[string]string, searchType SearchType) (string, error) {
+	if len(fragments) == 0 {
+		return in, nil
+	}
+	e := &fragmentExpander{
+		fragments:  fragments,
+		searchType: searchType,
+		expanded:   map[string]string{},
+	}
+	return e.expandFragments(in, nil)
+}
+
+// fragmentExpander expands the fragments referenced by a query. Each fragment
+// is expanded and validated once, no matter how often it is referenced.
+type fragmentExpander struct {
+	fragments  map[string]string
+	searchType SearchType
+	expanded   map[string]string
+}
+
+func (e *fragmentExpander) expandFragments(in string, stack []string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(in); {
+		c := in[i]
+		switch {
+		case (c == '"' || c == '\'') && (atTermStart(in, i) || in[i-1] == ':'):
+			n := scanQuoted(in[i:], c)
+			b.WriteString(in[i : i+n])
+			i += n
+			continue
+		case c == '@' && atTermStart(in, i):
+			name := scanFragmentName(in[i+1:])
+			end := i + 1 + len(name)
+			if fragment, ok := e.fragments[name]; ok && name != "" && atTermEnd(in, end) {
+				expanded, err := e.expandFragment(name, fragment, stack)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(expanded)
+				if b.Len() > maxExpandedQueryLength {
+					return "", errExpandedQueryTooLong
+				}
+				i = end
+				continue
+			}
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String(), nil
+}
+
+// expandFragment validates the fragment with the given name and expands the
+// other fragments it references.
+func (e *fragmentExpander) expandFragment(name, fragment string, stack []string) (string, error) {
+	if expanded, ok := e.expanded[name]; ok {
+		return expanded, nil
+	}
+
+	for i, seen := range stack {
+		if seen == name {
+			cycle := append(append([]string{}, stack[i:]...), name)
+			return "", errors.Errorf("query fragment cycle: @%s", strings.Join(cycle, " -> @"))
+		}
+	}
+
+	// The fragment is validated before its references are expanded, so that
+	// an invalid fragment fails without expanding the fragments it references.
+	// Only the unexpanded fragment is parsed, since the referenced fragments
+	// are validated on their own and parsing the expanded fragment may be
+	// expensive.
+	nodes, err := Parse(fragment, e.searchType)
+	if err == nil {
+		_, err = Pipeline(Init(fragment, e.searchType))
+	}
+	if err != nil {
+		return "", errors.Errorf("invalid query fragment @%s (%q): %s", name, fragment, err)
+	}
+
+	expanded, err := e.expandFragments(fragment, append(stack, name))
+	if err != nil {
+		return "", err
+	}
+
+	// Fragments containing a top-level OR are grouped so that the expression
+	// does not bind with the surrounding terms.
+	if len(nodes) == 1 {
+		if operator, ok := nodes[0].(Operator); ok && operator.Kind == Or {
+			expanded = fmt.Sprintf("(%s)", expanded)
+		}
+	}
+
+	e.expanded[name] = expanded
+	return expanded, nil
+}
+
+func atTermStart(in string, i int) bool {
+	return i == 0 || isSpace([]byte(in[i-1:i])) || in[i-1] == '('
+}
+
+func atTermEnd(in string, i int) bool {
+	return i == len(in) || isSpace([]byte(in[i:])) || in[i] == ')'
+}
+
+func isFragmentNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func scanFragmentName(in string) string {
+	i := 0
+	for i < len(in) && isFragmentNameChar(in[i]) {
+		i++
+	}
+	return in[:i]
+}
+
+// scanQuoted returns the length of the quoted string at the start of in,
+// including its delimiters. If the string is unterminated, only the opening
+// delimiter is consumed.
+func scanQuoted(in string, delimiter byte) int {
+	for i := 1; i < len(in); i++ {
+		switch in[i] {
+		case '\\':
+			i++
+		case delimiter:
+			return i + 1
+		}
+	}
+	return 1
+}
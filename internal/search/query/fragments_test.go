@@ -0,0 +1,94 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExpandFragments(t *testing.T) {
+	fragments := map[string]string{
+		"nogen":         `-file:vendor/ -file:_test.go$`,
+		"acme":          `repo:^github\.com/acme/`,
+		"acmegen":       `@acme @nogen`,
+		"go-or-ts":      `lang:go or lang:typescript`,
+		"cycle-a":       `@cycle-b`,
+		"cycle-b":       `foo @cycle-a`,
+		"self":          `@self`,
+		"invalid":       `-file:vendor/ count:many`,
+		"nested":        `@invalid`,
+		"invalid-cycle": `count:many @cycle-a`,
+	}
+
+	cases := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: `foo @nogen`, want: `foo -file:vendor/ -file:_test.go$`},
+		{input: `@acmegen foo`, want: `repo:^github\.com/acme/ -file:vendor/ -file:_test.go$ foo`},
+		{input: `(@acme or repo:bar) foo`, want: `(repo:^github\.com/acme/ or repo:bar) foo`},
+		{input: `@go-or-ts foo`, want: `(lang:go or lang:typescript) foo`},
+		{input: `repo:foo@nogen bar`, want: `repo:foo@nogen bar`},
+		{input: `foo@nogen`, want: `foo@nogen`},
+		{input: `"@nogen" content:'@nogen'`, want: `"@nogen" content:'@nogen'`},
+		{input: `@Override`, want: `@Override`},
+		{input: `@nogenerated`, want: `@nogenerated`},
+		{input: `@cycle-a`, wantErr: `query fragment cycle: @cycle-a -> @cycle-b -> @cycle-a`},
+		{input: `foo @self`, wantErr: `query fragment cycle: @self -> @self`},
+		{input: `@invalid`, wantErr: `invalid query fragment @invalid ("-file:vendor/ count:many"): field count has value many, many is not a number`},
+		{input: `@nested`, wantErr: `invalid query fragment @invalid ("-file:vendor/ count:many"): field count has value many, many is not a number`},
+		{input: `@invalid-cycle`, wantErr: `invalid query fragment @invalid-cycle ("count:many @cycle-a"): field count has value many, many is not a number`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			have, err := ExpandFragments(tc.input, fragments, SearchTypeStandard)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got none", tc.wantErr)
+				}
+				if err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error. want=%q have=%q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if have != tc.want {
+				t.Errorf("unexpected expansion. want=%q have=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestExpandFragmentsFanOut(t *testing.T) {
+	// Each fragment references the next one twice, so the fully expanded
+	// query would double in length with every level.
+	fanOut := func(depth int) map[string]string {
+		fragments := map[string]string{}
+		for i := 0; i < depth; i++ {
+			fragments[fmt.Sprintf("f%d", i)] = fmt.Sprintf("@f%d @f%d", i+1, i+1)
+		}
+		fragments[fmt.Sprintf("f%d", depth)] = "x"
+		return fragments
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		have, err := ExpandFragments("@f0", fanOut(3), SearchTypeStandard)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := strings.TrimSpace(strings.Repeat("x ", 8)); have != want {
+			t.Errorf("unexpected expansion. want=%q have=%q", want, have)
+		}
+	})
+
+	t.Run("exceeds limit", func(t *testing.T) {
+		_, err := ExpandFragments("@f0", fanOut(64), SearchTypeStandard)
+		if err != errExpandedQueryTooLong {
+			t.Fatalf("unexpected error. want=%v have=%v", errExpandedQueryTooLong, err)
+		}
+	})
+}
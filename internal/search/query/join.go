@@ -0,0 +1,150 @@
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// JoinKey is the property on which the results of the operands of a Join are
+// joined.
+type JoinKey string
+
+const (
+	// JoinKeyRepo joins results that are in the same repository.
+	JoinKeyRepo JoinKey = "repo"
+	// JoinKeyFile joins results that are in the same file. Commit and diff
+	// results are in every file modified by the commit.
+	JoinKeyFile JoinKey = "file"
+)
+
+// Join is a query combining subqueries with AND whose results are joined on
+// the key given by `join:` rather than intersected by result identity. This
+// allows combining subqueries returning different result types, like
+//
+//	(type:symbol Foo) AND (type:diff author:bob) join:file
+//
+// which returns the symbols named Foo and the diffs by bob in files that
+// contain both.
+type Join struct {
+	Key      JoinKey
+	Operands []Plan
+}
+
+// Plan returns the plans of all operands of j as a single plan.
+func (j *Join) Plan() Plan {
+	var plan Plan
+	for _, operand := range j.Operands {
+		plan = append(plan, operand...)
+	}
+	return plan
+}
+
+// ParseJoin parses the input as a join of parenthesized subqueries combined
+// with AND. Parameters outside of the subqueries apply to every subquery,
+// except for `join:`, which selects the join key. Each subquery is processed
+// by Init and the given steps.
+//
+// ParseJoin returns nil if the input is not a join. Joins are opt-in: without
+// `join:`, subqueries combined with AND are intersected as part of the
+// regular query plan, whatever their result types.
+func ParseJoin(in string, searchType SearchType, steps ...step) (*Join, error) {
+	nodes, err := parseGroups(in, searchType)
+	if err != nil || len(nodes) != 1 {
+		// Malformed queries are not joins. The regular query plan reports
+		// their errors.
+		return nil, nil
+	}
+
+	var (
+		groups []Operator
+		shared []string
+		key    JoinKey
+	)
+	for _, node := range joinTerms(nodes) {
+		switch n := node.(type) {
+		case Operator:
+			if !n.Annotation.Labels.IsSet(IsGroup) {
+				return nil, nil
+			}
+			groups = append(groups, n)
+		case Parameter:
+			if n.Field == FieldJoin {
+				if key != "" {
+					return nil, errors.Errorf("field %q may only be used once", FieldJoin)
+				}
+				if n.Negated {
+					return nil, errors.Errorf("field %q does not support negation", FieldJoin)
+				}
+				switch k := JoinKey(strings.ToLower(n.Value)); k {
+				case JoinKeyRepo, JoinKeyFile:
+					key = k
+				default:
+					return nil, errors.Errorf("invalid value %q for field %q. Valid values are: repo, file", n.Value, FieldJoin)
+				}
+				continue
+			}
+			shared = append(shared, sourceOf(in, n.Annotation.Range))
+		default:
+			return nil, nil
+		}
+	}
+
+	if key == "" {
+		return nil, nil
+	}
+	if len(groups) < 2 {
+		return nil, errors.Errorf("field %q requires at least two parenthesized subqueries combined with AND, e.g. (type:symbol Foo) AND (type:diff author:bob) join:%s", FieldJoin, key)
+	}
+
+	// Parameters are ordered before patterns in the parse tree. Keep the
+	// subqueries in the order they were written in.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Annotation.Range.Start.Column < groups[j].Annotation.Range.Start.Column
+	})
+
+	joinOperands := make([]Plan, 0, len(groups))
+	for _, group := range groups {
+		// The range of a group includes its enclosing parentheses
+		operand := sourceOf(in, group.Annotation.Range)
+		operand = operand[1 : len(operand)-1]
+		// Subqueries containing a top-level OR are grouped so that the shared
+		// parameters apply to all of their terms.
+		if len(group.Operands) == 1 {
+			if operator, ok := group.Operands[0].(Operator); ok && operator.Kind == Or {
+				operand = "(" + operand + ")"
+			}
+		}
+		if len(shared) > 0 {
+			operand += " " + strings.Join(shared, " ")
+		}
+
+		plan, err := Pipeline(append([]step{Init(operand, searchType)}, steps...)...)
+		if err != nil {
+			return nil, err
+		}
+		joinOperands = append(joinOperands, plan)
+	}
+
+	return &Join{Key: key, Operands: joinOperands}, nil
+}
+
+// joinTerms returns the nodes combined with AND at the top level of a parse
+// tree returned by parseGroups. Groups are not descended into.
+func joinTerms(nodes []Node) []Node {
+	var terms []Node
+	for _, node := range nodes {
+		if operator, ok := node.(Operator); ok && operator.Kind == And && !operator.Annotation.Labels.IsSet(IsGroup) {
+			terms = append(terms, joinTerms(operator.Operands)...)
+			continue
+		}
+		terms = append(terms, node)
+	}
+	return terms
+}
+
+// sourceOf returns the part of the input a node was parsed from.
+func sourceOf(in string, r Range) string {
+	return in[r.Start.Column:r.End.Column]
+}
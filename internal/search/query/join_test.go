@@ -0,0 +1,93 @@
+package query
+
+import "testing"
+
+func TestParseJoin(t *testing.T) {
+	test := func(input string) string {
+		join, err := ParseJoin(input, SearchTypeLiteral)
+		if err != nil {
+			return "error: " + err.Error()
+		}
+		if join == nil {
+			return "<nil>"
+		}
+		s := "join:" + string(join.Key)
+		for _, operand := range join.Operands {
+			s += " | " + operand.ToQ().String()
+		}
+		return s
+	}
+
+	cases := []struct {
+		input string
+		want  string
+	}{{
+		input: `(type:symbol Foo) AND (type:diff author:bob) join:repo`,
+		want:  `join:repo | (and "type:symbol" "Foo") | (and "type:diff" "author:bob")`,
+	}, {
+		input: `(type:symbol Foo) AND (type:diff author:bob)`,
+		want:  `<nil>`,
+	}, {
+		input: `repo:^github\.com/acme/ (type:symbol Foo) and (type:diff author:bob) join:file`,
+		want:  `join:file | (and "type:symbol" "repo:^github\\.com/acme/" "Foo") | (and "type:diff" "author:bob" "repo:^github\\.com/acme/")`,
+	}, {
+		input: `(type:symbol Foo) AND (type:diff author:bob or type:commit fix) repo:acme join:repo`,
+		want:  `join:repo | (and "type:symbol" "repo:acme" "Foo") | (or (and "repo:acme" "type:diff" "author:bob") (and "repo:acme" "type:commit" "fix"))`,
+	}, {
+		input: `(type:symbol Foo) AND ((type:diff author:bob) or (type:commit fix)) join:FILE`,
+		want:  `join:file | (and "type:symbol" "Foo") | (or (and "type:diff" "author:bob") (and "type:commit" "fix"))`,
+	}, {
+		input: `(foo) AND (bar) join:repo`,
+		want:  `join:repo | "foo" | "bar"`,
+	}, {
+		input: `(type:symbol Foo) AND (type:symbol Bar)`,
+		want:  `<nil>`,
+	}, {
+		input: `(type:symbol Foo) AND (type:symbol Bar) join:file`,
+		want:  `join:file | (and "type:symbol" "Foo") | (and "type:symbol" "Bar")`,
+	}, {
+		input: `(type:symbol Foo) OR (type:diff author:bob) join:repo`,
+		want:  `<nil>`,
+	}, {
+		input: `(type:symbol Foo) (type:diff author:bob) join:repo`,
+		want:  `join:repo | (and "type:symbol" "Foo") | (and "type:diff" "author:bob")`,
+	}, {
+		input: `(type:symbol \)) AND (type:diff /fo(o|x)/) join:file`,
+		want:  `join:file | (and "type:symbol" "\\)") | (and "type:diff" "/fo(o|x)/")`,
+	}, {
+		input: `(type:symbol "a b") AND (type:diff author:"bob (jr)") join:repo`,
+		want:  `join:repo | (and "type:symbol" "\"a b\"") | (and "type:diff" "author:bob (jr)")`,
+	}, {
+		input: `foo (type:symbol Foo) AND (type:diff author:bob)`,
+		want:  `<nil>`,
+	}, {
+		input: `type:symbol Foo and type:diff author:bob`,
+		want:  `<nil>`,
+	}, {
+		input: `(type:symbol Foo AND (type:diff author:bob)`,
+		want:  `<nil>`,
+	}, {
+		input: `(type:symbol Foo) AND (type:diff author:bob) join:commit`,
+		want:  `error: invalid value "commit" for field "join". Valid values are: repo, file`,
+	}, {
+		input: `(type:symbol Foo) AND (type:diff author:bob) join:file join:repo`,
+		want:  `error: field "join" may only be used once`,
+	}, {
+		input: `(type:symbol Foo) join:file`,
+		want:  `error: field "join" requires at least two parenthesized subqueries combined with AND, e.g. (type:symbol Foo) AND (type:diff author:bob) join:file`,
+	}, {
+		input: `type:symbol Foo join:file`,
+		want:  `<nil>`,
+	}, {
+		input: `(type:symbol Foo join:file) AND (type:diff author:bob) join:repo`,
+		want:  `error: field "join" is only supported next to parenthesized subqueries combined with AND, e.g. (type:symbol Foo) AND (type:diff author:bob) join:file`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			if have := test(tc.input); have != tc.want {
+				t.Errorf("unexpected join.\nwant: %s\nhave: %s", tc.want, have)
+			}
+		})
+	}
+}
@@ -19,6 +19,9 @@ const (
 	// than canonical form (r: instead of repo:)
 	IsAlias
 	Standard
+	// IsGroup flags an operator as a parenthesized group that the parser
+	// preserved rather than merging it into the surrounding expression.
+	IsGroup
 )
 
 var allLabels = map[labels]string{
@@ -32,6 +35,7 @@ var allLabels = map[labels]string{
 	Structural:                "Structural",
 	IsPredicate:               "IsPredicate",
 	IsAlias:                   "IsAlias",
+	IsGroup:                   "IsGroup",
 }
 
 func (l *labels) IsSet(label labels) bool {
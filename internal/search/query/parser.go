@@ -151,6 +151,9 @@ const (
 	// If set, implies that at least one expression was disambiguated by
 	// explicit parentheses.
 	disambiguated
+	// If set, each parenthesized group is parsed as an And operator labeled
+	// IsGroup, which is not merged into the surrounding expression.
+	preserveGroups
 )
 
 func isSet(h, heuristic heuristics) bool { return h&heuristic != 0 }
@@ -914,7 +917,7 @@ loop:
 		}
 		switch {
 		case p.match(LPAREN) && !isSet(p.heuristics, allowDanglingParens):
-			if isSet(p.heuristics, parensAsPatterns) {
+			if isSet(p.heuristics, parensAsPatterns) && !isSet(p.heuristics, preserveGroups) {
 				if value, advance, ok := ScanBalancedPattern(p.buf[p.pos:]); ok {
 					if label.IsSet(Literal) {
 						label.Set(HeuristicParensAsPatterns)
@@ -927,6 +930,7 @@ loop:
 			}
 			// If the above failed, we treat this paren
 			// group as part of an and/or expression.
+			groupStart := p.pos
 			_ = p.expect(LPAREN) // Guaranteed to succeed.
 			p.balanced++
 			p.heuristics |= disambiguated
@@ -934,6 +938,13 @@ loop:
 			if err != nil {
 				return nil, err
 			}
+			if isSet(p.heuristics, preserveGroups) {
+				result = []Node{Operator{
+					Kind:       And,
+					Operands:   result,
+					Annotation: Annotation{Labels: IsGroup, Range: newRange(groupStart, p.pos)},
+				}}
+			}
 			nodes = append(nodes, result...)
 		case p.expect(RPAREN) && !isSet(p.heuristics, allowDanglingParens):
 			if p.balanced <= 0 {
@@ -1007,7 +1018,7 @@ func reduce(left, right []Node, kind OperatorKind) ([]Node, bool) {
 
 	switch term := right[0].(type) {
 	case Operator:
-		if kind == term.Kind {
+		if kind == term.Kind && !term.Annotation.Labels.IsSet(IsGroup) {
 			// Reduce right node.
 			left = append(left, term.Operands...)
 			if len(right) > 1 {
@@ -1023,7 +1034,7 @@ func reduce(left, right []Node, kind OperatorKind) ([]Node, bool) {
 			}
 			return left, true
 		}
-		if operator, ok := left[0].(Operator); ok && operator.Kind == kind {
+		if operator, ok := left[0].(Operator); ok && operator.Kind == kind && !operator.Annotation.Labels.IsSet(IsGroup) {
 			// Reduce left node.
 			return append(operator.Operands, right...), true
 		}
@@ -1035,7 +1046,7 @@ func reduce(left, right []Node, kind OperatorKind) ([]Node, bool) {
 			}
 			return left, true
 		}
-		if operator, ok := left[0].(Operator); ok && operator.Kind == kind {
+		if operator, ok := left[0].(Operator); ok && operator.Kind == kind && !operator.Annotation.Labels.IsSet(IsGroup) {
 			// Reduce left node.
 			return append(operator.Operands, right...), true
 		}
@@ -1180,6 +1191,26 @@ func Parse(in string, searchType SearchType) ([]Node, error) {
 	return NewOperator(nodes, And), nil
 }
 
+// parseGroups parses a raw input string like Parse, except that parentheses
+// at the start of a term always delimit groups, and each group is preserved in
+// the parse tree as an And operator labeled IsGroup.
+func parseGroups(in string, searchType SearchType) ([]Node, error) {
+	parser := &parser{
+		buf:        []byte(in),
+		heuristics: parensAsPatterns | preserveGroups,
+		leafParser: searchType,
+	}
+
+	nodes, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.balanced != 0 {
+		return nil, errors.New("unbalanced expression")
+	}
+	return NewOperator(nodes, And), nil
+}
+
 func ParseSearchType(in string, searchType SearchType) (Q, error) {
 	return Run(Init(in, searchType))
 }
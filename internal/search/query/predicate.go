@@ -41,6 +41,9 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
+		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"has.author":       func() Predicate { return &FileHasContributorPredicate{} },
 	},
 }
 
@@ -307,3 +310,37 @@ func (f *FileHasOwnerPredicate) ParseParams(params string) error {
 
 func (f FileHasOwnerPredicate) Field() string { return FieldFile }
 func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.commit.after(...) */
+
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+}
+
+func (f *FileHasCommitAfterPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.Errorf("file:has.commit.after argument should not be empty")
+	}
+	f.TimeRef = params
+	return nil
+}
+
+func (f FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+
+/* file:has.contributor(name) */
+
+type FileHasContributorPredicate struct {
+	Contributor string
+}
+
+func (f *FileHasContributorPredicate) ParseParams(params string) error {
+	if params == "" {
+		return errors.Errorf("file:has.contributor argument should not be empty")
+	}
+	f.Contributor = params
+	return nil
+}
+
+func (f FileHasContributorPredicate) Field() string { return FieldFile }
+func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
@@ -168,3 +168,35 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasCommitAfterPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		p := &FileHasCommitAfterPredicate{}
+		if err := p.ParseParams("2 weeks ago"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileHasCommitAfterPredicate{TimeRef: "2 weeks ago"}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		if err := (&FileHasCommitAfterPredicate{}).ParseParams(""); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestFileHasContributorPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		p := &FileHasContributorPredicate{}
+		if err := p.ParseParams("alice@example.com"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&FileHasContributorPredicate{Contributor: "alice@example.com"}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		if err := (&FileHasContributorPredicate{}).ParseParams(""); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
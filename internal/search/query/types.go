@@ -443,6 +443,30 @@ func (p Parameters) FileHasOwner() (include, exclude []string) {
 	return include, exclude
 }
 
+func (p Parameters) FileHasCommitAfter() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCommitAfterPredicate, negated bool) {
+		if negated {
+			exclude = append(exclude, pred.TimeRef)
+		} else {
+			include = append(include, pred.TimeRef)
+		}
+	})
+
+	return include, exclude
+}
+
+func (p Parameters) FileHasContributor() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasContributorPredicate, negated bool) {
+		if negated {
+			exclude = append(exclude, pred.Contributor)
+		} else {
+			include = append(include, pred.Contributor)
+		}
+	})
+
+	return include, exclude
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -292,6 +292,10 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldJoin:
+		// Valid uses of join: are removed from the query by ParseJoin.
+		return errors.Errorf("field %q is only supported next to parenthesized subqueries combined with AND, e.g. (type:symbol Foo) AND (type:diff author:bob) join:file", field)
 	default:
 		return isUnrecognizedField()
 	}
@@ -376,6 +380,82 @@ func validateTypeStructural(nodes []Node) error {
 	return nil
 }
 
+// validateSelectCapture validates that a query with select:capture has a
+// single regular expression pattern with the selected capture group.
+func validateSelectCapture(nodes []Node) error {
+	var selectValue string
+	VisitField(nodes, FieldSelect, func(value string, _ bool, _ Annotation) {
+		selectValue = value
+	})
+	sp, err := filter.SelectPathFromString(selectValue)
+	if err != nil || sp.Root() != filter.Capture {
+		return nil
+	}
+
+	var patterns []Pattern
+	VisitPattern(nodes, func(value string, negated bool, annotation Annotation) {
+		patterns = append(patterns, Pattern{Value: value, Negated: negated, Annotation: annotation})
+	})
+	if len(patterns) != 1 || patterns[0].Negated || !patterns[0].Annotation.Labels.IsSet(Regexp) {
+		return errors.New(`select:capture requires a single regular expression search pattern, e.g. patterntype:regexp select:capture.1 go\s+(\d+\.\d+)`)
+	}
+
+	re, err := regexp.Compile(patterns[0].Value)
+	if err != nil {
+		return err
+	}
+	_, err = sp.CaptureGroup(re)
+	return err
+}
+
+// validateSelectReferences validates that a query with select:symbol.references
+// does not search for anything other than symbols, since only symbol
+// definitions can be resolved into references.
+func validateSelectReferences(nodes []Node) error {
+	var selectValue string
+	VisitField(nodes, FieldSelect, func(value string, _ bool, _ Annotation) {
+		selectValue = value
+	})
+	if selectValue != filter.Symbol+".references" {
+		return nil
+	}
+
+	var err error
+	VisitField(nodes, FieldType, func(value string, _ bool, _ Annotation) {
+		if err == nil && value != "symbol" && value != "reference" {
+			err = errors.Errorf("select:symbol.references only applies to symbol searches and is not supported with type:%s", value)
+		}
+	})
+	return err
+}
+
+// validateTypeReference validates that a query with type:reference does not
+// search for other result types as well, since all results of a references
+// search are references.
+func validateTypeReference(nodes []Node) error {
+	var types []string
+	VisitField(nodes, FieldType, func(value string, _ bool, _ Annotation) {
+		types = append(types, value)
+	})
+
+	hasReference := false
+	for _, value := range types {
+		if value == "reference" {
+			hasReference = true
+		}
+	}
+	if !hasReference {
+		return nil
+	}
+
+	for _, value := range types {
+		if value != "reference" {
+			return errors.Errorf("type:reference cannot be combined with type:%s", value)
+		}
+	}
+	return nil
+}
+
 func validateRefGlobs(nodes []Node) error {
 	if !ContainsRefGlobs(nodes) {
 		return nil
@@ -392,17 +472,29 @@ func validateRefGlobs(nodes []Node) error {
 
 // validatePredicates validates predicate parameters with respect to their validation logic.
 func validatePredicate(field, value string, negated bool) error {
-	if negated {
-		return errors.New("predicates do not currently support negation")
-	}
 	name, params := ParseAsPredicate(value)                // guaranteed to succeed
 	predicate := DefaultPredicateRegistry.Get(field, name) // guaranteed to succeed
+	if negated && !supportsNegation(predicate) {
+		return errors.New("predicates do not currently support negation")
+	}
 	if err := predicate.ParseParams(params); err != nil {
 		return errors.Errorf("invalid predicate value: %s", err)
 	}
 	return nil
 }
 
+// supportsNegation returns whether a predicate may be negated. File history
+// predicates are negatable, e.g., `-file:has.commit.after(1 month ago)` finds
+// files without recent changes.
+func supportsNegation(predicate Predicate) bool {
+	switch predicate.(type) {
+	case *FileHasCommitAfterPredicate, *FileHasContributorPredicate:
+		return true
+	default:
+		return false
+	}
+}
+
 // validateRepoHasFile validates that the repohasfile parameter can be executed.
 // A query like `repohasfile:foo type:symbol patter-to-match-symbols` is
 // currently not supported.
@@ -497,6 +589,9 @@ func validate(nodes []Node) error {
 		validateCommitParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateSelectCapture,
+		validateSelectReferences,
+		validateTypeReference,
 	)
 }
 
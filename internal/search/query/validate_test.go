@@ -95,10 +95,43 @@ func TestValidation(t *testing.T) {
 			input: "-context:a",
 			want:  `field "context" does not support negation`,
 		},
+		{
+			input: "-repo:has.description(foo)",
+			want:  "predicates do not currently support negation",
+		},
+		{
+			input: "-file:has.owner(alice)",
+			want:  "predicates do not currently support negation",
+		},
 		{
 			input: "type:symbol select:symbol.timelime",
 			want:  `invalid field "timelime" on select path "symbol.timelime"`,
 		},
+		{
+			input: "select:capture.",
+			want:  `invalid capture group on select path "capture."`,
+		},
+		{
+			input:      "foo select:capture",
+			want:       "select:capture requires a single regular expression search pattern, e.g. patterntype:regexp select:capture.1 go\\s+(\\d+\\.\\d+)",
+			searchType: SearchTypeLiteral,
+		},
+		{
+			input: "go(\\d+) select:capture.2",
+			want:  `invalid capture group 2 on select path "capture.2", the pattern has 1 capture groups`,
+		},
+		{
+			input: "go(?P<major>\\d+) select:capture.minor",
+			want:  `invalid capture group "minor" on select path "capture.minor", the pattern has no capture group with that name`,
+		},
+		{
+			input: "type:file foo select:symbol.references",
+			want:  "select:symbol.references only applies to symbol searches and is not supported with type:file",
+		},
+		{
+			input: "type:reference type:file foo",
+			want:  "type:reference cannot be combined with type:file",
+		},
 		{
 			input:      "nice try type:repo",
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents",
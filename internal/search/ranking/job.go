@@ -0,0 +1,192 @@
+package ranking
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// MaxBufferedResults is the largest `count:` value for which results are
+// ranked. Larger searches stream results as they arrive.
+const MaxBufferedResults = 10000
+
+const (
+	// referenceCountTimeout bounds the time spent looking up inbound
+	// references after the child job has finished. If the counts of some files
+	// are not known by then, all files are ranked without them.
+	referenceCountTimeout = 500 * time.Millisecond
+
+	referenceCountConcurrency = 8
+)
+
+// NewRankingJob creates a job that buffers all results of the given child job
+// and sends them ordered by score once the child job has finished. File matches
+// are ordered by Score and are sent before all other matches, which keep the
+// order they were received in. File matches with equal scores also keep the
+// order they were received in, which preserves the ranking of Zoekt results.
+func NewRankingJob(child job.Job) job.Job {
+	return &rankingJob{child: child}
+}
+
+type rankingJob struct {
+	child job.Job
+}
+
+func (j *rankingJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	tr, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu      sync.Mutex
+		matches result.Matches
+	)
+
+	bufferedStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		matches = append(matches, event.Results...)
+		mu.Unlock()
+
+		stream.Send(streaming.SearchEvent{Stats: event.Stats})
+	})
+
+	alert, err = j.child.Run(ctx, clients, bufferedStream)
+
+	// Send the results we have even if the child job failed, since the
+	// error may only affect a subset of the searched backends.
+	ranked, rankErr := rankMatches(ctx, clients.CodeIntel, matches)
+	if rankErr != nil {
+		// Ranking signals are best-effort and never fail the search.
+		tr.LogFields(otlog.Error(rankErr))
+	}
+	if len(ranked) > 0 {
+		stream.Send(streaming.SearchEvent{Results: ranked})
+	}
+
+	return alert, err
+}
+
+func (j *rankingJob) Name() string {
+	return "RankingJob"
+}
+
+func (j *rankingJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (j *rankingJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *rankingJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// rankMatches returns the given matches ordered by score. If the given code
+// intelligence client is non-nil, it is used to look up inbound reference counts
+// for file matches. An error looking up reference counts is returned along with
+// the ranked matches, which are then ranked without any reference counts so that
+// files whose counts happened to be looked up first are not favored.
+func rankMatches(ctx context.Context, counter precise.Client, matches result.Matches) (result.Matches, error) {
+	var files []*result.FileMatch
+	for _, m := range matches {
+		if fm, ok := m.(*result.FileMatch); ok {
+			files = append(files, fm)
+		}
+	}
+
+	var (
+		counts []int
+		err    error
+	)
+	if counter != nil && len(files) > 0 {
+		counts, err = inboundReferenceCounts(ctx, counter, files)
+		if err != nil {
+			counts = nil
+		}
+	}
+
+	scores := make(map[*result.FileMatch]float64, len(files))
+	for i, fm := range files {
+		signals := FileSignals(fm)
+		if counts != nil {
+			signals.InboundReferences = counts[i]
+		}
+		scores[fm] = Score(signals)
+	}
+
+	ranked := make(result.Matches, len(matches))
+	copy(ranked, matches)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		left, leftIsFile := ranked[i].(*result.FileMatch)
+		right, rightIsFile := ranked[j].(*result.FileMatch)
+		if leftIsFile != rightIsFile {
+			return leftIsFile
+		}
+		if !leftIsFile {
+			return false
+		}
+		return scores[left] > scores[right]
+	})
+
+	return ranked, err
+}
+
+type repoCommit struct {
+	repo   api.RepoID
+	commit api.CommitID
+}
+
+// inboundReferenceCounts returns the inbound reference count of each of the
+// given files. The counts of the files of a commit are looked up together, so
+// that the precise code intelligence data of the commit is resolved once. An
+// error is returned if the counts cannot be determined within
+// referenceCountTimeout.
+func inboundReferenceCounts(ctx context.Context, counter precise.Client, files []*result.FileMatch) ([]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, referenceCountTimeout)
+	defer cancel()
+
+	var keys []repoCommit
+	indexesByCommit := map[repoCommit][]int{}
+	for i, fm := range files {
+		key := repoCommit{repo: fm.Repo.ID, commit: fm.CommitID}
+		if _, ok := indexesByCommit[key]; !ok {
+			keys = append(keys, key)
+		}
+		indexesByCommit[key] = append(indexesByCommit[key], i)
+	}
+
+	counts := make([]int, len(files))
+	g := group.New().WithContext(ctx).WithMaxConcurrency(referenceCountConcurrency).WithCancelOnError().WithFirstError()
+	for _, key := range keys {
+		indexes := indexesByCommit[key]
+		g.Go(func(ctx context.Context) error {
+			paths := make([]string, 0, len(indexes))
+			for _, i := range indexes {
+				paths = append(paths, files[i].Path)
+			}
+
+			commitCounts, err := counter.InboundReferenceCounts(ctx, files[indexes[0]].Repo, files[indexes[0]].CommitID, paths)
+			if err != nil {
+				return err
+			}
+
+			for j, i := range indexes {
+				counts[i] = commitCounts[j]
+			}
+			return nil
+		})
+	}
+
+	return counts, g.Wait()
+}
@@ -0,0 +1,98 @@
+package ranking
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeReferenceCounter struct {
+	precise.Client
+	counts map[string]int
+	err    error
+
+	mu    sync.Mutex
+	calls []api.CommitID
+}
+
+func (c *fakeReferenceCounter) InboundReferenceCounts(_ context.Context, _ types.MinimalRepo, commit api.CommitID, paths []string) ([]int, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, commit)
+	c.mu.Unlock()
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	counts := make([]int, 0, len(paths))
+	for _, path := range paths {
+		counts = append(counts, c.counts[path])
+	}
+	return counts, nil
+}
+
+func TestRankingJob(t *testing.T) {
+	fm := func(commit api.CommitID, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{CommitID: commit, Path: path}}
+	}
+
+	var (
+		vendored = fm("a", "vendor/lib/lib.go")
+		test     = fm("a", "pkg/util_test.go")
+		util     = fm("a", "pkg/util.go")
+		core     = fm("b", "pkg/core.go")
+		repo     = &result.RepoMatch{Name: "repo"}
+	)
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{vendored, repo, test}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{util, core}})
+		return nil, nil
+	})
+
+	run := func(clients job.RuntimeClients) []result.Matches {
+		var events []result.Matches
+		stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+			if len(e.Results) > 0 {
+				events = append(events, e.Results)
+			}
+		})
+
+		alert, err := NewRankingJob(childJob).Run(context.Background(), clients, stream)
+		require.Nil(t, alert)
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("without reference counts", func(t *testing.T) {
+		require.Equal(t, []result.Matches{{util, core, test, vendored, repo}}, run(job.RuntimeClients{}))
+	})
+
+	t.Run("with reference counts", func(t *testing.T) {
+		counter := &fakeReferenceCounter{counts: map[string]int{"pkg/core.go": 50, "pkg/util.go": 2}}
+		require.Equal(t, []result.Matches{{core, util, test, vendored, repo}}, run(job.RuntimeClients{CodeIntel: counter}))
+
+		// The counts of the files of a commit are looked up at once
+		sort.Slice(counter.calls, func(i, j int) bool { return counter.calls[i] < counter.calls[j] })
+		require.Equal(t, []api.CommitID{"a", "b"}, counter.calls)
+	})
+
+	t.Run("reference counts error", func(t *testing.T) {
+		counter := &fakeReferenceCounter{counts: map[string]int{"pkg/core.go": 50}, err: errors.New("timeout")}
+		require.Equal(t, []result.Matches{{util, core, test, vendored, repo}}, run(job.RuntimeClients{CodeIntel: counter}))
+	})
+}
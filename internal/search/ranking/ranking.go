@@ -0,0 +1,72 @@
+// Package ranking orders search results by signals of file importance. It is
+// used to rank the results of searches that request a bounded number of
+// results with `count:`, where all results are buffered before being sent.
+package ranking
+
+import (
+	"math"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Signals are the inputs used to score a file match.
+type Signals struct {
+	// InboundReferences is the number of references to the symbols exported
+	// from the file.
+	InboundReferences int
+
+	// PathDepth is the number of directories containing the file.
+	PathDepth int
+
+	// IsTest is true if the file contains tests.
+	IsTest bool
+
+	// IsVendor is true if the file is vendored or otherwise generated by
+	// tooling, such as a dependency directory.
+	IsVendor bool
+
+	// RepoStars is the number of stars of the repository containing the file.
+	RepoStars int
+}
+
+// FileSignals returns the signals of the given file match that can be
+// computed without external data. Inbound references are left unset.
+func FileSignals(fm *result.FileMatch) Signals {
+	return Signals{
+		PathDepth: strings.Count(strings.Trim(fm.Path, "/"), "/"),
+		IsTest:    enry.IsTest(fm.Path),
+		IsVendor:  enry.IsVendor(fm.Path),
+		RepoStars: fm.Repo.Stars,
+	}
+}
+
+const (
+	inboundReferencesWeight = 1.0
+	repoStarsWeight         = 0.5
+	pathDepthWeight         = 0.25
+	testPenalty             = 1.0
+	vendorPenalty           = 2.0
+)
+
+// Score returns the score for a file with the given signals. Files with higher
+// scores are ranked before files with lower scores.
+//
+// Reference counts and stars follow a long tail, so both contribute
+// logarithmically. Deeply nested, test, and vendored files are penalized.
+func Score(s Signals) float64 {
+	score := inboundReferencesWeight*math.Log1p(float64(s.InboundReferences)) +
+		repoStarsWeight*math.Log1p(float64(s.RepoStars)) -
+		pathDepthWeight*float64(s.PathDepth)
+
+	if s.IsTest {
+		score -= testPenalty
+	}
+	if s.IsVendor {
+		score -= vendorPenalty
+	}
+
+	return score
+}
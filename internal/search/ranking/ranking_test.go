@@ -0,0 +1,46 @@
+package ranking
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileSignals(t *testing.T) {
+	testCases := map[string]Signals{
+		"main.go":                          {},
+		"cmd/server/main.go":               {PathDepth: 2},
+		"internal/search/ranking_test.go":  {PathDepth: 2, IsTest: true},
+		"vendor/github.com/foo/bar/bar.go": {PathDepth: 4, IsVendor: true},
+		"node_modules/react/index.js":      {PathDepth: 2, IsVendor: true},
+	}
+
+	for path, expected := range testCases {
+		fm := &result.FileMatch{File: result.File{Path: path, Repo: types.MinimalRepo{Stars: 0}}}
+		if diff := cmp.Diff(expected, FileSignals(fm)); diff != "" {
+			t.Errorf("unexpected signals for %q (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	// Each signal set should be ranked strictly before the next.
+	ordered := []Signals{
+		{InboundReferences: 1000, RepoStars: 100},
+		{InboundReferences: 10},
+		{},
+		{PathDepth: 3},
+		{IsTest: true},
+		{IsVendor: true},
+		{IsVendor: true, IsTest: true, PathDepth: 6},
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		if prev, curr := Score(ordered[i-1]), Score(ordered[i]); prev <= curr {
+			t.Errorf("expected %+v (%f) to score higher than %+v (%f)", ordered[i-1], prev, ordered[i], curr)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+// Package references implements searching for references to symbols. Symbol
+// definitions are found by a symbol search, and references to each definition
+// are resolved with precise code intelligence data when it is available.
+// Repositories without precise data fall back to a search-based approximation
+// matching the symbol name as a whole word.
+package references
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// NewSearchJob creates a job that resolves the symbol matches streamed by the
+// given child job into the references of those symbols. Only references in
+// files matched by the include and exclude patterns and languages of
+// patternInfo are streamed, at most limit file matches in total.
+func NewSearchJob(child job.Job, patternInfo *search.TextPatternInfo, limit int) job.Job {
+	return &searchJob{
+		child:       child,
+		patternInfo: patternInfo,
+		limit:       limit,
+	}
+}
+
+// referencesConcurrency bounds the number of symbols whose precise references
+// are looked up concurrently.
+const referencesConcurrency = 8
+
+type searchJob struct {
+	child       job.Job
+	patternInfo *search.TextPatternInfo
+	limit       int
+}
+
+func (j *searchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	matchPath, err := j.pathMatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		symbols []*result.SymbolMatch
+	)
+
+	// Collect symbol definitions from the child, forwarding only stats. The
+	// definitions themselves are replaced by their references below.
+	symbolStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		for _, match := range event.Results {
+			if fm, ok := match.(*result.FileMatch); ok {
+				symbols = append(symbols, fm.Symbols...)
+			}
+		}
+		mu.Unlock()
+
+		stream.Send(streaming.SearchEvent{Stats: event.Stats})
+	})
+
+	// Resolve the references of the symbols that were found even if the
+	// symbol search failed for some of the repositories.
+	alert, errs := j.child.Run(ctx, clients, symbolStream)
+
+	var (
+		sentMu sync.Mutex
+		sent   int
+		seen   = map[fileKey]struct{}{}
+	)
+
+	// send streams the given matches unless doing so would exceed the limit of
+	// the job, in which case the matches are truncated. Files that have already
+	// been streamed, for example by the search-based fallback of another symbol,
+	// are dropped and do not count towards the limit. It returns false once the
+	// limit has been reached.
+	send := func(event streaming.SearchEvent) bool {
+		sentMu.Lock()
+		defer sentMu.Unlock()
+
+		results := make(result.Matches, 0, len(event.Results))
+		for _, match := range event.Results {
+			if sent+len(results) >= j.limit {
+				break
+			}
+			if fm, ok := match.(*result.FileMatch); ok {
+				key := fileKey{repo: fm.Repo.ID, commit: fm.CommitID, path: fm.Path}
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+			}
+			results = append(results, match)
+		}
+		event.Results = results
+
+		sent += len(event.Results)
+		if len(event.Results) > 0 || !event.Stats.Zero() {
+			stream.Send(event)
+		}
+		return sent < j.limit
+	}
+	full := func() bool {
+		sentMu.Lock()
+		defer sentMu.Unlock()
+		return sent >= j.limit
+	}
+
+	// Look up the precise references of all symbols before streaming any of
+	// them, so that the symbols share the limit of the job regardless of the
+	// order in which their lookups finish.
+	preciseLocations := make([][]precise.Location, len(symbols))
+	fallbacks := map[string][]*search.RepositoryRevisions{}
+
+	g := group.New().WithContext(ctx).WithMaxConcurrency(referencesConcurrency)
+	for i, symbol := range symbols {
+		i, symbol := i, symbol
+		g.Go(func(ctx context.Context) error {
+			// Every file match contains at least one location, so no symbol
+			// needs more than limit locations.
+			rng := symbol.Symbol.Range()
+			locations, ok, err := preciseReferences(ctx, clients.CodeIntel, symbol.File.Repo, symbol.File.CommitID, symbol.File.Path, rng.Start.Line, rng.Start.Character, j.limit)
+
+			// Symbols whose precise references cannot be looked up fall back
+			// to search-based references, and the lookup error is reported.
+			if err != nil || !ok {
+				mu.Lock()
+				fallbacks[symbol.Symbol.Name] = appendRepoRev(fallbacks[symbol.Symbol.Name], symbol.File.Repo, symbol.File.CommitID)
+				mu.Unlock()
+				return err
+			}
+
+			preciseLocations[i] = locations
+			return nil
+		})
+	}
+	errs = errors.Append(errs, g.Wait())
+
+	matches, err := toFileMatches(ctx, clients, interleaveLocations(preciseLocations), matchPath, j.limit)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	send(streaming.SearchEvent{Results: matches})
+
+	names := make([]string, 0, len(fallbacks))
+	for name := range fallbacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if full() {
+			break
+		}
+
+		fallbackJob := &searcher.TextSearchJob{
+			PatternInfo: &search.TextPatternInfo{
+				Pattern:                      `\b` + regexp.QuoteMeta(name) + `\b`,
+				IsRegExp:                     true,
+				IsCaseSensitive:              true,
+				PatternMatchesContent:        true,
+				FileMatchLimit:               int32(j.limit),
+				IncludePatterns:              j.patternInfo.IncludePatterns,
+				ExcludePattern:               j.patternInfo.ExcludePattern,
+				PathPatternsAreCaseSensitive: j.patternInfo.PathPatternsAreCaseSensitive,
+				Languages:                    j.patternInfo.Languages,
+				IncludeLangs:                 j.patternInfo.IncludeLangs,
+				ExcludeLangs:                 j.patternInfo.ExcludeLangs,
+			},
+			Repos:           fallbacks[name],
+			UseFullDeadline: true,
+		}
+
+		// Stop the fallback search as soon as the limit has been reached
+		fallbackCtx, cancel := context.WithCancel(ctx)
+		_, err := fallbackJob.Run(fallbackCtx, clients, streaming.StreamFunc(func(event streaming.SearchEvent) {
+			if !send(event) {
+				cancel()
+			}
+		}))
+		cancel()
+		if err != nil && !(full() && errors.Is(err, context.Canceled)) {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	return alert, errs
+}
+
+func (j *searchJob) Name() string {
+	return "SymbolReferencesSearchJob"
+}
+
+func (j *searchJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			otlog.Int("limit", j.limit),
+		)
+	}
+	return res
+}
+
+func (j *searchJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *searchJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// pathMatcher matches the paths of the files in which references are streamed
+// against the include and exclude patterns of the job. Languages detected from
+// file content are approximated by their file extensions, since precise
+// locations carry no file content.
+func (j *searchJob) pathMatcher() (pathmatch.PathMatcher, error) {
+	includePatterns := append(append([]string{}, j.patternInfo.IncludePatterns...), mapSlice(j.patternInfo.IncludeLangs, query.LangToFileRegexp)...)
+
+	excludePatterns := mapSlice(j.patternInfo.ExcludeLangs, query.LangToFileRegexp)
+	if j.patternInfo.ExcludePattern != "" {
+		excludePatterns = append(excludePatterns, j.patternInfo.ExcludePattern)
+	}
+
+	return pathmatch.CompilePathPatterns(includePatterns, query.UnionRegExps(excludePatterns), pathmatch.CompileOptions{
+		RegExp:        true,
+		CaseSensitive: j.patternInfo.PathPatternsAreCaseSensitive,
+	})
+}
+
+func mapSlice(values []string, f func(string) string) []string {
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i] = f(v)
+	}
+	return res
+}
+
+func preciseReferences(ctx context.Context, client precise.Client, repo types.MinimalRepo, commit api.CommitID, path string, line, character, limit int) ([]precise.Location, bool, error) {
+	if client == nil {
+		return nil, false, nil
+	}
+
+	return client.References(ctx, repo, commit, path, line, character, limit)
+}
+
+func appendRepoRev(repoRevs []*search.RepositoryRevisions, repo types.MinimalRepo, commit api.CommitID) []*search.RepositoryRevisions {
+	for _, repoRev := range repoRevs {
+		if repoRev.Repo.ID != repo.ID {
+			continue
+		}
+		for _, rev := range repoRev.Revs {
+			if rev == string(commit) {
+				return repoRevs
+			}
+		}
+
+		repoRev.Revs = append(repoRev.Revs, string(commit))
+		return repoRevs
+	}
+
+	return append(repoRevs, &search.RepositoryRevisions{Repo: repo, Revs: []string{string(commit)}})
+}
+
+// interleaveLocations merges the locations of several symbols by taking one
+// location of each symbol in turn, so that truncating the merged locations does
+// not drop all references to some of the symbols.
+func interleaveLocations(locationsBySymbol [][]precise.Location) []precise.Location {
+	var interleaved []precise.Location
+	for i := 0; ; i++ {
+		done := true
+		for _, locations := range locationsBySymbol {
+			if i < len(locations) {
+				interleaved = append(interleaved, locations[i])
+				done = false
+			}
+		}
+		if done {
+			return interleaved
+		}
+	}
+}
+
+type fileKey struct {
+	repo   api.RepoID
+	commit api.CommitID
+	path   string
+}
+
+// toFileMatches groups the given locations by file and converts up to limit of
+// the files, in order of their first location, into file matches with one chunk
+// per referenced line. Locations in repositories that are not visible to the
+// current actor, or in files whose path is not matched, are dropped.
+func toFileMatches(ctx context.Context, clients job.RuntimeClients, locations []precise.Location, matchPath pathmatch.PathMatcher, limit int) ([]result.Match, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(locations))
+	for _, location := range locations {
+		repoIDs = append(repoIDs, location.Repo.ID)
+	}
+
+	// 🚨 SECURITY: Cross-repository references may point into repositories the
+	// current actor cannot see. The repo store enforces repository permissions.
+	visibleRepos, err := clients.DB.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []fileKey
+	rangesByFile := map[fileKey][]result.Range{}
+	reposByFile := map[fileKey]types.MinimalRepo{}
+	for _, location := range locations {
+		repo, ok := visibleRepos[location.Repo.ID]
+		if !ok || !matchPath.MatchPath(location.Path) {
+			continue
+		}
+
+		key := fileKey{repo: repo.ID, commit: location.Commit, path: location.Path}
+		if _, ok := rangesByFile[key]; !ok {
+			keys = append(keys, key)
+			reposByFile[key] = types.MinimalRepo{ID: repo.ID, Name: repo.Name, Stars: repo.Stars}
+		}
+		rangesByFile[key] = append(rangesByFile[key], location.Range)
+	}
+
+	matches := make([]result.Match, 0, len(keys))
+	for _, key := range keys {
+		if len(matches) >= limit {
+			break
+		}
+		repo := reposByFile[key]
+
+		content, err := clients.Gitserver.ReadFile(ctx, repo.Name, key.commit, key.path, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		matches = append(matches, &result.FileMatch{
+			File: result.File{
+				Repo:     repo,
+				CommitID: key.commit,
+				Path:     key.path,
+			},
+			ChunkMatches: toChunkMatches(content, rangesByFile[key]),
+		})
+	}
+
+	return matches, nil
+}
+
+// toChunkMatches creates one chunk match per line containing one of the given
+// ranges. The ranges are expected to have their line and column set; offsets
+// are computed from the given file content.
+func toChunkMatches(content []byte, ranges []result.Range) result.ChunkMatches {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+
+	lineOffsets := make([]int, len(lines))
+	for i, offset := 0, 0; i < len(lines); i++ {
+		lineOffsets[i] = offset
+		offset += len(lines[i])
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Start.Line != ranges[j].Start.Line {
+			return ranges[i].Start.Line < ranges[j].Start.Line
+		}
+		return ranges[i].Start.Column < ranges[j].Start.Column
+	})
+
+	var chunks result.ChunkMatches
+	for _, rng := range ranges {
+		line := rng.Start.Line
+		if line < 0 || line >= len(lines) || rng.End.Line >= len(lines) {
+			continue
+		}
+
+		start := result.Location{
+			Offset: lineOffsets[line] + columnToOffset(lines[line], rng.Start.Column),
+			Line:   line,
+			Column: rng.Start.Column,
+		}
+		end := result.Location{
+			Offset: lineOffsets[rng.End.Line] + columnToOffset(lines[rng.End.Line], rng.End.Column),
+			Line:   rng.End.Line,
+			Column: rng.End.Column,
+		}
+
+		if n := len(chunks); n > 0 && chunks[n-1].ContentStart.Line == line {
+			if last := chunks[n-1].Ranges[len(chunks[n-1].Ranges)-1]; last == (result.Range{Start: start, End: end}) {
+				continue
+			}
+			chunks[n-1].Ranges = append(chunks[n-1].Ranges, result.Range{Start: start, End: end})
+			continue
+		}
+
+		chunks = append(chunks, result.ChunkMatch{
+			Content: string(bytes.TrimSuffix(lines[line], []byte("\n"))),
+			ContentStart: result.Location{
+				Offset: lineOffsets[line],
+				Line:   line,
+				Column: 0,
+			},
+			Ranges: result.Ranges{{Start: start, End: end}},
+		})
+	}
+
+	return chunks
+}
+
+// columnToOffset converts a rune-based column into a byte offset within the
+// given line.
+func columnToOffset(line []byte, column int) int {
+	offset := 0
+	for i := 0; i < column && offset < len(line); i++ {
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,270 @@
+package references
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/endpoint"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/precise"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakePreciseClient struct {
+	precise.Client
+	references map[string][]precise.Location
+	errs       map[string]error
+}
+
+func (c fakePreciseClient) References(_ context.Context, _ types.MinimalRepo, _ api.CommitID, path string, _, _, limit int) ([]precise.Location, bool, error) {
+	if err := c.errs[path]; err != nil {
+		return nil, false, err
+	}
+	locations, ok := c.references[path]
+	if len(locations) > limit {
+		locations = locations[:limit]
+	}
+	return locations, ok, nil
+}
+
+func TestSearchJob(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/test/repo"}
+
+	symbolMatch := func(path, name string) *result.FileMatch {
+		file := result.File{Repo: repo, CommitID: "deadbeef", Path: path}
+		return &result.FileMatch{
+			File:    file,
+			Symbols: []*result.SymbolMatch{result.NewSymbolMatch(&file, 0, 5, name, "function", "", "", "go", "func "+name+"()", false)},
+		}
+	}
+
+	location := func(path string) precise.Location {
+		return precise.Location{
+			Repo:   repo,
+			Commit: "deadbeef",
+			Path:   path,
+			Range: result.Range{
+				Start: result.Location{Line: 0, Column: 0},
+				End:   result.Location{Line: 0, Column: 3},
+			},
+		}
+	}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{symbolMatch("foo.go", "Foo"), symbolMatch("bar.go", "Bar")}})
+		return nil, nil
+	})
+
+	repos := database.NewMockRepoStore()
+	repos.GetReposSetByIDsFunc.SetDefaultReturn(map[api.RepoID]*types.Repo{repo.ID: {ID: repo.ID, Name: repo.Name}}, nil)
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		return []byte("Foo()\n"), nil
+	})
+
+	var (
+		mu               sync.Mutex
+		fallbackFor      []string
+		fallbackIncludes [][]string
+	)
+	searcher.MockSearchFilesInRepo = func(_ context.Context, repo types.MinimalRepo, _ api.RepoName, rev string, info *search.TextPatternInfo, _ time.Duration, s streaming.Sender) (bool, error) {
+		mu.Lock()
+		fallbackFor = append(fallbackFor, info.Pattern)
+		fallbackIncludes = append(fallbackIncludes, info.IncludePatterns)
+		mu.Unlock()
+
+		s.Send(streaming.SearchEvent{Results: result.Matches{&result.FileMatch{File: result.File{Repo: repo, CommitID: api.CommitID(rev), Path: "fallback.go"}}}})
+		return false, nil
+	}
+	t.Cleanup(func() { searcher.MockSearchFilesInRepo = nil })
+
+	runJob := func(child job.Job, codeIntel precise.Client, patternInfo *search.TextPatternInfo, limit int) ([]string, error) {
+		mu.Lock()
+		fallbackFor = nil
+		fallbackIncludes = nil
+		mu.Unlock()
+
+		clients := job.RuntimeClients{
+			Logger:       logtest.Scoped(t),
+			DB:           db,
+			Gitserver:    gitserverClient,
+			SearcherURLs: endpoint.Static("test"),
+			CodeIntel:    codeIntel,
+		}
+
+		var paths []string
+		stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+			for _, m := range e.Results {
+				fm := m.(*result.FileMatch)
+				paths = append(paths, fmt.Sprintf("%s:%d", fm.Path, len(fm.ChunkMatches)))
+			}
+		})
+
+		_, err := NewSearchJob(child, patternInfo, limit).Run(context.Background(), clients, stream)
+		sort.Strings(paths)
+		return paths, err
+	}
+
+	run := func(codeIntel precise.Client, limit int) []string {
+		paths, err := runJob(childJob, codeIntel, &search.TextPatternInfo{}, limit)
+		require.NoError(t, err)
+		return paths
+	}
+
+	t.Run("precise", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go"), location("b.go")},
+			"bar.go": {location("c.go")},
+		}}
+
+		require.Equal(t, []string{"a.go:1", "b.go:1", "c.go:1"}, run(client, 10))
+		require.Empty(t, fallbackFor)
+	})
+
+	t.Run("search-based fallback", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go")},
+		}}
+
+		require.Equal(t, []string{"a.go:1", "fallback.go:0"}, run(client, 10))
+		require.Equal(t, []string{`\bBar\b`}, fallbackFor)
+	})
+
+	t.Run("without precise code intelligence", func(t *testing.T) {
+		require.Equal(t, []string{"fallback.go:0"}, run(nil, 10))
+		sort.Strings(fallbackFor)
+		require.Equal(t, []string{`\bBar\b`, `\bFoo\b`}, fallbackFor)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go"), location("b.go")},
+			"bar.go": {location("c.go"), location("d.go")},
+		}}
+
+		require.Len(t, run(client, 3), 3)
+		require.Empty(t, fallbackFor)
+	})
+
+	t.Run("limit shared between symbols", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go"), location("b.go"), location("c.go")},
+			"bar.go": {location("d.go"), location("e.go")},
+		}}
+
+		for i := 0; i < 10; i++ {
+			require.Equal(t, []string{"a.go:1", "d.go:1"}, run(client, 2))
+		}
+	})
+
+	t.Run("files referencing several symbols", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go"), location("b.go")},
+			"bar.go": {location("a.go"), location("c.go")},
+		}}
+
+		require.Equal(t, []string{"a.go:1", "b.go:1"}, run(client, 2))
+	})
+
+	t.Run("precise lookup error", func(t *testing.T) {
+		client := fakePreciseClient{
+			references: map[string][]precise.Location{"foo.go": {location("a.go")}},
+			errs:       map[string]error{"bar.go": errors.New("lookup failed")},
+		}
+
+		paths, err := runJob(childJob, client, &search.TextPatternInfo{}, 10)
+		require.ErrorContains(t, err, "lookup failed")
+		require.Equal(t, []string{"a.go:1", "fallback.go:0"}, paths)
+		require.Equal(t, []string{`\bBar\b`}, fallbackFor)
+	})
+
+	t.Run("symbol search error", func(t *testing.T) {
+		failingJob := mockjob.NewMockJob()
+		failingJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{symbolMatch("foo.go", "Foo")}})
+			return nil, errors.New("backend timed out")
+		})
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go")},
+		}}
+
+		paths, err := runJob(failingJob, client, &search.TextPatternInfo{}, 10)
+		require.ErrorContains(t, err, "backend timed out")
+		require.Equal(t, []string{"a.go:1"}, paths)
+	})
+
+	t.Run("file filters", func(t *testing.T) {
+		client := fakePreciseClient{references: map[string][]precise.Location{
+			"foo.go": {location("a.go"), location("a_test.go"), location("b.md")},
+		}}
+		patternInfo := &search.TextPatternInfo{
+			IncludePatterns: []string{`\.go$`},
+			ExcludePattern:  `_test\.go$`,
+		}
+
+		paths, err := runJob(childJob, client, patternInfo, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.go:1", "fallback.go:0"}, paths)
+		require.Equal(t, [][]string{{`\.go$`}}, fallbackIncludes)
+	})
+}
+
+func TestToChunkMatches(t *testing.T) {
+	content := []byte("package foo\n\nfunc Foo() { Foo() }\n// héllo Foo\n")
+
+	rng := func(line, start, end int) result.Range {
+		return result.Range{
+			Start: result.Location{Line: line, Column: start},
+			End:   result.Location{Line: line, Column: end},
+		}
+	}
+
+	got := toChunkMatches(content, []result.Range{
+		rng(3, 9, 12),
+		rng(2, 13, 16),
+		rng(2, 5, 8),
+		rng(2, 5, 8),
+		rng(10, 0, 3),
+	})
+
+	want := result.ChunkMatches{{
+		Content:      "func Foo() { Foo() }",
+		ContentStart: result.Location{Offset: 13, Line: 2},
+		Ranges: result.Ranges{
+			{Start: result.Location{Offset: 18, Line: 2, Column: 5}, End: result.Location{Offset: 21, Line: 2, Column: 8}},
+			{Start: result.Location{Offset: 26, Line: 2, Column: 13}, End: result.Location{Offset: 29, Line: 2, Column: 16}},
+		},
+	}, {
+		Content:      "// héllo Foo",
+		ContentStart: result.Location{Offset: 34, Line: 3},
+		Ranges: result.Ranges{
+			{Start: result.Location{Offset: 44, Line: 3, Column: 9}, End: result.Location{Offset: 47, Line: 3, Column: 12}},
+		},
+	}}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected chunk matches (-want +got):\n%s", diff)
+	}
+}
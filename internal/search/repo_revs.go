@@ -90,6 +90,8 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 // - 'foo@*bar' refers to the 'foo' repo and all refs matching the glob 'bar/*',
 //   because git interprets the ref glob 'bar' as being 'bar/*' (see `man git-log`
 //   section on the --glob flag)
+// - 'foo@pr/*' refers to the 'foo' repo and the head refs of all its pull
+//   requests and merge requests (see PullRequestRevs)
 func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpecifier) {
 	i := strings.Index(repoAndOptionalRev, "@")
 	if i == -1 {
@@ -107,6 +109,10 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 		if part == "" {
 			continue
 		}
+		if part == PullRequestRevs {
+			revs = append(revs, pullRequestRefGlobs...)
+			continue
+		}
 		revs = append(revs, parseRev(part))
 	}
 	if len(revs) == 0 {
@@ -115,6 +121,20 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 	return repo, revs
 }
 
+// PullRequestRevs is a shorthand revision that refers to the head refs of all
+// pull requests and merge requests of a repository, e.g. `rev:pr/*`. These refs
+// are only searchable if gitserver fetches them from the code host.
+const PullRequestRevs = "pr/*"
+
+var pullRequestRefGlobs = []RevisionSpecifier{
+	// GitHub pull requests
+	{RefGlob: "refs/pull/*/head"},
+	// GitLab merge requests
+	{RefGlob: "refs/merge-requests/*/head"},
+	// Bitbucket Server pull requests
+	{RefGlob: "refs/pull-requests/*/from"},
+}
+
 func parseRev(spec string) RevisionSpecifier {
 	if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
@@ -16,6 +16,15 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		"repo@rev1:rev2": {repo: "repo", revs: []RevisionSpecifier{{RevSpec: "rev1"}, {RevSpec: "rev2"}}},
 		"repo@:rev1:":    {repo: "repo", revs: []RevisionSpecifier{{RevSpec: "rev1"}}},
 		"repo@*glob":     {repo: "repo", revs: []RevisionSpecifier{{RefGlob: "glob"}}},
+		"repo@rev:pr/*": {
+			repo: "repo",
+			revs: []RevisionSpecifier{
+				{RevSpec: "rev"},
+				{RefGlob: "refs/pull/*/head"},
+				{RefGlob: "refs/merge-requests/*/head"},
+				{RefGlob: "refs/pull-requests/*/from"},
+			},
+		},
 		"repo@rev1:*glob1:^rev2": {
 			repo: "repo",
 			revs: []RevisionSpecifier{{RevSpec: "rev1"}, {RefGlob: "glob1"}, {RevSpec: "^rev2"}},
@@ -0,0 +1,71 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// CaptureMatch is the values of a capture group of the search pattern in a
+// file, as selected by select:capture.
+type CaptureMatch struct {
+	File
+
+	// Captures are the distinct values of the capture group in the file,
+	// sorted by value.
+	Captures []Capture
+}
+
+// Capture is a value of a capture group and the number of times it was
+// matched.
+type Capture struct {
+	Value string
+	Count int
+}
+
+func (c *CaptureMatch) RepoName() types.MinimalRepo {
+	return c.File.Repo
+}
+
+// ResultCount returns the number of distinct values, since that is what is
+// returned to the client.
+func (c *CaptureMatch) ResultCount() int {
+	return len(c.Captures)
+}
+
+func (c *CaptureMatch) Limit(limit int) int {
+	if limit < len(c.Captures) {
+		c.Captures = c.Captures[:limit]
+		return 0
+	}
+	return limit - len(c.Captures)
+}
+
+func (c *CaptureMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: c.Repo.Name,
+			ID:   c.Repo.ID,
+		}
+	case filter.Capture:
+		return c
+	}
+	return nil
+}
+
+func (c *CaptureMatch) Key() Key {
+	k := Key{
+		TypeRank: rankCaptureMatch,
+		Repo:     c.Repo.Name,
+		Commit:   c.CommitID,
+		Path:     c.Path,
+	}
+
+	if c.InputRev != nil {
+		k.Rev = *c.InputRev
+	}
+
+	return k
+}
+
+func (c *CaptureMatch) searchResultMarker() {}
@@ -46,6 +46,10 @@ type FileMatch struct {
 	ChunkMatches ChunkMatches
 	Symbols      []*SymbolMatch `json:"-"`
 
+	// Language is the language of the file detected from its content by the
+	// search backend. It is empty if the backend did not detect a language.
+	Language string
+
 	LimitHit bool
 }
 
@@ -64,6 +64,8 @@ func Search(
 			ExcludePattern:               p.ExcludePattern,
 			IncludePatterns:              p.IncludePatterns,
 			Languages:                    p.Languages,
+			IncludeLangs:                 p.IncludeLangs,
+			ExcludeLangs:                 p.ExcludeLangs,
 			CombyRule:                    p.CombyRule,
 			PathPatternsAreRegExps:       true,
 			Select:                       p.Select.Root(),
@@ -31,6 +31,7 @@ func BuildProgressEvent(stats ProgressStats, namer RepoNamer) Progress {
 		DurationMs:        stats.ElapsedMilliseconds,
 		Skipped:           skipped,
 		Trace:             stats.Trace,
+		ExpandedQuery:     stats.ExpandedQuery,
 	}
 }
 
@@ -52,6 +53,8 @@ type ProgressStats struct {
 
 	Trace string // only filled if requested
 
+	ExpandedQuery string // only filled if the query references query fragments
+
 	DisplayLimit int
 
 	// we smuggle in the namer via this field. Note: we don't calculate the
@@ -25,6 +25,11 @@ type Progress struct {
 
 	// Trace is the URL of an associated trace if the query is logging one.
 	Trace string `json:"trace,omitempty"`
+
+	// ExpandedQuery is the query that was searched after expanding query
+	// fragments defined in settings. It is empty if the query references no
+	// fragments.
+	ExpandedQuery string `json:"expandedQuery,omitempty"`
 }
 
 // Skipped is a description of shards or documents that were skipped.
@@ -18,6 +18,10 @@ type ProgressAggregator struct {
 	DisplayLimit int
 	Trace        string // may be empty
 
+	// ExpandedQuery is the query after expanding query fragments. It is empty
+	// if the query references no fragments.
+	ExpandedQuery string
+
 	RepoNamer api.RepoNamer
 
 	// Dirty is true if p has changed since the last call to Current.
@@ -66,6 +70,7 @@ func (p *ProgressAggregator) currentStats() api.ProgressStats {
 		LimitHit:            p.Stats.IsLimitHit,
 		SuggestedLimit:      suggestedLimit,
 		Trace:               p.Trace,
+		ExpandedQuery:       p.ExpandedQuery,
 		DisplayLimit:        p.DisplayLimit,
 	}
 }
@@ -127,6 +127,8 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case CaptureMatchType:
+		r.EventMatch = &EventCaptureMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CSVContentType is the content type of search matches encoded as CSV.
+const CSVContentType = "text/csv"
+
+// CSVHeader is the header row of CSVEncoder.
+var CSVHeader = []string{"repository", "path", "line", "preview", "commit"}
+
+// CSVEncoder encodes search matches as CSV, flattened to one row per matched
+// line or symbol. Lines are 1-based. Columns that do not apply to a match,
+// like the path of a commit match, are left empty. Only matches can be
+// encoded, all other events of a search stream are dropped.
+type CSVEncoder struct {
+	w     *csv.Writer
+	flush func()
+}
+
+// NewCSVEncoder returns an encoder writing to w. Writes are buffered until
+// Flush is called.
+func NewCSVEncoder(w io.Writer) *CSVEncoder {
+	return &CSVEncoder{
+		w:     csv.NewWriter(w),
+		flush: func() {},
+	}
+}
+
+// NewCSVWriter returns an encoder streaming to the client of w.
+func NewCSVWriter(w http.ResponseWriter) (*CSVEncoder, error) {
+	flusher, err := streamResponse(w, CSVContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVEncoder{
+		w:     csv.NewWriter(w),
+		flush: flusher.Flush,
+	}, nil
+}
+
+// Header writes CSVHeader.
+func (e *CSVEncoder) Header() error {
+	return e.w.Write(CSVHeader)
+}
+
+// Match writes the rows of match.
+func (e *CSVEncoder) Match(match EventMatch) error {
+	for _, row := range CSVRows(match) {
+		if err := e.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes all buffered rows.
+func (e *CSVEncoder) Flush() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return err
+	}
+	e.flush()
+	return nil
+}
+
+// CSVRows returns the rows of match, with columns as in CSVHeader.
+func CSVRows(match EventMatch) [][]string {
+	row := func(repo, path string, line int, preview, commit string) []string {
+		lineStr := ""
+		if line > 0 {
+			lineStr = strconv.Itoa(line)
+		}
+		return []string{repo, path, lineStr, preview, commit}
+	}
+
+	switch v := match.(type) {
+	case *EventContentMatch:
+		rows := make([][]string, 0, len(v.LineMatches)+len(v.ChunkMatches))
+		for _, lm := range v.LineMatches {
+			rows = append(rows, row(v.Repository, v.Path, int(lm.LineNumber)+1, lm.Line, v.Commit))
+		}
+		for _, cm := range v.ChunkMatches {
+			rows = append(rows, row(v.Repository, v.Path, cm.ContentStart.Line+1, cm.Content, v.Commit))
+		}
+		return rows
+	case *EventPathMatch:
+		return [][]string{row(v.Repository, v.Path, 0, "", v.Commit)}
+	case *EventSymbolMatch:
+		rows := make([][]string, 0, len(v.Symbols))
+		for _, symbol := range v.Symbols {
+			rows = append(rows, row(v.Repository, v.Path, int(symbol.Line), symbol.Name, v.Commit))
+		}
+		return rows
+	case *EventCommitMatch:
+		subject, _, _ := strings.Cut(v.Message, "\n")
+		return [][]string{row(v.Repository, "", 0, subject, v.OID)}
+	case *EventRepoMatch:
+		return [][]string{row(v.Repository, "", 0, "", "")}
+	case *EventCaptureMatch:
+		rows := make([][]string, 0, len(v.Captures))
+		for _, capture := range v.Captures {
+			rows = append(rows, row(v.Repository, v.Path, 0, capture.Value, v.Commit))
+		}
+		return rows
+	default:
+		return nil
+	}
+}
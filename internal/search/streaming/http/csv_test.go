@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVEncoder(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf)
+	require.NoError(t, enc.Header())
+
+	matches := []EventMatch{
+		&EventContentMatch{
+			Type:       ContentMatchType,
+			Path:       "main.go",
+			Repository: "github.com/foo/bar",
+			Commit:     "deadbeef",
+			LineMatches: []EventLineMatch{
+				{Line: "func main() {", LineNumber: 2},
+				{Line: `fmt.Println("a, b")`, LineNumber: 3},
+			},
+		},
+		&EventContentMatch{
+			Type:       ContentMatchType,
+			Path:       "README.md",
+			Repository: "github.com/foo/bar",
+			ChunkMatches: []ChunkMatch{{
+				Content:      "# bar\nmore",
+				ContentStart: Location{Line: 0},
+			}},
+		},
+		&EventPathMatch{
+			Type:       PathMatchType,
+			Path:       "go.mod",
+			Repository: "github.com/foo/bar",
+		},
+		&EventSymbolMatch{
+			Type:       SymbolMatchType,
+			Path:       "main.go",
+			Repository: "github.com/foo/bar",
+			Symbols:    []Symbol{{Name: "main", Line: 3}},
+		},
+		&EventCommitMatch{
+			Type:       CommitMatchType,
+			Repository: "github.com/foo/bar",
+			OID:        "cafe",
+			Message:    "add main\n\nlonger description",
+		},
+		&EventRepoMatch{
+			Type:       RepoMatchType,
+			Repository: "github.com/foo/baz",
+		},
+		&EventCaptureMatch{
+			Type:       CaptureMatchType,
+			Path:       "go.mod",
+			Repository: "github.com/foo/baz",
+			Captures:   []Capture{{Value: "1.18", Count: 1}, {Value: "1.19", Count: 2}},
+		},
+	}
+	for _, match := range matches {
+		require.NoError(t, enc.Match(match))
+	}
+	require.NoError(t, enc.Flush())
+
+	want := `repository,path,line,preview,commit
+github.com/foo/bar,main.go,3,func main() {,deadbeef
+github.com/foo/bar,main.go,4,"fmt.Println(""a, b"")",deadbeef
+github.com/foo/bar,README.md,1,"# bar
+more",
+github.com/foo/bar,go.mod,,,
+github.com/foo/bar,main.go,3,main,
+github.com/foo/bar,,,add main,cafe
+github.com/foo/baz,,,,
+github.com/foo/baz,go.mod,,1.18,
+github.com/foo/baz,go.mod,,1.19,
+`
+	require.Equal(t, want, buf.String())
+}
@@ -1,3 +1,4 @@
 // package http contains Sourcegraph's streaming HTTP protocol, which is based
-// on Server Sent Events (SSE).
+// on Server Sent Events (SSE). Search matches can also be encoded as newline
+// delimited JSON or CSV, see NDJSONEncoder and CSVEncoder.
 package http
@@ -160,6 +160,32 @@ type EventCommitMatch struct {
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// EventCaptureMatch is the values of a capture group of the search pattern in
+// a file, as selected by select:capture.
+type EventCaptureMatch struct {
+	// Type is always CaptureMatchType. Included here for marshalling.
+	Type MatchType `json:"type"`
+
+	Path            string     `json:"path"`
+	RepositoryID    int32      `json:"repositoryID"`
+	Repository      string     `json:"repository"`
+	RepoStars       int        `json:"repoStars,omitempty"`
+	RepoLastFetched *time.Time `json:"repoLastFetched,omitempty"`
+	Branches        []string   `json:"branches,omitempty"`
+	Commit          string     `json:"commit,omitempty"`
+
+	Captures []Capture `json:"captures"`
+}
+
+func (e *EventCaptureMatch) eventMatch() {}
+
+// Capture is a value of a capture group and the number of times it was
+// matched in a file.
+type Capture struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
 // EventFilter is a suggestion for a search filter. Currently has a 1-1
 // correspondance with the SearchFilter graphql type.
 type EventFilter struct {
@@ -199,6 +225,7 @@ const (
 	SymbolMatchType
 	CommitMatchType
 	PathMatchType
+	CaptureMatchType
 )
 
 func (t MatchType) MarshalJSON() ([]byte, error) {
@@ -213,6 +240,8 @@ func (t MatchType) MarshalJSON() ([]byte, error) {
 		return []byte(`"commit"`), nil
 	case PathMatchType:
 		return []byte(`"path"`), nil
+	case CaptureMatchType:
+		return []byte(`"capture"`), nil
 	default:
 		return nil, errors.Errorf("unknown MatchType: %d", t)
 	}
@@ -229,6 +258,8 @@ func (t *MatchType) UnmarshalJSON(b []byte) error {
 		*t = CommitMatchType
 	} else if bytes.Equal(b, []byte(`"path"`)) {
 		*t = PathMatchType
+	} else if bytes.Equal(b, []byte(`"capture"`)) {
+		*t = CaptureMatchType
 	} else {
 		return errors.Errorf("unknown MatchType: %s", b)
 	}
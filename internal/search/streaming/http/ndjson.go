@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// NDJSONContentType is the content type of a search stream encoded as newline
+// delimited JSON.
+const NDJSONContentType = "application/x-ndjson"
+
+// NDJSONEncoder encodes a search stream as newline delimited JSON. Every match
+// is written on its own line in the same format as in the "matches" event of
+// the event stream. All other events are written as an object with the event
+// name in "type" and the event data in "data", e.g.
+//
+//	{"type":"content","path":"main.go","repository":"github.com/foo/bar",...}
+//	{"type":"progress","data":{"done":true,"matchCount":1,...}}
+//	{"type":"done"}
+type NDJSONEncoder struct {
+	w     *bufio.Writer
+	flush func()
+}
+
+// NewNDJSONEncoder returns an encoder writing to w. Writes are buffered until
+// Flush is called.
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	return &NDJSONEncoder{
+		w:     bufio.NewWriter(w),
+		flush: func() {},
+	}
+}
+
+// NewNDJSONWriter returns an encoder streaming to the client of w.
+func NewNDJSONWriter(w http.ResponseWriter) (*NDJSONEncoder, error) {
+	flusher, err := streamResponse(w, NDJSONContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NDJSONEncoder{
+		w:     bufio.NewWriter(w),
+		flush: flusher.Flush,
+	}, nil
+}
+
+// Match writes a line for match.
+func (e *NDJSONEncoder) Match(match EventMatch) error {
+	return e.line(match)
+}
+
+// Event writes a line for event with data json marshalled and flushes. data
+// is omitted if nil.
+func (e *NDJSONEncoder) Event(event string, data any) error {
+	if err := e.line(ndjsonEvent{Type: event, Data: data}); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// Flush writes all buffered lines.
+func (e *NDJSONEncoder) Flush() error {
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+	e.flush()
+	return nil
+}
+
+func (e *NDJSONEncoder) line(v any) error {
+	// json.Encoder terminates every value with a newline.
+	return json.NewEncoder(e.w).Encode(v)
+}
+
+type ndjsonEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
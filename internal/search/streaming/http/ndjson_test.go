@@ -0,0 +1,39 @@
+package http
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+
+	require.NoError(t, enc.Match(&EventPathMatch{
+		Type:         PathMatchType,
+		Path:         "go.mod",
+		RepositoryID: 1,
+		Repository:   "github.com/foo/bar",
+	}))
+	require.NoError(t, enc.Match(&EventRepoMatch{
+		Type:         RepoMatchType,
+		RepositoryID: 2,
+		Repository:   "github.com/foo/baz",
+	}))
+	// Matches are buffered until they are flushed.
+	require.Empty(t, buf.String())
+
+	require.NoError(t, enc.Event("progress", map[string]any{"done": true}))
+	require.NoError(t, enc.Event("done", nil))
+
+	want := `{"type":"path","path":"go.mod","repositoryID":1,"repository":"github.com/foo/bar"}
+{"type":"repo","repositoryID":2,"repository":"github.com/foo/baz"}
+{"type":"progress","data":{"done":true}}
+{"type":"done"}
+`
+	require.Equal(t, want, buf.String())
+}
@@ -24,12 +24,26 @@ type Writer struct {
 }
 
 func NewWriter(w http.ResponseWriter) (*Writer, error) {
+	flusher, err := streamResponse(w, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:     w,
+		flush: flusher.Flush,
+	}, nil
+}
+
+// streamResponse sets the headers for streaming a response of contentType to
+// w. It returns an error if w does not support flushing.
+func streamResponse(w http.ResponseWriter, contentType string) (http.Flusher, error) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		return nil, errors.New("http flushing not supported")
 	}
 
-	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Transfer-Encoding", "chunked")
@@ -39,10 +53,7 @@ func NewWriter(w http.ResponseWriter) (*Writer, error) {
 	// full time a search takes to complete.
 	w.Header().Set("X-Accel-Buffering", "no")
 
-	return &Writer{
-		w:     w,
-		flush: flusher.Flush,
-	}, nil
+	return flusher, nil
 }
 
 // Event writes event with data json marshalled.
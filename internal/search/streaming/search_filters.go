@@ -93,17 +93,21 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		}
 	}
 
-	addLangFilter := func(fileMatchPath string, lineMatchCount int32, limitHit bool) {
-		if ext := path.Ext(fileMatchPath); ext != "" {
-			rawLanguage, _ := inventory.GetLanguageByFilename(fileMatchPath)
-			language := strings.ToLower(rawLanguage)
-			if language != "" {
-				if strings.Contains(language, " ") {
-					language = strconv.Quote(language)
-				}
-				value := fmt.Sprintf(`lang:%s`, language)
-				s.filters.Add(value, rawLanguage, lineMatchCount, limitHit, "lang")
+	// addLangFilter adds a filter for the language detected from the content
+	// of a file if there is one, and for the language of its extension
+	// otherwise.
+	addLangFilter := func(fileMatchPath, detectedLanguage string, lineMatchCount int32, limitHit bool) {
+		rawLanguage := detectedLanguage
+		if rawLanguage == "" && path.Ext(fileMatchPath) != "" {
+			rawLanguage, _ = inventory.GetLanguageByFilename(fileMatchPath)
+		}
+		language := strings.ToLower(rawLanguage)
+		if language != "" {
+			if strings.Contains(language, " ") {
+				language = strconv.Quote(language)
 			}
+			value := fmt.Sprintf(`lang:%s`, language)
+			s.filters.Add(value, rawLanguage, lineMatchCount, limitHit, "lang")
 		}
 	}
 
@@ -125,8 +129,17 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			}
 			lines := int32(v.ResultCount())
 			addRepoFilter(v.Repo.Name, v.Repo.ID, rev, lines)
-			addLangFilter(v.Path, lines, v.LimitHit)
+			addLangFilter(v.Path, v.Language, lines, v.LimitHit)
 			addFileFilter(v.Path, lines, v.LimitHit)
+		case *result.CaptureMatch:
+			rev := ""
+			if v.InputRev != nil {
+				rev = *v.InputRev
+			}
+			count := int32(v.ResultCount())
+			addRepoFilter(v.Repo.Name, v.Repo.ID, rev, count)
+			addLangFilter(v.Path, "", count, false)
+			addFileFilter(v.Path, count, false)
 		case *result.RepoMatch:
 			// It should be fine to leave this blank since revision specifiers
 			// can only be used with the 'repo:' scope. In that case,
@@ -69,6 +69,26 @@ func TestSearchFiltersUpdate(t *testing.T) {
 			wantFilterKind:  "repo",
 			wantFilterCount: 2,
 		},
+		{
+			name: "FileMatch, lang: filter from detected language",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.FileMatch{
+							File: result.File{
+								Repo: repo,
+								Path: "bin/deploy",
+							},
+							Language:     "Python",
+							ChunkMatches: result.ChunkMatches{{Ranges: make(result.Ranges, 1)}},
+						},
+					},
+				},
+			},
+			wantFilterName:  "lang:python",
+			wantFilterKind:  "lang",
+			wantFilterCount: 1,
+		},
 	}
 
 	for _, c := range cases {
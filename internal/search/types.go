@@ -21,9 +21,11 @@ import (
 
 // Inputs contains fields we set before kicking off search.
 type Inputs struct {
-	Plan                query.Plan // the comprehensive query plan
-	Query               query.Q    // the current basic query being evaluated, one part of query.Plan
-	OriginalQuery       string     // the raw string of the original search query
+	Plan                query.Plan  // the comprehensive query plan
+	Query               query.Q     // the current basic query being evaluated, one part of query.Plan
+	OriginalQuery       string      // the raw string of the original search query
+	ExpandedQuery       string      // the original search query with query fragments expanded, empty if it references none
+	Join                *query.Join // set if the query joins subqueries, in which case Plan contains the plans of all subqueries
 	PatternType         query.SearchType
 	UserSettings        *schema.Settings
 	OnSourcegraphDotCom bool
@@ -203,6 +205,14 @@ type TextPatternInfo struct {
 	PatternMatchesPath    bool
 
 	Languages []string
+
+	// IncludeLangs and ExcludeLangs are the values of lang: and -lang:
+	// filters. They are set instead of adding file extension patterns to
+	// IncludePatterns and ExcludePattern if the ContentBasedLangFilters
+	// feature is enabled, so that the language detected from the content of
+	// a file is taken into account.
+	IncludeLangs []string
+	ExcludeLangs []string
 }
 
 func (p *TextPatternInfo) Fields() []otlog.Field {
@@ -253,6 +263,12 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if len(p.Languages) > 0 {
 		add(trace.Strings("languages", p.Languages))
 	}
+	if len(p.IncludeLangs) > 0 {
+		add(trace.Strings("includeLangs", p.IncludeLangs))
+	}
+	if len(p.ExcludeLangs) > 0 {
+		add(trace.Strings("excludeLangs", p.ExcludeLangs))
+	}
 	return res
 }
 
@@ -286,6 +302,9 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLangs {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
@@ -314,8 +333,9 @@ func (p *TextPatternInfo) String() string {
 // struct and read sites of a flag.
 type Features struct {
 	// ContentBasedLangFilters when true will use the language detected from
-	// the content of the file, rather than just file name patterns. This is
-	// currently just supported by Zoekt.
+	// the content of the file, rather than just file name patterns. Zoekt
+	// uses the languages it detected at index time, and searcher detects the
+	// language of files whose name does not determine it.
 	ContentBasedLangFilters bool `json:"search-content-based-lang-detection"`
 
 	// HybridSearch when true will consult the Zoekt index when running
@@ -328,6 +348,11 @@ type Features struct {
 	// predicate.
 	CodeOwnershipFilters bool `json:"code-ownership"`
 
+	// Ranking when true will buffer the results of searches bounded by
+	// `count:` and order file matches by importance signals before sending
+	// them.
+	Ranking bool `json:"search-ranking"`
+
 	// When true lucky search runs by default. Adding for A/B testing in
 	// 08/2022. To be removed at latest by 12/2022.
 	AbLuckySearch bool `json:"ab-lucky-search"`
@@ -387,6 +387,7 @@ func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ sea
 					Repo:     repo,
 					Path:     file.FileName,
 				},
+				Language: detectedLanguage(&file),
 			}
 			matches = append(matches, &fm)
 		}
@@ -400,6 +401,16 @@ func sendMatches(event *zoekt.SearchResult, getRepoInputRev repoRevFunc, typ sea
 	})
 }
 
+// detectedLanguage returns the language Zoekt detected for the given file when
+// indexing it, or the empty string if Zoekt skipped detection.
+func detectedLanguage(file *zoekt.FileMatch) string {
+	switch file.Language {
+	case "binary", "skipped":
+		return ""
+	}
+	return file.Language
+}
+
 func zoektFileMatchToMultilineMatches(file *zoekt.FileMatch) result.ChunkMatches {
 	cms := make(result.ChunkMatches, 0, len(file.ChunkMatches))
 	for _, l := range file.LineMatches {
@@ -34,8 +34,11 @@ func QueryToZoektQuery(b query.Basic, resultTypes result.Types, feat *search.Fea
 	filesInclude, filesExclude := b.IncludeExcludeValues(query.FieldFile)
 	// Handle lang: and -lang: filters.
 	langInclude, langExclude := b.IncludeExcludeValues(query.FieldLang)
-	filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
-	filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	contentBasedLangFilters := feat != nil && feat.ContentBasedLangFilters
+	if !contentBasedLangFilters {
+		filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
+		filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	}
 
 	var and []zoekt.Q
 	if q != nil {
@@ -68,26 +71,41 @@ func QueryToZoektQuery(b query.Basic, resultTypes result.Types, feat *search.Fea
 		and = append(and, zoekt.NewAnd(repoHasFilters...))
 	}
 
-	// Languages are already partially expressed with IncludePatterns, but Zoekt creates
-	// more precise language metadata based on file contents analyzed by go-enry, so it's
-	// useful to pass lang: queries down.
-	//
-	// Currently, negated lang queries create filename-based ExcludePatterns that cannot be
-	// corrected by the more precise language metadata. If this is a problem, indexed search
-	// queries should have a special query converter that produces *only* Language predicates
-	// instead of filepatterns.
-	if len(langInclude) > 0 && feat.ContentBasedLangFilters {
-		or := &zoekt.Or{}
+	// Zoekt creates more precise language metadata based on file contents
+	// analyzed by go-enry, so files without a known extension (e.g. scripts
+	// with a shebang) can be matched by their language. A file matches a
+	// language if either its name or its detected language matches.
+	if contentBasedLangFilters {
 		for _, lang := range langInclude {
-			lang, _ = enry.GetLanguageByAlias(lang) // Invariant: lang is valid.
-			or.Children = append(or.Children, &zoekt.Language{Language: lang})
+			q, err := langQuery(lang, isCaseSensitive)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, q)
+		}
+		for _, lang := range langExclude {
+			q, err := langQuery(lang, isCaseSensitive)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, &zoekt.Not{Child: q})
 		}
-		and = append(and, or)
 	}
 
 	return zoekt.Simplify(zoekt.NewAnd(and...)), nil
 }
 
+// langQuery returns a query matching files whose name has one of the file
+// extensions of lang, or whose content was detected as lang by Zoekt.
+func langQuery(lang string, isCaseSensitive bool) (zoekt.Q, error) {
+	fileRe, err := FileRe(query.LangToFileRegexp(lang), isCaseSensitive)
+	if err != nil {
+		return nil, err
+	}
+	lang, _ = enry.GetLanguageByAlias(lang) // Invariant: lang is valid.
+	return zoekt.NewOr(fileRe, &zoekt.Language{Language: lang}), nil
+}
+
 func QueryForFileContentArgs(opt query.RepoHasFileContentArgs, caseSensitive bool) zoekt.Q {
 	var children []zoekt.Q
 	if opt.Path != "" {
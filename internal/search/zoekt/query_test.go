@@ -88,13 +88,22 @@ func TestQueryToZoektQuery(t *testing.T) {
 			Query:   `file:"\\.go(?m:$)" file:"\\.go(?m:$)"`,
 		},
 		{
-			Name:    "language gets passed as both file include and lang: predicate",
+			Name:    "language matches either file include or lang: predicate",
 			Type:    search.TextRequest,
 			Pattern: `file:\.go$ lang:go`,
 			Features: search.Features{
 				ContentBasedLangFilters: true,
 			},
-			Query: `file:"\\.go(?m:$)" file:"\\.go(?m:$)" lang:Go`,
+			Query: `file:"\\.go(?m:$)" (file:"\\.go(?m:$)" or lang:Go)`,
+		},
+		{
+			Name:    "negated language excludes both file pattern and lang: predicate",
+			Type:    search.TextRequest,
+			Pattern: `-lang:go`,
+			Features: search.Features{
+				ContentBasedLangFilters: true,
+			},
+			Query: `-(file:"\\.go(?m:$)" or lang:Go)`,
 		},
 	}
 	for _, tt := range cases {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -25,6 +26,47 @@ func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathex
 		return nil, err
 	}
 
+	return processState(ctx, state, root, getChildren)
+}
+
+// ElementCorrelator correlates LSIF elements that are decoded one at a time, e.g. while
+// converting an index that was not uploaded as LSIF JSON (such as a SCIP index), so that the
+// decoded elements do not need to be held in memory all at once.
+type ElementCorrelator struct {
+	state *wrappedState
+	count int
+}
+
+// NewElementCorrelator creates a new correlator for the elements of an index with the given root.
+func NewElementCorrelator(root string) *ElementCorrelator {
+	return &ElementCorrelator{state: newWrappedState(root)}
+}
+
+// Correlate maps the given element into the correlation state.
+func (c *ElementCorrelator) Correlate(element reader.Element) error {
+	c.count++
+
+	if err := correlateElement(c.state, translateElement(element)); err != nil {
+		return errors.Errorf("dump malformed on element %d: %s", c.count, err)
+	}
+
+	return nil
+}
+
+// Finish returns the correlated elements canonicalized and pruned for storage.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func (c *ElementCorrelator) Finish(ctx context.Context, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	if c.state.LSIFVersion == "" {
+		return nil, ErrMissingMetaData
+	}
+
+	return processState(ctx, c.state.State, c.state.dumpRoot, getChildren)
+}
+
+// processState canonicalizes and prunes the given correlation state, then converts it to the
+// format we send to the writer.
+func processState(ctx context.Context, state *State, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
 	// Remove duplicate elements, collapse linked elements
 	canonicalize(state)
 
@@ -194,6 +194,33 @@ func TestCorrelate(t *testing.T) {
 	}
 }
 
+func TestElementCorrelator(t *testing.T) {
+	input, err := os.ReadFile("../testdata/dump1.lsif")
+	if err != nil {
+		t.Fatalf("unexpected error reading test file: %s", err)
+	}
+
+	expectedState, err := correlateFromReader(context.Background(), bytes.NewReader(input), "root")
+	if err != nil {
+		t.Fatalf("unexpected error correlating input: %s", err)
+	}
+
+	correlator := NewElementCorrelator("root")
+	for pair := range reader.Read(context.Background(), bytes.NewReader(input)) {
+		if pair.Err != nil {
+			t.Fatalf("unexpected error reading input: %s", pair.Err)
+		}
+
+		if err := correlator.Correlate(pair.Element); err != nil {
+			t.Fatalf("unexpected error correlating element: %s", err)
+		}
+	}
+
+	if diff := cmp.Diff(expectedState, correlator.state.State, datastructures.Comparers...); diff != "" {
+		t.Errorf("unexpected state (-want +got):\n%s", diff)
+	}
+}
+
 func TestCorrelateMetaDataRoot(t *testing.T) {
 	input, err := os.ReadFile("../testdata/dump2.lsif")
 	if err != nil {
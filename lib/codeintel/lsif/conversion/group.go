@@ -118,6 +118,7 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 			ImplementationResultID: toID(rangeData.ImplementationResultID),
 			HoverResultID:          toID(rangeData.HoverResultID),
 			MonikerIDs:             monikerIDs,
+			Symbol:                 toSymbolData(rangeData),
 		}
 
 		if rangeData.HoverResultID != 0 {
@@ -144,6 +145,24 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 	return document
 }
 
+// toSymbolData returns the symbol defined at the given range, if the range is tagged
+// as a definition with a full range. Other tags are not useful once the document is
+// serialized.
+func toSymbolData(r Range) *precise.SymbolData {
+	if r.Tag == nil || r.Tag.Type != "definition" || r.Tag.FullRange == nil {
+		return nil
+	}
+
+	return &precise.SymbolData{
+		Name:               r.Tag.Text,
+		Kind:               r.Tag.Kind,
+		FullStartLine:      r.Tag.FullRange.Start.Line,
+		FullStartCharacter: r.Tag.FullRange.Start.Character,
+		FullEndLine:        r.Tag.FullRange.End.Line,
+		FullEndCharacter:   r.Tag.FullRange.End.Character,
+	}
+}
+
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan precise.IndexedResultChunkData {
 	type entry struct {
 		id     int
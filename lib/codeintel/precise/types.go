@@ -27,15 +27,28 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine              int  // 0-indexed, inclusive
-	StartCharacter         int  // 0-indexed, inclusive
-	EndLine                int  // 0-indexed, inclusive
-	EndCharacter           int  // 0-indexed, inclusive
-	DefinitionResultID     ID   // possibly empty
-	ReferenceResultID      ID   // possibly empty
-	ImplementationResultID ID   // possibly empty
-	HoverResultID          ID   // possibly empty
-	MonikerIDs             []ID // possibly empty
+	StartLine              int         // 0-indexed, inclusive
+	StartCharacter         int         // 0-indexed, inclusive
+	EndLine                int         // 0-indexed, inclusive
+	EndCharacter           int         // 0-indexed, inclusive
+	DefinitionResultID     ID          // possibly empty
+	ReferenceResultID      ID          // possibly empty
+	ImplementationResultID ID          // possibly empty
+	HoverResultID          ID          // possibly empty
+	MonikerIDs             []ID        // possibly empty
+	Symbol                 *SymbolData // possibly nil
+}
+
+// SymbolData describes the symbol defined at a range, as reported by the indexer in the
+// definition tag of the range. The full range encloses the entire definition of the
+// symbol, such as the body of a function.
+type SymbolData struct {
+	Name               string
+	Kind               protocol.SymbolKind
+	FullStartLine      int // 0-indexed, inclusive
+	FullStartCharacter int // 0-indexed, inclusive
+	FullEndLine        int // 0-indexed, inclusive
+	FullEndCharacter   int // 0-indexed, inclusive
 }
 
 const (
@@ -246,6 +259,7 @@ type GroupedBundleDataMaps struct {
 	ResultChunks      map[int]ResultChunkData
 	Definitions       map[string]map[string]map[string][]LocationData
 	References        map[string]map[string]map[string][]LocationData
+	Implementations   map[string]map[string]map[string][]LocationData
 	Packages          []Package
 	PackageReferences []PackageReference
 }
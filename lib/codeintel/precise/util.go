@@ -178,48 +178,9 @@ func GroupedBundleDataMapsToChans(ctx context.Context, maps *GroupedBundleDataMa
 			}
 		}
 	}()
-	monikerDefsChan := make(chan MonikerLocations)
-	go func() {
-		defer close(monikerDefsChan)
-
-		for kind, kindMap := range maps.Definitions {
-			for scheme, identMap := range kindMap {
-				for ident, locations := range identMap {
-					select {
-					case monikerDefsChan <- MonikerLocations{
-						Kind:       kind,
-						Scheme:     scheme,
-						Identifier: ident,
-						Locations:  locations,
-					}:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}
-		}
-	}()
-	monikerRefsChan := make(chan MonikerLocations)
-	go func() {
-		defer close(monikerRefsChan)
-
-		for kind, kindMap := range maps.References {
-			for scheme, identMap := range kindMap {
-				for ident, locations := range identMap {
-					select {
-					case monikerRefsChan <- MonikerLocations{
-						Kind:       kind,
-						Scheme:     scheme,
-						Identifier: ident,
-						Locations:  locations,
-					}:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}
-		}
-	}()
+	monikerDefsChan := monikerLocationsToChan(ctx, maps.Definitions)
+	monikerRefsChan := monikerLocationsToChan(ctx, maps.References)
+	monikerImplsChan := monikerLocationsToChan(ctx, maps.Implementations)
 
 	return &GroupedBundleDataChans{
 		Meta:              maps.Meta,
@@ -227,6 +188,7 @@ func GroupedBundleDataMapsToChans(ctx context.Context, maps *GroupedBundleDataMa
 		ResultChunks:      resultChunkChan,
 		Definitions:       monikerDefsChan,
 		References:        monikerRefsChan,
+		Implementations:   monikerImplsChan,
 		Packages:          maps.Packages,
 		PackageReferences: maps.PackageReferences,
 	}
@@ -242,26 +204,9 @@ func GroupedBundleDataChansToMaps(chans *GroupedBundleDataChans) *GroupedBundleD
 	for indexedResultChunk := range chans.ResultChunks {
 		resultChunkMap[indexedResultChunk.Index] = indexedResultChunk.ResultChunk
 	}
-	monikerDefsMap := make(map[string]map[string]map[string][]LocationData)
-	for monikerDefs := range chans.Definitions {
-		if _, exists := monikerDefsMap[monikerDefs.Kind]; !exists {
-			monikerDefsMap[monikerDefs.Kind] = make(map[string]map[string][]LocationData)
-		}
-		if _, exists := monikerDefsMap[monikerDefs.Kind][monikerDefs.Scheme]; !exists {
-			monikerDefsMap[monikerDefs.Kind][monikerDefs.Scheme] = make(map[string][]LocationData)
-		}
-		monikerDefsMap[monikerDefs.Kind][monikerDefs.Scheme][monikerDefs.Identifier] = monikerDefs.Locations
-	}
-	monikerRefsMap := make(map[string]map[string]map[string][]LocationData)
-	for monikerRefs := range chans.References {
-		if _, exists := monikerRefsMap[monikerRefs.Kind]; !exists {
-			monikerRefsMap[monikerRefs.Kind] = make(map[string]map[string][]LocationData)
-		}
-		if _, exists := monikerRefsMap[monikerRefs.Kind][monikerRefs.Scheme]; !exists {
-			monikerRefsMap[monikerRefs.Kind][monikerRefs.Scheme] = make(map[string][]LocationData)
-		}
-		monikerRefsMap[monikerRefs.Kind][monikerRefs.Scheme][monikerRefs.Identifier] = monikerRefs.Locations
-	}
+	monikerDefsMap := monikerLocationsFromChan(chans.Definitions)
+	monikerRefsMap := monikerLocationsFromChan(chans.References)
+	monikerImplsMap := monikerLocationsFromChan(chans.Implementations)
 
 	return &GroupedBundleDataMaps{
 		Meta:              chans.Meta,
@@ -269,7 +214,53 @@ func GroupedBundleDataChansToMaps(chans *GroupedBundleDataChans) *GroupedBundleD
 		ResultChunks:      resultChunkMap,
 		Definitions:       monikerDefsMap,
 		References:        monikerRefsMap,
+		Implementations:   monikerImplsMap,
 		Packages:          chans.Packages,
 		PackageReferences: chans.PackageReferences,
 	}
 }
+
+func monikerLocationsToChan(ctx context.Context, monikerLocations map[string]map[string]map[string][]LocationData) chan MonikerLocations {
+	ch := make(chan MonikerLocations)
+	go func() {
+		defer close(ch)
+
+		for kind, kindMap := range monikerLocations {
+			for scheme, identMap := range kindMap {
+				for ident, locations := range identMap {
+					select {
+					case ch <- MonikerLocations{
+						Kind:       kind,
+						Scheme:     scheme,
+						Identifier: ident,
+						Locations:  locations,
+					}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return ch
+}
+
+func monikerLocationsFromChan(ch chan MonikerLocations) map[string]map[string]map[string][]LocationData {
+	monikerLocationsMap := make(map[string]map[string]map[string][]LocationData)
+	if ch == nil {
+		return monikerLocationsMap
+	}
+
+	for monikerLocations := range ch {
+		if _, exists := monikerLocationsMap[monikerLocations.Kind]; !exists {
+			monikerLocationsMap[monikerLocations.Kind] = make(map[string]map[string][]LocationData)
+		}
+		if _, exists := monikerLocationsMap[monikerLocations.Kind][monikerLocations.Scheme]; !exists {
+			monikerLocationsMap[monikerLocations.Kind][monikerLocations.Scheme] = make(map[string][]LocationData)
+		}
+		monikerLocationsMap[monikerLocations.Kind][monikerLocations.Scheme][monikerLocations.Identifier] = monikerLocations.Locations
+	}
+
+	return monikerLocationsMap
+}
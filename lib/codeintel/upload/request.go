@@ -169,6 +169,9 @@ func makeUploadURL(opts uploadRequestOptions) (*url.URL, error) {
 	if opts.UploadRecordOptions.AssociatedIndexID != nil {
 		qs.Add("associatedIndexId", formatInt(*opts.UploadRecordOptions.AssociatedIndexID))
 	}
+	if opts.UploadRecordOptions.BaseUploadID != nil {
+		qs.Add("baseUploadId", formatInt(*opts.UploadRecordOptions.BaseUploadID))
+	}
 	if opts.MultiPart {
 		qs.Add("multiPart", "true")
 	}
@@ -43,4 +43,5 @@ type UploadRecordOptions struct {
 	Indexer           string
 	IndexerVersion    string
 	AssociatedIndexID *int
+	BaseUploadID      *int
 }
@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS lsif_data_all_documents;
+DROP TABLE IF EXISTS lsif_data_shared_documents;
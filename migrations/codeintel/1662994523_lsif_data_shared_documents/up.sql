@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS lsif_data_shared_documents (
+    dump_id integer NOT NULL,
+    path text NOT NULL,
+    source_dump_id integer NOT NULL,
+    PRIMARY KEY (dump_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS lsif_data_shared_documents_source_dump_id_path ON lsif_data_shared_documents USING btree (source_dump_id, path);
+
+COMMENT ON TABLE lsif_data_shared_documents IS 'Associates the documents of a delta upload that did not change since its base upload with the lsif_data_documents row of an earlier upload that stores them.';
+COMMENT ON COLUMN lsif_data_shared_documents.dump_id IS 'The identifier of the delta upload sharing the document.';
+COMMENT ON COLUMN lsif_data_shared_documents.path IS 'The path of the shared document.';
+COMMENT ON COLUMN lsif_data_shared_documents.source_dump_id IS 'The identifier of the upload whose lsif_data_documents row stores the shared document.';
+
+CREATE OR REPLACE VIEW lsif_data_all_documents AS
+SELECT
+    d.dump_id,
+    d.path,
+    d.data,
+    d.schema_version,
+    d.num_diagnostics,
+    d.ranges,
+    d.hovers,
+    d.monikers,
+    d.packages,
+    d.diagnostics
+FROM lsif_data_documents d
+UNION ALL
+SELECT
+    s.dump_id,
+    d.path,
+    d.data,
+    d.schema_version,
+    d.num_diagnostics,
+    d.ranges,
+    d.hovers,
+    d.monikers,
+    d.packages,
+    d.diagnostics
+FROM lsif_data_shared_documents s
+JOIN lsif_data_documents d ON d.dump_id = s.source_dump_id AND d.path = s.path;
+
+COMMENT ON VIEW lsif_data_all_documents IS 'The documents of each upload, including the documents a delta upload shares with an earlier upload.';
@@ -0,0 +1 @@
+ALTER TABLE lsif_data_metadata DROP COLUMN IF EXISTS data_size;
@@ -0,0 +1,3 @@
+ALTER TABLE lsif_data_metadata ADD COLUMN IF NOT EXISTS data_size bigint;
+
+COMMENT ON COLUMN lsif_data_metadata.data_size IS 'The number of bytes occupied by the payloads of the dump''s rows in the lsif_data_* tables, excluding the documents it shares with another dump. Null for dumps processed before the size was recorded.';
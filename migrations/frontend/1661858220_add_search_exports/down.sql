@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS search_export_chunks;
+DROP TABLE IF EXISTS search_exports;
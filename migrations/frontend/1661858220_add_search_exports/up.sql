@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS search_exports (
+    id SERIAL PRIMARY KEY,
+    state text DEFAULT 'queued',
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT NOW(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer not null default 0,
+    num_failures integer not null default 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json [],
+    worker_hostname text not null default '',
+    -- additional columns
+    user_id integer not null REFERENCES users(id) ON DELETE CASCADE,
+    query text not null,
+    pattern_type text not null default '',
+    version text not null default '',
+    format text not null,
+    created_at timestamp with time zone not null default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS search_exports_user_id ON search_exports(user_id);
+
+COMMENT ON TABLE search_exports IS 'Searches run in the background by the frontend to export all of their results.';
+
+CREATE TABLE IF NOT EXISTS search_export_chunks (
+    id SERIAL PRIMARY KEY,
+    export_id integer not null REFERENCES search_exports(id) ON DELETE CASCADE,
+    repo_ids integer [] not null,
+    object_key text not null,
+    num_matches integer not null default 0,
+    created_at timestamp with time zone not null default NOW()
+);
+
+CREATE INDEX IF NOT EXISTS search_export_chunks_export_id ON search_export_chunks(export_id);
+
+COMMENT ON TABLE search_export_chunks IS 'The encoded results of a search export. Every chunk holds the results of a batch of repositories, which are skipped when the export is resumed.';
+
+COMMENT ON COLUMN search_export_chunks.object_key IS 'The key of the object in the search export upload store which holds the encoded matches of the chunk.';
+
+COMMENT ON COLUMN search_export_chunks.num_matches IS 'The number of matches in the chunk, counted towards the count: of the export''s query.';
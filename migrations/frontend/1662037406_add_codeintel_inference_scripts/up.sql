@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS codeintel_inference_scripts (
+    repository_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    script text NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE codeintel_inference_scripts IS 'Lua scripts that override or extend the auto-indexing recognizers of a repository.';
+COMMENT ON COLUMN codeintel_inference_scripts.script IS 'A Lua script returning a table of recognizers. Recognizers with the same name as a built-in recognizer replace it.';
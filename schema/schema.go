@@ -619,10 +619,16 @@ type ExperimentalFeatures struct {
 	Gerrit string `json:"gerrit,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerPartialClones description: JSON array of configuration that maps from Git clone URL domain/path to an object filter. Matching repositories are cloned as partial clones that omit the objects matched by the filter, and gitserver fetches missing objects from the code host when they are needed. This only applies to repositories cloned or re-cloned after the setting changes, and not to repositories that use `customGitFetch`.
+	GitServerPartialClones []*PartialCloneMapping `json:"gitServerPartialClones,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver instances each repository is stored on. Replicas are chosen by the same hashing scheme used to assign repositories to gitserver instances. Fetches and deletions are sent to every replica, and reads fail over to another replica when a gitserver instance is unavailable. Values larger than the number of gitserver instances are capped.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// Mercurial description: Allow adding Mercurial code host connections
+	Mercurial string `json:"mercurial,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
@@ -1134,6 +1140,19 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+// MercurialConnection description: Configuration for a connection to Mercurial repositories.
+type MercurialConnection struct {
+	// Repos description: A list of repository paths, relative to the base URL.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if your base URL is https://hg.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://hg.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/repos/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Mercurial repositories. Credentials for HTTP(S) can be included in the URL.
+	Url string `json:"url"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1428,6 +1447,14 @@ type ParentSourcegraph struct {
 	Url string `json:"url,omitempty"`
 }
 
+// PartialCloneMapping description: Mapping from Git clone URL domain/path to a partial clone object filter. The `domainPath` field contains the Git clone URL domain/path part. The `filter` field contains the object filter.
+type PartialCloneMapping struct {
+	// DomainPath description: Git clone URL domain/path
+	DomainPath string `json:"domainPath"`
+	// Filter description: Object filter in the syntax of `git clone --filter`. `blob:none` omits all blobs, `blob:limit=<n>[kmg]` omits blobs of at least the given size.
+	Filter string `json:"filter"`
+}
+
 // PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
 type PasswordPolicy struct {
 	// Enabled description: Enables password policy
@@ -1752,6 +1779,8 @@ type Settings struct {
 	SearchIncludeForks *bool `json:"search.includeForks,omitempty"`
 	// SearchMigrateParser description: REMOVED. Previously, a flag to enable and/or-expressions in queries as an aid transition to new language features in versions <= 3.24.0.
 	SearchMigrateParser *bool `json:"search.migrateParser,omitempty"`
+	// SearchQueryFragments description: Named query fragments that can be referenced in a search query as `@name`. Each reference is replaced with the fragment's query, e.g. a fragment `nogen` defined as `-file:vendor/ -file:_test.go$` can be used as `@nogen`. Fragments may reference other fragments. Fragments defined in user settings take precedence over organization and global settings.
+	SearchQueryFragments map[string]string `json:"search.queryFragments,omitempty"`
 	// SearchRepositoryGroups description: DEPRECATED: Use search contexts instead.
 	//
 	// Named groups of repositories that can be referenced in a search query using the `repogroup:` operator. The list can contain string literals (to include single repositories) and JSON objects with a "regex" field (to include all repositories matching the regular expression). Retrieving repogroups via the GQL interface will currently exclude repositories matched by regex patterns. #14208.
@@ -46,6 +46,10 @@ var GoModulesSchemaJSON string
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
 // NpmPackagesSchemaJSON is the content of the file "npm-packages.schema.json".
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string